@@ -5,26 +5,35 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
-	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
-	"github.com/lightninglabs/neutrino"
 	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/rpc/legacyrpc"
+	"github.com/tinhnguyenhn/colxwallet/rpc/remotesigner"
 	"github.com/tinhnguyenhn/colxwallet/wallet"
-	"github.com/tinhnguyenhn/colxwallet/walletdb"
 )
 
 var (
 	cfg *config
 )
 
+// backEndRetryInterval is the time waited before connecting to the chain
+// backend again after failing to.
+const backEndRetryInterval = 10 * time.Second
+
 func main() {
 	// Use all processor cores.
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -88,6 +97,25 @@ func walletMain() error {
 		go rpcClientConnectLoop(legacyRPCServer, loader)
 	}
 
+	// Delegate all signatures to the remote signer, if one is configured,
+	// before the wallet is made available to RPC clients.
+	if cfg.RemoteSigner != "" {
+		signer, err := dialRemoteSigner()
+		if err != nil {
+			log.Errorf("Unable to connect to remote signer: %v", err)
+			return err
+		}
+		loader.RunAfterLoad(func(w *wallet.Wallet) {
+			w.SetSigner(signer)
+		})
+	}
+
+	if cfg.PruneHistory != 0 {
+		loader.RunAfterLoad(func(w *wallet.Wallet) {
+			w.SetHistoryPruning(cfg.PruneHistory)
+		})
+	}
+
 	loader.RunAfterLoad(func(w *wallet.Wallet) {
 		startWalletRPCServices(w, rpcs, legacyRPCServer)
 	})
@@ -137,6 +165,29 @@ func walletMain() error {
 	return nil
 }
 
+// dialRemoteSigner returns a signer delegating signatures to the gRPC signer
+// service configured with the --remotesigner option.
+func dialRemoteSigner() (*remotesigner.Client, error) {
+	tlsConfig, err := remotesigner.ClientTLSConfig(
+		cfg.RemoteSignerCert, cfg.RemoteSignerClientCert,
+		cfg.RemoteSignerClientKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(
+		cfg.RemoteSigner,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Delegating signatures to remote signer %s",
+		cfg.RemoteSigner)
+	return remotesigner.NewClient(conn), nil
+}
+
 // rpcClientConnectLoop continuously attempts a connection to the consensus RPC
 // server.  When a connection is established, the client is used to sync the
 // loaded wallet, either immediately or when loaded at a later time.
@@ -145,56 +196,37 @@ func walletMain() error {
 // associated with the server for RPC passthrough and to enable additional
 // methods.
 func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Loader) {
+	// Electrum and Esplora servers are usually authenticated with the
+	// system's root certificates, so the CA file is only used when given
+	// explicitly.
 	var certs []byte
-	if !cfg.UseSPV {
+	switch cfg.Backend {
+	case backendBtcd:
 		certs = readCAFile()
+	case backendElectrum, backendEsplora:
+		if cfg.CAFile.ExplicitlySet() {
+			certs = readCAFile()
+		}
 	}
 
 	for {
-		var (
-			chainClient chain.Interface
-			err         error
-		)
-
-		if cfg.UseSPV {
-			var (
-				chainService *neutrino.ChainService
-				spvdb        walletdb.DB
-			)
-			netDir := networkDir(cfg.AppDataDir.Value, activeNet.Params)
-			spvdb, err = walletdb.Create(
-				"bdb", filepath.Join(netDir, "neutrino.db"),
-				true, cfg.DBTimeout,
-			)
-			if err != nil {
-				log.Errorf("Unable to create Neutrino DB: %s", err)
-				continue
-			}
-			defer spvdb.Close()
-			chainService, err = neutrino.NewChainService(
-				neutrino.Config{
-					DataDir:      netDir,
-					Database:     spvdb,
-					ChainParams:  *activeNet.Params,
-					ConnectPeers: cfg.ConnectPeers,
-					AddPeers:     cfg.AddPeers,
-				})
-			if err != nil {
-				log.Errorf("Couldn't create Neutrino ChainService: %s", err)
-				continue
-			}
-			chainClient = chain.NewNeutrinoClient(activeNet.Params, chainService)
+		log.Infof("Attempting %s chain backend connection", cfg.Backend)
+		chainClient, err := newChainClient(certs)
+		if err == nil {
 			err = chainClient.Start()
 			if err != nil {
-				log.Errorf("Couldn't start Neutrino client: %s", err)
-			}
-		} else {
-			chainClient, err = startChainRPC(certs)
-			if err != nil {
-				log.Errorf("Unable to open connection to consensus RPC server: %v", err)
-				continue
+				chainClient.Stop()
 			}
 		}
+		if err != nil {
+			log.Errorf("Unable to connect to the %s chain backend: %v",
+				cfg.Backend, err)
+
+			// Not every backend retries connecting by itself, so
+			// wait before trying again.
+			time.Sleep(backEndRetryInterval)
+			continue
+		}
 
 		// Rather than inlining this logic directly into the loader
 		// callback, a function variable is used to avoid running any of
@@ -262,17 +294,98 @@ func readCAFile() []byte {
 	return certs
 }
 
-// startChainRPC opens a RPC client connection to a btcd server for blockchain
-// services.  This function uses the RPC options from the global config and
-// there is no recovery in case the server is not available or if there is an
-// authentication error.  Instead, all requests to the client will simply error.
-func startChainRPC(certs []byte) (*chain.RPCClient, error) {
-	log.Infof("Attempting RPC client connection to %v", cfg.RPCConnect)
-	rpcc, err := chain.NewRPCClient(activeNet.Params, cfg.RPCConnect,
-		cfg.BtcdUsername, cfg.BtcdPassword, certs, cfg.DisableClientTLS, 0)
-	if err != nil {
-		return nil, err
+// newChainClient creates the client of the chain backend selected by the
+// global config.  When backends are added, it is wrapped along with them by a
+// client failing over between them and connecting blocks a quorum of them
+// agree on.
+func newChainClient(certs []byte) (chain.Interface, error) {
+	backEndCfg := chainBackEndConfig(certs)
+	client, err := chain.NewBackEnd(cfg.Backend, backEndCfg)
+	if err != nil || len(cfg.addBackends) == 0 {
+		return client, err
+	}
+
+	multiCfg := &chain.MultiConfig{
+		BackEnds: []chain.MultiBackEnd{{
+			Name:   backendName(cfg.Backend, backEndCfg.Host),
+			Client: client,
+		}},
+		Quorum: cfg.BackendQuorum,
+	}
+	for _, spec := range cfg.addBackends {
+		// The btcd CA file is used by added btcd backends not given
+		// their own root certificates.
+		certs := spec.certs
+		if certs == nil && spec.backend == backendBtcd &&
+			!spec.disableTLS {
+
+			certs = readCAFile()
+		}
+		client, err := chain.NewBackEnd(spec.backend, &chain.BackEndConfig{
+			ChainParams: activeNet.Params,
+			Host:        spec.host,
+			User:        spec.user,
+			Pass:        spec.pass,
+			Certs:       certs,
+			DisableTLS:  spec.disableTLS,
+			Options:     spec.options,
+		})
+		if err != nil {
+			for _, backEnd := range multiCfg.BackEnds {
+				backEnd.Client.Stop()
+			}
+			return nil, fmt.Errorf("unable to create the %s chain "+
+				"backend: %v", spec.name(), err)
+		}
+		multiCfg.BackEnds = append(multiCfg.BackEnds, chain.MultiBackEnd{
+			Name:   spec.name(),
+			Client: client,
+		})
+	}
+	return chain.NewMultiClient(multiCfg)
+}
+
+// chainBackEndConfig returns the configuration of the chain backend selected
+// by the global config.  The options of the built-in backends are taken from
+// their own config options, and are overridden by the backendopt options.
+func chainBackEndConfig(certs []byte) *chain.BackEndConfig {
+	backEndCfg := &chain.BackEndConfig{
+		ChainParams: activeNet.Params,
+		Host:        cfg.RPCConnect,
+		User:        cfg.BtcdUsername,
+		Pass:        cfg.BtcdPassword,
+		Certs:       certs,
+		DisableTLS:  cfg.DisableClientTLS,
+		Options:     make(map[string]string),
+	}
+
+	switch cfg.Backend {
+	case backendBitcoind:
+		backEndCfg.Host = cfg.BitcoindRPCHost
+		backEndCfg.User = cfg.BitcoindRPCUser
+		backEndCfg.Pass = cfg.BitcoindRPCPass
+		backEndCfg.Options["zmqpubrawblock"] = cfg.ZMQPubRawBlock
+		backEndCfg.Options["zmqpubrawtx"] = cfg.ZMQPubRawTx
+		backEndCfg.Options["zmqreaddeadline"] = cfg.ZMQReadDeadline.String()
+		backEndCfg.Options["prunednodemaxpeers"] = strconv.Itoa(
+			cfg.PrunedNodeMaxPeers,
+		)
+		backEndCfg.Options["blockpollinginterval"] =
+			cfg.BlockPollingInterval.String()
+		backEndCfg.Options["txpollinginterval"] =
+			cfg.TxPollingInterval.String()
+
+	case backendNeutrino:
+		backEndCfg.Options["datadir"] = networkDir(
+			cfg.AppDataDir.Value, activeNet.Params,
+		)
+		backEndCfg.Options["addpeer"] = strings.Join(cfg.AddPeers, ",")
+		backEndCfg.Options["connect"] = strings.Join(cfg.ConnectPeers, ",")
+		backEndCfg.Options["dbtimeout"] = cfg.DBTimeout.String()
+	}
+
+	for name, value := range cfg.backendOptions {
+		backEndCfg.Options[name] = value
 	}
-	err = rpcc.Start()
-	return rpcc, err
+	return backEndCfg
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/tinhnguyenhn/colxd/txscript"
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/gcs"
+	"github.com/tinhnguyenhn/colxutil/gcs/builder"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
 )
@@ -47,6 +49,10 @@ type BitcoindClient struct {
 	// the RPC and ZMQ connections to a bitcoind node.
 	chainConn *BitcoindConn
 
+	// ownsConn is set for clients created by the bitcoind backend driver,
+	// whose connection is stopped together with the client.
+	ownsConn bool
+
 	// bestBlock keeps track of the tip of the current best chain.
 	bestBlockMtx sync.RWMutex
 	bestBlock    waddrmgr.BlockStamp
@@ -107,6 +113,10 @@ type BitcoindClient struct {
 // chain.Interface interface.
 var _ Interface = (*BitcoindClient)(nil)
 
+// A compile-time check to ensure that BitcoindClient satisfies the
+// chain.SpendNotifier interface.
+var _ SpendNotifier = (*BitcoindClient)(nil)
+
 // BackEnd returns the name of the driver.
 func (c *BitcoindClient) BackEnd() string {
 	return "bitcoind"
@@ -497,6 +507,10 @@ func (c *BitcoindClient) Stop() {
 	c.chainConn.RemoveClient(c.id)
 
 	c.notificationQueue.Stop()
+
+	if c.ownsConn {
+		c.chainConn.Stop()
+	}
 }
 
 // WaitForShutdown blocks until the client has finished disconnecting and all
@@ -907,9 +921,10 @@ func (c *BitcoindClient) reorg(currentBlock waddrmgr.BlockStamp,
 
 // FilterBlocks scans the blocks contained in the FilterBlocksRequest for any
 // addresses of interest. Each block will be fetched and filtered sequentially,
-// returning a FilterBlocksReponse for the first block containing a matching
-// address. If no matches are found in the range of blocks requested, the
-// returned response will be nil.
+// skipping those whose BIP158 filter doesn't match when bitcoind maintains the
+// block filter index, and returning a FilterBlocksReponse for the first block
+// containing a matching address. If no matches are found in the range of
+// blocks requested, the returned response will be nil.
 //
 // NOTE: This is part of the chain.Interface interface.
 func (c *BitcoindClient) FilterBlocks(
@@ -917,10 +932,26 @@ func (c *BitcoindClient) FilterBlocks(
 
 	blockFilterer := NewBlockFilterer(c.chainConn.cfg.ChainParams, req)
 
+	// Construct the watchlist using the addresses and outpoints contained
+	// in the filter blocks request.
+	watchList, err := buildFilterBlocksWatchList(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Iterate over the requested blocks, fetching each from the rpc client.
 	// Each block will scanned using the reverse addresses indexes generated
-	// above, breaking out early if any addresses are found.
+	// above, breaking out early if any addresses are found. When bitcoind
+	// maintains the block filter index, only the blocks whose filter
+	// matches the watchlist are fetched.
 	for i, block := range req.Blocks {
+		matched, err := c.matchBlockFilter(&block.Hash, watchList)
+		if err != nil {
+			return nil, err
+		} else if !matched {
+			continue
+		}
+
 		// TODO(conner): add prefetching, since we already know we'll be
 		// fetching *every* block
 		rawBlock, err := c.GetBlock(&block.Hash)
@@ -953,6 +984,51 @@ func (c *BitcoindClient) FilterBlocks(
 	return nil, nil
 }
 
+// matchBlockFilter returns whether the BIP158 filter of a block matches any of
+// the scripts of the watchlist.  Blocks are reported as matching when bitcoind
+// doesn't maintain the block filter index, or hasn't indexed their filter yet,
+// so that they are fetched and filtered in full.
+func (c *BitcoindClient) matchBlockFilter(hash *chainhash.Hash,
+	watchList [][]byte) (bool, error) {
+
+	if !c.chainConn.hasBlockFilterIndex {
+		return true, nil
+	}
+	if len(watchList) == 0 {
+		return false, nil
+	}
+
+	result, err := c.chainConn.client.GetBlockFilter(*hash, nil)
+	if err != nil {
+		log.Debugf("Unable to get filter of block %v: %v", hash, err)
+		return true, nil
+	}
+	rawFilter, err := hex.DecodeString(result.Filter)
+	if err != nil {
+		return false, err
+	}
+
+	// Ensure the filter is large enough to be deserialized.
+	if len(rawFilter) < 4 {
+		return false, nil
+	}
+
+	filter, err := gcs.FromNBytes(
+		builder.DefaultP, builder.DefaultM, rawFilter,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	// Skip any empty filters.
+	if filter.N() == 0 {
+		return false, nil
+	}
+
+	key := builder.DeriveKey(hash)
+	return filter.MatchAny(key, watchList)
+}
+
 // rescan performs a rescan of the chain using a bitcoind backend, from the
 // specified hash to the best known hash, while watching out for reorgs that
 // happen during the rescan. It uses the addresses and outputs being tracked by
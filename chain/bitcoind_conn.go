@@ -2,6 +2,7 @@ package chain
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -16,6 +17,8 @@ import (
 	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxd/rpcclient"
 	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
 )
 
 const (
@@ -83,10 +86,30 @@ type BitcoindConfig struct {
 	//
 	// NOTE: This only applies for pruned bitcoind nodes.
 	PrunedModeMaxPeers int
+
+	// PollingConfig, when set, makes the connection poll bitcoind's RPC
+	// for new blocks and transactions rather than subscribe to its ZMQ
+	// notifications, for nodes that don't expose ZMQ.  The ZMQ options are
+	// then unused.
+	PollingConfig *PollingConfig
+}
+
+// PollingConfig contains the parameters of the RPC polling a BitcoindConn
+// learns about new blocks and transactions with when ZMQ is unavailable.
+type PollingConfig struct {
+	// BlockPollingInterval is the interval at which the best block of the
+	// bitcoind node is polled.
+	BlockPollingInterval time.Duration
+
+	// TxPollingInterval is the interval at which the mempool of the
+	// bitcoind node is polled for new transactions.  Unconfirmed
+	// transactions aren't notified when it is zero.
+	TxPollingInterval time.Duration
 }
 
 // BitcoindConn represents a persistent client connection to a bitcoind node
-// that listens for events read from a ZMQ connection.
+// that listens for events read from a ZMQ connection, or polled from its RPC
+// server.
 type BitcoindConn struct {
 	started int32 // To be used atomically.
 	stopped int32 // To be used atomically.
@@ -106,6 +129,11 @@ type BitcoindConn struct {
 	// NOTE: This is nil when the bitcoind node is not pruned.
 	prunedBlockDispatcher *PrunedBlockDispatcher
 
+	// hasBlockFilterIndex is whether the bitcoind node maintains the index
+	// of BIP158 block filters, which are then matched before fetching
+	// blocks to filter.
+	hasBlockFilterIndex bool
+
 	// zmqBlockConn is the ZMQ connection we'll use to read raw block
 	// events.
 	zmqBlockConn *gozmq.Conn
@@ -114,6 +142,21 @@ type BitcoindConn struct {
 	// events.
 	zmqTxConn *gozmq.Conn
 
+	// polledTip is the best block found by the last poll of the bitcoind
+	// node, and polledChain the hashes of the blocks below it by height,
+	// down to the maximum reorg depth, used to find the block the chain
+	// forked from on reorgs.
+	//
+	// NOTE: These are only used by the block polling goroutine.
+	polledTip   wtxmgr.Block
+	polledChain map[int32]chainhash.Hash
+
+	// polledMempool is the set of transactions found in the mempool of
+	// the bitcoind node by its last poll.
+	//
+	// NOTE: This is only used by the transaction polling goroutine.
+	polledMempool map[chainhash.Hash]struct{}
+
 	// rescanClients is the set of active bitcoind rescan clients to which
 	// ZMQ event notfications will be sent to.
 	rescanClientsMtx sync.Mutex
@@ -164,26 +207,42 @@ func NewBitcoindConn(cfg *BitcoindConfig) (*BitcoindConn, error) {
 			"pruned: %v", err)
 	}
 
+	// Check if the node maintains the block filter index, in which case
+	// the filters of blocks are matched before fetching them.
+	_, err = client.GetBlockFilter(*cfg.ChainParams.GenesisHash, nil)
+	hasBlockFilterIndex := err == nil
+	if hasBlockFilterIndex {
+		log.Debug("Detected bitcoind block filter index")
+	}
+
 	// Establish two different ZMQ connections to bitcoind to retrieve block
 	// and transaction event notifications. We'll use two as a separation of
 	// concern to ensure one type of event isn't dropped from the connection
-	// queue due to another type of event filling it up.
-	zmqBlockConn, err := gozmq.Subscribe(
-		cfg.ZMQBlockHost, []string{rawBlockZMQCommand},
-		cfg.ZMQReadDeadline,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("unable to subscribe for zmq block "+
-			"events: %v", err)
-	}
+	// queue due to another type of event filling it up. Neither is used
+	// when polling bitcoind's RPC instead.
+	var zmqBlockConn, zmqTxConn *gozmq.Conn
+	if cfg.PollingConfig == nil {
+		zmqBlockConn, err = gozmq.Subscribe(
+			cfg.ZMQBlockHost, []string{rawBlockZMQCommand},
+			cfg.ZMQReadDeadline,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to subscribe for zmq "+
+				"block events: %v", err)
+		}
 
-	zmqTxConn, err := gozmq.Subscribe(
-		cfg.ZMQTxHost, []string{rawTxZMQCommand}, cfg.ZMQReadDeadline,
-	)
-	if err != nil {
-		zmqBlockConn.Close()
-		return nil, fmt.Errorf("unable to subscribe for zmq tx "+
-			"events: %v", err)
+		zmqTxConn, err = gozmq.Subscribe(
+			cfg.ZMQTxHost, []string{rawTxZMQCommand},
+			cfg.ZMQReadDeadline,
+		)
+		if err != nil {
+			zmqBlockConn.Close()
+			return nil, fmt.Errorf("unable to subscribe for zmq "+
+				"tx events: %v", err)
+		}
+	} else if cfg.PollingConfig.BlockPollingInterval <= 0 {
+		return nil, errors.New("the block polling interval must be " +
+			"positive")
 	}
 
 	// Only initialize the PrunedBlockDispatcher when the connected bitcoind
@@ -212,6 +271,7 @@ func NewBitcoindConn(cfg *BitcoindConfig) (*BitcoindConn, error) {
 		cfg:                   *cfg,
 		client:                client,
 		prunedBlockDispatcher: prunedBlockDispatcher,
+		hasBlockFilterIndex:   hasBlockFilterIndex,
 		zmqBlockConn:          zmqBlockConn,
 		zmqTxConn:             zmqTxConn,
 		rescanClients:         make(map[uint64]*BitcoindClient),
@@ -220,7 +280,8 @@ func NewBitcoindConn(cfg *BitcoindConfig) (*BitcoindConn, error) {
 }
 
 // Start attempts to establish a RPC and ZMQ connection to a bitcoind node. If
-// successful, a goroutine is spawned to read events from the ZMQ connection.
+// successful, a goroutine is spawned to read events from the ZMQ connection,
+// or to poll them from the RPC server when polling is configured.
 // It's possible for this function to fail due to a limited number of connection
 // attempts. This is done to prevent waiting forever on the connection to be
 // established in the case that the node is down.
@@ -238,6 +299,10 @@ func (c *BitcoindConn) Start() error {
 		}
 	}
 
+	if c.cfg.PollingConfig != nil {
+		return c.startPolling()
+	}
+
 	c.wg.Add(2)
 	go c.blockEventHandler()
 	go c.txEventHandler()
@@ -245,6 +310,44 @@ func (c *BitcoindConn) Start() error {
 	return nil
 }
 
+// startPolling records the current best block and mempool of the bitcoind
+// node, and spawns the goroutines polling it for new ones.
+func (c *BitcoindConn) startPolling() error {
+	bestHash, err := c.client.GetBestBlockHash()
+	if err != nil {
+		return err
+	}
+	header, err := c.client.GetBlockHeaderVerbose(bestHash)
+	if err != nil {
+		return err
+	}
+	c.polledTip = wtxmgr.Block{Hash: *bestHash, Height: header.Height}
+	c.polledChain = map[int32]chainhash.Hash{header.Height: *bestHash}
+
+	c.wg.Add(1)
+	go c.blockPollHandler()
+
+	if c.cfg.PollingConfig.TxPollingInterval <= 0 {
+		return nil
+	}
+
+	// Only transactions entering the mempool after the connection
+	// started are notified, as they are through ZMQ.
+	txids, err := c.client.GetRawMempool()
+	if err != nil {
+		return err
+	}
+	c.polledMempool = make(map[chainhash.Hash]struct{}, len(txids))
+	for _, txid := range txids {
+		c.polledMempool[*txid] = struct{}{}
+	}
+
+	c.wg.Add(1)
+	go c.txPollHandler()
+
+	return nil
+}
+
 // Stop terminates the RPC and ZMQ connection to a bitcoind node and removes any
 // active rescan clients.
 func (c *BitcoindConn) Stop() {
@@ -258,8 +361,10 @@ func (c *BitcoindConn) Stop() {
 
 	close(c.quit)
 	c.client.Shutdown()
-	c.zmqBlockConn.Close()
-	c.zmqTxConn.Close()
+	if c.zmqBlockConn != nil {
+		c.zmqBlockConn.Close()
+		c.zmqTxConn.Close()
+	}
 
 	if c.prunedBlockDispatcher != nil {
 		c.prunedBlockDispatcher.Stop()
@@ -344,17 +449,9 @@ func (c *BitcoindConn) blockEventHandler() {
 				continue
 			}
 
-			c.rescanClientsMtx.Lock()
-			for _, client := range c.rescanClients {
-				select {
-				case client.zmqBlockNtfns <- block:
-				case <-client.quit:
-				case <-c.quit:
-					c.rescanClientsMtx.Unlock()
-					return
-				}
+			if !c.notifyBlock(block) {
+				return
 			}
-			c.rescanClientsMtx.Unlock()
 		default:
 			// It's possible that the message wasn't fully read if
 			// bitcoind shuts down, which will produce an unreadable
@@ -446,17 +543,9 @@ func (c *BitcoindConn) txEventHandler() {
 				continue
 			}
 
-			c.rescanClientsMtx.Lock()
-			for _, client := range c.rescanClients {
-				select {
-				case client.zmqTxNtfns <- tx:
-				case <-client.quit:
-				case <-c.quit:
-					c.rescanClientsMtx.Unlock()
-					return
-				}
+			if !c.notifyTx(tx) {
+				return
 			}
-			c.rescanClientsMtx.Unlock()
 		default:
 			// It's possible that the message wasn't fully read if
 			// bitcoind shuts down, which will produce an unreadable
@@ -472,6 +561,201 @@ func (c *BitcoindConn) txEventHandler() {
 	}
 }
 
+// blockPollHandler polls the best block of the bitcoind node and forwards the
+// blocks connected since the last poll along to the current rescan clients.
+//
+// NOTE: This must be run as a goroutine.
+func (c *BitcoindConn) blockPollHandler() {
+	defer c.wg.Done()
+
+	interval := c.cfg.PollingConfig.BlockPollingInterval
+	log.Infof("Started polling bitcoind for blocks every %v", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.pollBlocks(); err != nil {
+				log.Errorf("Unable to poll bitcoind for blocks: %v",
+					err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// pollBlocks forwards the blocks connected since the last poll along to the
+// current rescan clients.  The headers of the new best chain are walked back
+// to the block it forked from the previously polled one, so that the blocks
+// are forwarded in order and reorgs are handled by the rescan clients as they
+// are when notified through ZMQ.
+func (c *BitcoindConn) pollBlocks() error {
+	bestHash, err := c.client.GetBestBlockHash()
+	if err != nil {
+		return err
+	}
+	if *bestHash == c.polledTip.Hash {
+		return nil
+	}
+
+	var (
+		hashes     []chainhash.Hash
+		hash       = *bestHash
+		bestHeight int32
+	)
+	for {
+		header, err := c.client.GetBlockHeaderVerbose(&hash)
+		if err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			bestHeight = header.Height
+		}
+		if polled, ok := c.polledChain[header.Height]; ok &&
+			polled == hash {
+
+			break
+		}
+		hashes = append(hashes, hash)
+
+		// Reorgs deeper than the polled chain are left for the rescan
+		// clients to walk back.
+		if header.Height <= c.polledTip.Height-waddrmgr.MaxReorgDepth ||
+			header.PreviousHash == "" {
+
+			break
+		}
+		prevHash, err := chainhash.NewHashFromStr(header.PreviousHash)
+		if err != nil {
+			return err
+		}
+		hash = *prevHash
+	}
+
+	for i := len(hashes) - 1; i >= 0; i-- {
+		block, err := c.GetBlock(&hashes[i])
+		if err != nil {
+			return err
+		}
+
+		height := bestHeight - int32(i)
+		c.polledTip = wtxmgr.Block{Hash: hashes[i], Height: height}
+		for h := range c.polledChain {
+			if h >= height || h <= height-waddrmgr.MaxReorgDepth {
+				delete(c.polledChain, h)
+			}
+		}
+		c.polledChain[height] = hashes[i]
+
+		if !c.notifyBlock(block) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// txPollHandler polls the mempool of the bitcoind node and forwards the
+// transactions that entered it since the last poll along to the current rescan
+// clients.
+//
+// NOTE: This must be run as a goroutine.
+func (c *BitcoindConn) txPollHandler() {
+	defer c.wg.Done()
+
+	interval := c.cfg.PollingConfig.TxPollingInterval
+	log.Infof("Started polling bitcoind for transactions every %v",
+		interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.pollMempool(); err != nil {
+				log.Errorf("Unable to poll bitcoind for "+
+					"transactions: %v", err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// pollMempool forwards the transactions that entered the mempool since the
+// last poll along to the current rescan clients.
+func (c *BitcoindConn) pollMempool() error {
+	txids, err := c.client.GetRawMempool()
+	if err != nil {
+		return err
+	}
+
+	mempool := make(map[chainhash.Hash]struct{}, len(txids))
+	for _, txid := range txids {
+		mempool[*txid] = struct{}{}
+		if _, ok := c.polledMempool[*txid]; ok {
+			continue
+		}
+
+		// The transaction may have been mined or evicted since the
+		// mempool was polled, and is otherwise retried by the next
+		// poll.
+		tx, err := c.client.GetRawTransaction(txid)
+		if err != nil {
+			log.Debugf("Unable to get mempool transaction %v: %v",
+				txid, err)
+			delete(mempool, *txid)
+			continue
+		}
+		if !c.notifyTx(tx.MsgTx()) {
+			return nil
+		}
+	}
+	c.polledMempool = mempool
+
+	return nil
+}
+
+// notifyBlock forwards a block along to the current rescan clients, returning
+// false if the connection was stopped.
+func (c *BitcoindConn) notifyBlock(block *wire.MsgBlock) bool {
+	c.rescanClientsMtx.Lock()
+	defer c.rescanClientsMtx.Unlock()
+
+	for _, client := range c.rescanClients {
+		select {
+		case client.zmqBlockNtfns <- block:
+		case <-client.quit:
+		case <-c.quit:
+			return false
+		}
+	}
+	return true
+}
+
+// notifyTx forwards a transaction along to the current rescan clients,
+// returning false if the connection was stopped.
+func (c *BitcoindConn) notifyTx(tx *wire.MsgTx) bool {
+	c.rescanClientsMtx.Lock()
+	defer c.rescanClientsMtx.Unlock()
+
+	for _, client := range c.rescanClients {
+		select {
+		case client.zmqTxNtfns <- tx:
+		case <-client.quit:
+		case <-c.quit:
+			return false
+		}
+	}
+	return true
+}
+
 // getCurrentNet returns the network on which the bitcoind node is running.
 func getCurrentNet(client *rpcclient.Client) (wire.BitcoinNet, error) {
 	hash, err := client.GetBlockHash(0)
@@ -0,0 +1,379 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/gcs/builder"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// fakeBitcoindServer is a bitcoind JSON-RPC server serving the few methods
+// needed to poll it for blocks and transactions.
+type fakeBitcoindServer struct {
+	*httptest.Server
+
+	mtx     sync.Mutex
+	blocks  []*wire.MsgBlock
+	mempool []*wire.MsgTx
+	nonce   uint32
+
+	// filterIndex is whether block filters are served, and
+	// blocksFetched counts the blocks fetched.
+	filterIndex   bool
+	blocksFetched int
+}
+
+func newFakeBitcoindServer(numBlocks int) *fakeBitcoindServer {
+	s := &fakeBitcoindServer{
+		blocks: []*wire.MsgBlock{chainParams.GenesisBlock},
+	}
+	s.mine(0, numBlocks)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// mine replaces the given number of blocks at the tip of the chain with new
+// blocks.
+func (s *fakeBitcoindServer) mine(replace, numBlocks int,
+	txs ...*wire.MsgTx) {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.blocks = s.blocks[:len(s.blocks)-replace]
+	for i := 0; i < numBlocks; i++ {
+		s.nonce++
+		s.blocks = append(s.blocks, newFakeBlock(
+			s.blocks[len(s.blocks)-1], s.nonce, txs...,
+		))
+		txs = nil
+	}
+}
+
+// addToMempool adds a transaction to the mempool of the server.
+func (s *fakeBitcoindServer) addToMempool(tx *wire.MsgTx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.mempool = append(s.mempool, tx)
+}
+
+// findBlock returns the height of a block of the chain of the server.
+//
+// NOTE: The mtx must be held.
+func (s *fakeBitcoindServer) findBlock(hashStr string) (int, bool) {
+	for height, block := range s.blocks {
+		if block.BlockHash().String() == hashStr {
+			return height, true
+		}
+	}
+	return 0, false
+}
+
+func (s *fakeBitcoindServer) handle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+		ID     interface{}       `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var params []interface{}
+	for _, param := range req.Params {
+		var v interface{}
+		_ = json.Unmarshal(param, &v)
+		params = append(params, v)
+	}
+
+	s.mtx.Lock()
+	result, rpcErr := s.call(req.Method, params)
+	s.mtx.Unlock()
+
+	resp := map[string]interface{}{
+		"result": result,
+		"error":  rpcErr,
+		"id":     req.ID,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// call serves a JSON-RPC request.
+//
+// NOTE: The mtx must be held.
+func (s *fakeBitcoindServer) call(method string,
+	params []interface{}) (interface{}, *btcjson.RPCError) {
+
+	notFound := &btcjson.RPCError{
+		Code:    btcjson.ErrRPCInvalidAddressOrKey,
+		Message: "Block not found",
+	}
+
+	switch method {
+	case "getnetworkinfo":
+		return map[string]interface{}{
+			"version":    200000,
+			"subversion": "/Satoshi:0.20.0/",
+		}, nil
+
+	case "getblockchaininfo":
+		return map[string]interface{}{
+			"chain":         "regtest",
+			"blocks":        len(s.blocks) - 1,
+			"bestblockhash": s.blocks[len(s.blocks)-1].BlockHash().String(),
+			"pruned":        false,
+			"softforks":     map[string]interface{}{},
+		}, nil
+
+	case "getbestblockhash":
+		return s.blocks[len(s.blocks)-1].BlockHash().String(), nil
+
+	case "getblockhash":
+		height := int(params[0].(float64))
+		if height >= len(s.blocks) {
+			return nil, notFound
+		}
+		return s.blocks[height].BlockHash().String(), nil
+
+	case "getblockheader":
+		height, ok := s.findBlock(params[0].(string))
+		if !ok {
+			return nil, notFound
+		}
+		header := s.blocks[height].Header
+		result := map[string]interface{}{
+			"hash":   header.BlockHash().String(),
+			"height": height,
+		}
+		if height > 0 {
+			result["previousblockhash"] = header.PrevBlock.String()
+		}
+		return result, nil
+
+	case "getblock":
+		height, ok := s.findBlock(params[0].(string))
+		if !ok {
+			return nil, notFound
+		}
+		s.blocksFetched++
+		var buf bytes.Buffer
+		_ = s.blocks[height].Serialize(&buf)
+		return hex.EncodeToString(buf.Bytes()), nil
+
+	case "getblockfilter":
+		if !s.filterIndex {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCMisc,
+				Message: "Index is not enabled for filtertype basic",
+			}
+		}
+		height, ok := s.findBlock(params[0].(string))
+		if !ok {
+			return nil, notFound
+		}
+		filter, err := builder.BuildBasicFilter(s.blocks[height], nil)
+		if err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCMisc,
+				Message: err.Error(),
+			}
+		}
+		rawFilter, _ := filter.NBytes()
+		return map[string]interface{}{
+			"filter": hex.EncodeToString(rawFilter),
+			"header": hex.EncodeToString(make([]byte, 32)),
+		}, nil
+
+	case "getrawmempool":
+		txids := make([]string, 0, len(s.mempool))
+		for _, tx := range s.mempool {
+			txids = append(txids, tx.TxHash().String())
+		}
+		return txids, nil
+
+	case "getrawtransaction":
+		for _, tx := range s.mempool {
+			if tx.TxHash().String() == params[0].(string) {
+				var buf bytes.Buffer
+				_ = tx.Serialize(&buf)
+				return hex.EncodeToString(buf.Bytes()), nil
+			}
+		}
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "No such mempool transaction",
+		}
+
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMethodNotFound.Code,
+			Message: "Method not found",
+		}
+	}
+}
+
+// nextPolledBlock returns the next block forwarded to the client.
+func nextPolledBlock(t *testing.T, c *BitcoindClient) *wire.MsgBlock {
+	t.Helper()
+
+	select {
+	case block := <-c.zmqBlockNtfns:
+		return block
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for block")
+		return nil
+	}
+}
+
+// TestBitcoindConnPolling ensures that a connection polling bitcoind's RPC
+// forwards new blocks in order, including those of a reorg from the block the
+// chain forked from, and transactions entering the mempool.
+func TestBitcoindConnPolling(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeBitcoindServer(5)
+	defer server.Close()
+
+	oldTx := wire.NewMsgTx(wire.TxVersion)
+	oldTx.AddTxIn(&wire.TxIn{})
+	oldTx.AddTxOut(wire.NewTxOut(1, []byte{0x51}))
+	server.addToMempool(oldTx)
+
+	conn, err := NewBitcoindConn(&BitcoindConfig{
+		ChainParams: &chainParams,
+		Host:        strings.TrimPrefix(server.URL, "http://"),
+		User:        "user",
+		Pass:        "pass",
+		PollingConfig: &PollingConfig{
+			BlockPollingInterval: 10 * time.Millisecond,
+			TxPollingInterval:    10 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+	client := conn.NewBitcoindClient()
+	conn.AddClient(client)
+	require.NoError(t, conn.Start())
+	defer conn.Stop()
+
+	// Only transactions entering the mempool after the connection started
+	// are forwarded.
+	newTx := wire.NewMsgTx(wire.TxVersion)
+	newTx.AddTxIn(&wire.TxIn{})
+	newTx.AddTxOut(wire.NewTxOut(2, []byte{0x51}))
+	server.addToMempool(newTx)
+	select {
+	case tx := <-client.zmqTxNtfns:
+		require.Equal(t, newTx.TxHash(), tx.TxHash())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for transaction")
+	}
+
+	// New blocks are forwarded in order.
+	server.mine(0, 2)
+	require.Equal(t, server.blocks[6].BlockHash(),
+		nextPolledBlock(t, client).BlockHash())
+	require.Equal(t, server.blocks[7].BlockHash(),
+		nextPolledBlock(t, client).BlockHash())
+
+	// Reorgs are forwarded from the block the new chain forked from.
+	server.mine(2, 3)
+	for height := 6; height <= 8; height++ {
+		require.Equal(t, server.blocks[height].BlockHash(),
+			nextPolledBlock(t, client).BlockHash())
+	}
+
+	select {
+	case block := <-client.zmqBlockNtfns:
+		t.Fatalf("unexpected block %v", block.BlockHash())
+	case tx := <-client.zmqTxNtfns:
+		t.Fatalf("unexpected transaction %v", tx.TxHash())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// TestBitcoindClientFilterBlocks ensures that the blocks scanned by
+// FilterBlocks are only fetched when their filter matches if bitcoind
+// maintains the block filter index, and are all fetched otherwise.
+func TestBitcoindClientFilterBlocks(t *testing.T) {
+	t.Parallel()
+
+	addr, err := btcutil.NewAddressPubKeyHash(
+		bytes.Repeat([]byte{1}, 20), &chainParams,
+	)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+
+	server := newFakeBitcoindServer(3)
+	defer server.Close()
+	server.mine(0, 3, tx)
+
+	req := &FilterBlocksRequest{
+		ExternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{
+			{Scope: waddrmgr.KeyScopeBIP0044}: addr,
+		},
+	}
+	for height := 1; height < len(server.blocks); height++ {
+		req.Blocks = append(req.Blocks, wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   server.blocks[height].BlockHash(),
+				Height: int32(height),
+			},
+		})
+	}
+
+	for _, filterIndex := range []bool{true, false} {
+		server.mtx.Lock()
+		server.filterIndex = filterIndex
+		server.blocksFetched = 0
+		server.mtx.Unlock()
+
+		conn, err := NewBitcoindConn(&BitcoindConfig{
+			ChainParams: &chainParams,
+			Host:        strings.TrimPrefix(server.URL, "http://"),
+			User:        "user",
+			Pass:        "pass",
+			PollingConfig: &PollingConfig{
+				BlockPollingInterval: time.Hour,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, filterIndex, conn.hasBlockFilterIndex)
+
+		resp, err := conn.NewBitcoindClient().FilterBlocks(req)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		require.Equal(t, uint32(3), resp.BatchIndex)
+		require.Len(t, resp.RelevantTxns, 1)
+		require.Equal(t, tx.TxHash(), resp.RelevantTxns[0].TxHash())
+
+		server.mtx.Lock()
+		blocksFetched := server.blocksFetched
+		server.mtx.Unlock()
+		if filterIndex {
+			require.Equal(t, 1, blocksFetched)
+		} else {
+			require.Equal(t, 4, blocksFetched)
+		}
+
+		conn.Stop()
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+)
+
+var (
+	// ErrBackEndRegistered is returned when a chain backend driver is
+	// registered with the name of an already registered driver.
+	ErrBackEndRegistered = errors.New("chain backend already registered")
+
+	// ErrUnknownBackEnd is returned when no chain backend driver is
+	// registered with the requested name.
+	ErrUnknownBackEnd = errors.New("unknown chain backend")
+)
+
+// ConfigOption describes an option specific to a chain backend driver.
+type ConfigOption struct {
+	// Name is the name the option is set with in BackEndConfig.Options.
+	Name string
+
+	// Description is a short human readable description of the option.
+	Description string
+
+	// Default is the value of the option when it is not set.
+	Default string
+
+	// Required marks options without a default that must always be set.
+	Required bool
+}
+
+// BackEndConfig is the configuration a chain backend driver creates a client
+// from.
+type BackEndConfig struct {
+	// ChainParams are the parameters of the chain the backend must follow.
+	ChainParams *chaincfg.Params
+
+	// Host is the address of the backend's server, if it has one.
+	Host string
+
+	// User and Pass are the credentials to authenticate to the backend's
+	// server with.
+	User string
+	Pass string
+
+	// Certs are the TLS certificates to authenticate the backend's server
+	// with, unless DisableTLS is set.
+	Certs      []byte
+	DisableTLS bool
+
+	// Options are the options specific to the driver, keyed by the name of
+	// the ConfigOption of the driver's schema describing them.  Drivers
+	// are always passed a value for every option of their schema.
+	Options map[string]string
+}
+
+// Driver defines a structure for chain backends to use when they register
+// themselves as an implementation of the Interface interface.
+type Driver struct {
+	// BackEnd is the name uniquely identifying the backend, and is
+	// returned by the BackEnd method of its clients.
+	BackEnd string
+
+	// ConfigSchema describes the options specific to the driver.
+	ConfigSchema []ConfigOption
+
+	// New is the function that will be invoked to create a client of the
+	// backend.  The client is returned without being started.
+	New func(cfg *BackEndConfig) (Interface, error)
+}
+
+// drivers holds all of the registered chain backends.
+var drivers = make(map[string]*Driver)
+
+// RegisterDriver adds a chain backend driver to the available backends.
+// ErrBackEndRegistered is returned if a driver with the same name has already
+// been registered.
+func RegisterDriver(driver Driver) error {
+	if _, exists := drivers[driver.BackEnd]; exists {
+		return ErrBackEndRegistered
+	}
+
+	drivers[driver.BackEnd] = &driver
+	return nil
+}
+
+// BackEnds returns the sorted names of the registered chain backends.
+func BackEnds() []string {
+	backEnds := make([]string, 0, len(drivers))
+	for name := range drivers {
+		backEnds = append(backEnds, name)
+	}
+	sort.Strings(backEnds)
+	return backEnds
+}
+
+// LookupDriver returns the driver of the named chain backend.
+// ErrUnknownBackEnd is returned if the backend is not registered.
+func LookupDriver(backEnd string) (*Driver, error) {
+	drv, exists := drivers[backEnd]
+	if !exists {
+		return nil, ErrUnknownBackEnd
+	}
+	return drv, nil
+}
+
+// NewBackEnd creates an unstarted client of the named chain backend.  The
+// options of the configuration are checked against the schema of the driver,
+// and the defaults of unset options are filled in before the driver is called.
+//
+// ErrUnknownBackEnd is returned if the backend is not registered.
+func NewBackEnd(backEnd string, cfg *BackEndConfig) (Interface, error) {
+	drv, err := LookupDriver(backEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := make(map[string]struct{}, len(drv.ConfigSchema))
+	for _, opt := range drv.ConfigSchema {
+		schema[opt.Name] = struct{}{}
+	}
+	for name := range cfg.Options {
+		if _, ok := schema[name]; !ok {
+			return nil, fmt.Errorf("unknown option %q for the %s "+
+				"backend", name, backEnd)
+		}
+	}
+
+	options := make(map[string]string, len(drv.ConfigSchema))
+	for _, opt := range drv.ConfigSchema {
+		value, ok := cfg.Options[opt.Name]
+		switch {
+		case ok:
+		case opt.Required:
+			return nil, fmt.Errorf("option %q is required by the "+
+				"%s backend", opt.Name, backEnd)
+		default:
+			value = opt.Default
+		}
+		options[opt.Name] = value
+	}
+
+	drvCfg := *cfg
+	drvCfg.Options = options
+	return drv.New(&drvCfg)
+}
@@ -0,0 +1,77 @@
+package chain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxwallet/chain"
+)
+
+// TestRegisterDriver ensures that registered chain backend drivers are listed
+// and created by name with options checked against their schema.
+func TestRegisterDriver(t *testing.T) {
+	errCreated := errors.New("created")
+
+	var created *chain.BackEndConfig
+	driver := chain.Driver{
+		BackEnd: "test",
+		ConfigSchema: []chain.ConfigOption{{
+			Name:     "endpoint",
+			Required: true,
+		}, {
+			Name:    "timeout",
+			Default: "30s",
+		}},
+		New: func(cfg *chain.BackEndConfig) (chain.Interface, error) {
+			created = cfg
+			return nil, errCreated
+		},
+	}
+	require.NoError(t, chain.RegisterDriver(driver))
+	require.Equal(t, chain.ErrBackEndRegistered, chain.RegisterDriver(driver))
+	require.Equal(t, chain.ErrBackEndRegistered, chain.RegisterDriver(
+		chain.Driver{BackEnd: "btcd"},
+	))
+
+	require.Equal(t, []string{
+		"bitcoind", "btcd", "electrum", "esplora", "neutrino", "test",
+	}, chain.BackEnds())
+
+	_, err := chain.NewBackEnd("unknown", &chain.BackEndConfig{})
+	require.Equal(t, chain.ErrUnknownBackEnd, err)
+
+	// Unknown options and missing required options are rejected before
+	// the driver is called.
+	invalid := []map[string]string{
+		{"endpoint": "localhost", "unknown": "value"},
+		{"timeout": "1m"},
+	}
+	for _, options := range invalid {
+		_, err := chain.NewBackEnd("test", &chain.BackEndConfig{
+			Options: options,
+		})
+		require.Error(t, err)
+		require.NotEqual(t, errCreated, err)
+		require.Nil(t, created)
+	}
+
+	// Defaults are filled in for unset options.
+	options := map[string]string{"endpoint": "localhost"}
+	_, err = chain.NewBackEnd("test", &chain.BackEndConfig{
+		ChainParams: &chaincfg.MainNetParams,
+		Host:        "localhost:8334",
+		Options:     options,
+	})
+	require.Equal(t, errCreated, err)
+	require.Equal(t, &chain.BackEndConfig{
+		ChainParams: &chaincfg.MainNetParams,
+		Host:        "localhost:8334",
+		Options: map[string]string{
+			"endpoint": "localhost",
+			"timeout":  "30s",
+		},
+	}, created)
+	require.Equal(t, map[string]string{"endpoint": "localhost"}, options)
+}
@@ -0,0 +1,375 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lightninglabs/neutrino"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+const (
+	// The names of the chain backends implemented by this package.
+	btcdBackEnd     = "btcd"
+	bitcoindBackEnd = "bitcoind"
+	neutrinoBackEnd = "neutrino"
+	electrumBackEnd = "electrum"
+	esploraBackEnd  = "esplora"
+
+	// The default ports of Electrum servers over TLS and plain TCP.
+	defaultElectrumTLSPort = "50002"
+	defaultElectrumTCPPort = "50001"
+)
+
+func init() {
+	drivers := []Driver{{
+		BackEnd: btcdBackEnd,
+		ConfigSchema: []ConfigOption{{
+			Name: "reconnectattempts",
+			Description: "The number of times a lost connection " +
+				"is retried, 0 for no limit",
+			Default: "0",
+		}},
+		New: newBtcdBackEnd,
+	}, {
+		BackEnd: bitcoindBackEnd,
+		ConfigSchema: []ConfigOption{{
+			Name: "zmqpubrawblock",
+			Description: "The address of the ZMQ socket bitcoind " +
+				"publishes raw blocks on, unless polling",
+		}, {
+			Name: "zmqpubrawtx",
+			Description: "The address of the ZMQ socket bitcoind " +
+				"publishes raw transactions on, unless polling",
+		}, {
+			Name:        "zmqreaddeadline",
+			Description: "The read deadline of the ZMQ connections",
+			Default:     "5s",
+		}, {
+			Name: "prunednodemaxpeers",
+			Description: "The maximum number of peers blocks are " +
+				"requested from when they were pruned by bitcoind",
+			Default: "4",
+		}, {
+			Name: "blockpollinginterval",
+			Description: "The interval at which bitcoind's RPC is " +
+				"polled for blocks rather than using ZMQ, if " +
+				"positive",
+			Default: "0",
+		}, {
+			Name: "txpollinginterval",
+			Description: "The interval at which bitcoind's mempool " +
+				"is polled for transactions when polling for " +
+				"blocks, if positive",
+			Default: "0",
+		}},
+		New: newBitcoindBackEnd,
+	}, {
+		BackEnd: neutrinoBackEnd,
+		ConfigSchema: []ConfigOption{{
+			Name: "datadir",
+			Description: "The directory the neutrino database and " +
+				"block headers are stored in",
+			Required: true,
+		}, {
+			Name: "addpeer",
+			Description: "Comma separated peers to connect with " +
+				"at startup",
+		}, {
+			Name: "connect",
+			Description: "Comma separated peers to connect only " +
+				"to at startup",
+		}, {
+			Name:        "dbtimeout",
+			Description: "The timeout of opening the neutrino database",
+			Default:     "60s",
+		}},
+		New: newNeutrinoBackEnd,
+	}, {
+		BackEnd: electrumBackEnd,
+		ConfigSchema: []ConfigOption{{
+			Name:        "requesttimeout",
+			Description: "The time waited for the server to answer",
+			Default:     "30s",
+		}, {
+			Name: "pinginterval",
+			Description: "The interval at which the server is " +
+				"pinged to keep the connection alive",
+			Default: "1m",
+		}},
+		New: newElectrumBackEnd,
+	}, {
+		BackEnd: esploraBackEnd,
+		ConfigSchema: []ConfigOption{{
+			Name:        "requesttimeout",
+			Description: "The time waited for the server to answer",
+			Default:     "30s",
+		}, {
+			Name: "pollinterval",
+			Description: "The interval at which the server is polled " +
+				"for new blocks and transactions",
+			Default: "30s",
+		}, {
+			Name: "maxretries",
+			Description: "The number of times a request failing " +
+				"because of the network or server is retried",
+			Default: "3",
+		}, {
+			Name: "retrybackoff",
+			Description: "The time waited before retrying a failed " +
+				"request, doubled on every retry",
+			Default: "1s",
+		}},
+		New: newEsploraBackEnd,
+	}}
+	for _, driver := range drivers {
+		if err := RegisterDriver(driver); err != nil {
+			panic(fmt.Sprintf("failed to register chain backend "+
+				"'%s': %v\n", driver.BackEnd, err))
+		}
+	}
+}
+
+// newBtcdBackEnd creates a client of a btcd RPC server.
+func newBtcdBackEnd(cfg *BackEndConfig) (Interface, error) {
+	attempts, err := strconv.Atoi(cfg.Options["reconnectattempts"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid reconnectattempts: %v", err)
+	}
+	return NewRPCClient(
+		cfg.ChainParams, cfg.Host, cfg.User, cfg.Pass, cfg.Certs,
+		cfg.DisableTLS, attempts,
+	)
+}
+
+// newBitcoindBackEnd connects to a bitcoind node and creates a client of the
+// connection.  The connection is stopped together with the client.
+func newBitcoindBackEnd(cfg *BackEndConfig) (Interface, error) {
+	readDeadline, err := time.ParseDuration(cfg.Options["zmqreaddeadline"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid zmqreaddeadline: %v", err)
+	}
+	maxPeers, err := strconv.Atoi(cfg.Options["prunednodemaxpeers"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid prunednodemaxpeers: %v", err)
+	}
+	blockPollingInterval, err := time.ParseDuration(
+		cfg.Options["blockpollinginterval"],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blockpollinginterval: %v", err)
+	}
+	txPollingInterval, err := time.ParseDuration(
+		cfg.Options["txpollinginterval"],
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid txpollinginterval: %v", err)
+	}
+
+	// bitcoind's RPC is polled for notifications when ZMQ is
+	// unavailable.
+	var pollingCfg *PollingConfig
+	switch {
+	case blockPollingInterval > 0:
+		pollingCfg = &PollingConfig{
+			BlockPollingInterval: blockPollingInterval,
+			TxPollingInterval:    txPollingInterval,
+		}
+	case cfg.Options["zmqpubrawblock"] == "" ||
+		cfg.Options["zmqpubrawtx"] == "":
+
+		return nil, errors.New("the zmqpubrawblock and zmqpubrawtx " +
+			"options are required unless polling for blocks")
+	}
+
+	conn, err := NewBitcoindConn(&BitcoindConfig{
+		ChainParams:     cfg.ChainParams,
+		Host:            cfg.Host,
+		User:            cfg.User,
+		Pass:            cfg.Pass,
+		ZMQBlockHost:    cfg.Options["zmqpubrawblock"],
+		ZMQTxHost:       cfg.Options["zmqpubrawtx"],
+		ZMQReadDeadline: readDeadline,
+		Dialer: func(addr string) (net.Conn, error) {
+			return net.Dial("tcp", addr)
+		},
+		PrunedModeMaxPeers: maxPeers,
+		PollingConfig:      pollingCfg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Start(); err != nil {
+		conn.Stop()
+		return nil, err
+	}
+
+	client := conn.NewBitcoindClient()
+	client.ownsConn = true
+	return client, nil
+}
+
+// newNeutrinoBackEnd creates a neutrino light client storing its data in the
+// configured directory.  The data is stored in a bdb walletdb database, so the
+// bdb driver must be registered by the caller, as it is by the wallet.
+func newNeutrinoBackEnd(cfg *BackEndConfig) (Interface, error) {
+	dbTimeout, err := time.ParseDuration(cfg.Options["dbtimeout"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid dbtimeout: %v", err)
+	}
+
+	dataDir := cfg.Options["datadir"]
+	db, err := walletdb.Create(
+		"bdb", filepath.Join(dataDir, "neutrino.db"), true, dbTimeout,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create neutrino database: %v",
+			err)
+	}
+	chainService, err := neutrino.NewChainService(neutrino.Config{
+		DataDir:      dataDir,
+		Database:     db,
+		ChainParams:  *cfg.ChainParams,
+		ConnectPeers: splitPeers(cfg.Options["connect"]),
+		AddPeers:     splitPeers(cfg.Options["addpeer"]),
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return NewNeutrinoClient(cfg.ChainParams, chainService), nil
+}
+
+// newElectrumBackEnd creates a client of an Electrum server, connected to over
+// TLS unless it is disabled.  The certificates of the configuration, if any,
+// replace the system's root certificates.
+func newElectrumBackEnd(cfg *BackEndConfig) (Interface, error) {
+	requestTimeout, err := time.ParseDuration(cfg.Options["requesttimeout"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid requesttimeout: %v", err)
+	}
+	pingInterval, err := time.ParseDuration(cfg.Options["pinginterval"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pinginterval: %v", err)
+	}
+
+	if cfg.Host == "" {
+		return nil, errors.New("the electrum backend requires the " +
+			"address of a server")
+	}
+	server := cfg.Host
+	host, _, err := net.SplitHostPort(server)
+	if err != nil {
+		host = server
+		port := defaultElectrumTLSPort
+		if cfg.DisableTLS {
+			port = defaultElectrumTCPPort
+		}
+		server = net.JoinHostPort(server, port)
+	}
+
+	var tlsConfig *tls.Config
+	if !cfg.DisableTLS {
+		tlsConfig = &tls.Config{ServerName: host}
+		if len(cfg.Certs) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(cfg.Certs) {
+				return nil, errors.New("invalid electrum " +
+					"server certificates")
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+
+	return NewElectrumClient(&ElectrumConfig{
+		ChainParams:    cfg.ChainParams,
+		Server:         server,
+		TLSConfig:      tlsConfig,
+		RequestTimeout: requestTimeout,
+		PingInterval:   pingInterval,
+	}), nil
+}
+
+// newEsploraBackEnd creates a client of an Esplora REST API, whose base URL is
+// given as the host.  URLs without a scheme are requested over HTTPS unless TLS
+// is disabled.  The certificates of the configuration, if any, replace the
+// system's root certificates.
+func newEsploraBackEnd(cfg *BackEndConfig) (Interface, error) {
+	requestTimeout, err := time.ParseDuration(cfg.Options["requesttimeout"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid requesttimeout: %v", err)
+	}
+	pollInterval, err := time.ParseDuration(cfg.Options["pollinterval"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pollinterval: %v", err)
+	}
+	maxRetries, err := strconv.Atoi(cfg.Options["maxretries"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid maxretries: %v", err)
+	}
+	retryBackoff, err := time.ParseDuration(cfg.Options["retrybackoff"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid retrybackoff: %v", err)
+	}
+
+	if cfg.Host == "" {
+		return nil, errors.New("the esplora backend requires the URL " +
+			"of a server")
+	}
+	url := cfg.Host
+	if !strings.Contains(url, "://") {
+		if cfg.DisableTLS {
+			url = "http://" + url
+		} else {
+			url = "https://" + url
+		}
+	}
+
+	httpClient := http.DefaultClient
+	if len(cfg.Certs) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(cfg.Certs) {
+			return nil, errors.New("invalid esplora server " +
+				"certificates")
+		}
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+			},
+		}
+	}
+
+	return NewEsploraClient(&EsploraConfig{
+		ChainParams:    cfg.ChainParams,
+		URL:            url,
+		HTTPClient:     httpClient,
+		RequestTimeout: requestTimeout,
+		PollInterval:   pollInterval,
+		MaxRetries:     maxRetries,
+		RetryBackoff:   retryBackoff,
+	}), nil
+}
+
+// splitPeers splits a comma separated list of peers.
+func splitPeers(peers string) []string {
+	var split []string
+	for _, peer := range strings.Split(peers, ",") {
+		if peer = strings.TrimSpace(peer); peer != "" {
+			split = append(split, peer)
+		}
+	}
+	return split
+}
@@ -0,0 +1,1199 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+const (
+	// electrumProtocolVersion is the version of the Electrum protocol
+	// negotiated with servers.
+	electrumProtocolVersion = "1.4"
+
+	// electrumHeadersChunk is the number of headers requested at once,
+	// which is the maximum servers return for a single request.
+	electrumHeadersChunk = 2016
+
+	// electrumMaxCachedHeaders is the number of headers kept in memory
+	// before the cache is cleared.
+	electrumMaxCachedHeaders = 10 * electrumHeadersChunk
+
+	// electrumMaxConcurrentRequests is the number of requests pipelined
+	// when fetching the histories of many addresses at once.
+	electrumMaxConcurrentRequests = 16
+
+	// defaultElectrumRequestTimeout and defaultElectrumPingInterval are
+	// used when the configuration of a client leaves them unset.
+	defaultElectrumRequestTimeout = 30 * time.Second
+	defaultElectrumPingInterval   = time.Minute
+)
+
+var (
+	// ErrElectrumClientShuttingDown is returned when requests are made to
+	// an Electrum client that is shutting down.
+	ErrElectrumClientShuttingDown = errors.New("client is shutting down")
+
+	// ErrElectrumNoBlocks is returned when requesting a full block from an
+	// Electrum server, which only serves headers and transactions.
+	ErrElectrumNoBlocks = errors.New("electrum servers do not serve " +
+		"blocks")
+)
+
+// ElectrumConfig contains the parameters of a connection to an Electrum
+// server.
+type ElectrumConfig struct {
+	// ChainParams are the parameters of the chain the server follows.
+	ChainParams *chaincfg.Params
+
+	// Server is the host and port of the Electrum server.
+	Server string
+
+	// TLSConfig is the configuration of the TLS connection to the server,
+	// or nil to connect over plain TCP.
+	TLSConfig *tls.Config
+
+	// RequestTimeout is the time waited for the server to answer a
+	// request.
+	RequestTimeout time.Duration
+
+	// PingInterval is the interval at which the server is pinged to keep
+	// the connection alive.
+	PingInterval time.Duration
+}
+
+// electrumHeader is a block header and its height as notified by an Electrum
+// server.
+type electrumHeader struct {
+	Height int32  `json:"height"`
+	Hex    string `json:"hex"`
+}
+
+// electrumHeaders is a chunk of consecutive serialized block headers.
+type electrumHeaders struct {
+	Count int32  `json:"count"`
+	Hex   string `json:"hex"`
+	Max   int32  `json:"max"`
+}
+
+// electrumHistoryItem is a transaction of the history of a script hash.  Its
+// height is 0 or negative while it is unconfirmed.
+type electrumHistoryItem struct {
+	Height int32  `json:"height"`
+	TxHash string `json:"tx_hash"`
+}
+
+// electrumMerkle is the merkle branch proving the inclusion of a transaction
+// in a block.
+type electrumMerkle struct {
+	BlockHeight int32    `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Pos         uint32   `json:"pos"`
+}
+
+// ElectrumClient is an implementation of the chain.Interface interface on top
+// of the Electrum server protocol.  Addresses are watched through script hash
+// subscriptions, and block notifications are derived from the header
+// subscription.  The inclusion of every confirmed transaction reported by the
+// server is verified against the merkle root of its block header.
+type ElectrumClient struct {
+	// notifyBlocks signals whether the client is sending block
+	// notifications to the caller. This must be used atomically.
+	notifyBlocks uint32
+
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	cfg  ElectrumConfig
+	conn *electrumConn
+
+	notificationQueue *ConcurrentQueue
+
+	// chainMtx guards the view of the best chain: the best block, the
+	// recent blocks notified to the caller, used to detect reorgs, and the
+	// caches of headers fetched from the server.
+	chainMtx  sync.Mutex
+	bestBlock waddrmgr.BlockStamp
+	blocks    map[int32]waddrmgr.BlockStamp
+	headers   map[int32]*wire.BlockHeader
+	heights   map[chainhash.Hash]int32
+
+	// watched maps the script hashes subscribed to to their addresses, and
+	// seenTxs the relevant transactions notified to the caller to the
+	// height they were notified at, 0 if unconfirmed.
+	watchMtx sync.Mutex
+	watched  map[string]btcutil.Address
+	seenTxs  map[chainhash.Hash]int32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to ensure that ElectrumClient satisfies the
+// chain.Interface interface.
+var _ Interface = (*ElectrumClient)(nil)
+
+// NewElectrumClient creates a client of the Electrum server described by the
+// configuration.  The connection is established when the client is started.
+func NewElectrumClient(cfg *ElectrumConfig) *ElectrumClient {
+	clientCfg := *cfg
+	if clientCfg.RequestTimeout <= 0 {
+		clientCfg.RequestTimeout = defaultElectrumRequestTimeout
+	}
+	if clientCfg.PingInterval <= 0 {
+		clientCfg.PingInterval = defaultElectrumPingInterval
+	}
+
+	return &ElectrumClient{
+		cfg:               clientCfg,
+		notificationQueue: NewConcurrentQueue(20),
+		blocks:            make(map[int32]waddrmgr.BlockStamp),
+		headers:           make(map[int32]*wire.BlockHeader),
+		heights:           make(map[chainhash.Hash]int32),
+		watched:           make(map[string]btcutil.Address),
+		seenTxs:           make(map[chainhash.Hash]int32),
+		quit:              make(chan struct{}),
+	}
+}
+
+// BackEnd returns the name of the driver.
+func (c *ElectrumClient) BackEnd() string {
+	return electrumBackEnd
+}
+
+// Start connects to the Electrum server, ensures it follows the configured
+// chain and subscribes to its headers.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	conn, err := dialElectrum(
+		c.cfg.Server, c.cfg.TLSConfig, c.cfg.RequestTimeout,
+	)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+
+	var version []string
+	err = c.conn.call(
+		"server.version", &version, "btcwallet",
+		electrumProtocolVersion,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to negotiate protocol version: %v",
+			err)
+	}
+
+	// Verify that the server is on the correct network.
+	genesis, err := c.header(0)
+	if err != nil {
+		return err
+	}
+	if genesis.BlockHash() != *c.cfg.ChainParams.GenesisHash {
+		return errors.New("mismatched networks")
+	}
+
+	var tip electrumHeader
+	err = c.conn.call("blockchain.headers.subscribe", &tip)
+	if err != nil {
+		return fmt.Errorf("unable to subscribe to headers: %v", err)
+	}
+	header, err := parseElectrumHeader(tip.Hex)
+	if err != nil {
+		return err
+	}
+	c.chainMtx.Lock()
+	c.cacheHeader(tip.Height, header)
+	c.setBestBlock(tip.Height, header)
+	c.chainMtx.Unlock()
+
+	// Start the notification queue and immediately dispatch a
+	// ClientConnected notification to the caller. This is needed as some of
+	// the callers will require this notification before proceeding.
+	c.notificationQueue.Start()
+	c.notificationQueue.ChanIn() <- ClientConnected{}
+
+	c.wg.Add(2)
+	go c.ntfnHandler()
+	go c.pingHandler()
+
+	return nil
+}
+
+// Stop disconnects from the Electrum server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+
+	close(c.quit)
+	if c.conn != nil {
+		c.conn.close()
+	}
+	c.notificationQueue.Stop()
+}
+
+// WaitForShutdown blocks until the client has finished disconnecting and all
+// handlers have exited.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) WaitForShutdown() {
+	c.wg.Wait()
+	if c.conn != nil {
+		c.conn.wg.Wait()
+	}
+}
+
+// Notifications returns a channel to retrieve notifications from.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) Notifications() <-chan interface{} {
+	return c.notificationQueue.ChanOut()
+}
+
+// GetBestBlock returns the tip of the chain notified by the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	c.chainMtx.Lock()
+	defer c.chainMtx.Unlock()
+
+	hash := c.bestBlock.Hash
+	return &hash, c.bestBlock.Height, nil
+}
+
+// BlockStamp returns the tip of the chain notified by the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	c.chainMtx.Lock()
+	bestBlock := c.bestBlock
+	c.chainMtx.Unlock()
+
+	return &bestBlock, nil
+}
+
+// IsCurrent returns whether the tip of the chain notified by the server is
+// recent.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) IsCurrent() bool {
+	c.chainMtx.Lock()
+	defer c.chainMtx.Unlock()
+
+	return c.bestBlock.Timestamp.After(time.Now().Add(-isCurrentDelta))
+}
+
+// GetBlock always returns ErrElectrumNoBlocks, as Electrum servers do not
+// serve blocks.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	return nil, ErrElectrumNoBlocks
+}
+
+// GetBlockHash returns the hash of the block of the main chain at the given
+// height.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	header, err := c.header(int32(height))
+	if err != nil {
+		return nil, err
+	}
+	hash := header.BlockHash()
+	return &hash, nil
+}
+
+// GetBlockHeader returns the header of a block of the main chain.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+
+	height, err := c.GetBlockHeight(hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.header(height)
+	if err != nil {
+		return nil, err
+	}
+	if header.BlockHash() != *hash {
+		return nil, fmt.Errorf("block %v is not in the main chain",
+			hash)
+	}
+	return header, nil
+}
+
+// GetBlockHeight returns the height of a block of the main chain.  Electrum
+// servers only serve headers by height, so blocks not seen by the client yet
+// are searched for backwards from the tip of the chain.
+func (c *ElectrumClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	if *hash == *c.cfg.ChainParams.GenesisHash {
+		return 0, nil
+	}
+
+	c.chainMtx.Lock()
+	height, ok := c.heights[*hash]
+	tip := c.bestBlock.Height
+	c.chainMtx.Unlock()
+	if ok {
+		return height, nil
+	}
+
+	for end := tip; end > 0; end -= electrumHeadersChunk {
+		start := end - electrumHeadersChunk + 1
+		if start < 0 {
+			start = 0
+		}
+		headers, err := c.fetchHeaders(start, end-start+1)
+		if err != nil {
+			return 0, err
+		}
+		for i, header := range headers {
+			if header.BlockHash() == *hash {
+				return start + int32(i), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("block %v is not in the main chain", hash)
+}
+
+// SendRawTransaction broadcasts a transaction through the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) SendRawTransaction(tx *wire.MsgTx,
+	allowHighFees bool) (*chainhash.Hash, error) {
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	var txid string
+	err := c.conn.call(
+		"blockchain.transaction.broadcast", &txid,
+		hex.EncodeToString(buf.Bytes()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(txid)
+}
+
+// NotifyBlocks starts sending block connected and disconnected notifications
+// to the caller.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) NotifyBlocks() error {
+	atomic.StoreUint32(&c.notifyBlocks, 1)
+	return nil
+}
+
+// shouldNotifyBlocks determines whether the client should send block
+// notifications to the caller.
+func (c *ElectrumClient) shouldNotifyBlocks() bool {
+	return atomic.LoadUint32(&c.notifyBlocks) == 1
+}
+
+// NotifyReceived subscribes to the script hashes of the given addresses, so
+// that the caller is notified of the transactions paying to or spending from
+// them.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) NotifyReceived(addrs []btcutil.Address) error {
+	for _, addr := range addrs {
+		scriptHash, status, err := c.watch(addr)
+		if err != nil {
+			return err
+		}
+
+		// Addresses that already have a history are processed right
+		// away, as the server only notifies changes of the status.
+		if status != nil {
+			if err := c.processHistory(scriptHash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Rescan subscribes to the given addresses, and to those of the outpoints, and
+// notifies the caller of their transactions confirmed since the given block or
+// unconfirmed.  The spends of the outpoints are part of the histories of the
+// addresses they pay to.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) Rescan(startHash *chainhash.Hash,
+	addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address) error {
+
+	startHeight, err := c.GetBlockHeight(startHash)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range outPoints {
+		addrs = append(addrs, addr)
+	}
+	scriptHashes := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		scriptHash, _, err := c.watch(addr)
+		if err != nil {
+			return err
+		}
+		scriptHashes = append(scriptHashes, scriptHash)
+	}
+
+	histories, err := c.histories(scriptHashes)
+	if err != nil {
+		return err
+	}
+	var items []electrumHistoryItem
+	for _, history := range histories {
+		for _, item := range history {
+			if item.Height <= 0 || item.Height >= startHeight {
+				items = append(items, item)
+			}
+		}
+	}
+	if err := c.notifyHistory(items); err != nil {
+		return err
+	}
+
+	bestBlock, err := c.BlockStamp()
+	if err != nil {
+		return err
+	}
+	select {
+	case c.notificationQueue.ChanIn() <- &RescanFinished{
+		Hash:   &bestBlock.Hash,
+		Height: bestBlock.Height,
+		Time:   bestBlock.Timestamp,
+	}:
+	case <-c.quit:
+		return ErrElectrumClientShuttingDown
+	}
+	return nil
+}
+
+// FilterBlocks scans the blocks contained in the FilterBlocksRequest for any
+// addresses of interest.  Rather than fetching the blocks, the histories of the
+// addresses are looked up, and only their transactions are fetched and
+// filtered, block by block, returning a FilterBlocksResponse for the first
+// block containing a matching address.  If no matches are found in the range
+// of blocks requested, the returned response will be nil.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *ElectrumClient) FilterBlocks(
+	req *FilterBlocksRequest) (*FilterBlocksResponse, error) {
+
+	if len(req.Blocks) == 0 {
+		return nil, nil
+	}
+	startHeight := req.Blocks[0].Height
+	endHeight := req.Blocks[len(req.Blocks)-1].Height
+
+	var scriptHashes []string
+	addScriptHash := func(addr btcutil.Address) error {
+		scriptHash, err := electrumScriptHash(addr)
+		if err != nil {
+			return err
+		}
+		scriptHashes = append(scriptHashes, scriptHash)
+		return nil
+	}
+	for _, addr := range req.ExternalAddrs {
+		if err := addScriptHash(addr); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range req.InternalAddrs {
+		if err := addScriptHash(addr); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range req.WatchedOutPoints {
+		if err := addScriptHash(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	histories, err := c.histories(scriptHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index the transactions of the histories within the requested range
+	// by the height of their block.
+	blockTxs := make(map[int32][]chainhash.Hash)
+	seen := make(map[chainhash.Hash]struct{})
+	for _, history := range histories {
+		for _, item := range history {
+			if item.Height < startHeight || item.Height > endHeight {
+				continue
+			}
+			txid, err := chainhash.NewHashFromStr(item.TxHash)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[*txid]; ok {
+				continue
+			}
+			seen[*txid] = struct{}{}
+			blockTxs[item.Height] = append(
+				blockTxs[item.Height], *txid,
+			)
+		}
+	}
+
+	blockFilterer := NewBlockFilterer(c.cfg.ChainParams, req)
+
+	// Iterate over the requested blocks, filtering the transactions of the
+	// histories confirmed in each, breaking out early if any addresses are
+	// found.
+	for i, block := range req.Blocks {
+		txids := blockTxs[block.Height]
+		if len(txids) == 0 {
+			continue
+		}
+
+		// The transactions are filtered in block order, as the
+		// outpoints found are watched for spends by the following
+		// transactions of the block.
+		txs := make([]*wire.MsgTx, len(txids))
+		positions := make(map[*wire.MsgTx]uint32, len(txids))
+		for j := range txids {
+			tx, pos, err := c.confirmedTransaction(
+				&txids[j], block.Height,
+			)
+			if err != nil {
+				return nil, err
+			}
+			txs[j] = tx
+			positions[tx] = pos
+		}
+		sort.Slice(txs, func(j, k int) bool {
+			return positions[txs[j]] < positions[txs[k]]
+		})
+		filterBlock := &wire.MsgBlock{Transactions: txs}
+
+		if !blockFilterer.FilterBlock(filterBlock) {
+			continue
+		}
+
+		// If any external or internal addresses were detected in this
+		// block, we return them to the caller so that the rescan
+		// windows can widened with subsequent addresses. The
+		// `BatchIndex` is returned so that the caller can compute the
+		// *next* block from which to begin again.
+		resp := &FilterBlocksResponse{
+			BatchIndex:         uint32(i),
+			BlockMeta:          block,
+			FoundExternalAddrs: blockFilterer.FoundExternal,
+			FoundInternalAddrs: blockFilterer.FoundInternal,
+			FoundOutPoints:     blockFilterer.FoundOutPoints,
+			RelevantTxns:       blockFilterer.RelevantTxns,
+		}
+
+		return resp, nil
+	}
+
+	// No addresses were found for this range.
+	return nil, nil
+}
+
+// ntfnHandler handles the subscription notifications of the server until the
+// client is stopped, stopping the client when the connection is lost.
+//
+// NOTE: This must be called as a goroutine.
+func (c *ElectrumClient) ntfnHandler() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case n := <-c.conn.notifications.ChanOut():
+			msg := n.(*electrumMessage)
+			switch msg.Method {
+			case "blockchain.headers.subscribe":
+				var tips []electrumHeader
+				err := json.Unmarshal(msg.Params, &tips)
+				if err != nil || len(tips) == 0 {
+					log.Errorf("Invalid electrum header "+
+						"notification: %s", msg.Params)
+					continue
+				}
+				if err := c.connectTip(&tips[0]); err != nil {
+					log.Errorf("Unable to process new "+
+						"electrum tip at height %d: %v",
+						tips[0].Height, err)
+				}
+
+			case "blockchain.scripthash.subscribe":
+				var params []*string
+				err := json.Unmarshal(msg.Params, &params)
+				if err != nil || len(params) == 0 ||
+					params[0] == nil {
+
+					log.Errorf("Invalid electrum script "+
+						"hash notification: %s",
+						msg.Params)
+					continue
+				}
+				if err := c.processHistory(*params[0]); err != nil {
+					log.Errorf("Unable to process history "+
+						"of script hash %s: %v",
+						*params[0], err)
+				}
+			}
+
+		case <-c.conn.quit:
+			c.Stop()
+			return
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// pingHandler pings the server periodically to keep the connection alive.
+//
+// NOTE: This must be called as a goroutine.
+func (c *ElectrumClient) pingHandler() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.conn.call("server.ping", nil); err != nil {
+				log.Warnf("Unable to ping electrum server: %v",
+					err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// connectTip processes a new tip notified by the server.  The blocks notified
+// to the caller that are no longer part of the main chain are disconnected,
+// and the blocks between the common ancestor and the new tip are connected.
+func (c *ElectrumClient) connectTip(tip *electrumHeader) error {
+	tipHeader, err := parseElectrumHeader(tip.Hex)
+	if err != nil {
+		return err
+	}
+
+	c.chainMtx.Lock()
+	best := c.bestBlock
+	if tip.Height == best.Height && tipHeader.BlockHash() == best.Hash {
+		c.chainMtx.Unlock()
+		return nil
+	}
+	if tip.Height == best.Height+1 && tipHeader.PrevBlock == best.Hash {
+		c.cacheHeader(tip.Height, tipHeader)
+		c.setBestBlock(tip.Height, tipHeader)
+		c.chainMtx.Unlock()
+
+		c.notifyBlock(BlockConnected{
+			Block: wtxmgr.Block{
+				Hash:   tipHeader.BlockHash(),
+				Height: tip.Height,
+			},
+			Time: tipHeader.Timestamp,
+		})
+		return nil
+	}
+
+	// The new tip doesn't extend the best chain, either because of a
+	// reorg or missed blocks, so the cached headers can't be trusted
+	// anymore.
+	c.headers = make(map[int32]*wire.BlockHeader)
+	c.heights = make(map[chainhash.Hash]int32)
+	c.cacheHeader(tip.Height, tipHeader)
+	c.chainMtx.Unlock()
+
+	// Disconnect the blocks above the new tip, and then those that are no
+	// longer part of the main chain, until reaching the common ancestor.
+	forkHeight := best.Height
+	if forkHeight >= tip.Height {
+		forkHeight = tip.Height - 1
+	}
+	for height := best.Height; height > forkHeight; height-- {
+		c.disconnectBlock(height)
+	}
+	for ; forkHeight > 0; forkHeight-- {
+		c.chainMtx.Lock()
+		block, ok := c.blocks[forkHeight]
+		c.chainMtx.Unlock()
+		if !ok {
+			break
+		}
+
+		header, err := c.header(forkHeight)
+		if err != nil {
+			return err
+		}
+		if header.BlockHash() == block.Hash {
+			break
+		}
+		c.disconnectBlock(forkHeight)
+	}
+
+	for height := forkHeight + 1; height <= tip.Height; height++ {
+		header, err := c.header(height)
+		if err != nil {
+			return err
+		}
+
+		c.chainMtx.Lock()
+		prev, ok := c.blocks[height-1]
+		if ok && header.PrevBlock != prev.Hash {
+			c.chainMtx.Unlock()
+			return fmt.Errorf("header at height %d does not "+
+				"connect to the best chain", height)
+		}
+		c.setBestBlock(height, header)
+		c.chainMtx.Unlock()
+
+		c.notifyBlock(BlockConnected{
+			Block: wtxmgr.Block{
+				Hash:   header.BlockHash(),
+				Height: height,
+			},
+			Time: header.Timestamp,
+		})
+	}
+
+	return nil
+}
+
+// disconnectBlock removes the block at the given height from the best chain,
+// notifying the caller.
+func (c *ElectrumClient) disconnectBlock(height int32) {
+	c.chainMtx.Lock()
+	block, ok := c.blocks[height]
+	delete(c.blocks, height)
+	prev, prevOK := c.blocks[height-1]
+	if prevOK {
+		c.bestBlock = prev
+	}
+	c.chainMtx.Unlock()
+	if !ok {
+		return
+	}
+
+	c.notifyBlock(BlockDisconnected{
+		Block: wtxmgr.Block{
+			Hash:   block.Hash,
+			Height: block.Height,
+		},
+		Time: block.Timestamp,
+	})
+}
+
+// notifyBlock sends a block notification to the caller if requested.
+func (c *ElectrumClient) notifyBlock(ntfn interface{}) {
+	if !c.shouldNotifyBlocks() {
+		return
+	}
+
+	select {
+	case c.notificationQueue.ChanIn() <- ntfn:
+	case <-c.quit:
+	}
+}
+
+// setBestBlock makes the block at the given height the tip of the best chain.
+// The blocks deeper than the maximum reorg depth are forgotten.
+//
+// NOTE: The chainMtx must be held.
+func (c *ElectrumClient) setBestBlock(height int32,
+	header *wire.BlockHeader) {
+
+	c.bestBlock = waddrmgr.BlockStamp{
+		Hash:      header.BlockHash(),
+		Height:    height,
+		Timestamp: header.Timestamp,
+	}
+	c.blocks[height] = c.bestBlock
+	delete(c.blocks, height-waddrmgr.MaxReorgDepth)
+}
+
+// cacheHeader adds a header of the main chain to the cache.
+//
+// NOTE: The chainMtx must be held.
+func (c *ElectrumClient) cacheHeader(height int32, header *wire.BlockHeader) {
+	if len(c.headers) >= electrumMaxCachedHeaders {
+		c.headers = make(map[int32]*wire.BlockHeader)
+		c.heights = make(map[chainhash.Hash]int32)
+	}
+	c.headers[height] = header
+	c.heights[header.BlockHash()] = height
+}
+
+// header returns the header of the main chain at the given height, fetching
+// the chunk of headers starting at that height when it isn't cached.
+func (c *ElectrumClient) header(height int32) (*wire.BlockHeader, error) {
+	c.chainMtx.Lock()
+	header, ok := c.headers[height]
+	c.chainMtx.Unlock()
+	if ok {
+		return header, nil
+	}
+
+	headers, err := c.fetchHeaders(height, electrumHeadersChunk)
+	if err != nil {
+		return nil, err
+	}
+	return headers[0], nil
+}
+
+// fetchHeaders fetches and caches up to count headers of the main chain
+// starting at the given height, ensuring they are consecutive.
+func (c *ElectrumClient) fetchHeaders(start,
+	count int32) ([]*wire.BlockHeader, error) {
+
+	var chunk electrumHeaders
+	err := c.conn.call("blockchain.block.headers", &chunk, start, count)
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := hex.DecodeString(chunk.Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make([]*wire.BlockHeader, 0, chunk.Count)
+	r := bytes.NewReader(serialized)
+	for r.Len() > 0 {
+		header := &wire.BlockHeader{}
+		if err := header.Deserialize(r); err != nil {
+			return nil, err
+		}
+		if n := len(headers); n > 0 &&
+			header.PrevBlock != headers[n-1].BlockHash() {
+
+			return nil, fmt.Errorf("headers at height %d do not "+
+				"connect", start+int32(n))
+		}
+		headers = append(headers, header)
+	}
+	if len(headers) == 0 || len(headers) != int(chunk.Count) {
+		return nil, fmt.Errorf("no header at height %d", start)
+	}
+
+	c.chainMtx.Lock()
+	for i, header := range headers {
+		c.cacheHeader(start+int32(i), header)
+	}
+	c.chainMtx.Unlock()
+
+	return headers, nil
+}
+
+// watch subscribes to the script hash of an address, returning the script
+// hash and its current status, nil if it has no history.
+func (c *ElectrumClient) watch(addr btcutil.Address) (string, *string,
+	error) {
+
+	scriptHash, err := electrumScriptHash(addr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	c.watchMtx.Lock()
+	_, ok := c.watched[scriptHash]
+	c.watched[scriptHash] = addr
+	c.watchMtx.Unlock()
+	if ok {
+		return scriptHash, nil, nil
+	}
+
+	var status *string
+	err = c.conn.call("blockchain.scripthash.subscribe", &status, scriptHash)
+	if err != nil {
+		c.watchMtx.Lock()
+		delete(c.watched, scriptHash)
+		c.watchMtx.Unlock()
+		return "", nil, err
+	}
+	return scriptHash, status, nil
+}
+
+// histories fetches the histories of the given script hashes, pipelining the
+// requests.
+func (c *ElectrumClient) histories(
+	scriptHashes []string) (map[string][]electrumHistoryItem, error) {
+
+	unique := make(map[string]struct{}, len(scriptHashes))
+	for _, scriptHash := range scriptHashes {
+		unique[scriptHash] = struct{}{}
+	}
+
+	var (
+		mtx       sync.Mutex
+		histories = make(map[string][]electrumHistoryItem, len(unique))
+		firstErr  error
+		wg        sync.WaitGroup
+		sem       = make(chan struct{}, electrumMaxConcurrentRequests)
+	)
+	for scriptHash := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(scriptHash string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			var history []electrumHistoryItem
+			err := c.conn.call(
+				"blockchain.scripthash.get_history", &history,
+				scriptHash,
+			)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			histories[scriptHash] = history
+		}(scriptHash)
+	}
+	wg.Wait()
+
+	return histories, firstErr
+}
+
+// processHistory notifies the caller of the transactions of the history of a
+// script hash it hasn't been notified of yet, or whose confirmation changed.
+func (c *ElectrumClient) processHistory(scriptHash string) error {
+	histories, err := c.histories([]string{scriptHash})
+	if err != nil {
+		return err
+	}
+	return c.notifyHistory(histories[scriptHash])
+}
+
+// notifyHistory sends RelevantTx notifications for the given history items,
+// ordered by height with unconfirmed transactions last, skipping those already
+// notified at the same height.
+func (c *ElectrumClient) notifyHistory(items []electrumHistoryItem) error {
+	for i := range items {
+		if items[i].Height < 0 {
+			items[i].Height = 0
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		hi, hj := items[i].Height, items[j].Height
+		return hi != 0 && (hj == 0 || hi < hj)
+	})
+
+	for _, item := range items {
+		txid, err := chainhash.NewHashFromStr(item.TxHash)
+		if err != nil {
+			return err
+		}
+
+		c.watchMtx.Lock()
+		height, seen := c.seenTxs[*txid]
+		c.seenTxs[*txid] = item.Height
+		c.watchMtx.Unlock()
+		if seen && height == item.Height {
+			continue
+		}
+
+		ntfn, err := c.relevantTx(txid, item.Height)
+		if err != nil {
+			c.watchMtx.Lock()
+			delete(c.seenTxs, *txid)
+			c.watchMtx.Unlock()
+			return err
+		}
+
+		select {
+		case c.notificationQueue.ChanIn() <- ntfn:
+		case <-c.quit:
+			return ErrElectrumClientShuttingDown
+		}
+	}
+	return nil
+}
+
+// relevantTx fetches a transaction of a history, confirmed at the given height
+// unless it is 0, and creates its RelevantTx notification.
+func (c *ElectrumClient) relevantTx(txid *chainhash.Hash,
+	height int32) (RelevantTx, error) {
+
+	if height == 0 {
+		tx, err := c.transaction(txid)
+		if err != nil {
+			return RelevantTx{}, err
+		}
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			return RelevantTx{}, err
+		}
+		return RelevantTx{TxRecord: rec}, nil
+	}
+
+	tx, _, err := c.confirmedTransaction(txid, height)
+	if err != nil {
+		return RelevantTx{}, err
+	}
+	header, err := c.header(height)
+	if err != nil {
+		return RelevantTx{}, err
+	}
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, header.Timestamp)
+	if err != nil {
+		return RelevantTx{}, err
+	}
+	return RelevantTx{
+		TxRecord: rec,
+		Block: &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   header.BlockHash(),
+				Height: height,
+			},
+			Time: header.Timestamp,
+		},
+	}, nil
+}
+
+// transaction fetches a transaction from the server.
+func (c *ElectrumClient) transaction(txid *chainhash.Hash) (*wire.MsgTx,
+	error) {
+
+	var txHex string
+	err := c.conn.call("blockchain.transaction.get", &txHex, txid.String())
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, err
+	}
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, err
+	}
+	if tx.TxHash() != *txid {
+		return nil, fmt.Errorf("server returned transaction %v "+
+			"instead of %v", tx.TxHash(), txid)
+	}
+	return tx, nil
+}
+
+// confirmedTransaction fetches a transaction from the server and verifies its
+// inclusion in the block of the main chain at the given height, returning its
+// position in the block.
+func (c *ElectrumClient) confirmedTransaction(txid *chainhash.Hash,
+	height int32) (*wire.MsgTx, uint32, error) {
+
+	tx, err := c.transaction(txid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var proof electrumMerkle
+	err = c.conn.call(
+		"blockchain.transaction.get_merkle", &proof, txid.String(),
+		height,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	header, err := c.header(height)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	root := *txid
+	pos := proof.Pos
+	for _, branchStr := range proof.Merkle {
+		branch, err := chainhash.NewHashFromStr(branchStr)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var pair [chainhash.HashSize * 2]byte
+		if pos&1 == 1 {
+			copy(pair[:], branch[:])
+			copy(pair[chainhash.HashSize:], root[:])
+		} else {
+			copy(pair[:], root[:])
+			copy(pair[chainhash.HashSize:], branch[:])
+		}
+		root = chainhash.DoubleHashH(pair[:])
+		pos >>= 1
+	}
+	if root != header.MerkleRoot {
+		return nil, 0, fmt.Errorf("invalid merkle proof for transaction "+
+			"%v in block at height %d", txid, height)
+	}
+
+	return tx, proof.Pos, nil
+}
+
+// parseElectrumHeader parses a hex encoded block header.
+func parseElectrumHeader(headerHex string) (*wire.BlockHeader, error) {
+	serialized, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+	header := &wire.BlockHeader{}
+	if err := header.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// electrumScriptHash returns the script hash identifying an address to
+// Electrum servers: the reversed SHA256 hash of its output script.
+func electrumScriptHash(addr btcutil.Address) (string, error) {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(pkScript)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:]), nil
+}
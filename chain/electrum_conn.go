@@ -0,0 +1,226 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// errElectrumConnClosed is returned for requests made on, or interrupted by,
+// a closed connection to an Electrum server.
+var errElectrumConnClosed = errors.New("electrum connection closed")
+
+// ElectrumError is an error returned by an Electrum server in response to a
+// request.
+type ElectrumError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns the error message of the server.
+func (e *ElectrumError) Error() string {
+	return fmt.Sprintf("electrum server error %d: %s", e.Code, e.Message)
+}
+
+// electrumRequest is a JSON-RPC request sent to an Electrum server.
+type electrumRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// electrumMessage is a message received from an Electrum server: either the
+// response to a request, or a notification of a subscription when it has no
+// ID.
+type electrumMessage struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *ElectrumError  `json:"error"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// electrumConn is a JSON-RPC connection to an Electrum server.  Requests are
+// pipelined over the connection and matched with their responses by ID, while
+// subscription notifications are delivered through the notifications queue,
+// so that reading responses never waits for notifications to be handled.
+type electrumConn struct {
+	conn           net.Conn
+	requestTimeout time.Duration
+
+	writeMtx sync.Mutex
+
+	pendingMtx sync.Mutex
+	nextID     uint64
+	pending    map[uint64]chan *electrumMessage
+
+	notifications *ConcurrentQueue
+
+	closeOnce sync.Once
+	quit      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// dialElectrum connects to the Electrum server at addr, over TLS unless
+// tlsConfig is nil.
+func dialElectrum(addr string, tlsConfig *tls.Config,
+	requestTimeout time.Duration) (*electrumConn, error) {
+
+	dialer := &net.Dialer{Timeout: requestTimeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &electrumConn{
+		conn:           conn,
+		requestTimeout: requestTimeout,
+		pending:        make(map[uint64]chan *electrumMessage),
+		notifications:  NewConcurrentQueue(20),
+		quit:           make(chan struct{}),
+	}
+
+	c.notifications.Start()
+	c.wg.Add(1)
+	go c.readHandler()
+
+	return c, nil
+}
+
+// readHandler reads the messages of the server, dispatching responses to the
+// pending requests and notifications to the notifications queue, until the
+// connection is closed.
+//
+// NOTE: This must be called as a goroutine.
+func (c *electrumConn) readHandler() {
+	defer c.wg.Done()
+	defer c.close()
+
+	scanner := bufio.NewScanner(c.conn)
+
+	// Responses carrying histories or blocks of headers can be large.
+	scanner.Buffer(nil, 16*1024*1024)
+
+	for scanner.Scan() {
+		var msg electrumMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			log.Errorf("Unable to parse message of electrum "+
+				"server %v: %v", c.conn.RemoteAddr(), err)
+			return
+		}
+
+		if msg.ID == nil {
+			select {
+			case c.notifications.ChanIn() <- &msg:
+			case <-c.quit:
+				return
+			}
+			continue
+		}
+
+		c.pendingMtx.Lock()
+		respChan, ok := c.pending[*msg.ID]
+		delete(c.pending, *msg.ID)
+		c.pendingMtx.Unlock()
+		if ok {
+			respChan <- &msg
+		}
+	}
+
+	select {
+	case <-c.quit:
+	default:
+		log.Infof("Lost connection to electrum server %v: %v",
+			c.conn.RemoteAddr(), scanner.Err())
+	}
+}
+
+// call sends a request to the server and waits for its response, which is
+// unmarshaled into result unless it is nil.
+func (c *electrumConn) call(method string, result interface{},
+	params ...interface{}) error {
+
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	// The response channel is buffered so that the read handler never
+	// blocks on requests that timed out.
+	respChan := make(chan *electrumMessage, 1)
+	c.pendingMtx.Lock()
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = respChan
+	c.pendingMtx.Unlock()
+
+	defer func() {
+		c.pendingMtx.Lock()
+		delete(c.pending, id)
+		c.pendingMtx.Unlock()
+	}()
+
+	req, err := json.Marshal(&electrumRequest{
+		JSONRPC: "2.0",
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+	req = append(req, '\n')
+
+	c.writeMtx.Lock()
+	c.conn.SetWriteDeadline(time.Now().Add(c.requestTimeout))
+	_, err = c.conn.Write(req)
+	c.writeMtx.Unlock()
+	if err != nil {
+		c.close()
+		return err
+	}
+
+	select {
+	case resp := <-respChan:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+
+	case <-time.After(c.requestTimeout):
+		return fmt.Errorf("electrum request %s timed out", method)
+
+	case <-c.quit:
+		return errElectrumConnClosed
+	}
+}
+
+// close closes the connection, interrupting any pending requests.
+func (c *electrumConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.quit)
+		c.conn.Close()
+		c.notifications.Stop()
+	})
+}
@@ -0,0 +1,728 @@
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// fakeElectrumServer is a local stand-in Electrum server, serving an in-memory
+// chain of blocks and mempool to the clients connected to it.
+type fakeElectrumServer struct {
+	t        *testing.T
+	listener net.Listener
+
+	mtx       sync.Mutex
+	blocks    []*wire.MsgBlock
+	mempool   []*wire.MsgTx
+	conns     []*fakeElectrumConn
+	nonce     uint32
+	badMerkle bool
+
+	// reverseHistory lists the transactions of each block in reverse
+	// order in the histories, as servers don't guarantee their order.
+	reverseHistory bool
+}
+
+// fakeElectrumConn is a client connection to a fakeElectrumServer.
+type fakeElectrumConn struct {
+	conn          net.Conn
+	writeMtx      sync.Mutex
+	subscriptions map[string]struct{}
+}
+
+// newFakeElectrumServer starts a fake Electrum server with a chain of the given
+// number of blocks on top of the genesis block.
+func newFakeElectrumServer(t *testing.T, numBlocks int) *fakeElectrumServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeElectrumServer{
+		t:        t,
+		listener: listener,
+		blocks:   []*wire.MsgBlock{chainParams.GenesisBlock},
+	}
+	for i := 0; i < numBlocks; i++ {
+		s.blocks = append(s.blocks, s.newBlock(s.blocks[i]))
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			c := &fakeElectrumConn{
+				conn:          conn,
+				subscriptions: make(map[string]struct{}),
+			}
+			s.mtx.Lock()
+			s.conns = append(s.conns, c)
+			s.mtx.Unlock()
+			go s.serve(c)
+		}
+	}()
+
+	return s
+}
+
+// newBlock creates a block on top of prev including the given transactions.
+//
+// NOTE: The mtx must be held.
+func (s *fakeElectrumServer) newBlock(prev *wire.MsgBlock,
+	txs ...*wire.MsgTx) *wire.MsgBlock {
+
+	s.nonce++
+	return newFakeBlock(prev, s.nonce, txs...)
+}
+
+// newFakeBlock creates a block on top of prev including the given
+// transactions after a coinbase made unique by the nonce.
+func newFakeBlock(prev *wire.MsgBlock, nonce uint32,
+	txs ...*wire.MsgTx) *wire.MsgBlock {
+
+	var sigScript [4]byte
+	binary.BigEndian.PutUint32(sigScript[:], nonce)
+	coinbase := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{
+				Index: wire.MaxPrevOutIndex,
+			},
+			SignatureScript: sigScript[:],
+		}},
+		TxOut: []*wire.TxOut{{Value: 50e8, PkScript: []byte{0x51}}},
+	}
+	txs = append([]*wire.MsgTx{coinbase}, txs...)
+
+	return &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:    1,
+			PrevBlock:  prev.BlockHash(),
+			MerkleRoot: calcMerkleRoot(txs),
+			Timestamp:  prev.Header.Timestamp.Add(10 * time.Minute),
+			Bits:       chainParams.PowLimitBits,
+		},
+		Transactions: txs,
+	}
+}
+
+// mine replaces the given number of blocks at the tip of the chain with new
+// blocks, the first including the given transactions, and notifies the
+// subscribed clients.
+func (s *fakeElectrumServer) mine(replace, numBlocks int, txs ...*wire.MsgTx) {
+	s.mtx.Lock()
+	s.blocks = s.blocks[:len(s.blocks)-replace]
+	for i := 0; i < numBlocks; i++ {
+		tip := s.blocks[len(s.blocks)-1]
+		s.blocks = append(s.blocks, s.newBlock(tip, txs...))
+		txs = nil
+	}
+	s.mempool = nil
+	s.mtx.Unlock()
+
+	s.notify()
+}
+
+// notify sends the tip of the chain and the status of the subscribed script
+// hashes to the clients.
+func (s *fakeElectrumServer) notify() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	height := len(s.blocks) - 1
+	tip := map[string]interface{}{
+		"height": height,
+		"hex":    serializeHeader(s.t, &s.blocks[height].Header),
+	}
+	for _, c := range s.conns {
+		c.send(s.t, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "blockchain.headers.subscribe",
+			"params":  []interface{}{tip},
+		})
+		for scriptHash := range c.subscriptions {
+			c.send(s.t, map[string]interface{}{
+				"jsonrpc": "2.0",
+				"method":  "blockchain.scripthash.subscribe",
+				"params": []interface{}{
+					scriptHash, s.status(scriptHash),
+				},
+			})
+		}
+	}
+}
+
+// send writes a message to the client.
+func (c *fakeElectrumConn) send(t *testing.T, msg interface{}) {
+	b, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+	c.conn.Write(append(b, '\n'))
+}
+
+// serve answers the requests of a client until it disconnects.
+func (s *fakeElectrumServer) serve(c *fakeElectrumConn) {
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(s.t, json.Unmarshal(scanner.Bytes(), &req))
+
+		s.mtx.Lock()
+		result, err := s.handle(c, req.Method, req.Params)
+		s.mtx.Unlock()
+
+		resp := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+		}
+		if err != nil {
+			resp["error"] = &ElectrumError{Code: 1, Message: err.Error()}
+		} else {
+			resp["result"] = result
+		}
+		c.send(s.t, resp)
+	}
+}
+
+// handle answers a request.
+//
+// NOTE: The mtx must be held.
+func (s *fakeElectrumServer) handle(c *fakeElectrumConn, method string,
+	params []json.RawMessage) (interface{}, error) {
+
+	var (
+		str    string
+		height int32
+	)
+	parseParams := func(dst ...interface{}) {
+		for i, d := range dst {
+			require.NoError(s.t, json.Unmarshal(params[i], d))
+		}
+	}
+
+	switch method {
+	case "server.version":
+		return []string{"fake", electrumProtocolVersion}, nil
+
+	case "server.ping":
+		return nil, nil
+
+	case "blockchain.headers.subscribe":
+		tip := len(s.blocks) - 1
+		return map[string]interface{}{
+			"height": tip,
+			"hex":    serializeHeader(s.t, &s.blocks[tip].Header),
+		}, nil
+
+	case "blockchain.block.headers":
+		var start, count int
+		parseParams(&start, &count)
+		var buf bytes.Buffer
+		n := 0
+		for i := start; i < len(s.blocks) && n < count; i++ {
+			require.NoError(s.t, s.blocks[i].Header.Serialize(&buf))
+			n++
+		}
+		return map[string]interface{}{
+			"count": n,
+			"hex":   hex.EncodeToString(buf.Bytes()),
+			"max":   electrumHeadersChunk,
+		}, nil
+
+	case "blockchain.scripthash.subscribe":
+		parseParams(&str)
+		c.subscriptions[str] = struct{}{}
+		return s.status(str), nil
+
+	case "blockchain.scripthash.get_history":
+		parseParams(&str)
+		history := s.history(str)
+		if history == nil {
+			history = []electrumHistoryItem{}
+		}
+		return history, nil
+
+	case "blockchain.transaction.get":
+		parseParams(&str)
+		tx, _ := s.findTx(str)
+		if tx == nil {
+			return nil, fmt.Errorf("unknown transaction %s", str)
+		}
+		var buf bytes.Buffer
+		require.NoError(s.t, tx.Serialize(&buf))
+		return hex.EncodeToString(buf.Bytes()), nil
+
+	case "blockchain.transaction.get_merkle":
+		parseParams(&str, &height)
+		block := s.blocks[height]
+		for pos, tx := range block.Transactions {
+			if tx.TxHash().String() != str {
+				continue
+			}
+			branch := merkleBranch(block.Transactions, pos)
+			if s.badMerkle {
+				branch[0] = chainhash.Hash{}.String()
+			}
+			return &electrumMerkle{
+				BlockHeight: height,
+				Merkle:      branch,
+				Pos:         uint32(pos),
+			}, nil
+		}
+		return nil, fmt.Errorf("transaction %s not in block %d", str,
+			height)
+
+	case "blockchain.transaction.broadcast":
+		parseParams(&str)
+		serialized, err := hex.DecodeString(str)
+		require.NoError(s.t, err)
+		tx := &wire.MsgTx{}
+		require.NoError(s.t, tx.Deserialize(bytes.NewReader(serialized)))
+		if found, _ := s.findTx(tx.TxHash().String()); found != nil {
+			return nil, fmt.Errorf("txn-already-known")
+		}
+		s.mempool = append(s.mempool, tx)
+		go s.notify()
+		return tx.TxHash().String(), nil
+	}
+
+	return nil, fmt.Errorf("unknown method %s", method)
+}
+
+// findTx returns a transaction of the chain or mempool, and the height of its
+// block, 0 when unconfirmed.
+//
+// NOTE: The mtx must be held.
+func (s *fakeElectrumServer) findTx(txid string) (*wire.MsgTx, int32) {
+	for height, block := range s.blocks {
+		for _, tx := range block.Transactions {
+			if tx.TxHash().String() == txid {
+				return tx, int32(height)
+			}
+		}
+	}
+	for _, tx := range s.mempool {
+		if tx.TxHash().String() == txid {
+			return tx, 0
+		}
+	}
+	return nil, 0
+}
+
+// history returns the transactions of the chain and mempool paying to or
+// spending from the script hash.
+//
+// NOTE: The mtx must be held.
+func (s *fakeElectrumServer) history(scriptHash string) []electrumHistoryItem {
+	matches := func(pkScript []byte) bool {
+		hash := sha256.Sum256(pkScript)
+		for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+			hash[i], hash[j] = hash[j], hash[i]
+		}
+		return hex.EncodeToString(hash[:]) == scriptHash
+	}
+	relevant := func(tx *wire.MsgTx) bool {
+		for _, txOut := range tx.TxOut {
+			if matches(txOut.PkScript) {
+				return true
+			}
+		}
+		for _, txIn := range tx.TxIn {
+			prevTx, _ := s.findTx(txIn.PreviousOutPoint.Hash.String())
+			if prevTx != nil && matches(
+				prevTx.TxOut[txIn.PreviousOutPoint.Index].PkScript,
+			) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var history []electrumHistoryItem
+	for height, block := range s.blocks {
+		var items []electrumHistoryItem
+		for _, tx := range block.Transactions {
+			if relevant(tx) {
+				items = append(items, electrumHistoryItem{
+					Height: int32(height),
+					TxHash: tx.TxHash().String(),
+				})
+			}
+		}
+		if s.reverseHistory {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+		}
+		history = append(history, items...)
+	}
+	for _, tx := range s.mempool {
+		if relevant(tx) {
+			history = append(history, electrumHistoryItem{
+				TxHash: tx.TxHash().String(),
+			})
+		}
+	}
+	return history
+}
+
+// status returns the status of a script hash, or nil if it has no history.
+//
+// NOTE: The mtx must be held.
+func (s *fakeElectrumServer) status(scriptHash string) interface{} {
+	history := s.history(scriptHash)
+	if len(history) == 0 {
+		return nil
+	}
+	var status string
+	for _, item := range history {
+		status += fmt.Sprintf("%s:%d:", item.TxHash, item.Height)
+	}
+	hash := sha256.Sum256([]byte(status))
+	return hex.EncodeToString(hash[:])
+}
+
+// close stops the server and disconnects its clients.
+func (s *fakeElectrumServer) close() {
+	s.listener.Close()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, c := range s.conns {
+		c.conn.Close()
+	}
+}
+
+// merkleBranch returns the merkle branch of the transaction at the given
+// position of a block, as hex encoded hashes.
+func merkleBranch(txs []*wire.MsgTx, pos int) []string {
+	level := make([]chainhash.Hash, 0, len(txs))
+	for _, tx := range txs {
+		level = append(level, tx.TxHash())
+	}
+
+	var branch []string
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		branch = append(branch, level[pos^1].String())
+
+		next := make([]chainhash.Hash, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			var pair [chainhash.HashSize * 2]byte
+			copy(pair[:], level[i][:])
+			copy(pair[chainhash.HashSize:], level[i+1][:])
+			next = append(next, chainhash.DoubleHashH(pair[:]))
+		}
+		level = next
+		pos >>= 1
+	}
+	return branch
+}
+
+// serializeHeader returns a hex encoded block header.
+func serializeHeader(t *testing.T, header *wire.BlockHeader) string {
+	var buf bytes.Buffer
+	require.NoError(t, header.Serialize(&buf))
+	return hex.EncodeToString(buf.Bytes())
+}
+
+// nextElectrumNtfn returns the next notification of the client.
+func nextElectrumNtfn(t *testing.T, c *ElectrumClient) interface{} {
+	t.Helper()
+
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return nil
+	}
+}
+
+// TestElectrumClient ensures that the Electrum client serves the chain of the
+// server, notifies relevant transactions and blocks, handles reorgs, and
+// verifies the transactions it is served.
+func TestElectrumClient(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeElectrumServer(t, 5)
+	defer server.close()
+
+	addr, err := btcutil.NewAddressPubKeyHash(
+		bytes.Repeat([]byte{1}, 20), &chainParams,
+	)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	payTo := func(value int64) *wire.MsgTx {
+		return &wire.MsgTx{
+			Version: 1,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{
+					Hash:  chainhash.Hash{byte(value)},
+					Index: 0,
+				},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+		}
+	}
+
+	// Pay to the address in block 3.
+	txA := payTo(1000)
+	server.mtx.Lock()
+	server.blocks = server.blocks[:3]
+	for len(server.blocks) < 6 {
+		var txs []*wire.MsgTx
+		if len(server.blocks) == 3 {
+			txs = append(txs, txA)
+		}
+		tip := server.blocks[len(server.blocks)-1]
+		server.blocks = append(server.blocks, server.newBlock(tip, txs...))
+	}
+	blocks := append([]*wire.MsgBlock(nil), server.blocks...)
+	server.mtx.Unlock()
+
+	client := NewElectrumClient(&ElectrumConfig{
+		ChainParams: &chainParams,
+		Server:      server.listener.Addr().String(),
+	})
+	require.NoError(t, client.Start())
+	defer func() {
+		client.Stop()
+		client.WaitForShutdown()
+	}()
+	require.Equal(t, ClientConnected{}, nextElectrumNtfn(t, client))
+
+	// The client serves the headers of the server's chain.
+	bestHash, bestHeight, err := client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].BlockHash(), *bestHash)
+	require.Equal(t, int32(5), bestHeight)
+	hash, err := client.GetBlockHash(3)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].BlockHash(), *hash)
+	header, err := client.GetBlockHeader(hash)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].Header, *header)
+	_, err = client.GetBlockHeader(&chainhash.Hash{1})
+	require.Error(t, err)
+	_, err = client.GetBlock(hash)
+	require.Equal(t, ErrElectrumNoBlocks, err)
+
+	// Filtering the blocks finds the block paying to the address.
+	var metas []wtxmgr.BlockMeta
+	for height := int32(1); height <= 5; height++ {
+		metas = append(metas, wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   blocks[height].BlockHash(),
+				Height: height,
+			},
+			Time: blocks[height].Header.Timestamp,
+		})
+	}
+	scopedIndex := waddrmgr.ScopedIndex{
+		Scope: waddrmgr.KeyScopeBIP0044,
+		Index: 7,
+	}
+	filterReq := &FilterBlocksRequest{
+		Blocks: metas,
+		ExternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{
+			scopedIndex: addr,
+		},
+		InternalAddrs:    map[waddrmgr.ScopedIndex]btcutil.Address{},
+		WatchedOutPoints: map[wire.OutPoint]btcutil.Address{},
+	}
+	resp, err := client.FilterBlocks(filterReq)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Equal(t, uint32(2), resp.BatchIndex)
+	require.Equal(t, metas[2], resp.BlockMeta)
+	require.Len(t, resp.RelevantTxns, 1)
+	require.Equal(t, txA.TxHash(), resp.RelevantTxns[0].TxHash())
+	require.Equal(t, map[waddrmgr.KeyScope]map[uint32]struct{}{
+		waddrmgr.KeyScopeBIP0044: {7: {}},
+	}, resp.FoundExternalAddrs)
+	require.Contains(t, resp.FoundOutPoints, wire.OutPoint{
+		Hash: txA.TxHash(),
+	})
+
+	// Nothing is found after the block paying to the address.
+	filterReq.Blocks = metas[3:]
+	resp, err = client.FilterBlocks(filterReq)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	// Rescanning notifies the transaction paying to the address.
+	require.NoError(t, client.Rescan(
+		&metas[0].Hash, []btcutil.Address{addr}, nil,
+	))
+	relevant := nextElectrumNtfn(t, client).(RelevantTx)
+	require.Equal(t, txA.TxHash(), relevant.TxRecord.Hash)
+	require.Equal(t, &metas[2], relevant.Block)
+	finished := nextElectrumNtfn(t, client).(*RescanFinished)
+	require.Equal(t, int32(5), finished.Height)
+	require.Equal(t, blocks[5].BlockHash(), *finished.Hash)
+
+	// New blocks are notified, along with the transactions they confirm
+	// for the addresses rescanned.
+	require.NoError(t, client.NotifyBlocks())
+	txB := payTo(2000)
+	server.mine(0, 1, txB)
+	connected := nextElectrumNtfn(t, client).(BlockConnected)
+	require.Equal(t, int32(6), connected.Height)
+	relevant = nextElectrumNtfn(t, client).(RelevantTx)
+	require.Equal(t, txB.TxHash(), relevant.TxRecord.Hash)
+	require.Equal(t, connected.Hash, relevant.Block.Hash)
+
+	// Broadcast transactions are notified once accepted to the mempool.
+	txC := payTo(3000)
+	txid, err := client.SendRawTransaction(txC, false)
+	require.NoError(t, err)
+	require.Equal(t, txC.TxHash(), *txid)
+	relevant = nextElectrumNtfn(t, client).(RelevantTx)
+	require.Equal(t, txC.TxHash(), relevant.TxRecord.Hash)
+	require.Nil(t, relevant.Block)
+	_, err = client.SendRawTransaction(txC, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "txn-already-known")
+
+	// A reorg replacing block 6 disconnects it before connecting the new
+	// chain, in which the mempool transaction confirms.
+	server.mine(1, 2, txC)
+	disconnected := nextElectrumNtfn(t, client).(BlockDisconnected)
+	require.Equal(t, int32(6), disconnected.Height)
+	require.Equal(t, connected.Hash, disconnected.Hash)
+	server.mtx.Lock()
+	blocks = append([]*wire.MsgBlock(nil), server.blocks...)
+	server.mtx.Unlock()
+	for height := int32(6); height <= 7; height++ {
+		connected = nextElectrumNtfn(t, client).(BlockConnected)
+		require.Equal(t, height, connected.Height)
+		require.Equal(t, blocks[height].BlockHash(), connected.Hash)
+	}
+	relevant = nextElectrumNtfn(t, client).(RelevantTx)
+	require.Equal(t, txC.TxHash(), relevant.TxRecord.Hash)
+	require.Equal(t, int32(6), relevant.Block.Height)
+	require.Equal(t, blocks[6].BlockHash(), relevant.Block.Hash)
+
+	bestHash, bestHeight, err = client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, blocks[7].BlockHash(), *bestHash)
+	require.Equal(t, int32(7), bestHeight)
+
+	// Transactions whose inclusion in their block can't be proven are
+	// rejected.
+	server.mtx.Lock()
+	server.badMerkle = true
+	server.mtx.Unlock()
+	filterReq.Blocks = metas[:3]
+	_, err = client.FilterBlocks(filterReq)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid merkle proof")
+
+	// The client shuts down when the server goes away.
+	server.close()
+	done := make(chan struct{})
+	go func() {
+		client.WaitForShutdown()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not shut down after losing the server")
+	}
+}
+
+// TestElectrumFilterBlocksInBlockOrder ensures that the transactions of a
+// block are filtered in block order, so that the spend of an output created
+// earlier in the same block is found.
+func TestElectrumFilterBlocksInBlockOrder(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeElectrumServer(t, 2)
+	defer server.close()
+
+	addr, err := btcutil.NewAddressPubKeyHash(
+		bytes.Repeat([]byte{1}, 20), &chainParams,
+	)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// Fund the address and spend its output elsewhere in the same block.
+	fundingTx := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{1}},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(1000, pkScript)},
+	}
+	spendingTx := &wire.MsgTx{
+		Version: 1,
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Hash: fundingTx.TxHash()},
+		}},
+		TxOut: []*wire.TxOut{wire.NewTxOut(900, []byte{0x51})},
+	}
+	server.mtx.Lock()
+	server.reverseHistory = true
+	tip := server.blocks[len(server.blocks)-1]
+	block := server.newBlock(tip, fundingTx, spendingTx)
+	server.blocks = append(server.blocks, block)
+	server.mtx.Unlock()
+
+	client := NewElectrumClient(&ElectrumConfig{
+		ChainParams: &chainParams,
+		Server:      server.listener.Addr().String(),
+	})
+	require.NoError(t, client.Start())
+	defer func() {
+		client.Stop()
+		client.WaitForShutdown()
+	}()
+	require.Equal(t, ClientConnected{}, nextElectrumNtfn(t, client))
+
+	resp, err := client.FilterBlocks(&FilterBlocksRequest{
+		Blocks: []wtxmgr.BlockMeta{{
+			Block: wtxmgr.Block{
+				Hash:   block.BlockHash(),
+				Height: 3,
+			},
+			Time: block.Header.Timestamp,
+		}},
+		ExternalAddrs: map[waddrmgr.ScopedIndex]btcutil.Address{
+			{Scope: waddrmgr.KeyScopeBIP0044}: addr,
+		},
+		InternalAddrs:    map[waddrmgr.ScopedIndex]btcutil.Address{},
+		WatchedOutPoints: map[wire.OutPoint]btcutil.Address{},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.Len(t, resp.RelevantTxns, 2)
+	require.Equal(t, fundingTx.TxHash(), resp.RelevantTxns[0].TxHash())
+	require.Equal(t, spendingTx.TxHash(), resp.RelevantTxns[1].TxHash())
+}
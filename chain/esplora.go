@@ -0,0 +1,1204 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/blockchain"
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+const (
+	// esploraTxsPageSize is the number of confirmed transactions of an
+	// address returned by the server per page of its history.
+	esploraTxsPageSize = 25
+
+	// esploraMaxConcurrentRequests is the number of requests made at once
+	// when looking up the histories of many addresses or outpoints.
+	esploraMaxConcurrentRequests = 8
+
+	// esploraMaxResponseSize is the maximum size of a response read from
+	// the server, which must fit the largest blocks.
+	esploraMaxResponseSize = 32 * 1024 * 1024
+
+	// The defaults used when the configuration of a client leaves them
+	// unset.
+	defaultEsploraRequestTimeout = 30 * time.Second
+	defaultEsploraPollInterval   = 30 * time.Second
+	defaultEsploraRetryBackoff   = time.Second
+)
+
+var (
+	// ErrEsploraClientShuttingDown is returned when requests are made to
+	// an Esplora client that is shutting down.
+	ErrEsploraClientShuttingDown = errors.New("client is shutting down")
+)
+
+// EsploraConfig contains the parameters of a client of an Esplora REST API.
+type EsploraConfig struct {
+	// ChainParams are the parameters of the chain the server follows.
+	ChainParams *chaincfg.Params
+
+	// URL is the base URL of the REST API, such as
+	// https://blockstream.info/api.
+	URL string
+
+	// HTTPClient is the client the requests are made with, or nil to use
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	// RequestTimeout is the time waited for the server to answer a
+	// request.
+	RequestTimeout time.Duration
+
+	// PollInterval is the interval at which the server is polled for new
+	// blocks and unconfirmed transactions.
+	PollInterval time.Duration
+
+	// MaxRetries is the number of times a request failing because of the
+	// network or an unavailable server is retried.
+	MaxRetries int
+
+	// RetryBackoff is the time waited before retrying a failed request for
+	// the first time, doubled on every retry.
+	RetryBackoff time.Duration
+}
+
+// EsploraError is an error returned by an Esplora server in response to a
+// request.
+type EsploraError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the status and error message of the server.
+func (e *EsploraError) Error() string {
+	return fmt.Sprintf("esplora server returned status %d: %s",
+		e.StatusCode, e.Message)
+}
+
+// esploraBlock is the summary of a block returned by the server.
+type esploraBlock struct {
+	ID        string `json:"id"`
+	Height    int32  `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// esploraBlockStatus is the status of a block in the chain of the server.
+type esploraBlockStatus struct {
+	InBestChain bool  `json:"in_best_chain"`
+	Height      int32 `json:"height"`
+}
+
+// esploraTxStatus is the confirmation status of a transaction.
+type esploraTxStatus struct {
+	Confirmed   bool   `json:"confirmed"`
+	BlockHeight int32  `json:"block_height"`
+	BlockHash   string `json:"block_hash"`
+}
+
+// esploraTx is a transaction of the history of an address.  Only the fields
+// needed to locate the transaction are decoded.
+type esploraTx struct {
+	TxID   string          `json:"txid"`
+	Status esploraTxStatus `json:"status"`
+}
+
+// esploraOutSpend is the spending status of an output.
+type esploraOutSpend struct {
+	Spent  bool            `json:"spent"`
+	TxID   string          `json:"txid"`
+	Status esploraTxStatus `json:"status"`
+}
+
+// EsploraClient is an implementation of the chain.Interface interface on top
+// of the REST API of an Esplora indexer.  The server is polled for new blocks,
+// which are fetched and filtered for the watched addresses and outpoints, and
+// for the unconfirmed transactions of the watched addresses.  The blocks
+// served are verified against their merkle root.
+type EsploraClient struct {
+	// notifyBlocks signals whether the client is sending block
+	// notifications to the caller. This must be used atomically.
+	notifyBlocks uint32
+
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	cfg        EsploraConfig
+	httpClient *http.Client
+
+	notificationQueue *ConcurrentQueue
+
+	// chainMtx guards the view of the best chain: the best block, and the
+	// recent blocks notified to the caller, used to detect reorgs.
+	chainMtx  sync.Mutex
+	bestBlock waddrmgr.BlockStamp
+	blocks    map[int32]waddrmgr.BlockStamp
+
+	// watchedScripts maps the output scripts of the watched addresses to
+	// them, watchedOutPoints holds the watched outpoints, and seenTxs maps
+	// the relevant transactions notified to the caller to the height they
+	// were notified at, 0 if unconfirmed.
+	watchMtx         sync.Mutex
+	watchedScripts   map[string]btcutil.Address
+	watchedOutPoints map[wire.OutPoint]struct{}
+	seenTxs          map[chainhash.Hash]int32
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to ensure that EsploraClient satisfies the
+// chain.Interface interface.
+var _ Interface = (*EsploraClient)(nil)
+
+// NewEsploraClient creates a client of the Esplora REST API described by the
+// configuration.
+func NewEsploraClient(cfg *EsploraConfig) *EsploraClient {
+	clientCfg := *cfg
+	clientCfg.URL = strings.TrimSuffix(clientCfg.URL, "/")
+	if clientCfg.RequestTimeout <= 0 {
+		clientCfg.RequestTimeout = defaultEsploraRequestTimeout
+	}
+	if clientCfg.PollInterval <= 0 {
+		clientCfg.PollInterval = defaultEsploraPollInterval
+	}
+	if clientCfg.RetryBackoff <= 0 {
+		clientCfg.RetryBackoff = defaultEsploraRetryBackoff
+	}
+	httpClient := clientCfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &EsploraClient{
+		cfg:               clientCfg,
+		httpClient:        httpClient,
+		notificationQueue: NewConcurrentQueue(20),
+		blocks:            make(map[int32]waddrmgr.BlockStamp),
+		watchedScripts:    make(map[string]btcutil.Address),
+		watchedOutPoints:  make(map[wire.OutPoint]struct{}),
+		seenTxs:           make(map[chainhash.Hash]int32),
+		quit:              make(chan struct{}),
+	}
+}
+
+// BackEnd returns the name of the driver.
+func (c *EsploraClient) BackEnd() string {
+	return esploraBackEnd
+}
+
+// Start ensures the server follows the configured chain, fetches its tip and
+// starts polling it.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	// Verify that the server is on the correct network.
+	genesis, err := c.GetBlockHash(0)
+	if err != nil {
+		return err
+	}
+	if *genesis != *c.cfg.ChainParams.GenesisHash {
+		return errors.New("mismatched networks")
+	}
+
+	tipHash, err := c.getHash("/blocks/tip/hash")
+	if err != nil {
+		return err
+	}
+	var tip esploraBlock
+	if err := c.getJSON("/block/"+tipHash.String(), &tip); err != nil {
+		return err
+	}
+	c.chainMtx.Lock()
+	c.setBestBlock(waddrmgr.BlockStamp{
+		Hash:      *tipHash,
+		Height:    tip.Height,
+		Timestamp: time.Unix(tip.Timestamp, 0),
+	})
+	c.chainMtx.Unlock()
+
+	// Start the notification queue and immediately dispatch a
+	// ClientConnected notification to the caller. This is needed as some of
+	// the callers will require this notification before proceeding.
+	c.notificationQueue.Start()
+	c.notificationQueue.ChanIn() <- ClientConnected{}
+
+	c.wg.Add(1)
+	go c.pollHandler()
+
+	return nil
+}
+
+// Stop stops polling the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+
+	close(c.quit)
+	c.notificationQueue.Stop()
+}
+
+// WaitForShutdown blocks until the client has finished polling the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+// Notifications returns a channel to retrieve notifications from.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) Notifications() <-chan interface{} {
+	return c.notificationQueue.ChanOut()
+}
+
+// GetBestBlock returns the tip of the chain last polled from the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	c.chainMtx.Lock()
+	defer c.chainMtx.Unlock()
+
+	hash := c.bestBlock.Hash
+	return &hash, c.bestBlock.Height, nil
+}
+
+// BlockStamp returns the tip of the chain last polled from the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	c.chainMtx.Lock()
+	bestBlock := c.bestBlock
+	c.chainMtx.Unlock()
+
+	return &bestBlock, nil
+}
+
+// IsCurrent returns whether the tip of the chain last polled from the server
+// is recent.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) IsCurrent() bool {
+	c.chainMtx.Lock()
+	defer c.chainMtx.Unlock()
+
+	return c.bestBlock.Timestamp.After(time.Now().Add(-isCurrentDelta))
+}
+
+// GetBlock fetches a block from the server, ensuring it matches the hash
+// requested and its merkle root.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	serialized, err := c.request(
+		http.MethodGet, "/block/"+hash.String()+"/raw", nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &wire.MsgBlock{}
+	if err := block.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, err
+	}
+	if block.BlockHash() != *hash {
+		return nil, fmt.Errorf("server returned block %v instead of %v",
+			block.BlockHash(), hash)
+	}
+
+	merkles := blockchain.BuildMerkleTreeStore(
+		btcutil.NewBlock(block).Transactions(), false,
+	)
+	if *merkles[len(merkles)-1] != block.Header.MerkleRoot {
+		return nil, fmt.Errorf("transactions of block %v do not match "+
+			"its merkle root", hash)
+	}
+
+	return block, nil
+}
+
+// GetBlockHash returns the hash of the block of the main chain at the given
+// height.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	return c.getHash("/block-height/" + strconv.FormatInt(height, 10))
+}
+
+// GetBlockHeader returns the header of a block.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+
+	headerHex, err := c.getText("/block/" + hash.String() + "/header")
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, err
+	}
+	header := &wire.BlockHeader{}
+	if err := header.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, err
+	}
+	if header.BlockHash() != *hash {
+		return nil, fmt.Errorf("server returned header of block %v "+
+			"instead of %v", header.BlockHash(), hash)
+	}
+	return header, nil
+}
+
+// GetBlockHeight returns the height of a block of the main chain.
+func (c *EsploraClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	var status esploraBlockStatus
+	err := c.getJSON("/block/"+hash.String()+"/status", &status)
+	if err != nil {
+		return 0, err
+	}
+	if !status.InBestChain {
+		return 0, fmt.Errorf("block %v is not in the main chain", hash)
+	}
+	return status.Height, nil
+}
+
+// SendRawTransaction publishes a transaction through the server.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) SendRawTransaction(tx *wire.MsgTx,
+	allowHighFees bool) (*chainhash.Hash, error) {
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	txHex := []byte(hex.EncodeToString(buf.Bytes()))
+
+	txid, err := c.request(http.MethodPost, "/tx", txHex)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(strings.TrimSpace(string(txid)))
+}
+
+// NotifyBlocks starts sending block connected and disconnected notifications
+// to the caller.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) NotifyBlocks() error {
+	atomic.StoreUint32(&c.notifyBlocks, 1)
+	return nil
+}
+
+// shouldNotifyBlocks determines whether the client should send block
+// notifications to the caller.
+func (c *EsploraClient) shouldNotifyBlocks() bool {
+	return atomic.LoadUint32(&c.notifyBlocks) == 1
+}
+
+// NotifyReceived watches the given addresses, so that the caller is notified
+// of the transactions paying to or spending from them from then on.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) NotifyReceived(addrs []btcutil.Address) error {
+	for _, addr := range addrs {
+		if err := c.watchAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rescan watches the given addresses and outpoints, and notifies the caller of
+// their transactions confirmed since the given block or unconfirmed.  The
+// histories of the addresses and the spends of the outpoints are looked up,
+// and the blocks confirming them are fetched and filtered.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) Rescan(startHash *chainhash.Hash,
+	addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address) error {
+
+	startHeight, err := c.GetBlockHeight(startHash)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if err := c.watchAddr(addr); err != nil {
+			return err
+		}
+	}
+	c.watchMtx.Lock()
+	for outPoint := range outPoints {
+		c.watchedOutPoints[outPoint] = struct{}{}
+	}
+	c.watchMtx.Unlock()
+
+	confirmed, unconfirmed, err := c.histories(
+		addrs, outPoints, startHeight, -1,
+	)
+	if err != nil {
+		return err
+	}
+
+	heights := make([]int32, 0, len(confirmed))
+	for height := range confirmed {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	for _, height := range heights {
+		hash := confirmed[height]
+		block, err := c.GetBlock(&hash)
+		if err != nil {
+			return err
+		}
+		meta := &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Hash: hash, Height: height},
+			Time:  block.Header.Timestamp,
+		}
+		for _, tx := range c.filterBlock(block) {
+			if err := c.notifyRelevantTx(tx, meta); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, txid := range unconfirmed {
+		if err := c.processMempoolTx(&txid); err != nil {
+			return err
+		}
+	}
+
+	bestBlock, err := c.BlockStamp()
+	if err != nil {
+		return err
+	}
+	select {
+	case c.notificationQueue.ChanIn() <- &RescanFinished{
+		Hash:   &bestBlock.Hash,
+		Height: bestBlock.Height,
+		Time:   bestBlock.Timestamp,
+	}:
+	case <-c.quit:
+		return ErrEsploraClientShuttingDown
+	}
+	return nil
+}
+
+// FilterBlocks scans the blocks contained in the FilterBlocksRequest for any
+// addresses of interest.  The histories of the addresses and the spends of the
+// outpoints are looked up first, so that only the blocks confirming them are
+// fetched and filtered, returning a FilterBlocksResponse for the first block
+// containing a matching address.  If no matches are found in the range of
+// blocks requested, the returned response will be nil.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *EsploraClient) FilterBlocks(
+	req *FilterBlocksRequest) (*FilterBlocksResponse, error) {
+
+	if len(req.Blocks) == 0 {
+		return nil, nil
+	}
+	startHeight := req.Blocks[0].Height
+	endHeight := req.Blocks[len(req.Blocks)-1].Height
+
+	addrs := make(
+		[]btcutil.Address, 0,
+		len(req.ExternalAddrs)+len(req.InternalAddrs),
+	)
+	for _, addr := range req.ExternalAddrs {
+		addrs = append(addrs, addr)
+	}
+	for _, addr := range req.InternalAddrs {
+		addrs = append(addrs, addr)
+	}
+	confirmed, _, err := c.histories(
+		addrs, req.WatchedOutPoints, startHeight, endHeight,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blockFilterer := NewBlockFilterer(c.cfg.ChainParams, req)
+
+	// Iterate over the requested blocks, fetching and filtering those
+	// confirming transactions of the histories, breaking out early if any
+	// addresses are found.
+	for i, blockMeta := range req.Blocks {
+		if _, ok := confirmed[blockMeta.Height]; !ok {
+			continue
+		}
+
+		block, err := c.GetBlock(&blockMeta.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if !blockFilterer.FilterBlock(block) {
+			continue
+		}
+
+		// If any external or internal addresses were detected in this
+		// block, we return them to the caller so that the rescan
+		// windows can widened with subsequent addresses. The
+		// `BatchIndex` is returned so that the caller can compute the
+		// *next* block from which to begin again.
+		resp := &FilterBlocksResponse{
+			BatchIndex:         uint32(i),
+			BlockMeta:          blockMeta,
+			FoundExternalAddrs: blockFilterer.FoundExternal,
+			FoundInternalAddrs: blockFilterer.FoundInternal,
+			FoundOutPoints:     blockFilterer.FoundOutPoints,
+			RelevantTxns:       blockFilterer.RelevantTxns,
+		}
+
+		return resp, nil
+	}
+
+	// No addresses were found for this range.
+	return nil, nil
+}
+
+// pollHandler polls the server for new blocks and unconfirmed transactions
+// until the client is stopped.
+//
+// NOTE: This must be called as a goroutine.
+func (c *EsploraClient) pollHandler() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.pollTip(); err != nil {
+				log.Errorf("Unable to poll esplora tip: %v", err)
+			}
+			if err := c.pollMempool(); err != nil {
+				log.Errorf("Unable to poll esplora mempool: %v",
+					err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// pollTip fetches the tip of the chain of the server.  The blocks notified to
+// the caller that are no longer part of the main chain are disconnected, and
+// the blocks between the common ancestor and the new tip are connected.
+func (c *EsploraClient) pollTip() error {
+	tipHash, err := c.getHash("/blocks/tip/hash")
+	if err != nil {
+		return err
+	}
+
+	c.chainMtx.Lock()
+	best := c.bestBlock
+	c.chainMtx.Unlock()
+	if *tipHash == best.Hash {
+		return nil
+	}
+
+	var tip esploraBlock
+	if err := c.getJSON("/block/"+tipHash.String(), &tip); err != nil {
+		return err
+	}
+
+	// Disconnect the blocks above the new tip, and then those that are no
+	// longer part of the main chain, until reaching the common ancestor.
+	forkHeight := best.Height
+	if forkHeight >= tip.Height {
+		forkHeight = tip.Height - 1
+	}
+	for height := best.Height; height > forkHeight; height-- {
+		c.disconnectBlock(height)
+	}
+	for ; forkHeight > 0; forkHeight-- {
+		c.chainMtx.Lock()
+		block, ok := c.blocks[forkHeight]
+		c.chainMtx.Unlock()
+		if !ok {
+			break
+		}
+
+		hash, err := c.GetBlockHash(int64(forkHeight))
+		if err != nil {
+			return err
+		}
+		if *hash == block.Hash {
+			break
+		}
+		c.disconnectBlock(forkHeight)
+	}
+
+	for height := forkHeight + 1; height <= tip.Height; height++ {
+		hash, err := c.GetBlockHash(int64(height))
+		if err != nil {
+			return err
+		}
+		if err := c.connectBlock(hash, height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// connectBlock fetches the block of the main chain at the given height, makes
+// it the tip of the best chain and notifies the caller of it and of its
+// relevant transactions.
+func (c *EsploraClient) connectBlock(hash *chainhash.Hash, height int32) error {
+	block, err := c.GetBlock(hash)
+	if err != nil {
+		return err
+	}
+
+	c.chainMtx.Lock()
+	prev, ok := c.blocks[height-1]
+	if ok && block.Header.PrevBlock != prev.Hash {
+		c.chainMtx.Unlock()
+		return fmt.Errorf("block at height %d does not connect to the "+
+			"best chain", height)
+	}
+	c.setBestBlock(waddrmgr.BlockStamp{
+		Hash:      *hash,
+		Height:    height,
+		Timestamp: block.Header.Timestamp,
+	})
+	c.chainMtx.Unlock()
+
+	meta := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: *hash, Height: height},
+		Time:  block.Header.Timestamp,
+	}
+	c.notifyBlock(BlockConnected(*meta))
+
+	for _, tx := range c.filterBlock(block) {
+		if err := c.notifyRelevantTx(tx, meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// disconnectBlock removes the block at the given height from the best chain,
+// notifying the caller.
+func (c *EsploraClient) disconnectBlock(height int32) {
+	c.chainMtx.Lock()
+	block, ok := c.blocks[height]
+	delete(c.blocks, height)
+	prev, prevOK := c.blocks[height-1]
+	if prevOK {
+		c.bestBlock = prev
+	}
+	c.chainMtx.Unlock()
+	if !ok {
+		return
+	}
+
+	c.notifyBlock(BlockDisconnected{
+		Block: wtxmgr.Block{
+			Hash:   block.Hash,
+			Height: block.Height,
+		},
+		Time: block.Timestamp,
+	})
+}
+
+// notifyBlock sends a block notification to the caller if requested.
+func (c *EsploraClient) notifyBlock(ntfn interface{}) {
+	if !c.shouldNotifyBlocks() {
+		return
+	}
+
+	select {
+	case c.notificationQueue.ChanIn() <- ntfn:
+	case <-c.quit:
+	}
+}
+
+// setBestBlock makes the given block the tip of the best chain.  The blocks
+// deeper than the maximum reorg depth are forgotten.
+//
+// NOTE: The chainMtx must be held.
+func (c *EsploraClient) setBestBlock(block waddrmgr.BlockStamp) {
+	c.bestBlock = block
+	c.blocks[block.Height] = block
+	delete(c.blocks, block.Height-waddrmgr.MaxReorgDepth)
+}
+
+// pollMempool looks up the unconfirmed transactions of the watched addresses,
+// notifying the caller of those it hasn't been notified of yet.
+func (c *EsploraClient) pollMempool() error {
+	c.watchMtx.Lock()
+	addrStrs := make([]string, 0, len(c.watchedScripts))
+	for _, addr := range c.watchedScripts {
+		addrStrs = append(addrStrs, addr.EncodeAddress())
+	}
+	c.watchMtx.Unlock()
+
+	var (
+		mtx   sync.Mutex
+		txids = make(map[chainhash.Hash]struct{})
+	)
+	err := c.forEach(len(addrStrs), func(i int) error {
+		var mempoolTxs []esploraTx
+		err := c.getJSON(
+			"/address/"+addrStrs[i]+"/txs/mempool", &mempoolTxs,
+		)
+		if err != nil {
+			return err
+		}
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		for _, tx := range mempoolTxs {
+			txid, err := chainhash.NewHashFromStr(tx.TxID)
+			if err != nil {
+				return err
+			}
+			txids[*txid] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for txid := range txids {
+		if err := c.processMempoolTx(&txid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processMempoolTx fetches an unconfirmed transaction of the history of a
+// watched address or outpoint and notifies the caller of it, unless it was
+// notified already.
+func (c *EsploraClient) processMempoolTx(txid *chainhash.Hash) error {
+	c.watchMtx.Lock()
+	_, seen := c.seenTxs[*txid]
+	c.watchMtx.Unlock()
+	if seen {
+		return nil
+	}
+
+	tx, err := c.transaction(txid)
+	if err != nil {
+		return err
+	}
+
+	// The transaction is relevant as part of a history, so it is only
+	// filtered to watch its outputs.
+	c.filterTx(tx)
+	return c.notifyRelevantTx(tx, nil)
+}
+
+// watchAddr watches the transactions paying to or spending from an address.
+func (c *EsploraClient) watchAddr(addr btcutil.Address) error {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return err
+	}
+
+	c.watchMtx.Lock()
+	c.watchedScripts[string(pkScript)] = addr
+	c.watchMtx.Unlock()
+	return nil
+}
+
+// filterBlock returns the transactions of a block relevant to the watched
+// addresses and outpoints.
+func (c *EsploraClient) filterBlock(block *wire.MsgBlock) []*wire.MsgTx {
+	var relevant []*wire.MsgTx
+	for _, tx := range block.Transactions {
+		if c.filterTx(tx) {
+			relevant = append(relevant, tx)
+		}
+	}
+	return relevant
+}
+
+// filterTx returns whether a transaction spends a watched outpoint or pays to
+// a watched address, in which case its outputs paying to watched addresses are
+// watched from then on.
+func (c *EsploraClient) filterTx(tx *wire.MsgTx) bool {
+	c.watchMtx.Lock()
+	defer c.watchMtx.Unlock()
+
+	var isRelevant bool
+	for _, txIn := range tx.TxIn {
+		if _, ok := c.watchedOutPoints[txIn.PreviousOutPoint]; ok {
+			isRelevant = true
+			break
+		}
+	}
+
+	txHash := tx.TxHash()
+	for i, txOut := range tx.TxOut {
+		if _, ok := c.watchedScripts[string(txOut.PkScript)]; !ok {
+			continue
+		}
+		isRelevant = true
+		c.watchedOutPoints[wire.OutPoint{
+			Hash:  txHash,
+			Index: uint32(i),
+		}] = struct{}{}
+	}
+
+	return isRelevant
+}
+
+// notifyRelevantTx sends a RelevantTx notification for a transaction confirmed
+// in the given block, or unconfirmed if it is nil, unless it was already
+// notified at the same height.
+func (c *EsploraClient) notifyRelevantTx(tx *wire.MsgTx,
+	block *wtxmgr.BlockMeta) error {
+
+	var (
+		height   int32
+		received = time.Now()
+	)
+	if block != nil {
+		height = block.Height
+		received = block.Time
+	}
+
+	txid := tx.TxHash()
+	c.watchMtx.Lock()
+	seenHeight, seen := c.seenTxs[txid]
+	c.seenTxs[txid] = height
+	c.watchMtx.Unlock()
+	if seen && seenHeight == height {
+		return nil
+	}
+
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, received)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case c.notificationQueue.ChanIn() <- RelevantTx{
+		TxRecord: rec,
+		Block:    block,
+	}:
+	case <-c.quit:
+		return ErrEsploraClientShuttingDown
+	}
+	return nil
+}
+
+// histories looks up the histories of the given addresses and the spends of
+// the given outpoints, returning the hashes of the blocks confirming them
+// within the given range of heights, with no upper bound when endHeight is
+// negative, and the unconfirmed transactions.
+func (c *EsploraClient) histories(addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address, startHeight,
+	endHeight int32) (map[int32]chainhash.Hash, []chainhash.Hash, error) {
+
+	var (
+		mtx         sync.Mutex
+		confirmed   = make(map[int32]chainhash.Hash)
+		unconfirmed []chainhash.Hash
+		seen        = make(map[chainhash.Hash]struct{})
+	)
+	addTx := func(txidStr string, status *esploraTxStatus) error {
+		if status.Confirmed && (status.BlockHeight < startHeight ||
+			endHeight >= 0 && status.BlockHeight > endHeight) {
+
+			return nil
+		}
+
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if !status.Confirmed {
+			txid, err := chainhash.NewHashFromStr(txidStr)
+			if err != nil {
+				return err
+			}
+			if _, ok := seen[*txid]; !ok {
+				seen[*txid] = struct{}{}
+				unconfirmed = append(unconfirmed, *txid)
+			}
+			return nil
+		}
+
+		blockHash, err := chainhash.NewHashFromStr(status.BlockHash)
+		if err != nil {
+			return err
+		}
+		confirmed[status.BlockHeight] = *blockHash
+		return nil
+	}
+
+	unique := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		unique[addr.EncodeAddress()] = struct{}{}
+	}
+	addrStrs := make([]string, 0, len(unique))
+	for addrStr := range unique {
+		addrStrs = append(addrStrs, addrStr)
+	}
+	err := c.forEach(len(addrStrs), func(i int) error {
+		path := "/address/" + addrStrs[i] + "/txs"
+
+		var mempoolTxs []esploraTx
+		err := c.getJSON(path+"/mempool", &mempoolTxs)
+		if err != nil {
+			return err
+		}
+		for _, tx := range mempoolTxs {
+			if err := addTx(tx.TxID, &tx.Status); err != nil {
+				return err
+			}
+		}
+
+		// Confirmed transactions are returned by pages, newest first,
+		// so the history is only paged through until reaching the
+		// start height.
+		pagePath := path + "/chain"
+		for {
+			var page []esploraTx
+			if err := c.getJSON(pagePath, &page); err != nil {
+				return err
+			}
+			for _, tx := range page {
+				if tx.Status.BlockHeight < startHeight {
+					return nil
+				}
+				err := addTx(tx.TxID, &tx.Status)
+				if err != nil {
+					return err
+				}
+			}
+			if len(page) < esploraTxsPageSize {
+				return nil
+			}
+			pagePath = path + "/chain/" + page[len(page)-1].TxID
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ops := make([]wire.OutPoint, 0, len(outPoints))
+	for outPoint := range outPoints {
+		ops = append(ops, outPoint)
+	}
+	err = c.forEach(len(ops), func(i int) error {
+		var outSpend esploraOutSpend
+		err := c.getJSON(fmt.Sprintf("/tx/%v/outspend/%d", ops[i].Hash,
+			ops[i].Index), &outSpend)
+		if err != nil || !outSpend.Spent {
+			return err
+		}
+		return addTx(outSpend.TxID, &outSpend.Status)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return confirmed, unconfirmed, nil
+}
+
+// transaction fetches a transaction from the server.
+func (c *EsploraClient) transaction(txid *chainhash.Hash) (*wire.MsgTx,
+	error) {
+
+	txHex, err := c.getText("/tx/" + txid.String() + "/hex")
+	if err != nil {
+		return nil, err
+	}
+	serialized, err := hex.DecodeString(txHex)
+	if err != nil {
+		return nil, err
+	}
+	tx := &wire.MsgTx{}
+	if err := tx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, err
+	}
+	if tx.TxHash() != *txid {
+		return nil, fmt.Errorf("server returned transaction %v "+
+			"instead of %v", tx.TxHash(), txid)
+	}
+	return tx, nil
+}
+
+// forEach calls fn for the indexes up to n, making up to
+// esploraMaxConcurrentRequests calls at once, and returns the first error.
+func (c *EsploraClient) forEach(n int, fn func(i int) error) error {
+	var (
+		mtx      sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, esploraMaxConcurrentRequests)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(i); err != nil {
+				mtx.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mtx.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// getJSON makes a GET request to the server and unmarshals its JSON response.
+func (c *EsploraClient) getJSON(path string, result interface{}) error {
+	resp, err := c.request(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(resp, result)
+}
+
+// getText makes a GET request to the server and returns its text response.
+func (c *EsploraClient) getText(path string) (string, error) {
+	resp, err := c.request(http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(resp)), nil
+}
+
+// getHash makes a GET request to the server for a block or transaction hash.
+func (c *EsploraClient) getHash(path string) (*chainhash.Hash, error) {
+	hashStr, err := c.getText(path)
+	if err != nil {
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(hashStr)
+}
+
+// request makes a request to the server and returns the body of its response.
+// Requests failing because of the network or an unavailable server are
+// retried up to the configured number of times, with an exponential backoff.
+func (c *EsploraClient) request(method, path string, body []byte) ([]byte,
+	error) {
+
+	backoff := c.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.doRequest(method, path, body)
+		if err == nil {
+			return resp, nil
+		}
+
+		var esploraErr *EsploraError
+		if errors.As(err, &esploraErr) &&
+			esploraErr.StatusCode < http.StatusInternalServerError &&
+			esploraErr.StatusCode != http.StatusTooManyRequests {
+
+			return nil, err
+		}
+		if attempt >= c.cfg.MaxRetries {
+			return nil, err
+		}
+
+		log.Debugf("Retrying esplora request %s %s in %v: %v", method,
+			path, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-c.quit:
+			return nil, ErrEsploraClientShuttingDown
+		}
+		backoff *= 2
+	}
+}
+
+// doRequest makes a single request to the server.
+func (c *EsploraClient) doRequest(method, path string, body []byte) ([]byte,
+	error) {
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(), c.cfg.RequestTimeout,
+	)
+	defer cancel()
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(
+		ctx, method, c.cfg.URL+path, reqBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "text/plain")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(
+		io.LimitReader(resp.Body, esploraMaxResponseSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &EsploraError{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(respBody)),
+		}
+	}
+	return respBody, nil
+}
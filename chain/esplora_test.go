@@ -0,0 +1,638 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// fakeEsploraServer is an in-process stand-in for the REST API of an Esplora
+// indexer, serving an in-memory chain of blocks and mempool.
+type fakeEsploraServer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mtx sync.Mutex
+
+	// blocks is the main chain, and allBlocks indexes every block served,
+	// including those reorged out of the main chain.
+	blocks    []*wire.MsgBlock
+	allBlocks map[chainhash.Hash]*wire.MsgBlock
+	mempool   []*wire.MsgTx
+	nonce     uint32
+
+	// failures is the number of upcoming requests failed with a 503
+	// status, requests counts the requests received, and badBlocks makes
+	// the raw blocks served not match their merkle root.
+	failures  int
+	requests  int
+	badBlocks bool
+}
+
+// newFakeEsploraServer starts a fake Esplora server with a chain of the given
+// number of blocks on top of the genesis block.
+func newFakeEsploraServer(t *testing.T, numBlocks int) *fakeEsploraServer {
+	s := &fakeEsploraServer{
+		t:         t,
+		allBlocks: make(map[chainhash.Hash]*wire.MsgBlock),
+	}
+	s.addBlock(chainParams.GenesisBlock)
+	s.mine(0, numBlocks)
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// addBlock appends a block to the main chain.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) addBlock(block *wire.MsgBlock) {
+	s.blocks = append(s.blocks, block)
+	s.allBlocks[block.BlockHash()] = block
+}
+
+// mine replaces the given number of blocks at the tip of the chain with new
+// blocks, the first including the given transactions.  The mempool is cleared.
+func (s *fakeEsploraServer) mine(replace, numBlocks int, txs ...*wire.MsgTx) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.blocks = s.blocks[:len(s.blocks)-replace]
+	for i := 0; i < numBlocks; i++ {
+		s.nonce++
+		tip := s.blocks[len(s.blocks)-1]
+		s.addBlock(newFakeBlock(tip, s.nonce, txs...))
+		txs = nil
+	}
+	s.mempool = nil
+}
+
+// serveHTTP answers a request to the REST API.
+func (s *fakeEsploraServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.requests++
+	if s.failures > 0 {
+		s.failures--
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	status, resp := s.handle(r)
+	if status != http.StatusOK {
+		http.Error(w, resp.(string), status)
+		return
+	}
+	switch resp := resp.(type) {
+	case string:
+		w.Write([]byte(resp))
+	case []byte:
+		w.Write(resp)
+	default:
+		require.NoError(s.t, json.NewEncoder(w).Encode(resp))
+	}
+}
+
+// handle routes a request, returning the status and body of the response.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) handle(r *http.Request) (int, interface{}) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	notFound := func() (int, interface{}) {
+		return http.StatusNotFound, "not found"
+	}
+
+	switch {
+	case r.Method == http.MethodPost && len(path) == 1 && path[0] == "tx":
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(s.t, err)
+		serialized, err := hex.DecodeString(string(body))
+		require.NoError(s.t, err)
+		tx := &wire.MsgTx{}
+		require.NoError(s.t, tx.Deserialize(bytes.NewReader(serialized)))
+		if found, _ := s.findTx(tx.TxHash()); found != nil {
+			return http.StatusBadRequest, "txn-already-known"
+		}
+		s.mempool = append(s.mempool, tx)
+		return http.StatusOK, tx.TxHash().String()
+
+	case r.Method != http.MethodGet:
+		return notFound()
+
+	case len(path) == 3 && path[0] == "blocks" && path[1] == "tip" &&
+		path[2] == "hash":
+
+		return http.StatusOK, s.blocks[len(s.blocks)-1].BlockHash().String()
+
+	case len(path) == 2 && path[0] == "block-height":
+		height, err := strconv.Atoi(path[1])
+		if err != nil || height >= len(s.blocks) {
+			return notFound()
+		}
+		return http.StatusOK, s.blocks[height].BlockHash().String()
+
+	case len(path) >= 2 && path[0] == "block":
+		hash, err := chainhash.NewHashFromStr(path[1])
+		require.NoError(s.t, err)
+		block, ok := s.allBlocks[*hash]
+		if !ok {
+			return notFound()
+		}
+		height, inBestChain := s.blockHeight(hash)
+
+		if len(path) == 2 {
+			return http.StatusOK, &esploraBlock{
+				ID:        hash.String(),
+				Height:    height,
+				Timestamp: block.Header.Timestamp.Unix(),
+			}
+		}
+		switch path[2] {
+		case "status":
+			return http.StatusOK, &esploraBlockStatus{
+				InBestChain: inBestChain,
+				Height:      height,
+			}
+
+		case "header":
+			return http.StatusOK, serializeHeader(s.t, &block.Header)
+
+		case "raw":
+			if s.badBlocks {
+				coinbase := block.Transactions[0].Copy()
+				coinbase.TxOut[0].Value++
+				block = &wire.MsgBlock{
+					Header: block.Header,
+					Transactions: append(
+						[]*wire.MsgTx{coinbase},
+						block.Transactions[1:]...,
+					),
+				}
+			}
+			var buf bytes.Buffer
+			require.NoError(s.t, block.Serialize(&buf))
+			return http.StatusOK, buf.Bytes()
+		}
+
+	case len(path) >= 4 && path[0] == "address" && path[2] == "txs":
+		history := s.history(path[1])
+		switch {
+		case path[3] == "mempool":
+			var txs []*esploraTx
+			for _, tx := range history {
+				if !tx.Status.Confirmed {
+					txs = append(txs, tx)
+				}
+			}
+			return http.StatusOK, txs
+
+		case path[3] == "chain":
+			// Confirmed transactions are served newest first, by
+			// pages continuing after the last transaction seen.
+			var txs []*esploraTx
+			for i := len(history) - 1; i >= 0; i-- {
+				if history[i].Status.Confirmed {
+					txs = append(txs, history[i])
+				}
+			}
+			if len(path) == 5 {
+				for i, tx := range txs {
+					if tx.TxID == path[4] {
+						txs = txs[i+1:]
+						break
+					}
+				}
+			}
+			if len(txs) > esploraTxsPageSize {
+				txs = txs[:esploraTxsPageSize]
+			}
+			return http.StatusOK, txs
+		}
+
+	case len(path) >= 3 && path[0] == "tx":
+		txid, err := chainhash.NewHashFromStr(path[1])
+		require.NoError(s.t, err)
+		tx, _ := s.findTx(*txid)
+		if tx == nil {
+			return notFound()
+		}
+		switch {
+		case path[2] == "hex":
+			var buf bytes.Buffer
+			require.NoError(s.t, tx.Serialize(&buf))
+			return http.StatusOK, hex.EncodeToString(buf.Bytes())
+
+		case path[2] == "outspend" && len(path) == 4:
+			vout, err := strconv.Atoi(path[3])
+			require.NoError(s.t, err)
+			outPoint := wire.OutPoint{Hash: *txid, Index: uint32(vout)}
+			outSpend := &esploraOutSpend{}
+			s.forEachTx(func(tx *wire.MsgTx, status *esploraTxStatus) {
+				for _, txIn := range tx.TxIn {
+					if txIn.PreviousOutPoint == outPoint {
+						outSpend.Spent = true
+						outSpend.TxID = tx.TxHash().String()
+						outSpend.Status = *status
+					}
+				}
+			})
+			return http.StatusOK, outSpend
+		}
+	}
+
+	return notFound()
+}
+
+// blockHeight returns the height of a block and whether it is part of the
+// main chain.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) blockHeight(hash *chainhash.Hash) (int32, bool) {
+	for height, block := range s.blocks {
+		if block.BlockHash() == *hash {
+			return int32(height), true
+		}
+	}
+
+	// Blocks reorged out of the main chain are at the height following
+	// their parent.
+	prev := s.allBlocks[*hash].Header.PrevBlock
+	height, _ := s.blockHeight(&prev)
+	return height + 1, false
+}
+
+// forEachTx calls fn for every transaction of the main chain, and then of the
+// mempool, along with its confirmation status.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) forEachTx(fn func(*wire.MsgTx, *esploraTxStatus)) {
+	for height, block := range s.blocks {
+		status := &esploraTxStatus{
+			Confirmed:   true,
+			BlockHeight: int32(height),
+			BlockHash:   block.BlockHash().String(),
+		}
+		for _, tx := range block.Transactions {
+			fn(tx, status)
+		}
+	}
+	for _, tx := range s.mempool {
+		fn(tx, &esploraTxStatus{})
+	}
+}
+
+// findTx returns a transaction of the main chain or mempool and its status.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) findTx(txid chainhash.Hash) (*wire.MsgTx,
+	*esploraTxStatus) {
+
+	var (
+		found       *wire.MsgTx
+		foundStatus *esploraTxStatus
+	)
+	s.forEachTx(func(tx *wire.MsgTx, status *esploraTxStatus) {
+		if found == nil && tx.TxHash() == txid {
+			found, foundStatus = tx, status
+		}
+	})
+	return found, foundStatus
+}
+
+// history returns the transactions of the main chain and mempool paying to or
+// spending from an address, oldest first.
+//
+// NOTE: The mtx must be held.
+func (s *fakeEsploraServer) history(addr string) []*esploraTx {
+	paysTo := func(pkScript []byte) bool {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			pkScript, &chainParams,
+		)
+		return err == nil && len(addrs) == 1 &&
+			addrs[0].EncodeAddress() == addr
+	}
+
+	var history []*esploraTx
+	s.forEachTx(func(tx *wire.MsgTx, status *esploraTxStatus) {
+		relevant := false
+		for _, txOut := range tx.TxOut {
+			relevant = relevant || paysTo(txOut.PkScript)
+		}
+		for _, txIn := range tx.TxIn {
+			prevOut := txIn.PreviousOutPoint
+			prevTx, _ := s.findTx(prevOut.Hash)
+			relevant = relevant || prevTx != nil &&
+				paysTo(prevTx.TxOut[prevOut.Index].PkScript)
+		}
+		if relevant {
+			history = append(history, &esploraTx{
+				TxID:   tx.TxHash().String(),
+				Status: *status,
+			})
+		}
+	})
+	return history
+}
+
+// nextEsploraNtfn returns the next notification of the client.
+func nextEsploraNtfn(t *testing.T, c *EsploraClient) interface{} {
+	t.Helper()
+
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return nil
+	}
+}
+
+// TestEsploraClient ensures that the Esplora client serves the chain of the
+// server, finds the transactions of addresses and outpoints through their
+// histories, notifies new blocks, unconfirmed transactions and reorgs polled
+// from the server, verifies the blocks it is served, and retries requests
+// failing because the server is unavailable.
+func TestEsploraClient(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeEsploraServer(t, 0)
+	defer server.server.Close()
+
+	newAddr := func(b byte) (btcutil.Address, []byte) {
+		addr, err := btcutil.NewAddressPubKeyHash(
+			bytes.Repeat([]byte{b}, 20), &chainParams,
+		)
+		require.NoError(t, err)
+		pkScript, err := txscript.PayToAddrScript(addr)
+		require.NoError(t, err)
+		return addr, pkScript
+	}
+	addr, pkScript := newAddr(1)
+	pagedAddr, pagedPkScript := newAddr(2)
+
+	var nonce byte
+	newTx := func(prevOut wire.OutPoint, value int64,
+		pkScript []byte) *wire.MsgTx {
+
+		if prevOut == (wire.OutPoint{}) {
+			nonce++
+			prevOut.Hash = chainhash.Hash{nonce}
+		}
+		return &wire.MsgTx{
+			Version: 1,
+			TxIn:    []*wire.TxIn{{PreviousOutPoint: prevOut}},
+			TxOut:   []*wire.TxOut{wire.NewTxOut(value, pkScript)},
+		}
+	}
+
+	// Block 2 pays to the paged address, block 3 pays to the address,
+	// block 4 spends that output, and block 5 pays to the paged address
+	// more times than fit on a page of its history.
+	txPaged := newTx(wire.OutPoint{}, 500, pagedPkScript)
+	txA := newTx(wire.OutPoint{}, 1000, pkScript)
+	txSpend := newTx(wire.OutPoint{Hash: txA.TxHash()}, 900, []byte{0x51})
+	var pagedTxs []*wire.MsgTx
+	for i := 0; i < esploraTxsPageSize+5; i++ {
+		pagedTxs = append(pagedTxs, newTx(wire.OutPoint{}, 1, pagedPkScript))
+	}
+	server.mine(0, 1)
+	server.mine(0, 1, txPaged)
+	server.mine(0, 1, txA)
+	server.mine(0, 1, txSpend)
+	server.mine(0, 1, pagedTxs...)
+
+	server.mtx.Lock()
+	blocks := append([]*wire.MsgBlock(nil), server.blocks...)
+	server.failures = 2
+	server.mtx.Unlock()
+
+	client := NewEsploraClient(&EsploraConfig{
+		ChainParams:  &chainParams,
+		URL:          server.server.URL + "/",
+		PollInterval: 10 * time.Millisecond,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+
+	// Starting the client retries the requests failing because the server
+	// is unavailable.
+	require.NoError(t, client.Start())
+	defer func() {
+		client.Stop()
+		client.WaitForShutdown()
+	}()
+	require.Equal(t, ClientConnected{}, nextEsploraNtfn(t, client))
+
+	// The client serves the chain of the server.
+	bestHash, bestHeight, err := client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, blocks[5].BlockHash(), *bestHash)
+	require.Equal(t, int32(5), bestHeight)
+	hash, err := client.GetBlockHash(3)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].BlockHash(), *hash)
+	header, err := client.GetBlockHeader(hash)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].Header, *header)
+	height, err := client.GetBlockHeight(hash)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), height)
+	block, err := client.GetBlock(hash)
+	require.NoError(t, err)
+	require.Equal(t, blocks[3].BlockHash(), block.BlockHash())
+	_, err = client.GetBlockHeader(&chainhash.Hash{1})
+	require.Error(t, err)
+
+	var metas []wtxmgr.BlockMeta
+	for height := int32(1); height <= 5; height++ {
+		metas = append(metas, wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   blocks[height].BlockHash(),
+				Height: height,
+			},
+			Time: blocks[height].Header.Timestamp,
+		})
+	}
+	filterBlocks := func(blocks []wtxmgr.BlockMeta, addr btcutil.Address,
+		outPoints map[wire.OutPoint]btcutil.Address) *FilterBlocksResponse {
+
+		t.Helper()
+
+		external := make(map[waddrmgr.ScopedIndex]btcutil.Address)
+		if addr != nil {
+			external[waddrmgr.ScopedIndex{
+				Scope: waddrmgr.KeyScopeBIP0044,
+				Index: 7,
+			}] = addr
+		}
+		if outPoints == nil {
+			outPoints = make(map[wire.OutPoint]btcutil.Address)
+		}
+		resp, err := client.FilterBlocks(&FilterBlocksRequest{
+			Blocks:           blocks,
+			ExternalAddrs:    external,
+			InternalAddrs:    map[waddrmgr.ScopedIndex]btcutil.Address{},
+			WatchedOutPoints: outPoints,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	// Filtering the blocks finds the block paying to the address.
+	resp := filterBlocks(metas, addr, nil)
+	require.NotNil(t, resp)
+	require.Equal(t, uint32(2), resp.BatchIndex)
+	require.Equal(t, metas[2], resp.BlockMeta)
+	require.Len(t, resp.RelevantTxns, 1)
+	require.Equal(t, txA.TxHash(), resp.RelevantTxns[0].TxHash())
+	require.Equal(t, map[waddrmgr.KeyScope]map[uint32]struct{}{
+		waddrmgr.KeyScopeBIP0044: {7: {}},
+	}, resp.FoundExternalAddrs)
+
+	// The oldest transaction of the paged address is on the second page of
+	// its history.
+	resp = filterBlocks(metas, pagedAddr, nil)
+	require.NotNil(t, resp)
+	require.Equal(t, uint32(1), resp.BatchIndex)
+
+	// The spend of a watched outpoint is found through its spending
+	// status.
+	outPoint := wire.OutPoint{Hash: txA.TxHash()}
+	resp = filterBlocks(
+		metas[3:], nil, map[wire.OutPoint]btcutil.Address{
+			outPoint: addr,
+		},
+	)
+	require.NotNil(t, resp)
+	require.Equal(t, metas[3], resp.BlockMeta)
+	require.Len(t, resp.RelevantTxns, 1)
+	require.Equal(t, txSpend.TxHash(), resp.RelevantTxns[0].TxHash())
+
+	// Nothing is found after the spend.
+	require.Nil(t, filterBlocks(metas[4:], addr, nil))
+
+	// Rescanning notifies the transaction paying to the address and the
+	// one spending its output.
+	require.NoError(t, client.Rescan(
+		&metas[0].Hash, []btcutil.Address{addr}, nil,
+	))
+	for _, tx := range []*wire.MsgTx{txA, txSpend} {
+		relevant := nextEsploraNtfn(t, client).(RelevantTx)
+		require.Equal(t, tx.TxHash(), relevant.TxRecord.Hash)
+	}
+	finished := nextEsploraNtfn(t, client).(*RescanFinished)
+	require.Equal(t, int32(5), finished.Height)
+	require.Equal(t, blocks[5].BlockHash(), *finished.Hash)
+
+	// New blocks are polled and notified, along with the transactions
+	// they confirm for the addresses rescanned.
+	require.NoError(t, client.NotifyBlocks())
+	txB := newTx(wire.OutPoint{}, 2000, pkScript)
+	server.mine(0, 1, txB)
+	connected := nextEsploraNtfn(t, client).(BlockConnected)
+	require.Equal(t, int32(6), connected.Height)
+	relevant := nextEsploraNtfn(t, client).(RelevantTx)
+	require.Equal(t, txB.TxHash(), relevant.TxRecord.Hash)
+	require.Equal(t, connected.Hash, relevant.Block.Hash)
+
+	// Published transactions are notified once polled from the mempool.
+	txC := newTx(wire.OutPoint{}, 3000, pkScript)
+	txid, err := client.SendRawTransaction(txC, false)
+	require.NoError(t, err)
+	require.Equal(t, txC.TxHash(), *txid)
+	relevant = nextEsploraNtfn(t, client).(RelevantTx)
+	require.Equal(t, txC.TxHash(), relevant.TxRecord.Hash)
+	require.Nil(t, relevant.Block)
+
+	// Rejected transactions are not retried, and the rejection reason of
+	// the server is returned.
+	server.mtx.Lock()
+	requests := server.requests
+	server.mtx.Unlock()
+	_, err = client.SendRawTransaction(txC, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "txn-already-known")
+	server.mtx.Lock()
+	require.Less(t, server.requests-requests, 3)
+	server.mtx.Unlock()
+
+	// A reorg replacing block 6 disconnects it before connecting the new
+	// chain, in which the mempool transaction confirms.
+	server.mine(1, 2, txC)
+	disconnected := nextEsploraNtfn(t, client).(BlockDisconnected)
+	require.Equal(t, int32(6), disconnected.Height)
+	require.Equal(t, connected.Hash, disconnected.Hash)
+	server.mtx.Lock()
+	blocks = append([]*wire.MsgBlock(nil), server.blocks...)
+	server.mtx.Unlock()
+	connected = nextEsploraNtfn(t, client).(BlockConnected)
+	require.Equal(t, int32(6), connected.Height)
+	require.Equal(t, blocks[6].BlockHash(), connected.Hash)
+	relevant = nextEsploraNtfn(t, client).(RelevantTx)
+	require.Equal(t, txC.TxHash(), relevant.TxRecord.Hash)
+	require.Equal(t, connected.Hash, relevant.Block.Hash)
+	connected = nextEsploraNtfn(t, client).(BlockConnected)
+	require.Equal(t, int32(7), connected.Height)
+	require.Equal(t, blocks[7].BlockHash(), connected.Hash)
+
+	bestHash, bestHeight, err = client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, blocks[7].BlockHash(), *bestHash)
+	require.Equal(t, int32(7), bestHeight)
+
+	// The block reorged out is no longer part of the main chain.
+	_, err = client.GetBlockHeight(&disconnected.Hash)
+	require.Error(t, err)
+
+	// A client that isn't polling is used to count the remaining requests
+	// exactly.
+	client.Stop()
+	client.WaitForShutdown()
+	idleClient := NewEsploraClient(&EsploraConfig{
+		ChainParams:  &chainParams,
+		URL:          server.server.URL,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+
+	// Blocks not matching their merkle root are rejected.
+	server.mtx.Lock()
+	server.badBlocks = true
+	server.mtx.Unlock()
+	_, err = idleClient.GetBlock(&connected.Hash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "merkle root")
+	server.mtx.Lock()
+	server.badBlocks = false
+	server.mtx.Unlock()
+
+	// Requests are given up on after the maximum number of retries.
+	server.mtx.Lock()
+	server.failures = 3
+	requests = server.requests
+	server.mtx.Unlock()
+	_, err = idleClient.GetBlockHash(1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), fmt.Sprint(http.StatusServiceUnavailable))
+	server.mtx.Lock()
+	require.Equal(t, 3, server.requests-requests)
+	server.mtx.Unlock()
+}
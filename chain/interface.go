@@ -15,16 +15,6 @@ import (
 // the chain.
 const isCurrentDelta = 2 * time.Hour
 
-// BackEnds returns a list of the available back ends.
-// TODO: Refactor each into a driver and use dynamic registration.
-func BackEnds() []string {
-	return []string{
-		"bitcoind",
-		"btcd",
-		"neutrino",
-	}
-}
-
 // Interface allows more than one backing blockchain source, such as a
 // btcd RPC chain server, or an SPV library, as long as we write a driver for
 // it.
@@ -47,6 +37,13 @@ type Interface interface {
 	BackEnd() string
 }
 
+// SpendNotifier is implemented by the chain backends that only detect the
+// spends of the outpoints registered with them, rather than of every output
+// paying to a watched address.
+type SpendNotifier interface {
+	NotifySpent([]*wire.OutPoint) error
+}
+
 // Notification types.  These are defined here and processed from from reading
 // a notificationChan to avoid handling these notifications directly in
 // rpcclient callbacks, which isn't very Go-like and doesn't allow
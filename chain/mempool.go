@@ -0,0 +1,61 @@
+package chain
+
+import (
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+)
+
+// MempoolQuerier is implemented by chain clients able to tell whether the
+// backend holds a transaction in its mempool.  Not every backend can, so
+// callers must type assert their Interface to find out.
+type MempoolQuerier interface {
+	// HaveMempoolTx returns whether the transaction with the given hash is
+	// in the mempool of the backend.
+	HaveMempoolTx(*chainhash.Hash) (bool, error)
+}
+
+// A compile-time check to ensure that the RPC backed clients can query the
+// mempool.
+var (
+	_ MempoolQuerier = (*RPCClient)(nil)
+	_ MempoolQuerier = (*BitcoindClient)(nil)
+)
+
+// isNotInMempoolErr determines if the error returned by the getmempoolentry
+// RPC corresponds to the transaction missing from the mempool.
+func isNotInMempoolErr(err error) bool {
+	rpcErr, ok := err.(*btcjson.RPCError)
+	return ok && rpcErr.Code == btcjson.ErrRPCNoTxInfo
+}
+
+// HaveMempoolTx returns whether the transaction with the given hash is in the
+// mempool of the btcd backend.
+//
+// NOTE: This is part of the MempoolQuerier interface.
+func (c *RPCClient) HaveMempoolTx(txHash *chainhash.Hash) (bool, error) {
+	_, err := c.GetMempoolEntry(txHash.String())
+	switch {
+	case err == nil:
+		return true, nil
+	case isNotInMempoolErr(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// HaveMempoolTx returns whether the transaction with the given hash is in the
+// mempool of the bitcoind backend.
+//
+// NOTE: This is part of the MempoolQuerier interface.
+func (c *BitcoindClient) HaveMempoolTx(txHash *chainhash.Hash) (bool, error) {
+	_, err := c.chainConn.client.GetMempoolEntry(txHash.String())
+	switch {
+	case err == nil:
+		return true, nil
+	case isNotInMempoolErr(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
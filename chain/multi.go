@@ -0,0 +1,1077 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+const (
+	// multiBackEnd is the name reported by MultiClient as its backend.
+	multiBackEnd = "multi"
+
+	// defaultMultiHealthCheckInterval is used when the configuration of a
+	// MultiClient leaves the interval of its health checks unset.
+	defaultMultiHealthCheckInterval = time.Minute
+
+	// multiMaxAgreementDepth is the number of blocks below the tips of
+	// the backends searched for a block a quorum of them agrees on.
+	multiMaxAgreementDepth = 6
+)
+
+var (
+	// ErrNoQuorum is returned when fewer backends than the quorum agree on
+	// the best chain.
+	ErrNoQuorum = errors.New("chain backends do not agree on the best " +
+		"chain")
+
+	// ErrNoHealthyBackEnd is returned when all the backends of a
+	// MultiClient failed to answer a request.
+	ErrNoHealthyBackEnd = errors.New("no healthy chain backend")
+)
+
+// MultiBackEnd is a chain backend wrapped by a MultiClient.
+type MultiBackEnd struct {
+	// Name identifies the backend in logs and health reports.
+	Name string
+
+	// Client is the client of the backend.  It is started and stopped by
+	// the MultiClient.
+	Client Interface
+}
+
+// MultiConfig contains the parameters of a MultiClient.
+type MultiConfig struct {
+	// BackEnds are the chain backends wrapped, the first of which is used
+	// while it is healthy.
+	BackEnds []MultiBackEnd
+
+	// Quorum is the number of backends that must agree on a block before
+	// it is connected.  A majority of the backends is required when it is
+	// zero.
+	Quorum int
+
+	// HealthCheckInterval is the interval at which the health of the
+	// backends is checked.
+	HealthCheckInterval time.Duration
+}
+
+// BackEndHealth is the health of a backend of a MultiClient.
+type BackEndHealth struct {
+	// Name and BackEnd identify the backend and its driver.
+	Name    string
+	BackEnd string
+
+	// Healthy is whether the backend answered its last request, and
+	// Active whether it serves the requests of the MultiClient.
+	Healthy bool
+	Active  bool
+
+	// BestBlock is the best block reported by the backend at its last
+	// health check, and LastChecked the time of that check.
+	BestBlock   waddrmgr.BlockStamp
+	LastChecked time.Time
+
+	// LastError is the last error returned by the backend, if any.
+	LastError error
+}
+
+// multiMember is a backend of a MultiClient along with its health.
+type multiMember struct {
+	MultiBackEnd
+
+	healthy     bool
+	stopped     bool
+	bestBlock   waddrmgr.BlockStamp
+	lastChecked time.Time
+	lastErr     error
+}
+
+// multiRescan holds the arguments of an outstanding rescan, so that it can be
+// resumed by another backend when failing over.
+type multiRescan struct {
+	startHash *chainhash.Hash
+	addrs     []btcutil.Address
+	outPoints map[wire.OutPoint]btcutil.Address
+}
+
+// MultiClient is an implementation of the chain.Interface interface wrapping
+// several chain backends.  Requests are served by the first healthy backend,
+// failing over to the next ones on errors, and transactions are broadcast
+// through all of them.  Blocks are only connected once a quorum of backends
+// agree on them, and the transactions they confirm are held until then, so
+// that a single faulty backend can neither stall the wallet nor feed it blocks
+// that aren't part of the best chain.
+type MultiClient struct {
+	started int32 // To be used atomically.
+	stopped int32 // To be used atomically.
+
+	cfg MultiConfig
+
+	notificationQueue *ConcurrentQueue
+
+	// mtx guards the members, the active one and the outstanding rescan.
+	mtx     sync.Mutex
+	members []*multiMember
+	active  int
+	rescan  *multiRescan
+
+	// ntfnMtx guards the state of the block notifications: the votes of
+	// the backends for the blocks they connected and disconnected, the
+	// blocks notified to the caller by height, the tip of those, and the
+	// transaction notifications held until their block is agreed on.
+	ntfnMtx         sync.Mutex
+	connectVotes    map[wtxmgr.Block]map[int]struct{}
+	disconnectVotes map[wtxmgr.Block]map[int]struct{}
+	notified        map[int32]wtxmgr.BlockMeta
+	tip             wtxmgr.Block
+	held            map[wtxmgr.Block][]interface{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// A compile-time check to ensure that MultiClient satisfies the
+// chain.Interface interface.
+var _ Interface = (*MultiClient)(nil)
+
+// A compile-time check to ensure that MultiClient satisfies the
+// chain.SpendNotifier interface.
+var _ SpendNotifier = (*MultiClient)(nil)
+
+// NewMultiClient creates a client wrapping the backends of the configuration.
+func NewMultiClient(cfg *MultiConfig) (*MultiClient, error) {
+	clientCfg := *cfg
+	if len(clientCfg.BackEnds) == 0 {
+		return nil, errors.New("no chain backends to wrap")
+	}
+	if clientCfg.Quorum == 0 {
+		clientCfg.Quorum = len(clientCfg.BackEnds)/2 + 1
+	}
+	if clientCfg.Quorum < 0 || clientCfg.Quorum > len(clientCfg.BackEnds) {
+		return nil, fmt.Errorf("quorum of %d outside of 1-%d backends",
+			clientCfg.Quorum, len(clientCfg.BackEnds))
+	}
+	if clientCfg.HealthCheckInterval <= 0 {
+		clientCfg.HealthCheckInterval = defaultMultiHealthCheckInterval
+	}
+
+	members := make([]*multiMember, 0, len(clientCfg.BackEnds))
+	for _, backEnd := range clientCfg.BackEnds {
+		members = append(members, &multiMember{
+			MultiBackEnd: backEnd,
+			healthy:      true,
+		})
+	}
+
+	return &MultiClient{
+		cfg:               clientCfg,
+		notificationQueue: NewConcurrentQueue(20),
+		members:           members,
+		connectVotes:      make(map[wtxmgr.Block]map[int]struct{}),
+		disconnectVotes:   make(map[wtxmgr.Block]map[int]struct{}),
+		notified:          make(map[int32]wtxmgr.BlockMeta),
+		held:              make(map[wtxmgr.Block][]interface{}),
+		quit:              make(chan struct{}),
+	}, nil
+}
+
+// BackEnd returns the name of the driver.
+func (c *MultiClient) BackEnd() string {
+	return multiBackEnd
+}
+
+// Start starts the backends, and ensures a quorum of them started and agree
+// on the best chain.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) Start() error {
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		return nil
+	}
+
+	for _, member := range c.members {
+		if err := member.Client.Start(); err != nil {
+			log.Warnf("Unable to start chain backend %s: %v",
+				member.Name, err)
+			member.Client.Stop()
+			member.healthy = false
+			member.stopped = true
+			member.lastErr = err
+		}
+	}
+	if c.aliveMembers() < c.cfg.Quorum {
+		c.stopMembers()
+		return fmt.Errorf("fewer chain backends than the quorum of "+
+			"%d started", c.cfg.Quorum)
+	}
+	c.mtx.Lock()
+	c.failOver()
+	c.mtx.Unlock()
+
+	bestBlock, err := c.BlockStamp()
+	if err != nil {
+		c.stopMembers()
+		return err
+	}
+	c.tip = wtxmgr.Block{Hash: bestBlock.Hash, Height: bestBlock.Height}
+
+	// Start the notification queue and immediately dispatch a
+	// ClientConnected notification to the caller. This is needed as some of
+	// the callers will require this notification before proceeding.
+	c.notificationQueue.Start()
+	c.notificationQueue.ChanIn() <- ClientConnected{}
+
+	for i, member := range c.members {
+		if member.stopped {
+			continue
+		}
+		c.wg.Add(1)
+		go c.ntfnHandler(i)
+	}
+	c.wg.Add(1)
+	go c.healthHandler()
+
+	return nil
+}
+
+// Stop stops the backends.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+
+	close(c.quit)
+	c.stopMembers()
+	c.notificationQueue.Stop()
+}
+
+// stopMembers stops all the backends.
+func (c *MultiClient) stopMembers() {
+	for _, member := range c.members {
+		member.Client.Stop()
+	}
+}
+
+// WaitForShutdown blocks until the backends and the handlers of their
+// notifications have stopped.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) WaitForShutdown() {
+	c.wg.Wait()
+	for _, member := range c.members {
+		member.Client.WaitForShutdown()
+	}
+}
+
+// Notifications returns a channel to retrieve notifications from.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) Notifications() <-chan interface{} {
+	return c.notificationQueue.ChanOut()
+}
+
+// Health returns the health of the backends, in the order they were
+// configured.
+func (c *MultiClient) Health() []BackEndHealth {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	health := make([]BackEndHealth, 0, len(c.members))
+	for i, member := range c.members {
+		health = append(health, BackEndHealth{
+			Name:        member.Name,
+			BackEnd:     member.Client.BackEnd(),
+			Healthy:     member.healthy,
+			Active:      i == c.active,
+			BestBlock:   member.bestBlock,
+			LastChecked: member.lastChecked,
+			LastError:   member.lastErr,
+		})
+	}
+	return health
+}
+
+// GetBestBlock returns the highest block a quorum of the backends agree on.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	bestBlock, err := c.BlockStamp()
+	if err != nil {
+		return nil, 0, err
+	}
+	return &bestBlock.Hash, bestBlock.Height, nil
+}
+
+// BlockStamp returns the highest block a quorum of the backends agree on.  The
+// best blocks of the backends are compared first, and when they differ, the
+// blocks below the lowest of the quorum of highest tips are compared.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	c.mtx.Lock()
+	var indexes []int
+	for i, member := range c.members {
+		if !member.stopped {
+			indexes = append(indexes, i)
+		}
+	}
+	c.mtx.Unlock()
+
+	bestBlocks := make(map[int]*waddrmgr.BlockStamp, len(indexes))
+	var mtx sync.Mutex
+	c.forEachMember(indexes, func(i int, client Interface) error {
+		bestBlock, err := client.BlockStamp()
+		if err != nil {
+			return err
+		}
+		mtx.Lock()
+		bestBlocks[i] = bestBlock
+		mtx.Unlock()
+		return nil
+	})
+	if len(bestBlocks) < c.cfg.Quorum {
+		return nil, ErrNoQuorum
+	}
+
+	// Look for agreement at the height of the quorum-th highest tip, which
+	// at least a quorum of backends have reached.
+	heights := make([]int32, 0, len(bestBlocks))
+	for _, bestBlock := range bestBlocks {
+		heights = append(heights, bestBlock.Height)
+	}
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] > heights[j]
+	})
+	height := heights[c.cfg.Quorum-1]
+
+	for depth := int32(0); depth <= multiMaxAgreementDepth &&
+		height-depth >= 0; depth++ {
+
+		h := height - depth
+		votes := make(map[chainhash.Hash][]int)
+		var above []int
+		for i, bestBlock := range bestBlocks {
+			if bestBlock.Height == h {
+				votes[bestBlock.Hash] = append(
+					votes[bestBlock.Hash], i,
+				)
+			} else if bestBlock.Height > h {
+				above = append(above, i)
+			}
+		}
+		c.forEachMember(above, func(i int, client Interface) error {
+			hash, err := client.GetBlockHash(int64(h))
+			if err != nil {
+				return err
+			}
+			mtx.Lock()
+			votes[*hash] = append(votes[*hash], i)
+			mtx.Unlock()
+			return nil
+		})
+
+		for hash, voters := range votes {
+			if len(voters) < c.cfg.Quorum {
+				continue
+			}
+			hash := hash
+			if bestBlock := bestBlocks[voters[0]]; bestBlock.Hash == hash {
+				return bestBlock, nil
+			}
+			header, err := c.members[voters[0]].Client.GetBlockHeader(
+				&hash,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return &waddrmgr.BlockStamp{
+				Hash:      hash,
+				Height:    h,
+				Timestamp: header.Timestamp,
+			}, nil
+		}
+	}
+
+	return nil, ErrNoQuorum
+}
+
+// IsCurrent returns whether the active backend considers itself synced to the
+// network.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) IsCurrent() bool {
+	var isCurrent bool
+	c.withFailover(func(client Interface) error {
+		isCurrent = client.IsCurrent()
+		return nil
+	})
+	return isCurrent
+}
+
+// GetBlock returns a block from the active backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) GetBlock(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	var block *wire.MsgBlock
+	err := c.withFailover(func(client Interface) error {
+		var err error
+		block, err = client.GetBlock(hash)
+		return err
+	})
+	return block, err
+}
+
+// GetBlockHash returns the hash of the block of the main chain at the given
+// height from the active backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	var hash *chainhash.Hash
+	err := c.withFailover(func(client Interface) error {
+		var err error
+		hash, err = client.GetBlockHash(height)
+		return err
+	})
+	return hash, err
+}
+
+// GetBlockHeader returns the header of a block from the active backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+
+	var header *wire.BlockHeader
+	err := c.withFailover(func(client Interface) error {
+		var err error
+		header, err = client.GetBlockHeader(hash)
+		return err
+	})
+	return header, err
+}
+
+// GetBlockHeight returns the height of a block of the main chain from the
+// active backend.
+func (c *MultiClient) GetBlockHeight(hash *chainhash.Hash) (int32, error) {
+	var height int32
+	err := c.withFailover(func(client Interface) error {
+		switch client := client.(type) {
+		case *RPCClient:
+			header, err := client.GetBlockHeaderVerbose(hash)
+			if err != nil {
+				return err
+			}
+			height = header.Height
+			return nil
+
+		case interface {
+			GetBlockHeight(*chainhash.Hash) (int32, error)
+		}:
+			var err error
+			height, err = client.GetBlockHeight(hash)
+			return err
+
+		default:
+			return fmt.Errorf("the %s backend can't look up the "+
+				"height of blocks", client.BackEnd())
+		}
+	})
+	return height, err
+}
+
+// SetBirthday sets the birthday of the wallet on the backends that skip
+// filtering the blocks before it.
+func (c *MultiClient) SetBirthday(t time.Time) {
+	for _, member := range c.members {
+		switch client := member.Client.(type) {
+		case *BitcoindClient:
+			client.SetBirthday(t)
+		case *NeutrinoClient:
+			client.SetStartTime(t)
+		}
+	}
+}
+
+// SendRawTransaction broadcasts a transaction through all the backends.  The
+// broadcast succeeds when any backend accepts the transaction, otherwise the
+// error of the active backend is returned.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) SendRawTransaction(tx *wire.MsgTx,
+	allowHighFees bool) (*chainhash.Hash, error) {
+
+	c.mtx.Lock()
+	active := c.active
+	var indexes []int
+	for i, member := range c.members {
+		if !member.stopped {
+			indexes = append(indexes, i)
+		}
+	}
+	c.mtx.Unlock()
+
+	var (
+		mtx  sync.Mutex
+		txid *chainhash.Hash
+		errs = make(map[int]error)
+	)
+	var wg sync.WaitGroup
+	for _, i := range indexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			hash, err := c.members[i].Client.SendRawTransaction(
+				tx, allowHighFees,
+			)
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				log.Debugf("Chain backend %s rejected "+
+					"transaction %v: %v", c.members[i].Name,
+					tx.TxHash(), err)
+				errs[i] = err
+				return
+			}
+			txid = hash
+		}(i)
+	}
+	wg.Wait()
+
+	if txid != nil {
+		return txid, nil
+	}
+	if err, ok := errs[active]; ok {
+		return nil, err
+	}
+	for _, i := range indexes {
+		return nil, errs[i]
+	}
+	return nil, ErrNoHealthyBackEnd
+}
+
+// NotifyBlocks starts sending block connected and disconnected notifications
+// to the caller, once agreed on by a quorum of the backends.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) NotifyBlocks() error {
+	return c.forEachAlive(func(client Interface) error {
+		return client.NotifyBlocks()
+	})
+}
+
+// NotifyReceived watches the given addresses on all the backends, so that any
+// of them can take over from the active one.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) NotifyReceived(addrs []btcutil.Address) error {
+	return c.forEachAlive(func(client Interface) error {
+		return client.NotifyReceived(addrs)
+	})
+}
+
+// NotifySpent watches the given outpoints for spends on all the backends
+// detecting only the spends of registered outpoints.
+//
+// NOTE: This is part of the chain.SpendNotifier interface.
+func (c *MultiClient) NotifySpent(outPoints []*wire.OutPoint) error {
+	return c.forEachAlive(func(client Interface) error {
+		notifier, ok := client.(SpendNotifier)
+		if !ok {
+			return nil
+		}
+		return notifier.NotifySpent(outPoints)
+	})
+}
+
+// Rescan rescans the chain through the active backend.  When failing over
+// before the rescan finished, it is resumed by the next backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) Rescan(startHash *chainhash.Hash,
+	addrs []btcutil.Address,
+	outPoints map[wire.OutPoint]btcutil.Address) error {
+
+	c.mtx.Lock()
+	c.rescan = &multiRescan{
+		startHash: startHash,
+		addrs:     addrs,
+		outPoints: outPoints,
+	}
+	c.mtx.Unlock()
+
+	return c.withFailover(func(client Interface) error {
+		return client.Rescan(startHash, addrs, outPoints)
+	})
+}
+
+// FilterBlocks scans the blocks contained in the FilterBlocksRequest through
+// the active backend.
+//
+// NOTE: This is part of the chain.Interface interface.
+func (c *MultiClient) FilterBlocks(
+	req *FilterBlocksRequest) (*FilterBlocksResponse, error) {
+
+	var resp *FilterBlocksResponse
+	err := c.withFailover(func(client Interface) error {
+		var err error
+		resp, err = client.FilterBlocks(req)
+		return err
+	})
+	return resp, err
+}
+
+// withFailover calls fn with the client of the active backend, failing over to
+// the next healthy backends until one of them succeeds.
+func (c *MultiClient) withFailover(fn func(Interface) error) error {
+	tried := make(map[int]struct{})
+	lastErr := ErrNoHealthyBackEnd
+	for {
+		c.mtx.Lock()
+		i := c.active
+		if _, ok := tried[i]; ok || !c.members[i].healthy {
+			i = -1
+			for j, member := range c.members {
+				_, ok := tried[j]
+				if !ok && member.healthy {
+					i = j
+					break
+				}
+			}
+		}
+		c.mtx.Unlock()
+		if i < 0 {
+			return lastErr
+		}
+
+		tried[i] = struct{}{}
+		err := fn(c.members[i].Client)
+		if err == nil {
+			return nil
+		}
+		c.setHealth(i, nil, err)
+		lastErr = err
+	}
+}
+
+// forEachAlive calls fn with the clients of all the backends that haven't
+// stopped, returning an error only if no backend succeeds.
+func (c *MultiClient) forEachAlive(fn func(Interface) error) error {
+	c.mtx.Lock()
+	var indexes []int
+	for i, member := range c.members {
+		if !member.stopped {
+			indexes = append(indexes, i)
+		}
+	}
+	c.mtx.Unlock()
+
+	var succeeded int32
+	err := c.forEachMember(indexes, func(_ int, client Interface) error {
+		if err := fn(client); err != nil {
+			return err
+		}
+		atomic.AddInt32(&succeeded, 1)
+		return nil
+	})
+	if succeeded > 0 {
+		return nil
+	}
+	if err == nil {
+		err = ErrNoHealthyBackEnd
+	}
+	return err
+}
+
+// forEachMember concurrently calls fn with the clients of the backends at the
+// given indexes, marking those returning an error unhealthy, and returns the
+// first error.
+func (c *MultiClient) forEachMember(indexes []int,
+	fn func(int, Interface) error) error {
+
+	var (
+		mtx      sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for _, i := range indexes {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := fn(i, c.members[i].Client)
+			if err == nil {
+				return
+			}
+			c.setHealth(i, nil, err)
+
+			mtx.Lock()
+			if firstErr == nil {
+				firstErr = err
+			}
+			mtx.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// setHealth records the outcome of a request to a backend: its best block if
+// it succeeded, or the error it failed with.
+func (c *MultiClient) setHealth(i int, bestBlock *waddrmgr.BlockStamp,
+	err error) {
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	member := c.members[i]
+	wasHealthy := member.healthy
+	member.healthy = err == nil && !member.stopped
+	if err != nil {
+		member.lastErr = err
+	}
+	if bestBlock != nil {
+		member.bestBlock = *bestBlock
+		member.lastChecked = time.Now()
+	}
+
+	switch {
+	case wasHealthy && !member.healthy:
+		log.Warnf("Chain backend %s is unhealthy: %v", member.Name,
+			err)
+	case !wasHealthy && member.healthy:
+		log.Infof("Chain backend %s is healthy again", member.Name)
+	}
+
+	c.failOver()
+}
+
+// failOver makes the first healthy backend the active one, resuming any
+// outstanding rescan through it.
+//
+// NOTE: The mtx must be held.
+func (c *MultiClient) failOver() {
+	active := c.active
+	for i, member := range c.members {
+		if member.healthy {
+			active = i
+			break
+		}
+	}
+	if active == c.active {
+		return
+	}
+
+	log.Infof("Failing over from chain backend %s to %s",
+		c.members[c.active].Name, c.members[active].Name)
+	c.active = active
+
+	// The transaction notifications of the previous backend held until
+	// their block is agreed on are no longer relevant.
+	c.ntfnMtx.Lock()
+	c.held = make(map[wtxmgr.Block][]interface{})
+	c.ntfnMtx.Unlock()
+
+	if c.rescan == nil || atomic.LoadInt32(&c.started) == 0 {
+		return
+	}
+	rescan := *c.rescan
+	client := c.members[active].Client
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+
+		err := client.Rescan(
+			rescan.startHash, rescan.addrs, rescan.outPoints,
+		)
+		if err != nil {
+			log.Errorf("Unable to resume rescan: %v", err)
+		}
+	}()
+}
+
+// aliveMembers returns the number of backends that haven't stopped.
+func (c *MultiClient) aliveMembers() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var alive int
+	for _, member := range c.members {
+		if !member.stopped {
+			alive++
+		}
+	}
+	return alive
+}
+
+// healthHandler checks the health of the backends periodically until the
+// client is stopped.
+//
+// NOTE: This must be called as a goroutine.
+func (c *MultiClient) healthHandler() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.cfg.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.checkHealth()
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkHealth requests the best block of every backend that hasn't stopped.
+func (c *MultiClient) checkHealth() {
+	c.mtx.Lock()
+	var indexes []int
+	for i, member := range c.members {
+		if !member.stopped {
+			indexes = append(indexes, i)
+		}
+	}
+	c.mtx.Unlock()
+
+	c.forEachMember(indexes, func(i int, client Interface) error {
+		bestBlock, err := client.BlockStamp()
+		if err != nil {
+			return err
+		}
+		c.setHealth(i, bestBlock, nil)
+		return nil
+	})
+}
+
+// ntfnHandler handles the notifications of a backend until it or the client is
+// stopped.  The client is stopped when fewer backends than the quorum remain.
+//
+// NOTE: This must be called as a goroutine.
+func (c *MultiClient) ntfnHandler(i int) {
+	defer c.wg.Done()
+
+	member := c.members[i]
+	for {
+		select {
+		case n, ok := <-member.Client.Notifications():
+			if !ok {
+				c.memberStopped(i)
+				return
+			}
+			c.handleNtfn(i, n)
+
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// memberStopped marks a backend that shut down, stopping the client if fewer
+// backends than the quorum remain.
+func (c *MultiClient) memberStopped(i int) {
+	c.mtx.Lock()
+	member := c.members[i]
+	member.stopped = true
+	c.mtx.Unlock()
+
+	select {
+	case <-c.quit:
+		return
+	default:
+	}
+
+	c.setHealth(i, nil, errors.New("backend shut down"))
+	if c.aliveMembers() < c.cfg.Quorum {
+		log.Errorf("Fewer chain backends than the quorum of %d "+
+			"remain, disconnecting", c.cfg.Quorum)
+		c.Stop()
+	}
+}
+
+// handleNtfn handles a notification of a backend.  Block notifications are
+// counted as votes for the blocks, while the other notifications are only
+// forwarded from the active backend.
+func (c *MultiClient) handleNtfn(i int, n interface{}) {
+	switch n := n.(type) {
+	case ClientConnected:
+		// The caller was notified when the client started.
+
+	case BlockConnected:
+		c.voteConnected(i, wtxmgr.BlockMeta(n))
+
+	case BlockDisconnected:
+		c.voteDisconnected(i, wtxmgr.BlockMeta(n))
+
+	case RelevantTx:
+		if c.isActive(i) {
+			c.forwardTxNtfn(n.Block, n)
+		}
+
+	case FilteredBlockConnected:
+		if c.isActive(i) {
+			c.forwardTxNtfn(n.Block, n)
+		}
+
+	default:
+		if c.isActive(i) {
+			c.notify(n)
+		}
+	}
+}
+
+// isActive returns whether the backend at the given index is the active one.
+func (c *MultiClient) isActive(i int) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return i == c.active
+}
+
+// voteConnected counts the vote of a backend for a connected block, notifying
+// the caller of the block and of the transactions it confirms once a quorum of
+// backends connected it.
+func (c *MultiClient) voteConnected(i int, block wtxmgr.BlockMeta) {
+	c.ntfnMtx.Lock()
+	defer c.ntfnMtx.Unlock()
+
+	delete(c.disconnectVotes[block.Block], i)
+	votes, ok := c.connectVotes[block.Block]
+	if !ok {
+		votes = make(map[int]struct{})
+		c.connectVotes[block.Block] = votes
+	}
+	votes[i] = struct{}{}
+	if len(votes) < c.cfg.Quorum {
+		return
+	}
+	if notified, ok := c.notified[block.Height]; ok &&
+		notified.Block == block.Block {
+
+		return
+	}
+
+	// Blocks notified at the same height or above, which the quorum no
+	// longer agrees on, are disconnected first.
+	for height := c.tip.Height; height >= block.Height; height-- {
+		c.disconnectNotified(height)
+	}
+
+	for _, ntfn := range c.held[block.Block] {
+		c.notify(ntfn)
+	}
+	delete(c.held, block.Block)
+
+	c.notified[block.Height] = block
+	delete(c.notified, block.Height-waddrmgr.MaxReorgDepth)
+	c.tip = block.Block
+	c.notify(BlockConnected(block))
+
+	// Forget the votes and held notifications of the blocks deep enough
+	// not to matter anymore.
+	for b := range c.connectVotes {
+		if b.Height <= block.Height-waddrmgr.MaxReorgDepth {
+			delete(c.connectVotes, b)
+		}
+	}
+	for b := range c.disconnectVotes {
+		if b.Height <= block.Height-waddrmgr.MaxReorgDepth {
+			delete(c.disconnectVotes, b)
+		}
+	}
+	for b := range c.held {
+		if b.Height <= block.Height {
+			delete(c.held, b)
+		}
+	}
+}
+
+// voteDisconnected counts the vote of a backend for a disconnected block,
+// notifying the caller once a quorum of backends disconnected it.
+func (c *MultiClient) voteDisconnected(i int, block wtxmgr.BlockMeta) {
+	c.ntfnMtx.Lock()
+	defer c.ntfnMtx.Unlock()
+
+	delete(c.connectVotes[block.Block], i)
+	votes, ok := c.disconnectVotes[block.Block]
+	if !ok {
+		votes = make(map[int]struct{})
+		c.disconnectVotes[block.Block] = votes
+	}
+	votes[i] = struct{}{}
+	if len(votes) < c.cfg.Quorum {
+		return
+	}
+
+	notified, ok := c.notified[block.Height]
+	if !ok || notified.Block != block.Block {
+		return
+	}
+	for height := c.tip.Height; height >= block.Height; height-- {
+		c.disconnectNotified(height)
+	}
+}
+
+// disconnectNotified notifies the caller of the disconnection of the block
+// notified at the given height, if any.
+//
+// NOTE: The ntfnMtx must be held.
+func (c *MultiClient) disconnectNotified(height int32) {
+	block, ok := c.notified[height]
+	if !ok {
+		return
+	}
+	delete(c.notified, height)
+	delete(c.connectVotes, block.Block)
+	c.tip = wtxmgr.Block{Height: height - 1}
+	if prev, ok := c.notified[height-1]; ok {
+		c.tip = prev.Block
+	}
+	c.notify(BlockDisconnected(block))
+}
+
+// forwardTxNtfn forwards a transaction notification of the active backend.
+// Notifications of transactions confirmed above the agreed tip are held until
+// a quorum of backends connected their block.
+func (c *MultiClient) forwardTxNtfn(block *wtxmgr.BlockMeta, n interface{}) {
+	c.ntfnMtx.Lock()
+	defer c.ntfnMtx.Unlock()
+
+	if block != nil && block.Height > c.tip.Height {
+		if notified, ok := c.notified[block.Height]; !ok ||
+			notified.Block != block.Block {
+
+			c.held[block.Block] = append(c.held[block.Block], n)
+			return
+		}
+	}
+	c.notify(n)
+}
+
+// notify sends a notification to the caller.
+func (c *MultiClient) notify(n interface{}) {
+	select {
+	case c.notificationQueue.ChanIn() <- n:
+	case <-c.quit:
+	}
+}
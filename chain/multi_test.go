@@ -0,0 +1,464 @@
+package chain
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// fakeMultiBackEnd is a chain backend whose chain, failures and notifications
+// are controlled by the test.
+type fakeMultiBackEnd struct {
+	mtx      sync.Mutex
+	chain    []chainhash.Hash
+	err      error
+	sendErr  error
+	sent     int
+	rescans  int
+	received int
+
+	ntfns    chan interface{}
+	stopOnce sync.Once
+}
+
+func newFakeMultiBackEnd(chain []chainhash.Hash) *fakeMultiBackEnd {
+	return &fakeMultiBackEnd{
+		chain: append([]chainhash.Hash(nil), chain...),
+		ntfns: make(chan interface{}, 100),
+	}
+}
+
+// fakeChain returns the hashes of a chain of the given length, made unique by
+// the fork byte.
+func fakeChain(length int, fork byte) []chainhash.Hash {
+	chain := make([]chainhash.Hash, length)
+	for i := range chain {
+		chain[i][0] = byte(i)
+		chain[i][1] = fork
+	}
+	return chain
+}
+
+func (b *fakeMultiBackEnd) setErr(err error) {
+	b.mtx.Lock()
+	b.err = err
+	b.mtx.Unlock()
+}
+
+func (b *fakeMultiBackEnd) failure() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.err
+}
+
+// connect extends the chain of the backend with the given block and notifies
+// it.
+func (b *fakeMultiBackEnd) connect(hash chainhash.Hash) wtxmgr.BlockMeta {
+	b.mtx.Lock()
+	b.chain = append(b.chain, hash)
+	height := int32(len(b.chain) - 1)
+	b.mtx.Unlock()
+
+	block := wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: hash, Height: height},
+		Time:  time.Unix(int64(height), 0),
+	}
+	b.ntfns <- BlockConnected(block)
+	return block
+}
+
+// disconnect removes the tip of the chain of the backend and notifies it.
+func (b *fakeMultiBackEnd) disconnect() wtxmgr.BlockMeta {
+	b.mtx.Lock()
+	height := int32(len(b.chain) - 1)
+	hash := b.chain[height]
+	b.chain = b.chain[:height]
+	b.mtx.Unlock()
+
+	block := wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: hash, Height: height},
+		Time:  time.Unix(int64(height), 0),
+	}
+	b.ntfns <- BlockDisconnected(block)
+	return block
+}
+
+func (b *fakeMultiBackEnd) Start() error {
+	return b.failure()
+}
+
+func (b *fakeMultiBackEnd) Stop() {
+	b.stopOnce.Do(func() { close(b.ntfns) })
+}
+
+func (b *fakeMultiBackEnd) WaitForShutdown() {}
+
+func (b *fakeMultiBackEnd) GetBestBlock() (*chainhash.Hash, int32, error) {
+	bestBlock, err := b.BlockStamp()
+	if err != nil {
+		return nil, 0, err
+	}
+	return &bestBlock.Hash, bestBlock.Height, nil
+}
+
+func (b *fakeMultiBackEnd) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	if err := b.failure(); err != nil {
+		return nil, err
+	}
+	return &wire.MsgBlock{}, nil
+}
+
+func (b *fakeMultiBackEnd) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.err != nil {
+		return nil, b.err
+	}
+	if height < 0 || height >= int64(len(b.chain)) {
+		return nil, errors.New("no block at height")
+	}
+	hash := b.chain[height]
+	return &hash, nil
+}
+
+func (b *fakeMultiBackEnd) GetBlockHeader(
+	hash *chainhash.Hash) (*wire.BlockHeader, error) {
+
+	if err := b.failure(); err != nil {
+		return nil, err
+	}
+	return &wire.BlockHeader{Timestamp: time.Unix(int64(hash[0]), 0)}, nil
+}
+
+func (b *fakeMultiBackEnd) IsCurrent() bool {
+	return b.failure() == nil
+}
+
+func (b *fakeMultiBackEnd) FilterBlocks(
+	*FilterBlocksRequest) (*FilterBlocksResponse, error) {
+
+	return nil, b.failure()
+}
+
+func (b *fakeMultiBackEnd) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.err != nil {
+		return nil, b.err
+	}
+	height := int32(len(b.chain) - 1)
+	return &waddrmgr.BlockStamp{
+		Hash:      b.chain[height],
+		Height:    height,
+		Timestamp: time.Unix(int64(height), 0),
+	}, nil
+}
+
+func (b *fakeMultiBackEnd) SendRawTransaction(tx *wire.MsgTx,
+	_ bool) (*chainhash.Hash, error) {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.sent++
+	if b.sendErr != nil {
+		return nil, b.sendErr
+	}
+	txid := tx.TxHash()
+	return &txid, nil
+}
+
+func (b *fakeMultiBackEnd) Rescan(*chainhash.Hash, []btcutil.Address,
+	map[wire.OutPoint]btcutil.Address) error {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.rescans++
+	return b.err
+}
+
+func (b *fakeMultiBackEnd) NotifyReceived([]btcutil.Address) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.received++
+	return b.err
+}
+
+func (b *fakeMultiBackEnd) NotifyBlocks() error {
+	return b.failure()
+}
+
+func (b *fakeMultiBackEnd) Notifications() <-chan interface{} {
+	return b.ntfns
+}
+
+func (b *fakeMultiBackEnd) BackEnd() string {
+	return "fake"
+}
+
+// nextMultiNtfn returns the next notification of the client.
+func nextMultiNtfn(t *testing.T, c *MultiClient) interface{} {
+	t.Helper()
+
+	select {
+	case n := <-c.Notifications():
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for notification")
+		return nil
+	}
+}
+
+// requireNoMultiNtfn ensures the client has no pending notification.
+func requireNoMultiNtfn(t *testing.T, c *MultiClient) {
+	t.Helper()
+
+	select {
+	case n := <-c.Notifications():
+		t.Fatalf("unexpected notification %#v", n)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// newTestMultiClient creates a client wrapping the given backends with the
+// default quorum.
+func newTestMultiClient(t *testing.T,
+	backEnds ...*fakeMultiBackEnd) *MultiClient {
+
+	t.Helper()
+
+	cfg := &MultiConfig{HealthCheckInterval: time.Hour}
+	for i, backEnd := range backEnds {
+		cfg.BackEnds = append(cfg.BackEnds, MultiBackEnd{
+			Name:   string(rune('a' + i)),
+			Client: backEnd,
+		})
+	}
+	client, err := NewMultiClient(cfg)
+	require.NoError(t, err)
+	return client
+}
+
+// TestMultiClientQuorum ensures that blocks are only connected and
+// disconnected once a quorum of backends agree on them, and that the
+// transactions they confirm are held until then.
+func TestMultiClientQuorum(t *testing.T) {
+	t.Parallel()
+
+	chain := fakeChain(5, 0)
+	a := newFakeMultiBackEnd(chain)
+	b := newFakeMultiBackEnd(chain)
+	c := newFakeMultiBackEnd(chain[:4])
+	client := newTestMultiClient(t, a, b, c)
+	require.NoError(t, client.Start())
+	defer func() {
+		client.Stop()
+		client.WaitForShutdown()
+	}()
+	require.Equal(t, ClientConnected{}, nextMultiNtfn(t, client))
+
+	// The best block is the tip two of the three backends agree on.
+	hash, height, err := client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, chain[4], *hash)
+	require.Equal(t, int32(4), height)
+
+	// A block is connected once a second backend connects it, after the
+	// transactions it confirms, which are only forwarded from the active
+	// backend.
+	next := fakeChain(7, 0)
+	block := a.connect(next[5])
+	tx := RelevantTx{
+		TxRecord: &wtxmgr.TxRecord{Hash: chainhash.Hash{1}},
+		Block:    &block,
+	}
+	a.ntfns <- tx
+	b.ntfns <- RelevantTx{
+		TxRecord: &wtxmgr.TxRecord{Hash: chainhash.Hash{2}},
+		Block:    &block,
+	}
+	requireNoMultiNtfn(t, client)
+
+	b.connect(next[5])
+	require.Equal(t, tx, nextMultiNtfn(t, client))
+	require.Equal(t, BlockConnected(block), nextMultiNtfn(t, client))
+
+	c.connect(chain[4])
+	c.connect(next[5])
+	requireNoMultiNtfn(t, client)
+
+	// Unconfirmed transactions are forwarded right away.
+	unconfirmed := RelevantTx{
+		TxRecord: &wtxmgr.TxRecord{Hash: chainhash.Hash{3}},
+	}
+	a.ntfns <- unconfirmed
+	require.Equal(t, unconfirmed, nextMultiNtfn(t, client))
+
+	// A single backend can't reorg the chain.
+	a.disconnect()
+	fork := fakeChain(7, 1)
+	a.connect(fork[5])
+	requireNoMultiNtfn(t, client)
+
+	// Once a quorum follows the reorg, the block notified is
+	// disconnected before the one replacing it is connected.
+	b.disconnect()
+	require.Equal(t, BlockDisconnected(block), nextMultiNtfn(t, client))
+	forkBlock := b.connect(fork[5])
+	require.Equal(t, BlockConnected(forkBlock), nextMultiNtfn(t, client))
+
+	hash, height, err = client.GetBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, fork[5], *hash)
+	require.Equal(t, int32(5), height)
+
+	// Backends disagreeing on their tips agree on their common ancestor,
+	// and no best block is reported without a quorum of them.
+	c.connect(fakeChain(7, 2)[6])
+	b.connect(fakeChain(7, 3)[6])
+	bestBlock, err := client.BlockStamp()
+	require.NoError(t, err)
+	require.Equal(t, fork[5], bestBlock.Hash)
+	require.Equal(t, int32(5), bestBlock.Height)
+
+	b.setErr(errors.New("unreachable"))
+	c.setErr(errors.New("unreachable"))
+	_, err = client.BlockStamp()
+	require.Equal(t, ErrNoQuorum, err)
+}
+
+// TestMultiClientFailover ensures that requests fail over to the next healthy
+// backend, that outstanding rescans are resumed by it, that transactions are
+// broadcast through all the backends, and that the health of the backends is
+// reported.
+func TestMultiClientFailover(t *testing.T) {
+	t.Parallel()
+
+	chain := fakeChain(5, 0)
+	a := newFakeMultiBackEnd(chain)
+	b := newFakeMultiBackEnd(chain)
+	c := newFakeMultiBackEnd(chain)
+	c.setErr(errors.New("refused"))
+	client := newTestMultiClient(t, a, b, c)
+	require.NoError(t, client.Start())
+	defer func() {
+		client.Stop()
+		client.WaitForShutdown()
+	}()
+	require.Equal(t, ClientConnected{}, nextMultiNtfn(t, client))
+
+	health := client.Health()
+	require.Len(t, health, 3)
+	require.True(t, health[0].Healthy)
+	require.True(t, health[0].Active)
+	require.False(t, health[2].Healthy)
+	require.EqualError(t, health[2].LastError, "refused")
+
+	// Addresses are watched by all the backends that started.
+	require.NoError(t, client.NotifyReceived(nil))
+	require.Equal(t, 1, a.received)
+	require.Equal(t, 1, b.received)
+	require.Equal(t, 0, c.received)
+
+	require.NoError(t, client.Rescan(&chain[0], nil, nil))
+	require.Equal(t, 1, a.rescans)
+
+	// Requests fail over once the active backend fails, resuming the
+	// outstanding rescan.
+	a.setErr(errors.New("timeout"))
+	hash, err := client.GetBlockHash(2)
+	require.NoError(t, err)
+	require.Equal(t, chain[2], *hash)
+	health = client.Health()
+	require.False(t, health[0].Healthy)
+	require.False(t, health[0].Active)
+	require.True(t, health[1].Active)
+	require.Eventually(t, func() bool {
+		b.mtx.Lock()
+		defer b.mtx.Unlock()
+		return b.rescans == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Only the notifications of the active backend are forwarded.
+	progress := &RescanProgress{Height: 3}
+	a.ntfns <- &RescanProgress{Height: 2}
+	b.ntfns <- progress
+	require.Equal(t, progress, nextMultiNtfn(t, client))
+
+	// Transactions are broadcast through all the backends, succeeding
+	// when any of them accepts it.
+	tx := wire.NewMsgTx(wire.TxVersion)
+	b.sendErr = errors.New("txn-mempool-conflict")
+	txid, err := client.SendRawTransaction(tx, false)
+	require.NoError(t, err)
+	require.Equal(t, tx.TxHash(), *txid)
+	require.Equal(t, 1, a.sent)
+	require.Equal(t, 1, b.sent)
+
+	// The error of the active backend is returned when all of them
+	// reject it.
+	a.sendErr = errors.New("bad-txns-inputs-missingorspent")
+	_, err = client.SendRawTransaction(tx, false)
+	require.EqualError(t, err, "txn-mempool-conflict")
+
+	// Requests fail once no backend is healthy, and the first backend is
+	// used again once a health check finds it recovered.
+	b.setErr(errors.New("timeout"))
+	_, err = client.GetBlock(&chain[1])
+	require.EqualError(t, err, "timeout")
+	a.setErr(nil)
+	client.checkHealth()
+	health = client.Health()
+	require.True(t, health[0].Healthy)
+	require.True(t, health[0].Active)
+	require.Equal(t, chain[4], health[0].BestBlock.Hash)
+	_, err = client.GetBlock(&chain[1])
+	require.NoError(t, err)
+
+	// The client disconnects once fewer backends than the quorum remain.
+	b.Stop()
+	select {
+	case <-client.quit:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not stop without a quorum of backends")
+	}
+}
+
+// TestMultiClientStart ensures that the client fails to start without a
+// quorum of backends.
+func TestMultiClientStart(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewMultiClient(&MultiConfig{})
+	require.Error(t, err)
+	_, err = NewMultiClient(&MultiConfig{
+		BackEnds: []MultiBackEnd{{Name: "a"}},
+		Quorum:   2,
+	})
+	require.Error(t, err)
+
+	chain := fakeChain(3, 0)
+	a := newFakeMultiBackEnd(chain)
+	b := newFakeMultiBackEnd(chain)
+	b.setErr(errors.New("refused"))
+	client := newTestMultiClient(t, a, b)
+	require.Error(t, client.Start())
+
+	// Backends on different chains fail to start either.
+	a = newFakeMultiBackEnd(chain)
+	b = newFakeMultiBackEnd(fakeChain(3, 1))
+	client = newTestMultiClient(t, a, b)
+	require.Equal(t, ErrNoQuorum, client.Start())
+}
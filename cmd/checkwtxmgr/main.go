@@ -0,0 +1,139 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	_ "github.com/tinhnguyenhn/colxwallet/walletdb/bdb"
+)
+
+const defaultNet = "mainnet"
+
+var (
+	datadir = btcutil.AppDataDir("btcwallet", false)
+)
+
+// Flags.
+var opts = struct {
+	Force   bool          `short:"f" description:"Repair without prompt"`
+	Repair  bool          `long:"repair" description:"Repair the problems found"`
+	DbPath  string        `long:"db" description:"Path to wallet database"`
+	Timeout time.Duration `long:"timeout" description:"Timeout value when opening the wallet database"`
+}{
+	Force:   false,
+	DbPath:  filepath.Join(datadir, defaultNet, wallet.WalletDBName),
+	Timeout: wallet.DefaultDBTimeout,
+}
+
+func init() {
+	_, err := flags.Parse(&opts)
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func yes(s string) bool {
+	switch s {
+	case "y", "Y", "yes", "Yes":
+		return true
+	default:
+		return false
+	}
+}
+
+func no(s string) bool {
+	switch s {
+	case "n", "N", "no", "No":
+		return true
+	default:
+		return false
+	}
+}
+
+func main() {
+	os.Exit(mainInt())
+}
+
+func mainInt() int {
+	fmt.Println("Database path:", opts.DbPath)
+	_, err := os.Stat(opts.DbPath)
+	if os.IsNotExist(err) {
+		fmt.Println("Database file does not exist")
+		return 1
+	}
+
+	db, err := walletdb.Open("bdb", opts.DbPath, true, opts.Timeout)
+	if err != nil {
+		fmt.Println("Failed to open database:", err)
+		return 1
+	}
+	defer db.Close()
+
+	report, err := wallet.VerifyTransactionHistory(db, false)
+	if err != nil {
+		fmt.Println("Failed to verify transaction history:", err)
+		return 1
+	}
+	for i := range report.Problems {
+		fmt.Println(&report.Problems[i])
+	}
+	fmt.Printf("Mined balance: %v (expected %v)\n", report.MinedBalance,
+		report.ExpectedMinedBalance)
+
+	if len(report.Problems) == 0 {
+		fmt.Println("No problems found")
+		return 0
+	}
+	fmt.Printf("%d problems found\n", len(report.Problems))
+	if !opts.Repair {
+		fmt.Println("Run with --repair to repair them")
+		return 2
+	}
+
+	for !opts.Force {
+		fmt.Print("Repair btcwallet transaction history? [y/N] ")
+
+		scanner := bufio.NewScanner(bufio.NewReader(os.Stdin))
+		if !scanner.Scan() {
+			// Exit on EOF.
+			return 0
+		}
+		err := scanner.Err()
+		if err != nil {
+			fmt.Println()
+			fmt.Println(err)
+			return 1
+		}
+		resp := scanner.Text()
+		if yes(resp) {
+			break
+		}
+		if no(resp) || resp == "" {
+			return 0
+		}
+
+		fmt.Println("Enter yes or no.")
+	}
+
+	fmt.Println("Repairing btcwallet transaction history")
+
+	report, err = wallet.VerifyTransactionHistory(db, true)
+	if err != nil {
+		fmt.Println("Failed to repair transaction history:", err)
+		return 1
+	}
+	fmt.Printf("%d problems repaired\n", len(report.Problems))
+
+	return 0
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// remotesigner is the reference signing host for wallets started with the
+// --remotesigner option. It serves the gRPC SignerService with the keys derived
+// from a BIP0032 master private key, which never leaves the signing host.
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/netparams"
+	"github.com/tinhnguyenhn/colxwallet/rpc/remotesigner"
+)
+
+var newlineBytes = []byte{'\n'}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, args...)
+	os.Stderr.Write(newlineBytes)
+	os.Exit(1)
+}
+
+// Flags.
+var opts = struct {
+	TestNet3      bool   `long:"testnet" description:"Use the test bitcoin network (version 3)"`
+	SimNet        bool   `long:"simnet" description:"Use the simulation bitcoin network"`
+	Listen        string `long:"listen" description:"Interface/port to listen for signature requests on"`
+	RPCCert       string `long:"rpccert" description:"File containing the TLS certificate"`
+	RPCKey        string `long:"rpckey" description:"File containing the TLS certificate key"`
+	ClientCA      string `long:"clientca" description:"File containing the certificates of the CAs the TLS certificates of wallets must be signed by"`
+	MasterKeyFile string `long:"masterkeyfile" description:"File containing the extended master private key (xprv/tprv) of the wallet"`
+}{}
+
+// Parse and validate flags.
+func init() {
+	_, err := flags.Parse(&opts)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	if opts.TestNet3 && opts.SimNet {
+		fatalf("Multiple bitcoin networks may not be used simultaneously")
+	}
+	if opts.Listen == "" {
+		fatalf("Listen address is required")
+	}
+	if opts.RPCCert == "" || opts.RPCKey == "" {
+		fatalf("TLS certificate and key files are required")
+	}
+	if opts.ClientCA == "" {
+		fatalf("Client CA file is required")
+	}
+	if opts.MasterKeyFile == "" {
+		fatalf("Master key file is required")
+	}
+}
+
+func main() {
+	var activeNet = &netparams.MainNetParams
+	if opts.TestNet3 {
+		activeNet = &netparams.TestNet3Params
+	} else if opts.SimNet {
+		activeNet = &netparams.SimNetParams
+	}
+
+	keyBytes, err := ioutil.ReadFile(opts.MasterKeyFile)
+	if err != nil {
+		fatalf("Unable to read master key: %v", err)
+	}
+	masterKey, err := hdkeychain.NewKeyFromString(
+		strings.TrimSpace(string(keyBytes)),
+	)
+	if err != nil {
+		fatalf("Unable to parse master key: %v", err)
+	}
+	if !masterKey.IsForNet(activeNet.Params) {
+		fatalf("Master key is not for the %s network",
+			activeNet.Params.Name)
+	}
+	signer, err := remotesigner.NewHDSigner(masterKey)
+	if err != nil {
+		fatalf("Invalid master key: %v", err)
+	}
+
+	// Any client reaching the service could request signatures for any
+	// key, so wallets must authenticate with a client certificate.
+	tlsConfig, err := remotesigner.ServerTLSConfig(
+		opts.RPCCert, opts.RPCKey, opts.ClientCA,
+	)
+	if err != nil {
+		fatalf("Unable to load TLS configuration: %v", err)
+	}
+	listener, err := net.Listen("tcp", opts.Listen)
+	if err != nil {
+		fatalf("Unable to listen on %s: %v", opts.Listen, err)
+	}
+
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+	)
+	remotesigner.NewServer(signer, activeNet.Params).Register(server)
+
+	fmt.Printf("Signing for master key %08x on %s\n",
+		signer.MasterKeyFingerprint(), listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		fatalf("Signer server failed: %v", err)
+	}
+}
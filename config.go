@@ -6,18 +6,22 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	flags "github.com/jessevdk/go-flags"
 	"github.com/lightninglabs/neutrino"
 	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/internal/cfgutil"
 	"github.com/tinhnguyenhn/colxwallet/internal/legacy/keystore"
 	"github.com/tinhnguyenhn/colxwallet/netparams"
@@ -32,6 +36,16 @@ const (
 	defaultLogFilename      = "btcwallet.log"
 	defaultRPCMaxClients    = 10
 	defaultRPCMaxWebsockets = 25
+
+	defaultZMQReadDeadline    = 5 * time.Second
+	defaultPrunedNodeMaxPeers = 4
+
+	// The chain backends the wallet can synchronize with.
+	backendBtcd     = "btcd"
+	backendBitcoind = "bitcoind"
+	backendNeutrino = "neutrino"
+	backendElectrum = "electrum"
+	backendEsplora  = "esplora"
 )
 
 var (
@@ -59,7 +73,8 @@ type config struct {
 	DBTimeout     time.Duration           `long:"dbtimeout" description:"The timeout value to use when opening the wallet database."`
 
 	// Wallet options
-	WalletPass string `long:"walletpass" default-mask:"-" description:"The public wallet password -- Only required if the wallet was created with one"`
+	WalletPass   string `long:"walletpass" default-mask:"-" description:"The public wallet password -- Only required if the wallet was created with one"`
+	PruneHistory uint32 `long:"prunehistory" description:"Prune transactions whose outputs have all been spent for this many confirmations from the transaction history, keeping a summary of each -- Reorgs deeper than this can no longer be followed (0 to disable, minimum 1000)"`
 
 	// RPC client options
 	RPCConnect       string                  `short:"c" long:"rpcconnect" description:"Hostname/IP and port of btcd RPC server to connect to (default localhost:8334, testnet: localhost:18334, simnet: localhost:18556)"`
@@ -71,6 +86,25 @@ type config struct {
 	ProxyUser        string                  `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass        string                  `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
 
+	// Chain backend options
+	Backend        string   `long:"backend" description:"The chain backend to synchronize with {btcd, bitcoind, neutrino, electrum, esplora} or any other registered backend -- usespv selects neutrino (default: btcd)"`
+	BackendOptions []string `long:"backendopt" description:"Set an option of the chain backend driver, as name=value"`
+	backendOptions map[string]string
+	AddBackends    []string `long:"addbackend" description:"Add a chain backend to synchronize with alongside the backend option, as backend://[user:pass@]host[/path][?name=value&...] where the query sets driver options, notls=1 disables TLS and cafile sets the root certificates"`
+	BackendQuorum  int      `long:"backendquorum" description:"The number of chain backends that must agree on a block before it is connected when backends are added (default: a majority)"`
+	addBackends    []*backendSpec
+
+	// bitcoind client options
+	BitcoindRPCHost      string        `long:"bitcoindrpchost" description:"Hostname/IP and port of the bitcoind RPC server to connect to (default localhost:8332, testnet: localhost:18332, simnet: localhost:18554)"`
+	BitcoindRPCUser      string        `long:"bitcoindrpcuser" description:"Username for bitcoind RPC authentication (default: btcdusername)"`
+	BitcoindRPCPass      string        `long:"bitcoindrpcpass" default-mask:"-" description:"Password for bitcoind RPC authentication (default: btcdpassword)"`
+	ZMQPubRawBlock       string        `long:"zmqpubrawblock" description:"The address bitcoind publishes raw block notifications on through ZMQ (eg. tcp://127.0.0.1:28332)"`
+	ZMQPubRawTx          string        `long:"zmqpubrawtx" description:"The address bitcoind publishes raw transaction notifications on through ZMQ (eg. tcp://127.0.0.1:28333)"`
+	ZMQReadDeadline      time.Duration `long:"zmqreaddeadline" description:"The read deadline of the ZMQ connections to bitcoind.  Valid time units are {s, m, h}"`
+	PrunedNodeMaxPeers   int           `long:"prunednodemaxpeers" description:"The maximum number of peers blocks are requested from when they were pruned by bitcoind"`
+	BlockPollingInterval time.Duration `long:"blockpollinginterval" description:"Poll the bitcoind RPC server for blocks at this interval rather than receiving notifications through ZMQ, for nodes that don't expose it.  Valid time units are {s, m, h}"`
+	TxPollingInterval    time.Duration `long:"txpollinginterval" description:"Poll the mempool of bitcoind for transactions at this interval when polling for blocks (default: unconfirmed transactions aren't notified).  Valid time units are {s, m, h}"`
+
 	// SPV client options
 	UseSPV       bool          `long:"usespv" description:"Enables the experimental use of SPV rather than RPC for chain synchronization"`
 	AddPeers     []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
@@ -103,6 +137,12 @@ type config struct {
 	// when the new gRPC server is enabled.
 	ExperimentalRPCListeners []string `long:"experimentalrpclisten" description:"Listen for RPC connections on this interface/port"`
 
+	// Remote signer options
+	RemoteSigner           string `long:"remotesigner" description:"Hostname/IP and port of a gRPC signer service to delegate all signatures to"`
+	RemoteSignerCert       string `long:"remotesignercert" description:"File containing the certificate to authenticate the TLS connection with the remote signer"`
+	RemoteSignerClientCert string `long:"remotesignerclientcert" description:"File containing the client certificate the wallet authenticates with to the remote signer"`
+	RemoteSignerClientKey  string `long:"remotesignerclientkey" description:"File containing the client certificate key the wallet authenticates with to the remote signer"`
+
 	// Deprecated options
 	DataDir *cfgutil.ExplicitString `short:"b" long:"datadir" default-mask:"-" description:"DEPRECATED -- use appdata instead"`
 }
@@ -240,6 +280,111 @@ func parseAndSetDebugLevels(debugLevel string) error {
 	return nil
 }
 
+// parseBackendOption parses a name=value option of a chain backend driver,
+// ensuring the driver has an option of that name.
+func parseBackendOption(drv *chain.Driver, opt string) (string, string, error) {
+	i := strings.IndexByte(opt, '=')
+	if i < 0 {
+		return "", "", fmt.Errorf("backend option %q is not of the "+
+			"form name=value", opt)
+	}
+	name, value := opt[:i], opt[i+1:]
+	for _, schemaOpt := range drv.ConfigSchema {
+		if schemaOpt.Name == name {
+			return name, value, nil
+		}
+	}
+	return "", "", fmt.Errorf("the %s backend has no option %q",
+		drv.BackEnd, name)
+}
+
+// backendSpec describes a chain backend added with the addbackend option.
+type backendSpec struct {
+	backend    string
+	host       string
+	user       string
+	pass       string
+	certs      []byte
+	disableTLS bool
+	options    map[string]string
+}
+
+// name identifies the backend in logs and health reports.
+func (s *backendSpec) name() string {
+	return backendName(s.backend, s.host)
+}
+
+// backendName identifies a chain backend by its driver and the address of its
+// server, if it has one.
+func backendName(backend, host string) string {
+	if host == "" {
+		return backend
+	}
+	return backend + "@" + host
+}
+
+// parseBackendSpec parses a chain backend given as
+// backend://[user:pass@]host[/path][?name=value&...], ensuring the backend is
+// registered and all its required driver options are set.
+func parseBackendSpec(spec string) (*backendSpec, error) {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain backend %q: %v", spec, err)
+	}
+	drv, err := chain.LookupDriver(u.Scheme)
+	if err != nil {
+		return nil, fmt.Errorf("unknown chain backend %q in %q, must be "+
+			"one of %s", u.Scheme, spec,
+			strings.Join(chain.BackEnds(), ", "))
+	}
+
+	s := &backendSpec{
+		backend: u.Scheme,
+		host:    u.Host + u.Path,
+		options: make(map[string]string),
+	}
+	if u.User != nil {
+		s.user = u.User.Username()
+		s.pass, _ = u.User.Password()
+	}
+	for name, values := range u.Query() {
+		value := values[len(values)-1]
+		switch name {
+		case "notls":
+			s.disableTLS, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notls value %q "+
+					"in %q", value, spec)
+			}
+
+		case "cafile":
+			s.certs, err = ioutil.ReadFile(cleanAndExpandPath(value))
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA file "+
+					"of %q: %v", spec, err)
+			}
+
+		default:
+			name, value, err := parseBackendOption(
+				drv, name+"="+value,
+			)
+			if err != nil {
+				return nil, err
+			}
+			s.options[name] = value
+		}
+	}
+	for _, opt := range drv.ConfigSchema {
+		if _, ok := s.options[opt.Name]; opt.Required && !ok {
+			return nil, fmt.Errorf("the %s backend requires the %s "+
+				"option, missing from %q", drv.BackEnd,
+				opt.Name, spec)
+		}
+	}
+
+	return s, nil
+}
+
 // loadConfig initializes and parses the config using a config file and command
 // line options.
 //
@@ -266,6 +411,8 @@ func loadConfig() (*config, []string, error) {
 		LegacyRPCMaxClients:    defaultRPCMaxClients,
 		LegacyRPCMaxWebsockets: defaultRPCMaxWebsockets,
 		DataDir:                cfgutil.NewExplicitString(defaultAppDataDir),
+		ZMQReadDeadline:        defaultZMQReadDeadline,
+		PrunedNodeMaxPeers:     defaultPrunedNodeMaxPeers,
 		UseSPV:                 false,
 		AddPeers:               []string{},
 		ConnectPeers:           []string{},
@@ -374,6 +521,16 @@ func loadConfig() (*config, []string, error) {
 		return nil, nil, err
 	}
 
+	// Pruning is only allowed well below the depth of any expected reorg.
+	if cfg.PruneHistory != 0 && cfg.PruneHistory < wallet.MinPruneConfirmations {
+		str := "%s: The prunehistory option must be at least %d " +
+			"confirmations"
+		err := fmt.Errorf(str, "loadConfig", wallet.MinPruneConfirmations)
+		fmt.Fprintln(os.Stderr, err)
+		parser.WriteHelp(os.Stderr)
+		return nil, nil, err
+	}
+
 	// Append the network type to the log directory so it is "namespaced"
 	// per network.
 	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
@@ -501,11 +658,85 @@ func loadConfig() (*config, []string, error) {
 		"::1":       {},
 	}
 
+	// The usespv option predates the backend option, and selects the
+	// neutrino backend.
 	if cfg.UseSPV {
+		if cfg.Backend != "" && cfg.Backend != backendNeutrino {
+			str := "%s: the --usespv option may not be used with " +
+				"the %s backend"
+			err := fmt.Errorf(str, funcName, cfg.Backend)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.Backend = backendNeutrino
+	}
+	if cfg.Backend == "" {
+		cfg.Backend = backendBtcd
+	}
+
+	switch cfg.Backend {
+	case backendNeutrino:
+		cfg.UseSPV = true
 		neutrino.MaxPeers = cfg.MaxPeers
 		neutrino.BanDuration = cfg.BanDuration
 		neutrino.BanThreshold = cfg.BanThreshold
-	} else {
+
+	case backendBitcoind:
+		if cfg.BitcoindRPCHost == "" {
+			cfg.BitcoindRPCHost = net.JoinHostPort(
+				"localhost", activeNet.RPCServerPort,
+			)
+		}
+		cfg.BitcoindRPCHost, err = cfgutil.NormalizeAddress(
+			cfg.BitcoindRPCHost, activeNet.RPCServerPort,
+		)
+		if err != nil {
+			fmt.Fprintf(os.Stderr,
+				"Invalid bitcoindrpchost network address: %v\n", err)
+			return nil, nil, err
+		}
+
+		// bitcoind doesn't publish notifications through ZMQ by
+		// default, so there are no default addresses to use.  Without
+		// them, its RPC server must be polled instead.
+		if cfg.BlockPollingInterval < 0 || cfg.TxPollingInterval < 0 {
+			str := "%s: the --blockpollinginterval and " +
+				"--txpollinginterval options may not be negative"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if cfg.TxPollingInterval > 0 && cfg.BlockPollingInterval == 0 {
+			str := "%s: the --txpollinginterval option requires " +
+				"the --blockpollinginterval option"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if cfg.BlockPollingInterval == 0 &&
+			(cfg.ZMQPubRawBlock == "" || cfg.ZMQPubRawTx == "") {
+
+			str := "%s: the bitcoind backend requires the " +
+				"--zmqpubrawblock and --zmqpubrawtx options, " +
+				"or the --blockpollinginterval option"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if cfg.ZMQReadDeadline <= 0 || cfg.PrunedNodeMaxPeers <= 0 {
+			str := "%s: the --zmqreaddeadline and " +
+				"--prunednodemaxpeers options must be positive"
+			err := fmt.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+
+	case backendBtcd:
 		if cfg.RPCConnect == "" {
 			cfg.RPCConnect = net.JoinHostPort("localhost", activeNet.RPCClientPort)
 		}
@@ -560,6 +791,66 @@ func loadConfig() (*config, []string, error) {
 				}
 			}
 		}
+
+	case backendElectrum, backendEsplora:
+		// Public Electrum and Esplora servers are plentiful, so rather
+		// than picking one, the server must be chosen explicitly.
+		if cfg.RPCConnect == "" {
+			str := "%s: the %s backend requires the address of " +
+				"a server given by --rpcconnect"
+			err := fmt.Errorf(str, funcName, cfg.Backend)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+
+	default:
+		// Backends registered by other packages are connected to with
+		// the btcd RPC options, as given.
+	}
+
+	// The driver options of the backend must be part of its schema.
+	drv, err := chain.LookupDriver(cfg.Backend)
+	if err != nil {
+		str := "%s: unknown chain backend %q, must be one of %s"
+		err := fmt.Errorf(str, funcName, cfg.Backend,
+			strings.Join(chain.BackEnds(), ", "))
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	cfg.backendOptions = make(map[string]string, len(cfg.BackendOptions))
+	for _, opt := range cfg.BackendOptions {
+		name, value, err := parseBackendOption(drv, opt)
+		if err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.backendOptions[name] = value
+	}
+
+	// Added backends are wrapped along with the backend, and blocks are
+	// connected once a quorum of them agree on them.
+	for _, spec := range cfg.AddBackends {
+		backend, err := parseBackendSpec(spec)
+		if err != nil {
+			err := fmt.Errorf("%s: %v", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.addBackends = append(cfg.addBackends, backend)
+	}
+	if cfg.BackendQuorum < 0 || cfg.BackendQuorum > len(cfg.addBackends)+1 {
+		str := "%s: the backend quorum of %d must be between 1 and " +
+			"the %d chain backends"
+		err := fmt.Errorf(str, funcName, cfg.BackendQuorum,
+			len(cfg.addBackends)+1)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
 	}
 
 	// Only set default RPC listeners when there are no listeners set for
@@ -641,8 +932,26 @@ func loadConfig() (*config, []string, error) {
 		}
 	}
 
+	// The remote signer holds every private key of the wallet, so the
+	// connection to it is always authenticated with mutual TLS.
+	if cfg.RemoteSigner != "" && (cfg.RemoteSignerCert == "" ||
+		cfg.RemoteSignerClientCert == "" ||
+		cfg.RemoteSignerClientKey == "") {
+
+		str := "%s: the --remotesigner option requires the " +
+			"--remotesignercert, --remotesignerclientcert and " +
+			"--remotesignerclientkey options"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Expand environment variable and leading ~ for filepaths.
 	cfg.CAFile.Value = cleanAndExpandPath(cfg.CAFile.Value)
+	cfg.RemoteSignerCert = cleanAndExpandPath(cfg.RemoteSignerCert)
+	cfg.RemoteSignerClientCert = cleanAndExpandPath(cfg.RemoteSignerClientCert)
+	cfg.RemoteSignerClientKey = cleanAndExpandPath(cfg.RemoteSignerClientKey)
 	cfg.RPCCert.Value = cleanAndExpandPath(cfg.RPCCert.Value)
 	cfg.RPCKey.Value = cleanAndExpandPath(cfg.RPCKey.Value)
 
@@ -656,6 +965,12 @@ func loadConfig() (*config, []string, error) {
 	if cfg.BtcdPassword == "" {
 		cfg.BtcdPassword = cfg.Password
 	}
+	if cfg.BitcoindRPCUser == "" {
+		cfg.BitcoindRPCUser = cfg.BtcdUsername
+	}
+	if cfg.BitcoindRPCPass == "" {
+		cfg.BitcoindRPCPass = cfg.BtcdPassword
+	}
 
 	// Warn about missing config file after the final command line parse
 	// succeeds.  This prevents the warning on help messages and invalid
@@ -7,6 +7,16 @@
 package rpchelp
 
 var helpDescsEnUS = map[string]string{
+	// AbandonTransactionCmd help.
+	"abandontransaction--synopsis": "Removes an unconfirmed wallet transaction, and all unconfirmed transactions spending its outputs, so that the outputs they spend become available again.\n" +
+		"The transactions are no longer rebroadcast and are reported by gettransaction under the \"abandoned\" category.\n" +
+		"Only transactions that were evicted from mempools and will never confirm should be abandoned.",
+	"abandontransaction-txid": "The hash of the transaction to abandon",
+
+	// AbortRescanCmd help.
+	"abortrescan--synopsis": "Aborts the rescan started by rescanblockchain, keeping the transactions it found so far.",
+	"abortrescan--result0":  "Whether a rescan was aborted",
+
 	// AddMultisigAddressCmd help.
 	"addmultisigaddress--synopsis": "Generates and imports a multisig address and redeeming script to the 'imported' account.",
 	"addmultisigaddress-account":   "DEPRECATED -- Unused (all imported addresses belong to the imported account)",
@@ -44,6 +54,35 @@ var helpDescsEnUS = map[string]string{
 	"getaddressesbyaccount-account":   "Account name to fetch addresses for",
 	"getaddressesbyaccount--result0":  "All addresses controlled by 'account'",
 
+	// GetAddressInfoCmd help.
+	"getaddressinfo--synopsis": "Returns the derivation path, type, script and usage details of an address.",
+	"getaddressinfo-address":   "The address to query",
+
+	// GetAddressInfoResult help.
+	"getaddressinforesult-address":             "The payment address",
+	"getaddressinforesult-scriptPubKey":        "The output script paying to the address encoded as a hexadecimal string",
+	"getaddressinforesult-ismine":              "Whether the address is controlled by the wallet and can be spent from",
+	"getaddressinforesult-iswatchonly":         "Whether the address is tracked by the wallet without a private key",
+	"getaddressinforesult-isscript":            "Whether the address pays to a script hash",
+	"getaddressinforesult-iswitness":           "Whether the address is a native segwit address",
+	"getaddressinforesult-ischange":            "Whether the address was derived from an internal (change) branch",
+	"getaddressinforesult-isimported":          "Whether the key or script of the address was imported",
+	"getaddressinforesult-addresstype":         "The wallet address type (p2pkh, p2sh-p2wpkh, p2wpkh, script or rawpubkey)",
+	"getaddressinforesult-account":             "The account the address belongs to",
+	"getaddressinforesult-keyscope":            "The key scope of the address manager holding the address",
+	"getaddressinforesult-hdkeypath":           "The BIP0032 derivation path of the address key",
+	"getaddressinforesult-hdmasterfingerprint": "The fingerprint of the master key the address key is derived from, if known",
+	"getaddressinforesult-branch":              "The derivation branch of the address key (0 for external, 1 for internal)",
+	"getaddressinforesult-index":               "The derivation index of the address key",
+	"getaddressinforesult-pubkey":              "The public key of the address encoded as a hexadecimal string",
+	"getaddressinforesult-iscompressed":        "Whether the public key is compressed",
+	"getaddressinforesult-script":              "The class of the redeem or witness script",
+	"getaddressinforesult-hex":                 "The redeem or witness script encoded as a hexadecimal string (only set when the wallet is unlocked)",
+	"getaddressinforesult-addresses":           "The addresses required to sign for the redeem or witness script",
+	"getaddressinforesult-sigsrequired":        "The number of signatures required by a multisig script",
+	"getaddressinforesult-used":                "Whether the address has been used in a transaction",
+	"getaddressinforesult-label":               "The label of the address",
+
 	// GetBalanceCmd help.
 	"getbalance--synopsis":   "Calculates and returns the balance of one or all accounts.",
 	"getbalance-minconf":     "Minimum number of block confirmations required before an unspent output's value is included in the balance",
@@ -53,6 +92,28 @@ var helpDescsEnUS = map[string]string{
 	"getbalance--result0":    "The balance of 'account' valued in bitcoin",
 	"getbalance--result1":    "The balance of all accounts valued in bitcoin",
 
+	// GetBalancesCmd help.
+	"getbalances--synopsis": "Returns the balances of the wallet and of each of its accounts, split by how far their unspent outputs can be trusted and spent.",
+
+	// GetBalancesResult help.
+	"getbalancesresult-mine":      "The balances of outputs the wallet holds private keys for",
+	"getbalancesresult-watchonly": "The balances of watch-only outputs, omitted unless the wallet is watching-only or holds watch-only outputs",
+	"getbalancesresult-accounts":  "The balances of every account of every active key scope",
+
+	// AccountBalancesResult help.
+	"accountbalancesresult-keyscope":  "The key scope of the account",
+	"accountbalancesresult-account":   "The account number",
+	"accountbalancesresult-name":      "The account name",
+	"accountbalancesresult-mine":      "The balances of outputs of the account the wallet holds private keys for",
+	"accountbalancesresult-watchonly": "The balances of watch-only outputs of the account, omitted unless the wallet is watching-only or the account holds watch-only outputs",
+
+	// BalanceDetailsResult help.
+	"balancedetailsresult-trusted":            "The value of confirmed outputs and of unconfirmed outputs of transactions spending only wallet outputs, valued in bitcoin",
+	"balancedetailsresult-untrusted_pending":  "The value of unconfirmed outputs received from others, valued in bitcoin",
+	"balancedetailsresult-immature":           "The value of immature coinbase outputs, valued in bitcoin",
+	"balancedetailsresult-unconfirmed_change": "The part of the trusted balance in unconfirmed outputs, valued in bitcoin",
+	"balancedetailsresult-locked":             "The value of outputs locked by lockunspent or leased, which is not counted in any other balance, valued in bitcoin",
+
 	// GetBestBlockHashCmd help.
 	"getbestblockhash--synopsis": "Returns the hash of the newest block in the best chain that wallet has finished syncing with.",
 	"getbestblockhash--result0":  "The hash of the most recent synced-to block",
@@ -120,21 +181,28 @@ var helpDescsEnUS = map[string]string{
 	// GetTransactionResult help.
 	"gettransactionresult-amount":          "The total amount this transaction credits to the wallet, valued in bitcoin",
 	"gettransactionresult-fee":             "The total input value minus the total output value, or 0 if 'txid' is not a sent transaction",
-	"gettransactionresult-confirmations":   "The number of block confirmations of the transaction",
+	"gettransactionresult-confirmations":   "The number of block confirmations of the transaction, or the negated confirmations of the conflicting transaction if this transaction is conflicted",
 	"gettransactionresult-blockhash":       "The hash of the block this transaction is mined in, or the empty string if unmined",
 	"gettransactionresult-blockindex":      "Unset",
 	"gettransactionresult-blocktime":       "The Unix time of the block header this transaction is mined in, or 0 if unmined",
 	"gettransactionresult-txid":            "The transaction hash",
-	"gettransactionresult-walletconflicts": "Unset",
+	"gettransactionresult-walletconflicts": "Hashes of the transactions removed because they conflicted with this transaction, or the hash of the conflicting transaction if this transaction is conflicted",
 	"gettransactionresult-time":            "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-timereceived":    "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-details":         "Additional details for each recorded wallet credit and debit",
 	"gettransactionresult-hex":             "The transaction encoded as a hexadecimal string",
+	"gettransactionresult-rebroadcast":     "The rebroadcast state of an unmined transaction, if the wallet rebroadcast it",
+
+	// RebroadcastResult help.
+	"rebroadcastresult-attempts":    "The number of times the wallet rebroadcast the transaction",
+	"rebroadcastresult-lastattempt": "The Unix time of the last rebroadcast attempt",
+	"rebroadcastresult-nextattempt": "The Unix time the next rebroadcast attempt is due",
+	"rebroadcastresult-dropped":     "Whether the transaction was missing from the mempool of the backend during the last attempt",
 
 	// GetTransactionDetailsResult help.
 	"gettransactiondetailsresult-account":           "DEPRECATED -- Unset",
 	"gettransactiondetailsresult-address":           "The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input",
-	"gettransactiondetailsresult-category":          `The kind of detail: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, or "recv" for all other received outputs`,
+	"gettransactiondetailsresult-category":          `The kind of detail: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, "recv" for all other received outputs, "conflicted" for transactions removed because a conflicting transaction was mined, or "abandoned" for abandoned transactions`,
 	"gettransactiondetailsresult-amount":            "The amount of a received output",
 	"gettransactiondetailsresult-fee":               "The included fee for a sent transaction",
 	"gettransactiondetailsresult-vout":              "The transaction output index",
@@ -206,10 +274,10 @@ var helpDescsEnUS = map[string]string{
 	// ListTransactionsResult help.
 	"listtransactionsresult-account":            "DEPRECATED -- Unset",
 	"listtransactionsresult-address":            "Payment address for a transaction output",
-	"listtransactionsresult-category":           `The kind of transaction: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, or "recv" for all other received outputs.  Note: A single output may be included multiple times under different categories`,
+	"listtransactionsresult-category":           `The kind of transaction: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, "recv" for all other received outputs, or "conflicted" for transactions removed because a conflicting transaction was mined.  Note: A single output may be included multiple times under different categories`,
 	"listtransactionsresult-amount":             "The value of the transaction output valued in bitcoin",
 	"listtransactionsresult-fee":                "The total input value minus the total output value for sent transactions",
-	"listtransactionsresult-confirmations":      "The number of block confirmations of the transaction",
+	"listtransactionsresult-confirmations":      "The number of block confirmations of the transaction, or the negated confirmations of the conflicting transaction if this transaction is conflicted",
 	"listtransactionsresult-generated":          "Whether the transaction output is a coinbase output",
 	"listtransactionsresult-blockhash":          "The hash of the block this transaction is mined in, or the empty string if unmined",
 	"listtransactionsresult-blockheight":        "The block height containing the transaction.",
@@ -218,7 +286,7 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-label":              "A comment for the address/transaction, if any",
 	"listtransactionsresult-txid":               "The hash of the transaction",
 	"listtransactionsresult-vout":               "The transaction output index",
-	"listtransactionsresult-walletconflicts":    "Unset",
+	"listtransactionsresult-walletconflicts":    "Hashes of the transactions removed because they conflicted with this transaction, or the hash of the conflicting transaction if this transaction is conflicted",
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
@@ -236,7 +304,9 @@ var helpDescsEnUS = map[string]string{
 	"listtransactions-includewatchonly": "Unused",
 
 	// ListUnspentCmd help.
-	"listunspent--synopsis": "Returns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.",
+	"listunspent--synopsis": "Returns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n" +
+		"Two optional arrays of output tags may follow the addresses: if the first is set and not empty, only outputs with at least one of its tags are included, " +
+		"and outputs with any tag of the second are excluded.",
 	"listunspent-minconf":   "Minimum number of block confirmations required before a transaction output is considered",
 	"listunspent-maxconf":   "Maximum number of block confirmations required before a transaction output is excluded",
 	"listunspent-addresses": "If set, limits the returned details to unspent outputs received by any of these payment addresses",
@@ -246,6 +316,9 @@ var helpDescsEnUS = map[string]string{
 	"listunspentresult-vout":          "The output index of the referenced output",
 	"listunspentresult-address":       "The payment address that received the output",
 	"listunspentresult-account":       "The account associated with the receiving payment address",
+	"listunspentresult-label":         "The label of the receiving payment address, if any",
+	"listunspentresult-outputlabel":   "The label of the output itself, if any",
+	"listunspentresult-tags":          "The sorted tags of the output, if any",
 	"listunspentresult-scriptPubKey":  "The output script encoded as a hexadecimal string",
 	"listunspentresult-redeemScript":  "Unset",
 	"listunspentresult-amount":        "The amount of the output valued in bitcoin",
@@ -261,6 +334,16 @@ var helpDescsEnUS = map[string]string{
 	"lockunspent-transactions": "Transaction outputs to lock or unlock",
 	"lockunspent--result0":     "The boolean 'true'",
 
+	// RescanBlockchainCmd help.
+	"rescanblockchain--synopsis": "Rescans the blocks of a height range for transactions relevant to the wallet, without dropping its transaction history.\n" +
+		"The call returns once the rescan finished, and can be aborted with abortrescan.",
+	"rescanblockchain-startheight": "The height of the first block to rescan",
+	"rescanblockchain-stopheight":  "The height of the last block to rescan (default=the height the wallet is synced to)",
+
+	// RescanBlockchainResult help.
+	"rescanblockchainresult-start_height": "The height of the first block rescanned",
+	"rescanblockchainresult-stop_height":  "The height of the last block rescanned",
+
 	// SendFromCmd help.
 	"sendfrom--synopsis": "DEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
@@ -379,6 +462,19 @@ var helpDescsEnUS = map[string]string{
 	"exportwatchingwallet-download":  "Unused",
 	"exportwatchingwallet--result0":  "The watching-only database encoded as a base64 string",
 
+	// GetBackendHealthCmd help.
+	"getbackendhealth--synopsis": "Returns the health of the chain backends the wallet synchronizes with, in the order they were configured.",
+
+	// BackendHealthResult help.
+	"backendhealthresult-name":        "The name identifying the backend",
+	"backendhealthresult-backend":     "The driver of the backend",
+	"backendhealthresult-healthy":     "Whether the backend answered its last request",
+	"backendhealthresult-active":      "Whether the backend serves the requests of the wallet",
+	"backendhealthresult-besthash":    "The hash of the best block of the backend at its last health check",
+	"backendhealthresult-bestheight":  "The height of the best block of the backend at its last health check",
+	"backendhealthresult-lastchecked": "The Unix time of the last health check of the backend",
+	"backendhealthresult-lasterror":   "The last error returned by the backend",
+
 	// GetBestBlockCmd help.
 	"getbestblock--synopsis": "Returns the hash and height of the newest block in the best chain that wallet has finished syncing with.",
 
@@ -386,6 +482,18 @@ var helpDescsEnUS = map[string]string{
 	"getbestblockresult-hash":   "The hash of the block",
 	"getbestblockresult-height": "The blockchain height of the block",
 
+	// GetRescanStatusCmd help.
+	"getrescanstatus--synopsis": "Returns the progress of the rescan being performed, which is resumed from its last checkpoint if the wallet restarts.",
+
+	// GetRescanStatusResult help.
+	"getrescanstatusresult-active":        "Whether a rescan is being performed",
+	"getrescanstatusresult-startheight":   "The height of the block the rescan started from",
+	"getrescanstatusresult-currentheight": "The height of the last block processed by the rescan",
+	"getrescanstatusresult-targetheight":  "The height of the best block of the chain backend",
+	"getrescanstatusresult-starttime":     "The Unix time the rescan was started at",
+	"getrescanstatusresult-percent":       "The percentage of the blocks from the start to the target height that were rescanned",
+	"getrescanstatusresult-eta":           "The estimated number of seconds until the rescan finishes",
+
 	// GetUnconfirmedBalanceCmd help.
 	"getunconfirmedbalance--synopsis": "Calculates the unspent output value of all unmined transaction outputs for an account.",
 	"getunconfirmedbalance-account":   "The account to query the unconfirmed balance for (default=\"default\")",
@@ -400,6 +508,21 @@ var helpDescsEnUS = map[string]string{
 	"listalltransactions--synopsis": "Returns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.",
 	"listalltransactions-account":   "Unused (must be unset or \"*\")",
 
+	// ListTransactionsPageCmd help.
+	"listtransactionspage--synopsis": "Returns the 'listtransactions' results of a page of wallet transactions, newest first.\n" +
+		"Pages are continued by passing the cursor returned with the previous page, which keeps its position as new transactions arrive.",
+	"listtransactionspage-cursor":    "The cursor returned with the previous page, or unset or empty for the first page",
+	"listtransactionspage-count":     "Maximum number of transactions to create results from",
+	"listtransactionspage-account":   "Only include transactions paying to or spending from the named account (unset or \"*\" for all accounts)",
+	"listtransactionspage-category":  `Only include results in this category: "send", "receive", "generate" or "immature"`,
+	"listtransactionspage-label":     "Only include transactions with this transaction label or paying to an address with this address label",
+	"listtransactionspage-minamount": "Only include transactions whose net amount, in absolute value, is at least this many bitcoin",
+	"listtransactionspage-maxamount": "Only include transactions whose net amount, in absolute value, is at most this many bitcoin",
+
+	// ListTransactionsPageResult help.
+	"listtransactionspageresult-transactions": "The results of the page's transactions",
+	"listtransactionspageresult-nextcursor":   "The cursor to request the following page with, omitted when no transactions follow",
+
 	// RenameAccountCmd help.
 	"renameaccount--synopsis":  "Renames an account.",
 	"renameaccount-oldaccount": "The old account name to rename",
@@ -408,4 +531,61 @@ var helpDescsEnUS = map[string]string{
 	// WalletIsLockedCmd help.
 	"walletislocked--synopsis": "Returns whether or not the wallet is locked.",
 	"walletislocked--result0":  "Whether the wallet is locked",
+
+	// GetAccountXpubCmd help.
+	"getaccountxpub--synopsis": "Returns the extended public key of an account along with its key origin, for setting up watch-only wallets.",
+	"getaccountxpub-account":   "The name of the account",
+	"getaccountxpub-scope":     "The key scope of the account (bip44, bip49 or bip84), which selects the xpub, ypub or zpub (or testnet) key version",
+
+	// GetAccountXpubResult help.
+	"getaccountxpubresult-account":           "The name of the account",
+	"getaccountxpubresult-keyscope":          "The key scope of the account",
+	"getaccountxpubresult-xpub":              "The account's extended public key encoded with the SLIP-0132 version of the key scope",
+	"getaccountxpubresult-masterfingerprint": "The fingerprint of the master key the account is derived from, or 00000000 if unknown",
+	"getaccountxpubresult-path":              "The derivation path of the account key",
+	"getaccountxpubresult-keyorigin":         "The key origin of the account key in [fingerprint/purpose'/coin'/account'] form",
+
+	// ImportAccountXprvCmd help.
+	"importaccountxprv--synopsis":         "Imports a spendable account from an account extended private key (m/purpose'/coin'/account'), such as one exported from another HD wallet.\nThe key is stored encrypted with the wallet's private passphrase, so the wallet must be unlocked.\nWhen rescanning, the command returns once the chain has been scanned for the account's addresses.",
+	"importaccountxprv-account":           "The name of the new account",
+	"importaccountxprv-xprv":              "The account extended private key, whose version (xprv, yprv, zprv or their testnet equivalents) selects the key scope",
+	"importaccountxprv-addresstype":       "The address type (p2sh-p2wpkh or p2wpkh) of the account, required for xprv and yprv keys",
+	"importaccountxprv-masterfingerprint": "The hex-encoded fingerprint of the master key the account key was derived from",
+	"importaccountxprv-rescan":            "Scan the chain for the account's addresses",
+	"importaccountxprv-startheight":       "The height of the block to start scanning from",
+
+	// ImportAccountXprvResult help.
+	"importaccountxprvresult-account":           "The name of the account",
+	"importaccountxprvresult-keyscope":          "The key scope of the account",
+	"importaccountxprvresult-xpub":              "The account's extended public key encoded with the SLIP-0132 version of the key scope",
+	"importaccountxprvresult-masterfingerprint": "The fingerprint of the master key the account is derived from, or 00000000 if unknown",
+	"importaccountxprvresult-externalkeycount":  "The number of external addresses of the account, including any found while scanning",
+	"importaccountxprvresult-internalkeycount":  "The number of internal addresses of the account, including any found while scanning",
+
+	// GetAddressesByLabelCmd help.
+	"getaddressesbylabel--synopsis":       "Returns all wallet addresses carrying a label.",
+	"getaddressesbylabel-label":           "The label to look up",
+	"getaddressesbylabel--result0--desc":  "JSON object with payment addresses as keys and address details as values",
+	"getaddressesbylabel--result0--key":   "The payment address",
+	"getaddressesbylabel--result0--value": "Details about the address",
+
+	// GetAddressesByLabelResult help.
+	"getaddressesbylabelresult-purpose": "Purpose of the address (\"receive\" for external or \"change\" for internal addresses)",
+
+	// ListLabelsCmd help.
+	"listlabels--synopsis": "Returns the sorted list of labels in use by wallet addresses.",
+	"listlabels--result0":  "All distinct address labels",
+
+	// SetLabelCmd help.
+	"setlabel--synopsis": "Attaches a label to a wallet address, replacing any previous label.  Address labels are kept when the transaction history is dropped.",
+	"setlabel-address":   "The wallet address to label",
+	"setlabel-label":     "The new label (an empty string removes the label)",
+
+	// SetOutputLabelCmd help.
+	"setoutputlabel--synopsis": "Attaches a label and tags to an unspent wallet output, replacing any previous ones.\n" +
+		"Tags such as \"do-not-spend\" or \"cold-reserve\" can be used to control which outputs are spent, and are shown by 'listunspent'.",
+	"setoutputlabel-txid":  "The hash of the transaction of the output",
+	"setoutputlabel-vout":  "The index of the output",
+	"setoutputlabel-label": "The new label of the output (an empty string and no tags removes the label and tags)",
+	"setoutputlabel-tags":  "The new tags of the output",
 }
@@ -6,7 +6,10 @@
 
 package rpchelp
 
-import "github.com/tinhnguyenhn/colxd/btcjson"
+import (
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxwallet/rpc/walletjson"
+)
 
 // Common return types.
 var (
@@ -23,13 +26,17 @@ var Methods = []struct {
 	Method      string
 	ResultTypes []interface{}
 }{
+	{"abandontransaction", nil},
+	{"abortrescan", returnsBool},
 	{"addmultisigaddress", returnsString},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
 	{"dumpprivkey", returnsString},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
+	{"getaddressinfo", []interface{}{(*walletjson.GetAddressInfoResult)(nil)}},
 	{"getbalance", append(returnsNumber, returnsNumber[0])},
+	{"getbalances", []interface{}{(*walletjson.GetBalancesResult)(nil)}},
 	{"getbestblockhash", returnsString},
 	{"getblockcount", returnsNumber},
 	{"getinfo", []interface{}{(*btcjson.InfoWalletResult)(nil)}},
@@ -37,7 +44,7 @@ var Methods = []struct {
 	{"getrawchangeaddress", returnsString},
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
-	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"gettransaction", []interface{}{(*walletjson.GetTransactionResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
 	{"importprivkey", nil},
 	{"keypoolrefill", nil},
@@ -47,8 +54,9 @@ var Methods = []struct {
 	{"listreceivedbyaddress", []interface{}{(*[]btcjson.ListReceivedByAddressResult)(nil)}},
 	{"listsinceblock", []interface{}{(*btcjson.ListSinceBlockResult)(nil)}},
 	{"listtransactions", returnsLTRArray},
-	{"listunspent", []interface{}{(*btcjson.ListUnspentResult)(nil)}},
+	{"listunspent", []interface{}{(*[]walletjson.ListUnspentResult)(nil)}},
 	{"lockunspent", returnsBool},
+	{"rescanblockchain", []interface{}{(*walletjson.RescanBlockchainResult)(nil)}},
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
 	{"sendtoaddress", returnsString},
@@ -62,12 +70,21 @@ var Methods = []struct {
 	{"walletpassphrasechange", nil},
 	{"createnewaccount", nil},
 	{"exportwatchingwallet", returnsString},
+	{"getbackendhealth", []interface{}{(*[]walletjson.BackendHealthResult)(nil)}},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
+	{"getrescanstatus", []interface{}{(*walletjson.GetRescanStatusResult)(nil)}},
 	{"getunconfirmedbalance", returnsNumber},
 	{"listaddresstransactions", returnsLTRArray},
 	{"listalltransactions", returnsLTRArray},
+	{"listtransactionspage", []interface{}{(*walletjson.ListTransactionsPageResult)(nil)}},
 	{"renameaccount", nil},
 	{"walletislocked", returnsBool},
+	{"getaccountxpub", []interface{}{(*walletjson.GetAccountXpubResult)(nil)}},
+	{"importaccountxprv", []interface{}{(*walletjson.ImportAccountXprvResult)(nil)}},
+	{"getaddressesbylabel", []interface{}{(*map[string]walletjson.GetAddressesByLabelResult)(nil)}},
+	{"listlabels", returnsStringArray},
+	{"setlabel", nil},
+	{"setoutputlabel", nil},
 }
 
 // HelpDescs contains the locale-specific help strings along with the locale.
@@ -81,4 +81,9 @@ var (
 		Code:    btcjson.ErrRPCInvalidParameter,
 		Message: "Account name is reserved by RPC server",
 	}
+
+	ErrChainClientInactive = btcjson.RPCError{
+		Code:    btcjson.ErrRPCClientNotConnected,
+		Message: "Request requires a chain backend but none is connected",
+	}
 )
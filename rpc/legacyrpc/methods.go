@@ -8,10 +8,12 @@ package legacyrpc
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -23,7 +25,9 @@ import (
 	"github.com/tinhnguyenhn/colxd/txscript"
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
 	"github.com/tinhnguyenhn/colxwallet/chain"
+	"github.com/tinhnguyenhn/colxwallet/rpc/walletjson"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/wallet"
 	"github.com/tinhnguyenhn/colxwallet/wallet/txrules"
@@ -72,13 +76,17 @@ var rpcHandlers = map[string]struct {
 	noHelp bool
 }{
 	// Reference implementation wallet methods (implemented)
+	"abandontransaction":     {handler: abandonTransaction},
+	"abortrescan":            {handler: abortRescan},
 	"addmultisigaddress":     {handler: addMultiSigAddress},
 	"createmultisig":         {handler: createMultiSig},
 	"dumpprivkey":            {handler: dumpPrivKey},
 	"getaccount":             {handler: getAccount},
 	"getaccountaddress":      {handler: getAccountAddress},
 	"getaddressesbyaccount":  {handler: getAddressesByAccount},
+	"getaddressinfo":         {handler: getAddressInfo},
 	"getbalance":             {handler: getBalance},
+	"getbalances":            {handler: getBalances},
 	"getbestblockhash":       {handler: getBestBlockHash},
 	"getblockcount":          {handler: getBlockCount},
 	"getinfo":                {handlerWithChain: getInfo},
@@ -98,6 +106,7 @@ var rpcHandlers = map[string]struct {
 	"listtransactions":       {handler: listTransactions},
 	"listunspent":            {handler: listUnspent},
 	"lockunspent":            {handler: lockUnspent},
+	"rescanblockchain":       {handler: rescanBlockchain},
 	"sendfrom":               {handlerWithChain: sendFrom},
 	"sendmany":               {handler: sendMany},
 	"sendtoaddress":          {handler: sendToAddress},
@@ -125,7 +134,9 @@ var rpcHandlers = map[string]struct {
 
 	// Extensions to the reference client JSON-RPC API
 	"createnewaccount": {handler: createNewAccount},
+	"getbackendhealth": {handler: getBackendHealth},
 	"getbestblock":     {handler: getBestBlock},
+	"getrescanstatus":  {handler: getRescanStatus},
 	// This was an extension but the reference implementation added it as
 	// well, but with a different API (no account parameter).  It's listed
 	// here because it hasn't been update to use the reference
@@ -133,8 +144,21 @@ var rpcHandlers = map[string]struct {
 	"getunconfirmedbalance":   {handler: getUnconfirmedBalance},
 	"listaddresstransactions": {handler: listAddressTransactions},
 	"listalltransactions":     {handler: listAllTransactions},
+	"listtransactionspage":    {handler: listTransactionsPage},
 	"renameaccount":           {handler: renameAccount},
 	"walletislocked":          {handler: walletIsLocked},
+
+	// Account key export and import methods
+	"getaccountxpub":    {handler: getAccountXpub},
+	"importaccountxprv": {handler: importAccountXprv},
+
+	// Address label methods
+	"getaddressesbylabel": {handler: getAddressesByLabel},
+	"listlabels":          {handler: listLabels},
+	"setlabel":            {handler: setLabel},
+
+	// Output label and coin control methods
+	"setoutputlabel": {handler: setOutputLabel},
 }
 
 // unimplemented handles an unimplemented RPC request with the
@@ -168,7 +192,7 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	handlerData, ok := rpcHandlers[request.Method]
 	if ok && handlerData.handlerWithChain != nil && w != nil && chainClient != nil {
 		return func() (interface{}, *btcjson.RPCError) {
-			cmd, err := btcjson.UnmarshalCmd(request)
+			cmd, err := unmarshalCmd(request)
 			if err != nil {
 				return nil, btcjson.ErrRPCInvalidRequest
 			}
@@ -190,7 +214,7 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	}
 	if ok && handlerData.handler != nil && w != nil {
 		return func() (interface{}, *btcjson.RPCError) {
-			cmd, err := btcjson.UnmarshalCmd(request)
+			cmd, err := unmarshalCmd(request)
 			if err != nil {
 				return nil, btcjson.ErrRPCInvalidRequest
 			}
@@ -227,6 +251,48 @@ func lazyApplyHandler(request *btcjson.Request, w *wallet.Wallet, chainClient ch
 	}
 }
 
+// unmarshalCmd unmarshals the command of a request.  The tag filters of the
+// listunspent command follow its standard parameters, which are the only ones
+// btcjson accepts, so they are unmarshalled separately.
+func unmarshalCmd(request *btcjson.Request) (interface{}, error) {
+	if request.Method != "listunspent" {
+		return btcjson.UnmarshalCmd(request)
+	}
+
+	const numStdParams = 3
+	params := request.Params
+	if len(params) > numStdParams+2 {
+		return nil, errors.New("too many parameters")
+	}
+	var tagParams []json.RawMessage
+	if len(params) > numStdParams {
+		stdRequest := *request
+		stdRequest.Params = params[:numStdParams]
+		request = &stdRequest
+		tagParams = params[numStdParams:]
+	}
+
+	cmd, err := btcjson.UnmarshalCmd(request)
+	if err != nil {
+		return nil, err
+	}
+	listCmd := &walletjson.ListUnspentCmd{
+		ListUnspentCmd: *cmd.(*btcjson.ListUnspentCmd),
+	}
+	tags := []**[]string{&listCmd.IncludeTags, &listCmd.ExcludeTags}
+	for i, param := range tagParams {
+		if string(param) == "null" {
+			continue
+		}
+		var t []string
+		if err := json.Unmarshal(param, &t); err != nil {
+			return nil, err
+		}
+		*tags[i] = &t
+	}
+	return listCmd, nil
+}
+
 // makeResponse makes the JSON-RPC response struct for the result and error
 // returned by a requestHandler.  The returned response is not ready for
 // marshaling and sending off to a client, but must be
@@ -318,6 +384,42 @@ func makeMultiSigScript(w *wallet.Wallet, keys []string, nRequired int) ([]byte,
 	return txscript.MultiSigScript(keysesPrecious, nRequired)
 }
 
+// abandonTransaction handles an abandontransaction request by removing an
+// unconfirmed transaction, and every unconfirmed transaction spending its
+// outputs, from the wallet.
+func abandonTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.AbandonTransactionCmd)
+
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+
+	err = w.AbandonTransaction(txHash)
+	switch err {
+	case nil:
+		return nil, nil
+
+	case wtxmgr.ErrUnminedTxNotFound:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Invalid or non-wallet transaction id",
+		}
+
+	case wallet.ErrTxConfirmed:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Transaction not eligible for abandonment",
+		}
+
+	default:
+		return nil, err
+	}
+}
+
 // addMultiSigAddress handles an addmultisigaddress request by adding a
 // multisig address to the given wallet.
 func addMultiSigAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -447,6 +549,54 @@ func getBalance(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return balance.ToBTC(), nil
 }
 
+// balanceDetailsResult returns the getbalances representation of a balance
+// breakdown.  The trusted balance includes the unconfirmed change, as done by
+// the reference implementation.
+func balanceDetailsResult(b *wallet.BalanceBreakdown) walletjson.BalanceDetailsResult {
+	return walletjson.BalanceDetailsResult{
+		Trusted:           b.Trusted().ToBTC(),
+		UntrustedPending:  b.UntrustedPending.ToBTC(),
+		Immature:          b.Immature.ToBTC(),
+		UnconfirmedChange: b.UnconfirmedChange.ToBTC(),
+		Locked:            b.Locked.ToBTC(),
+	}
+}
+
+// getBalances handles a getbalances request by returning the balance breakdown
+// of the wallet and of each of its accounts.  Watch-only balances are only
+// included when there are any, or when the wallet is watching-only.
+func getBalances(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	bals, err := w.CalculateBalanceBreakdowns()
+	if err != nil {
+		return nil, err
+	}
+
+	watchingOnly := w.Manager.WatchOnly()
+	result := &walletjson.GetBalancesResult{
+		Mine:     balanceDetailsResult(&bals.Mine),
+		Accounts: make([]walletjson.AccountBalancesResult, 0, len(bals.Accounts)),
+	}
+	if watchingOnly || bals.WatchOnly.Total() != 0 {
+		watchOnly := balanceDetailsResult(&bals.WatchOnly)
+		result.WatchOnly = &watchOnly
+	}
+	for i := range bals.Accounts {
+		a := &bals.Accounts[i]
+		acctResult := walletjson.AccountBalancesResult{
+			KeyScope: a.KeyScope.String(),
+			Account:  a.AccountNumber,
+			Name:     a.AccountName,
+			Mine:     balanceDetailsResult(&a.Mine),
+		}
+		if watchingOnly || a.WatchOnly.Total() != 0 {
+			watchOnly := balanceDetailsResult(&a.WatchOnly)
+			acctResult.WatchOnly = &watchOnly
+		}
+		result.Accounts = append(result.Accounts, acctResult)
+	}
+	return result, nil
+}
+
 // getBestBlock handles a getbestblock request by returning a JSON object
 // with the height and hash of the most recently processed block.
 func getBestBlock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -458,6 +608,118 @@ func getBestBlock(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return result, nil
 }
 
+// getBackendHealth handles a getbackendhealth request by returning the health
+// of the chain backends the wallet synchronizes with.  A wallet synchronizing
+// with a single backend reports whether it answers requests.
+func getBackendHealth(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	chainClient := w.ChainClient()
+	if chainClient == nil {
+		return nil, &ErrChainClientInactive
+	}
+
+	multiClient, ok := chainClient.(*chain.MultiClient)
+	if !ok {
+		result := walletjson.BackendHealthResult{
+			Name:        chainClient.BackEnd(),
+			Backend:     chainClient.BackEnd(),
+			Active:      true,
+			LastChecked: time.Now().Unix(),
+		}
+		bestBlock, err := chainClient.BlockStamp()
+		if err != nil {
+			result.LastError = err.Error()
+		} else {
+			result.Healthy = true
+			result.BestHash = bestBlock.Hash.String()
+			result.BestHeight = bestBlock.Height
+		}
+		return []walletjson.BackendHealthResult{result}, nil
+	}
+
+	health := multiClient.Health()
+	results := make([]walletjson.BackendHealthResult, 0, len(health))
+	for _, h := range health {
+		result := walletjson.BackendHealthResult{
+			Name:    h.Name,
+			Backend: h.BackEnd,
+			Healthy: h.Healthy,
+			Active:  h.Active,
+		}
+		if !h.LastChecked.IsZero() {
+			result.BestHash = h.BestBlock.Hash.String()
+			result.BestHeight = h.BestBlock.Height
+			result.LastChecked = h.LastChecked.Unix()
+		}
+		if h.LastError != nil {
+			result.LastError = h.LastError.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// getRescanStatus handles a getrescanstatus request by returning the progress
+// of the rescan being performed.
+func getRescanStatus(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	status, err := w.RescanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &walletjson.GetRescanStatusResult{
+		Active: status.Active,
+	}
+	if status.Active {
+		result.StartHeight = status.StartHeight
+		result.CurrentHeight = status.CurrentHeight
+		result.TargetHeight = status.TargetHeight
+		result.StartTime = status.StartTime.Unix()
+		result.Percent = status.Progress * 100
+		result.ETA = int64(status.ETA.Seconds())
+	}
+	return result, nil
+}
+
+// rescanBlockchain handles a rescanblockchain request by rescanning the blocks
+// of the requested height range for all wallet addresses and outpoints.
+func rescanBlockchain(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.RescanBlockchainCmd)
+
+	if w.ChainClient() == nil {
+		return nil, &ErrChainClientInactive
+	}
+
+	startHeight := int32(0)
+	if cmd.StartHeight != nil {
+		startHeight = *cmd.StartHeight
+	}
+	stopHeight := int32(-1)
+	if cmd.StopHeight != nil {
+		stopHeight = *cmd.StopHeight
+	}
+
+	startStamp, stopStamp, err := w.RescanBlockchain(
+		startHeight, stopHeight, nil, nil,
+	)
+	switch {
+	case err == wallet.ErrInvalidRescanRange:
+		return nil, InvalidParameterError{err}
+	case err != nil:
+		return nil, err
+	}
+
+	return &walletjson.RescanBlockchainResult{
+		StartHeight: startStamp.Height,
+		StopHeight:  stopStamp.Height,
+	}, nil
+}
+
+// abortRescan handles an abortrescan request by aborting the rescan started by
+// rescanblockchain.
+func abortRescan(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	return w.AbortRescan(), nil
+}
+
 // getBestBlockHash handles a getbestblockhash request by returning the hash
 // of the most recently processed block.
 func getBestBlockHash(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -673,6 +935,273 @@ func renameAccount(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
 }
 
+// keyScopes maps the key scope names accepted by RPC parameters to the
+// wallet's key scopes.
+var keyScopes = map[string]waddrmgr.KeyScope{
+	"bip44": waddrmgr.KeyScopeBIP0044,
+	"bip49": waddrmgr.KeyScopeBIP0049Plus,
+	"bip84": waddrmgr.KeyScopeBIP0084,
+}
+
+// parseKeyScope returns the key scope named by an RPC parameter.
+func parseKeyScope(name string) (waddrmgr.KeyScope, error) {
+	scope, ok := keyScopes[name]
+	if !ok {
+		return waddrmgr.KeyScope{}, InvalidParameterError{
+			fmt.Errorf("unknown key scope %q (expected bip44, bip49 "+
+				"or bip84)", name),
+		}
+	}
+	return scope, nil
+}
+
+// getAccountXpub handles a getaccountxpub request by returning the extended
+// public key of an account together with its key origin.
+func getAccountXpub(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.GetAccountXpubCmd)
+
+	scope, err := parseKeyScope(*cmd.Scope)
+	if err != nil {
+		return nil, err
+	}
+	account, err := w.AccountNumber(scope, cmd.Account)
+	if err != nil {
+		if waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+			return nil, &ErrAccountNameNotFound
+		}
+		return nil, err
+	}
+
+	origin, err := w.AccountKeyOrigin(scope, account)
+	if err != nil {
+		if waddrmgr.IsError(err, waddrmgr.ErrNoExist) {
+			return nil, InvalidParameterError{err}
+		}
+		return nil, err
+	}
+
+	return walletjson.GetAccountXpubResult{
+		Account:  cmd.Account,
+		KeyScope: scope.String(),
+		XPub:     origin.PubKey.String(),
+		MasterFingerprint: masterKeyFingerprintString(
+			origin.MasterKeyFingerprint,
+		),
+		Path:      origin.PathString(),
+		KeyOrigin: origin.String(),
+	}, nil
+}
+
+// addressTypes maps the address type names accepted by RPC parameters to the
+// address types used to select the key scope of imported account keys.
+var addressTypes = map[string]waddrmgr.AddressType{
+	"p2sh-p2wpkh": waddrmgr.NestedWitnessPubKey,
+	"p2wpkh":      waddrmgr.WitnessPubKey,
+}
+
+// importAccountXprv handles an importaccountxprv request by importing a
+// spendable account backed by an account extended private key.  When a rescan
+// is requested, the request only completes once the chain has been scanned for
+// the account's addresses.
+func importAccountXprv(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.ImportAccountXprvCmd)
+
+	// The wildcard * is reserved by the rpc server with the special meaning
+	// of "all accounts", so disallow naming accounts to this string.
+	if cmd.Account == "*" {
+		return nil, &ErrReservedAccountName
+	}
+
+	xprv, err := hdkeychain.NewKeyFromString(cmd.XPrv)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Extended key decode failed: " + err.Error(),
+		}
+	}
+	if !xprv.IsPrivate() {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Key is not an extended private key",
+		}
+	}
+
+	var addrType *waddrmgr.AddressType
+	if cmd.AddressType != nil {
+		t, ok := addressTypes[*cmd.AddressType]
+		if !ok {
+			return nil, InvalidParameterError{
+				fmt.Errorf("unknown address type %q (expected "+
+					"p2sh-p2wpkh or p2wpkh)", *cmd.AddressType),
+			}
+		}
+		addrType = &t
+	}
+
+	var fingerprint uint32
+	if cmd.MasterFingerprint != nil {
+		b, err := hex.DecodeString(*cmd.MasterFingerprint)
+		if err != nil || len(b) != 4 {
+			return nil, InvalidParameterError{
+				errors.New("master fingerprint must be 4 " +
+					"hex-encoded bytes"),
+			}
+		}
+		fingerprint = binary.LittleEndian.Uint32(b)
+	}
+
+	var bs *waddrmgr.BlockStamp
+	if *cmd.Rescan {
+		if *cmd.StartHeight < 0 {
+			return nil, InvalidParameterError{
+				errors.New("start height must not be negative"),
+			}
+		}
+		bs = &waddrmgr.BlockStamp{Height: *cmd.StartHeight}
+	}
+
+	props, err := w.ImportAccountPrivKey(
+		cmd.Account, xprv, fingerprint, addrType, bs, *cmd.Rescan,
+	)
+	switch {
+	case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+	case waddrmgr.IsError(err, waddrmgr.ErrDuplicateAccount):
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInvalidAccountName,
+			Message: err.Error(),
+		}
+	case err != nil:
+		return nil, err
+	}
+
+	origin, err := w.AccountKeyOrigin(props.KeyScope, props.AccountNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return walletjson.ImportAccountXprvResult{
+		Account:  props.AccountName,
+		KeyScope: props.KeyScope.String(),
+		XPub:     origin.PubKey.String(),
+		MasterFingerprint: masterKeyFingerprintString(
+			origin.MasterKeyFingerprint,
+		),
+		ExternalKeyCount: props.ExternalKeyCount,
+		InternalKeyCount: props.InternalKeyCount,
+	}, nil
+}
+
+// setLabel handles a setlabel request by attaching a label to a wallet
+// address.  An empty label removes the address' label.
+func setLabel(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.SetLabelCmd)
+
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.SetAddressLabel(addr, cmd.Label)
+	if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+		return nil, &ErrAddressNotInWallet
+	}
+	if waddrmgr.IsError(err, waddrmgr.ErrInvalidLabel) {
+		return nil, InvalidParameterError{err}
+	}
+	return nil, err
+}
+
+// setOutputLabel handles a setoutputlabel request by replacing the label and
+// tags of an unspent wallet output.
+func setOutputLabel(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.SetOutputLabelCmd)
+
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+	var tags []string
+	if cmd.Tags != nil {
+		tags = *cmd.Tags
+	}
+
+	op := wire.OutPoint{Hash: *txHash, Index: cmd.Vout}
+	err = w.SetOutputLabel(op, cmd.Label, tags)
+	switch err {
+	case nil:
+		return nil, nil
+
+	case wtxmgr.ErrUnknownOutput:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Output is not an unspent wallet output",
+		}
+
+	case wtxmgr.ErrLabelTooLong, wtxmgr.ErrEmptyTag,
+		wtxmgr.ErrTagTooLong, wtxmgr.ErrTooManyTags:
+
+		return nil, InvalidParameterError{err}
+
+	default:
+		return nil, err
+	}
+}
+
+// getAddressesByLabel handles a getaddressesbylabel request by returning
+// all wallet addresses carrying a label, keyed by their encoding.
+func getAddressesByLabel(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.GetAddressesByLabelCmd)
+
+	addrs, err := w.AddressesByLabel(cmd.Label)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletInvalidAccountName,
+			Message: fmt.Sprintf("No addresses with label %q", cmd.Label),
+		}
+	}
+
+	result := make(map[string]walletjson.GetAddressesByLabelResult, len(addrs))
+	for _, addr := range addrs {
+		purpose := "receive"
+		ma, err := w.AddressInfo(addr)
+		if err == nil && ma.Internal() {
+			purpose = "change"
+		}
+		result[addr.EncodeAddress()] = walletjson.GetAddressesByLabelResult{
+			Purpose: purpose,
+		}
+	}
+	return result, nil
+}
+
+// listLabels handles a listlabels request by returning the sorted set of
+// labels in use by wallet addresses.
+func listLabels(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	labels, err := w.AddressLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(labels))
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		result = append(result, label)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
 // getNewAddress handles a getnewaddress request by returning a new
 // address for an account.  If the account does not exist an appropriate
 // error is returned.
@@ -783,8 +1312,31 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	// Transactions removed because a conflicting transaction was mined, or
+	// because they were abandoned, are reported from their records.
+	var (
+		conflict  *wtxmgr.ConflictedTx
+		abandoned *wtxmgr.AbandonedTx
+	)
+	if details == nil {
+		conflict, err = wallet.UnstableAPI(w).ConflictedTx(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if conflict != nil {
+			details = wallet.ConflictedTxDetails(conflict)
+		}
+	}
 	if details == nil {
-		return nil, &ErrNoTransactionInfo
+		abandoned, err = wallet.UnstableAPI(w).AbandonedTx(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if abandoned == nil {
+			return nil, &ErrNoTransactionInfo
+		}
+		details = wallet.AbandonedTxDetails(abandoned)
 	}
 
 	syncBlock := w.Manager.SyncedTo()
@@ -800,12 +1352,12 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 	// TODO: Add a "generated" field to this result type.  "generated":true
 	// is only added if the transaction is a coinbase.
-	ret := btcjson.GetTransactionResult{
+	ret := walletjson.GetTransactionResult{
 		TxID:            cmd.Txid,
 		Hex:             hex.EncodeToString(txBuf.Bytes()),
 		Time:            details.Received.Unix(),
 		TimeReceived:    details.Received.Unix(),
-		WalletConflicts: []string{}, // Not saved
+		WalletConflicts: []string{},
 		//Generated:     blockchain.IsCoinBaseTx(&details.MsgTx),
 	}
 
@@ -815,6 +1367,46 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		ret.Confirmations = int64(confirms(details.Block.Height, syncBlock.Height))
 	}
 
+	// Unmined transactions report how often the wallet rebroadcast them.
+	if details.Block.Height == -1 && conflict == nil && abandoned == nil {
+		state, err := wallet.UnstableAPI(w).RebroadcastState(txHash)
+		if err != nil {
+			return nil, err
+		}
+		if state != nil {
+			ret.Rebroadcast = &walletjson.RebroadcastResult{
+				Attempts:    state.Attempts,
+				LastAttempt: state.LastAttempt.Unix(),
+				NextAttempt: state.NextAttempt.Unix(),
+				Dropped:     state.Dropped,
+			}
+		}
+	}
+
+	// A conflicted transaction conflicts with the transaction that removed
+	// it and has the negated confirmations of that transaction.  Any other
+	// transaction lists the transactions removed because of it.
+	if conflict != nil {
+		ret.WalletConflicts = []string{conflict.ConflictingTx.String()}
+		winner, err := wallet.UnstableAPI(w).TxDetails(&conflict.ConflictingTx)
+		if err != nil {
+			return nil, err
+		}
+		if winner != nil && winner.Block.Height != -1 {
+			ret.Confirmations = -int64(confirms(winner.Block.Height,
+				syncBlock.Height))
+		}
+	} else if abandoned == nil {
+		conflicts, err := wallet.UnstableAPI(w).ConflictsOf(txHash)
+		if err != nil {
+			return nil, err
+		}
+		for i := range conflicts {
+			ret.WalletConflicts = append(ret.WalletConflicts,
+				conflicts[i].Hash.String())
+		}
+	}
+
 	var (
 		debitTotal  btcutil.Amount
 		creditTotal btcutil.Amount // Excludes change
@@ -900,6 +1492,17 @@ func getTransaction(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		})
 	}
 
+	switch {
+	case conflict != nil:
+		for i := range ret.Details {
+			ret.Details[i].Category = wallet.ConflictedCategory
+		}
+	case abandoned != nil:
+		for i := range ret.Details {
+			ret.Details[i].Category = wallet.AbandonedCategory
+		}
+	}
+
 	ret.Amount = creditTotal.ToBTC()
 	return ret, nil
 }
@@ -1244,6 +1847,65 @@ func listTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return w.ListTransactions(*cmd.From, *cmd.Count)
 }
 
+// listTransactionsPage handles a listtransactionspage request by returning
+// the listtransactions results of a page of wallet transactions, newest first,
+// along with a cursor to request the following page with.
+func listTransactionsPage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*walletjson.ListTransactionsPageCmd)
+
+	if *cmd.Count <= 0 {
+		return nil, InvalidParameterError{
+			errors.New("count must be positive"),
+		}
+	}
+	page := &wallet.TransactionPage{Limit: *cmd.Count}
+
+	if cmd.Cursor != nil && *cmd.Cursor != "" {
+		after, err := wallet.ParseTransactionCursor(*cmd.Cursor)
+		if err != nil {
+			return nil, InvalidParameterError{err}
+		}
+		page.After = after
+	}
+	if cmd.Account != nil && *cmd.Account != "*" {
+		page.Filter.Account = *cmd.Account
+	}
+	if cmd.Category != nil {
+		page.Filter.Category = *cmd.Category
+	}
+	if cmd.Label != nil {
+		page.Filter.Label = *cmd.Label
+	}
+	if cmd.MinAmount != nil {
+		amt, err := btcutil.NewAmount(*cmd.MinAmount)
+		if err != nil {
+			return nil, err
+		}
+		page.Filter.MinAmount = amt
+	}
+	if cmd.MaxAmount != nil {
+		amt, err := btcutil.NewAmount(*cmd.MaxAmount)
+		if err != nil {
+			return nil, err
+		}
+		page.Filter.MaxAmount = amt
+	}
+	if err := page.Filter.Validate(); err != nil {
+		return nil, InvalidParameterError{err}
+	}
+
+	txs, next, err := w.ListTransactionsPage(page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := walletjson.ListTransactionsPageResult{Transactions: txs}
+	if next != nil {
+		result.NextCursor = next.String()
+	}
+	return result, nil
+}
+
 // listAddressTransactions handles a listaddresstransactions request by
 // returning an array of maps with details of spent and received wallet
 // transactions.  The form of the reply is identical to listtransactions,
@@ -1291,16 +1953,91 @@ func listAllTransactions(icmd interface{}, w *wallet.Wallet) (interface{}, error
 
 // listUnspent handles the listunspent command.
 func listUnspent(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
-	cmd := icmd.(*btcjson.ListUnspentCmd)
+	cmd := icmd.(*walletjson.ListUnspentCmd)
+
+	var policy *wallet.TagPolicy
+	if cmd.IncludeTags != nil || cmd.ExcludeTags != nil {
+		policy = &wallet.TagPolicy{}
+		if cmd.IncludeTags != nil {
+			policy.IncludeTags = *cmd.IncludeTags
+		}
+		if cmd.ExcludeTags != nil {
+			policy.ExcludeTags = *cmd.ExcludeTags
+		}
+	}
 
+	var (
+		unspent []*btcjson.ListUnspentResult
+		err     error
+	)
 	if cmd.Addresses != nil && len(*cmd.Addresses) > 0 {
-		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCInvalidParameter,
-			Message: "Filtering by addresses has been deprecated",
+		addrs := make([]btcutil.Address, 0, len(*cmd.Addresses))
+		for _, addrStr := range *cmd.Addresses {
+			addr, err := decodeAddress(addrStr, w.ChainParams())
+			if err != nil {
+				return nil, err
+			}
+			addrs = append(addrs, addr)
 		}
+		unspent, err = w.ListAddressUnspent(
+			int32(*cmd.MinConf), int32(*cmd.MaxConf), addrs,
+		)
+	} else {
+		unspent, err = w.ListUnspent(
+			int32(*cmd.MinConf), int32(*cmd.MaxConf), "",
+		)
 	}
+	if err != nil {
+		return nil, err
+	}
+	return labelUnspent(w, unspent, policy)
+}
+
+// labelUnspent creates the listunspent results of unspent outputs, adding the
+// labels of their addresses and their own labels and tags.  Outputs not allowed
+// by the tag policy, if any, are left out.
+func labelUnspent(w *wallet.Wallet, unspent []*btcjson.ListUnspentResult,
+	policy *wallet.TagPolicy) ([]walletjson.ListUnspentResult, error) {
 
-	return w.ListUnspent(int32(*cmd.MinConf), int32(*cmd.MaxConf), "")
+	labels, err := w.AddressLabels()
+	if err != nil {
+		return nil, err
+	}
+	outputLabels, err := w.OutputLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]walletjson.ListUnspentResult, 0, len(unspent))
+	for _, u := range unspent {
+		txHash, err := chainhash.NewHashFromStr(u.TxID)
+		if err != nil {
+			return nil, err
+		}
+		l := outputLabels[wire.OutPoint{Hash: *txHash, Index: u.Vout}]
+		if !policy.Allows(l) {
+			continue
+		}
+
+		result := walletjson.ListUnspentResult{
+			TxID:          u.TxID,
+			Vout:          u.Vout,
+			Address:       u.Address,
+			Account:       u.Account,
+			Label:         labels[u.Address],
+			ScriptPubKey:  u.ScriptPubKey,
+			RedeemScript:  u.RedeemScript,
+			Amount:        u.Amount,
+			Confirmations: u.Confirmations,
+			Spendable:     u.Spendable,
+		}
+		if l != nil {
+			result.OutputLabel = l.Label
+			result.Tags = l.Tags
+		}
+		results = append(results, result)
+	}
+	return results, nil
 }
 
 // lockUnspent handles the lockunspent command.
@@ -1360,7 +2097,7 @@ func sendPairs(w *wallet.Wallet, amounts map[string]btcutil.Amount,
 	if err != nil {
 		return "", err
 	}
-	tx, err := w.SendOutputs(outputs, &keyScope, account, minconf, feeSatPerKb, "")
+	tx, err := w.SendOutputs(outputs, &keyScope, account, minconf, feeSatPerKb, nil, "")
 	if err != nil {
 		if err == txrules.ErrAmountNegative {
 			return "", ErrNeedPositiveAmount
@@ -1533,17 +2270,7 @@ func signMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 		return nil, err
 	}
 
-	privKey, err := w.PrivKeyForAddress(addr)
-	if err != nil {
-		return nil, err
-	}
-
-	var buf bytes.Buffer
-	_ = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
-	_ = wire.WriteVarString(&buf, 0, cmd.Message)
-	messageHash := chainhash.DoubleHashB(buf.Bytes())
-	sigbytes, err := btcec.SignCompact(btcec.S256(), privKey,
-		messageHash, true)
+	sigbytes, err := w.SignMessage(addr, cmd.Message)
 	if err != nil {
 		return nil, err
 	}
@@ -1807,6 +2534,139 @@ func validateAddress(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 	return result, nil
 }
 
+// addressTypeString returns the name of a waddrmgr address type as reported
+// by getaddressinfo.
+func addressTypeString(t waddrmgr.AddressType) string {
+	switch t {
+	case waddrmgr.PubKeyHash:
+		return "p2pkh"
+	case waddrmgr.Script:
+		return "script"
+	case waddrmgr.RawPubKey:
+		return "rawpubkey"
+	case waddrmgr.NestedWitnessPubKey:
+		return "p2sh-p2wpkh"
+	case waddrmgr.WitnessPubKey:
+		return "p2wpkh"
+	default:
+		return "unknown"
+	}
+}
+
+// masterKeyFingerprintString returns the hex encoding of a master key
+// fingerprint in the byte order it is serialized with in BIP0032 and PSBT
+// key paths.
+func masterKeyFingerprintString(fingerprint uint32) string {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], fingerprint)
+	return hex.EncodeToString(b[:])
+}
+
+// getAddressInfo handles the getaddressinfo command by returning the
+// derivation, type, script and usage details of a wallet address.
+func getAddressInfo(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+	cmd := icmd.(*btcjson.GetAddressInfoCmd)
+
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := walletjson.GetAddressInfoResult{
+		Address:      addr.EncodeAddress(),
+		ScriptPubKey: hex.EncodeToString(pkScript),
+	}
+	switch addr.(type) {
+	case *btcutil.AddressScriptHash, *btcutil.AddressWitnessScriptHash:
+		result.IsScript = true
+	}
+	switch addr.(type) {
+	case *btcutil.AddressWitnessPubKeyHash, *btcutil.AddressWitnessScriptHash:
+		result.IsWitness = true
+	}
+
+	details, err := w.AddressDetails(addr)
+	if err != nil {
+		if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+			// Addresses outside the wallet only carry the
+			// information encoded in the address itself.
+			return result, nil
+		}
+		return nil, err
+	}
+
+	ma := details.Address
+	result.IsMine = !details.WatchOnly
+	result.IsWatchOnly = details.WatchOnly
+	result.IsChange = ma.Internal()
+	result.IsImported = ma.Imported()
+	result.AddressType = addressTypeString(ma.AddrType())
+	result.Account = details.AccountName
+	result.KeyScope = details.KeyScope.String()
+	result.Used = details.Used
+	result.Label = details.Label
+
+	switch ma := ma.(type) {
+	case waddrmgr.ManagedPubKeyAddress:
+		compressed := ma.Compressed()
+		result.IsCompressed = &compressed
+		result.PubKey = ma.ExportPubKey()
+
+		scope, path, ok := ma.DerivationInfo()
+		if !ok {
+			break
+		}
+		// The account is the child index of the account key, which is
+		// only unhardened for some accounts imported from an xpub.
+		account := fmt.Sprintf("%d", path.Account)
+		if path.Account >= hdkeychain.HardenedKeyStart {
+			account = fmt.Sprintf("%d'",
+				path.Account-hdkeychain.HardenedKeyStart)
+		}
+		result.HDKeyPath = fmt.Sprintf("m/%d'/%d'/%s/%d/%d",
+			scope.Purpose, scope.Coin, account, path.Branch,
+			path.Index)
+		if path.MasterKeyFingerprint != 0 {
+			result.HDMasterFingerprint = masterKeyFingerprintString(
+				path.MasterKeyFingerprint,
+			)
+		}
+		result.Branch = &path.Branch
+		result.Index = &path.Index
+
+	case waddrmgr.ManagedScriptAddress:
+		// The script is only available if the manager is unlocked, so
+		// just break out now if there is an error.
+		script, err := ma.Script()
+		if err != nil {
+			break
+		}
+		result.Hex = hex.EncodeToString(script)
+
+		class, addrs, reqSigs, err := txscript.ExtractPkScriptAddrs(
+			script, w.ChainParams())
+		if err != nil {
+			result.Script = txscript.NonStandardTy.String()
+			break
+		}
+		addrStrings := make([]string, len(addrs))
+		for i, a := range addrs {
+			addrStrings[i] = a.EncodeAddress()
+		}
+		result.Addresses = addrStrings
+		result.Script = class.String()
+		if class == txscript.MultiSigTy {
+			result.SigsRequired = int32(reqSigs)
+		}
+	}
+
+	return result, nil
+}
+
 // verifyMessage handles the verifymessage command by verifying the provided
 // compact signature for the given address and message.
 func verifyMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
@@ -1825,10 +2685,7 @@ func verifyMessage(icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
 	// Validate the signature - this just shows that it was valid at all.
 	// we will compare it with the key next.
-	var buf bytes.Buffer
-	_ = wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
-	_ = wire.WriteVarString(&buf, 0, cmd.Message)
-	expectedMessageHash := chainhash.DoubleHashB(buf.Bytes())
+	expectedMessageHash := wallet.MessageHash(cmd.Message)
 	pk, wasCompressed, err := btcec.RecoverCompact(btcec.S256(), sig,
 		expectedMessageHash)
 	if err != nil {
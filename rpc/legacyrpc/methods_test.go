@@ -0,0 +1,231 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package legacyrpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/rpc/walletjson"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	_ "github.com/tinhnguyenhn/colxwallet/walletdb/bdb"
+)
+
+// waddrmgrNamespaceKey is the namespace key of the address manager in the
+// wallet database.
+var waddrmgrNamespaceKey = []byte("waddrmgr")
+
+// testWallet creates a test wallet on the test network.
+func testWallet(t *testing.T) (*wallet.Wallet, func()) {
+	dir, err := ioutil.TempDir("", "legacyrpc_test")
+	if err != nil {
+		t.Fatalf("unable to create db dir: %v", err)
+	}
+	cleanup := func() {
+		os.RemoveAll(dir)
+	}
+
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		cleanup()
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	loader := wallet.NewLoader(
+		&chaincfg.TestNet3Params, dir, true, 10*time.Second, 250,
+	)
+	w, err := loader.CreateNewWallet(
+		[]byte("hello"), []byte("world"), seed, time.Now(),
+	)
+	if err != nil {
+		cleanup()
+		t.Fatalf("unable to create wallet: %v", err)
+	}
+
+	return w, func() {
+		w.Database().Close()
+		cleanup()
+	}
+}
+
+// TestGetAddressInfoHDKeyPath ensures that getaddressinfo reports the BIP0032
+// derivation path of wallet addresses with their account index, which is only
+// unhardened for accounts imported from an xpub derived without hardening.
+func TestGetAddressInfoHDKeyPath(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	err := w.Unlock([]byte("world"), nil)
+	if err != nil {
+		t.Fatalf("unable to unlock wallet: %v", err)
+	}
+	scope := waddrmgr.KeyScopeBIP0084
+	account, err := w.NextAccount(scope, "second")
+	if err != nil {
+		t.Fatalf("unable to create account: %v", err)
+	}
+	scopedMgr, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		t.Fatalf("unable to fetch scoped manager: %v", err)
+	}
+
+	// The xpub of the imported account is derived as m/84'/0'/5.
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.MinSeedBytes)
+	if err != nil {
+		t.Fatalf("unable to create seed: %v", err)
+	}
+	acctKey, err := hdkeychain.NewMaster(seed, &chaincfg.TestNet3Params)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	for _, index := range []uint32{
+		hdkeychain.HardenedKeyStart + 84, hdkeychain.HardenedKeyStart, 5,
+	} {
+		acctKey, err = acctKey.Derive(index)
+		if err != nil {
+			t.Fatalf("unable to derive account key: %v", err)
+		}
+	}
+	acctPubKey, err := acctKey.Neuter()
+	if err != nil {
+		t.Fatalf("unable to neuter account key: %v", err)
+	}
+	var importedAccount uint32
+	err = walletdb.Update(w.Database(), func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		var err error
+		importedAccount, err = scopedMgr.NewAccountWatchingOnly(
+			ns, "xpub", acctPubKey, 0, nil,
+		)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to import account: %v", err)
+	}
+
+	tests := []struct {
+		account     uint32
+		hdKeyPath   string
+		accountName string
+	}{
+		{
+			account:     waddrmgr.DefaultAccountNum,
+			hdKeyPath:   "m/84'/0'/0'/0/0",
+			accountName: "default",
+		},
+		{
+			account:     account,
+			hdKeyPath:   "m/84'/0'/1'/0/0",
+			accountName: "second",
+		},
+		{
+			account:     importedAccount,
+			hdKeyPath:   "m/84'/0'/5/0/0",
+			accountName: "xpub",
+		},
+	}
+	for _, test := range tests {
+		// Addresses are derived through the address manager, as the
+		// wallet has no chain client to watch them.
+		var addrs []waddrmgr.ManagedAddress
+		db := w.Database()
+		err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			var err error
+			addrs, err = scopedMgr.NextExternalAddresses(
+				ns, test.account, 1,
+			)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("unable to create address: %v", err)
+		}
+
+		res, err := getAddressInfo(&btcjson.GetAddressInfoCmd{
+			Address: addrs[0].Address().EncodeAddress(),
+		}, w)
+		if err != nil {
+			t.Fatalf("getaddressinfo failed: %v", err)
+		}
+		info := res.(walletjson.GetAddressInfoResult)
+		if info.HDKeyPath != test.hdKeyPath {
+			t.Fatalf("expected hdkeypath %v, got %v",
+				test.hdKeyPath, info.HDKeyPath)
+		}
+		if info.Account != test.accountName {
+			t.Fatalf("expected account %v, got %v",
+				test.accountName, info.Account)
+		}
+	}
+}
+
+// TestUnmarshalListUnspentTags ensures the tag filters following the standard
+// listunspent parameters are unmarshalled.
+func TestUnmarshalListUnspentTags(t *testing.T) {
+	tests := []struct {
+		params  string
+		include *[]string
+		exclude *[]string
+		invalid bool
+	}{
+		{params: `[1, 10]`},
+		{
+			params:  `[1, 10, null, ["a", "b"]]`,
+			include: &[]string{"a", "b"},
+		},
+		{
+			params:  `[1, 10, [], null, ["c"]]`,
+			exclude: &[]string{"c"},
+		},
+		{params: `[1, 10, [], [], [], []]`, invalid: true},
+		{params: `[1, 10, [], "a"]`, invalid: true},
+	}
+
+	for i, test := range tests {
+		var params []json.RawMessage
+		if err := json.Unmarshal([]byte(test.params), &params); err != nil {
+			t.Fatalf("test %d: unable to unmarshal params: %v", i, err)
+		}
+		request := &btcjson.Request{
+			Jsonrpc: "1.0",
+			Method:  "listunspent",
+			Params:  params,
+		}
+
+		icmd, err := unmarshalCmd(request)
+		if test.invalid {
+			if err == nil {
+				t.Fatalf("test %d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d: unable to unmarshal cmd: %v", i, err)
+		}
+		cmd := icmd.(*walletjson.ListUnspentCmd)
+		if *cmd.MinConf != 1 || *cmd.MaxConf != 10 {
+			t.Fatalf("test %d: unexpected confirmations %d-%d", i,
+				*cmd.MinConf, *cmd.MaxConf)
+		}
+		if !reflect.DeepEqual(cmd.IncludeTags, test.include) {
+			t.Fatalf("test %d: unexpected include tags %v", i,
+				cmd.IncludeTags)
+		}
+		if !reflect.DeepEqual(cmd.ExcludeTags, test.exclude) {
+			t.Fatalf("test %d: unexpected exclude tags %v", i,
+				cmd.ExcludeTags)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// Package remotesigner implements a wallet.Signer that delegates signatures
+// to a remote signing host over the gRPC SignerService, along with a reference
+// server for that service.
+//
+// The service is described in the language-agnostic API document:
+//
+//	https://github.com/tinhnguyenhn/colxwallet/blob/master/rpc/documentation/api.md
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/tinhnguyenhn/colxd/btcec"
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	pb "github.com/tinhnguyenhn/colxwallet/rpc/walletrpc"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+)
+
+// DefaultTimeout is the time a Client waits for a signature before failing
+// the request.
+const DefaultTimeout = 30 * time.Second
+
+// Client is a wallet.Signer that requests every signature from a remote
+// SignerService.
+type Client struct {
+	client  pb.SignerServiceClient
+	timeout time.Duration
+}
+
+// A compile-time assertion to ensure that Client meets the wallet.Signer
+// interface.
+var _ wallet.Signer = (*Client)(nil)
+
+// ClientTLSConfig returns the TLS configuration of a wallet authenticating the
+// signing host with the certificates in the given CA file, and itself with the
+// certificate and key in the given files.
+func ClientTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	rootCAs, err := loadCertPool(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// NewClient returns a Client requesting signatures over the given connection.
+func NewClient(conn *grpc.ClientConn) *Client {
+	return &Client{
+		client:  pb.NewSignerServiceClient(conn),
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetTimeout sets the time the client waits for each signature.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// SignInput requests the signature of a transaction input from the remote
+// signer.
+//
+// This is part of the wallet.Signer interface.
+func (c *Client) SignInput(key *wallet.KeyDescriptor,
+	req *wallet.InputSignRequest) ([]byte, error) {
+
+	var buf bytes.Buffer
+	buf.Grow(req.Tx.SerializeSize())
+	if err := req.Tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignInput(ctx, &pb.SignInputRequest{
+		Key:          marshalKeyDescriptor(key),
+		Transaction:  buf.Bytes(),
+		InputIndex:   uint32(req.InputIndex),
+		OutputValue:  req.Output.Value,
+		OutputScript: req.Output.PkScript,
+		SubScript:    req.SubScript,
+		HashType:     uint32(req.HashType),
+		Witness:      req.Witness,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Signature) == 0 {
+		return nil, errors.New("remote signer returned an empty " +
+			"signature")
+	}
+	return resp.Signature, nil
+}
+
+// SignMessage requests the signature of a message from the remote signer.
+//
+// This is part of the wallet.Signer interface.
+func (c *Client) SignMessage(key *wallet.KeyDescriptor,
+	message string) ([]byte, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.SignMessage(ctx, &pb.SignMessageRequest{
+		Key:     marshalKeyDescriptor(key),
+		Message: message,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Signature) == 0 {
+		return nil, errors.New("remote signer returned an empty " +
+			"signature")
+	}
+	return resp.Signature, nil
+}
+
+// marshalKeyDescriptor returns the gRPC message describing key.
+func marshalKeyDescriptor(key *wallet.KeyDescriptor) *pb.KeyDescriptor {
+	msg := &pb.KeyDescriptor{
+		Compressed:           key.Compressed,
+		MasterKeyFingerprint: key.MasterKeyFingerprint,
+		DerivationPath:       key.Path,
+	}
+	if key.Address != nil {
+		msg.Address = key.Address.EncodeAddress()
+	}
+	if key.PubKey != nil {
+		msg.PublicKey = key.PubKey.SerializeCompressed()
+	}
+	return msg
+}
+
+// unmarshalKeyDescriptor returns the key descriptor described by a gRPC
+// message.
+func unmarshalKeyDescriptor(msg *pb.KeyDescriptor,
+	params *chaincfg.Params) (*wallet.KeyDescriptor, error) {
+
+	if msg == nil {
+		return nil, errors.New("missing key descriptor")
+	}
+
+	key := &wallet.KeyDescriptor{
+		Compressed:           msg.Compressed,
+		MasterKeyFingerprint: msg.MasterKeyFingerprint,
+		Path:                 msg.DerivationPath,
+	}
+	if msg.Address != "" {
+		addr, err := btcutil.DecodeAddress(msg.Address, params)
+		if err != nil {
+			return nil, err
+		}
+		key.Address = addr
+	}
+	if len(msg.PublicKey) != 0 {
+		pubKey, err := btcec.ParsePubKey(msg.PublicKey, btcec.S256())
+		if err != nil {
+			return nil, err
+		}
+		key.PubKey = pubKey
+	}
+	return key, nil
+}
+
+// unmarshalInputSignRequest returns the input sign request described by a gRPC
+// message.
+func unmarshalInputSignRequest(msg *pb.SignInputRequest) (
+	*wallet.InputSignRequest, error) {
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(msg.Transaction)); err != nil {
+		return nil, err
+	}
+	if int(msg.InputIndex) >= len(tx.TxIn) {
+		return nil, errors.New("input index out of range")
+	}
+
+	return &wallet.InputSignRequest{
+		Tx:         &tx,
+		InputIndex: int(msg.InputIndex),
+		Output:     wire.NewTxOut(msg.OutputValue, msg.OutputScript),
+		SubScript:  msg.SubScript,
+		HashType:   txscript.SigHashType(msg.HashType),
+		Witness:    msg.Witness,
+	}, nil
+}
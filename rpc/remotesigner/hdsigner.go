@@ -0,0 +1,118 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/tinhnguyenhn/colxd/btcec"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+)
+
+// HDSigner is a wallet.Signer holding a BIP0032 master private key. It signs
+// with the keys derived from the master key along the path of each key
+// descriptor, so it can sign for every account of a watch-only wallet created
+// from the same seed.
+type HDSigner struct {
+	masterKey   *hdkeychain.ExtendedKey
+	fingerprint uint32
+}
+
+// A compile-time assertion to ensure that HDSigner meets the wallet.Signer
+// interface.
+var _ wallet.Signer = (*HDSigner)(nil)
+
+// NewHDSigner returns an HDSigner for the given master private key.
+func NewHDSigner(masterKey *hdkeychain.ExtendedKey) (*HDSigner, error) {
+	if !masterKey.IsPrivate() {
+		return nil, errors.New("master key is not a private key")
+	}
+	if masterKey.Depth() != 0 {
+		return nil, errors.New("extended key is not a master key")
+	}
+
+	pubKey, err := masterKey.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	hash := btcutil.Hash160(pubKey.SerializeCompressed())
+
+	return &HDSigner{
+		masterKey:   masterKey,
+		fingerprint: binary.LittleEndian.Uint32(hash[:4]),
+	}, nil
+}
+
+// MasterKeyFingerprint returns the fingerprint of the signer's master key,
+// interpreted as a little-endian uint32 as done by the wallet.
+func (s *HDSigner) MasterKeyFingerprint() uint32 {
+	return s.fingerprint
+}
+
+// privKey derives the private key described by key. Keys without a
+// derivation path, keys of other master keys, and keys whose public key does
+// not match the derived key are refused.
+func (s *HDSigner) privKey(key *wallet.KeyDescriptor) (*btcec.PrivateKey,
+	error) {
+
+	if len(key.Path) == 0 {
+		return nil, errors.New("key has no derivation path")
+	}
+	if key.MasterKeyFingerprint != 0 &&
+		key.MasterKeyFingerprint != s.fingerprint {
+
+		return nil, fmt.Errorf("key is derived from master key %08x, "+
+			"not %08x", key.MasterKeyFingerprint, s.fingerprint)
+	}
+
+	extKey := s.masterKey
+	for _, index := range key.Path {
+		var err error
+		extKey, err = extKey.DeriveNonStandard(index) // nolint:staticcheck
+		if err != nil {
+			return nil, err
+		}
+	}
+	privKey, err := extKey.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if key.PubKey != nil && !privKey.PubKey().IsEqual(key.PubKey) {
+		return nil, errors.New("derived key does not match the " +
+			"public key")
+	}
+	return privKey, nil
+}
+
+// SignInput signs a transaction input with the derived key.
+//
+// This is part of the wallet.Signer interface.
+func (s *HDSigner) SignInput(key *wallet.KeyDescriptor,
+	req *wallet.InputSignRequest) ([]byte, error) {
+
+	privKey, err := s.privKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return req.Sign(privKey)
+}
+
+// SignMessage signs a message with the derived key.
+//
+// This is part of the wallet.Signer interface.
+func (s *HDSigner) SignMessage(key *wallet.KeyDescriptor,
+	message string) ([]byte, error) {
+
+	privKey, err := s.privKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SignMessageCompact(privKey, message, key.Compressed)
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/tinhnguyenhn/colxd/btcec"
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	_ "github.com/tinhnguyenhn/colxwallet/walletdb/bdb"
+)
+
+var (
+	testParams = &chaincfg.TestNet3Params
+
+	testSeed = bytes.Repeat([]byte{0x2a}, hdkeychain.RecommendedSeedLen)
+)
+
+// startSigner serves the SignerService with the given signer over an
+// in-memory connection and returns a client connected to it.
+func startSigner(t *testing.T, signer wallet.Signer) (*Client, func()) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	NewServer(signer, testParams).Register(server)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.Dial(
+		"bufconn", grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	)
+	require.NoError(t, err)
+
+	return NewClient(conn), func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+// testHDSigner returns an HDSigner for the master key of the test seed.
+func testHDSigner(t *testing.T) *HDSigner {
+	t.Helper()
+
+	masterKey, err := hdkeychain.NewMaster(testSeed, testParams)
+	require.NoError(t, err)
+	signer, err := NewHDSigner(masterKey)
+	require.NoError(t, err)
+	return signer
+}
+
+// testLockedWallet creates a wallet from the test seed and leaves it locked,
+// so that it can't sign with its own keys.
+func testLockedWallet(t *testing.T) (*wallet.Wallet, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+
+	loader := wallet.NewLoader(testParams, dir, true, 10*time.Second, 250)
+	w, err := loader.CreateNewWallet(
+		[]byte("public"), []byte("private"), testSeed, time.Now(),
+	)
+	require.NoError(t, err)
+
+	return w, func() {
+		_ = loader.UnloadWallet()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// nextAddress returns a new external address of the default account of the
+// given scope.
+func nextAddress(t *testing.T, w *wallet.Wallet,
+	scope waddrmgr.KeyScope) btcutil.Address {
+
+	t.Helper()
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	require.NoError(t, err)
+
+	var addr btcutil.Address
+	err = walletdb.Update(w.Database(), func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket([]byte("waddrmgr"))
+		addrs, err := manager.NextExternalAddresses(ns, 0, 1)
+		if err != nil {
+			return err
+		}
+		addr = addrs[0].Address()
+		return nil
+	})
+	require.NoError(t, err)
+	return addr
+}
+
+// TestRemoteSigning checks that a locked wallet delegating to a remote
+// signer holding its master key creates valid input and message signatures.
+func TestRemoteSigning(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testLockedWallet(t)
+	defer cleanup()
+
+	client, stop := startSigner(t, testHDSigner(t))
+	defer stop()
+	w.SetSigner(client)
+
+	scopes := []waddrmgr.KeyScope{
+		waddrmgr.KeyScopeBIP0084,
+		waddrmgr.KeyScopeBIP0049Plus,
+		waddrmgr.KeyScopeBIP0044,
+	}
+	for _, scope := range scopes {
+		addr := nextAddress(t, w, scope)
+		pkScript, err := txscript.PayToAddrScript(addr)
+		require.NoError(t, err)
+
+		const value = 100000
+		output := wire.NewTxOut(value, pkScript)
+		tx := &wire.MsgTx{
+			Version: 2,
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: 1},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(value/2, pkScript)},
+		}
+		sigHashes := txscript.NewTxSigHashes(tx)
+
+		witness, sigScript, err := w.ComputeInputScript(
+			tx, output, 0, sigHashes, txscript.SigHashAll, nil,
+		)
+		require.NoError(t, err, scope)
+		tx.TxIn[0].Witness = witness
+		tx.TxIn[0].SignatureScript = sigScript
+
+		vm, err := txscript.NewEngine(
+			pkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			sigHashes, value,
+		)
+		require.NoError(t, err, scope)
+		require.NoError(t, vm.Execute(), scope)
+	}
+
+	// A message signature must recover the public key of the address.
+	addr := nextAddress(t, w, waddrmgr.KeyScopeBIP0084)
+	sig, err := w.SignMessage(addr, "colxwallet")
+	require.NoError(t, err)
+
+	pubKey, compressed, err := btcec.RecoverCompact(
+		btcec.S256(), sig, wallet.MessageHash("colxwallet"),
+	)
+	require.NoError(t, err)
+	require.True(t, compressed)
+	require.Equal(
+		t, addr.ScriptAddress(),
+		btcutil.Hash160(pubKey.SerializeCompressed()),
+	)
+}
+
+// TestHDSignerRefusesUnknownKeys checks that the reference signer refuses
+// keys it can't identify as derived from its master key.
+func TestHDSignerRefusesUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	signer := testHDSigner(t)
+	client, stop := startSigner(t, signer)
+	defer stop()
+
+	path := []uint32{
+		84 + hdkeychain.HardenedKeyStart,
+		1 + hdkeychain.HardenedKeyStart,
+		hdkeychain.HardenedKeyStart, 0, 0,
+	}
+	otherKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		key  *wallet.KeyDescriptor
+	}{{
+		name: "no derivation path",
+		key: &wallet.KeyDescriptor{
+			MasterKeyFingerprint: signer.MasterKeyFingerprint(),
+		},
+	}, {
+		name: "other master key",
+		key: &wallet.KeyDescriptor{
+			MasterKeyFingerprint: signer.MasterKeyFingerprint() + 1,
+			Path:                 path,
+		},
+	}, {
+		name: "public key mismatch",
+		key: &wallet.KeyDescriptor{
+			PubKey:               otherKey.PubKey(),
+			MasterKeyFingerprint: signer.MasterKeyFingerprint(),
+			Path:                 path,
+		},
+	}}
+
+	for _, tc := range testCases {
+		_, err := client.SignMessage(tc.key, "colxwallet")
+		require.Error(t, err, tc.name)
+		require.Equal(
+			t, codes.FailedPrecondition, status.Code(err), tc.name,
+		)
+	}
+}
+
+// writeKeyPair writes a new self-signed TLS certificate and key to the given
+// directory, returning the paths of their files.
+func writeKeyPair(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	cert, key, err := btcutil.NewTLSCertPair(
+		name, time.Now().Add(time.Hour), nil,
+	)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".cert")
+	keyFile := filepath.Join(dir, name+".key")
+	require.NoError(t, ioutil.WriteFile(certFile, cert, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, key, 0600))
+	return certFile, keyFile
+}
+
+// TestServerRequiresClientCert checks that a server with the TLS
+// configuration of ServerTLSConfig only serves the clients authenticated
+// with a certificate of its client CAs.
+func TestServerRequiresClientCert(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	serverCert, serverKey := writeKeyPair(t, dir, "server")
+	clientCert, clientKey := writeKeyPair(t, dir, "client")
+	otherCert, otherKey := writeKeyPair(t, dir, "other")
+
+	serverConfig, err := ServerTLSConfig(serverCert, serverKey, clientCert)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(serverConfig)),
+	)
+	NewServer(testHDSigner(t), testParams).Register(server)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	var conns []*grpc.ClientConn
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	dial := func(config *tls.Config) *Client {
+		conn, err := grpc.Dial(
+			"localhost",
+			grpc.WithTransportCredentials(credentials.NewTLS(config)),
+			grpc.WithDialer(func(string, time.Duration) (net.Conn,
+				error) {
+
+				return listener.Dial()
+			}),
+		)
+		require.NoError(t, err)
+		conns = append(conns, conn)
+		return NewClient(conn)
+	}
+
+	// A key without a derivation path is refused by the signer, which
+	// shows that the request was served.
+	key := &wallet.KeyDescriptor{}
+
+	clientConfig, err := ClientTLSConfig(serverCert, clientCert, clientKey)
+	require.NoError(t, err)
+	_, err = dial(clientConfig).SignMessage(key, "colxwallet")
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Clients without a certificate, or with one of another CA, are
+	// refused before the request is served.
+	otherConfig, err := ClientTLSConfig(serverCert, otherCert, otherKey)
+	require.NoError(t, err)
+	noCertConfig := &tls.Config{RootCAs: clientConfig.RootCAs}
+	for _, config := range []*tls.Config{otherConfig, noCertConfig} {
+		_, err = dial(config).SignMessage(key, "colxwallet")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+}
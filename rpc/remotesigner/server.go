@@ -0,0 +1,130 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg"
+	pb "github.com/tinhnguyenhn/colxwallet/rpc/walletrpc"
+	"github.com/tinhnguyenhn/colxwallet/wallet"
+)
+
+// Server is the reference SignerService implementation. It serves signature
+// requests with any wallet.Signer, such as an HDSigner holding the master key
+// of a watch-only wallet.
+//
+// The server signs for any key of its signer without further checks, so it
+// must only be served to authenticated clients, such as with the mutual TLS
+// configuration returned by ServerTLSConfig.
+type Server struct {
+	signer wallet.Signer
+	params *chaincfg.Params
+}
+
+// A compile-time assertion to ensure that Server meets the
+// pb.SignerServiceServer interface.
+var _ pb.SignerServiceServer = (*Server)(nil)
+
+// NewServer returns a Server signing with the given signer for wallets of the
+// given network.
+func NewServer(signer wallet.Signer, params *chaincfg.Params) *Server {
+	return &Server{
+		signer: signer,
+		params: params,
+	}
+}
+
+// ServerTLSConfig returns the TLS configuration of a signing host serving the
+// certificate and key in the given files, which only accepts the clients
+// presenting a certificate signed by one of the CAs in the client CA file.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config,
+	error) {
+
+	keyPair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	clientCAs, err := loadCertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// loadCertPool returns a pool of the PEM encoded certificates in a file.
+func loadCertPool(file string) (*x509.CertPool, error) {
+	certs, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(certs) {
+		return nil, fmt.Errorf("no certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+// Register registers the SignerService with a gRPC server.
+func (s *Server) Register(server *grpc.Server) {
+	pb.RegisterSignerServiceServer(server, s)
+}
+
+// SignInput signs a transaction input.
+//
+// This is part of the pb.SignerServiceServer interface.
+func (s *Server) SignInput(ctx context.Context, req *pb.SignInputRequest) (
+	*pb.SignInputResponse, error) {
+
+	key, err := unmarshalKeyDescriptor(req.Key, s.params)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid key descriptor: %v", err)
+	}
+	signReq, err := unmarshalInputSignRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid transaction: %v", err)
+	}
+
+	sig, err := s.signer.SignInput(key, signReq)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &pb.SignInputResponse{Signature: sig}, nil
+}
+
+// SignMessage signs a message.
+//
+// This is part of the pb.SignerServiceServer interface.
+func (s *Server) SignMessage(ctx context.Context, req *pb.SignMessageRequest) (
+	*pb.SignMessageResponse, error) {
+
+	key, err := unmarshalKeyDescriptor(req.Key, s.params)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid key descriptor: %v", err)
+	}
+
+	sig, err := s.signer.SignMessage(key, req.Message)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	return &pb.SignMessageResponse{Signature: sig}, nil
+}
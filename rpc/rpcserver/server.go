@@ -18,6 +18,7 @@ package rpcserver
 import (
 	"bytes"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
@@ -44,10 +45,10 @@ import (
 
 // Public API version constants
 const (
-	semverString = "2.0.1"
+	semverString = "2.11.0"
 	semverMajor  = 2
-	semverMinor  = 0
-	semverPatch  = 1
+	semverMinor  = 11
+	semverPatch  = 0
 )
 
 // translateError creates a new gRPC error with an appropriate error code for
@@ -77,6 +78,10 @@ func errorCode(err error) codes.Code {
 			return codes.InvalidArgument
 		case waddrmgr.ErrDuplicateAccount:
 			return codes.AlreadyExists
+		case waddrmgr.ErrAddressNotFound, waddrmgr.ErrScopeNotFound:
+			return codes.NotFound
+		case waddrmgr.ErrInvalidLabel:
+			return codes.InvalidArgument
 		}
 
 		err = e.Err
@@ -93,6 +98,12 @@ func errorCode(err error) codes.Code {
 		return codes.NotFound
 	case hdkeychain.ErrInvalidSeedLen:
 		return codes.InvalidArgument
+	case wallet.ErrTransactionCursorOutOfRange:
+		return codes.InvalidArgument
+	case wallet.ErrInvalidRescanRange:
+		return codes.InvalidArgument
+	case wallet.ErrRescanAborted:
+		return codes.Canceled
 	default:
 		return codes.Unknown
 	}
@@ -109,11 +120,12 @@ type walletServer struct {
 }
 
 // loaderServer provides RPC clients with the ability to load and close wallets,
-// as well as establishing a RPC connection to a btcd consensus server.
+// as well as establishing a RPC connection to a btcd or bitcoind consensus
+// server.
 type loaderServer struct {
 	loader    *wallet.Loader
 	activeNet *netparams.Params
-	rpcClient *chain.RPCClient
+	rpcClient chain.Interface
 	mu        sync.Mutex
 }
 
@@ -163,7 +175,12 @@ func (s *walletServer) AccountNumber(ctx context.Context, req *pb.AccountNumberR
 func (s *walletServer) Accounts(ctx context.Context, req *pb.AccountsRequest) (
 	*pb.AccountsResponse, error) {
 
-	resp, err := s.wallet.Accounts(waddrmgr.KeyScopeBIP0044)
+	scope := waddrmgr.KeyScope{Purpose: req.Purpose, Coin: req.CoinType}
+	if req.Purpose == 0 && req.CoinType == 0 {
+		scope = waddrmgr.KeyScopeBIP0044
+	}
+
+	resp, err := s.wallet.Accounts(scope)
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -178,6 +195,18 @@ func (s *walletServer) Accounts(ctx context.Context, req *pb.AccountsRequest) (
 			InternalKeyCount: a.InternalKeyCount,
 			ImportedKeyCount: a.ImportedKeyCount,
 		}
+
+		// The imported account has no extended public key.
+		if a.AccountPubKey == nil {
+			continue
+		}
+		origin, err := s.wallet.AccountKeyOrigin(scope, a.AccountNumber)
+		if err != nil {
+			return nil, translateError(err)
+		}
+		accounts[i].AccountXpub = origin.PubKey.String()
+		accounts[i].MasterKeyFingerprint = origin.MasterKeyFingerprint
+		accounts[i].KeyOrigin = origin.String()
 	}
 	return &pb.AccountsResponse{
 		Accounts:           accounts,
@@ -197,6 +226,113 @@ func (s *walletServer) RenameAccount(ctx context.Context, req *pb.RenameAccountR
 	return &pb.RenameAccountResponse{}, nil
 }
 
+func (s *walletServer) SetAddressLabel(ctx context.Context, req *pb.SetAddressLabelRequest) (
+	*pb.SetAddressLabelResponse, error) {
+
+	addr, err := btcutil.DecodeAddress(req.Address, s.wallet.ChainParams())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid address %q: %v", req.Address, err)
+	}
+
+	err = s.wallet.SetAddressLabel(addr, req.Label)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.SetAddressLabelResponse{}, nil
+}
+
+func (s *walletServer) AddressLabels(ctx context.Context, req *pb.AddressLabelsRequest) (
+	*pb.AddressLabelsResponse, error) {
+
+	labels, err := s.wallet.AddressLabels()
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	addrs := make([]string, 0, len(labels))
+	for addr, label := range labels {
+		if req.Label != "" && label != req.Label {
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	resp := &pb.AddressLabelsResponse{
+		AddressLabels: make([]*pb.AddressLabelsResponse_AddressLabel, 0, len(addrs)),
+	}
+	for _, addr := range addrs {
+		resp.AddressLabels = append(resp.AddressLabels,
+			&pb.AddressLabelsResponse_AddressLabel{
+				Address: addr,
+				Label:   labels[addr],
+			})
+	}
+	return resp, nil
+}
+
+func (s *walletServer) AddressInfo(ctx context.Context, req *pb.AddressInfoRequest) (
+	*pb.AddressInfoResponse, error) {
+
+	addr, err := btcutil.DecodeAddress(req.Address, s.wallet.ChainParams())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid address %q: %v", req.Address, err)
+	}
+
+	details, err := s.wallet.AddressDetails(addr)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	ma := details.Address
+	resp := &pb.AddressInfoResponse{
+		Address: ma.Address().EncodeAddress(),
+		// The protobuf enum values match the waddrmgr address types.
+		AddressType:   pb.AddressInfoResponse_AddressType(ma.AddrType()),
+		Purpose:       details.KeyScope.Purpose,
+		CoinType:      details.KeyScope.Coin,
+		AccountNumber: ma.InternalAccount(),
+		AccountName:   details.AccountName,
+		Internal:      ma.Internal(),
+		Imported:      ma.Imported(),
+		WatchOnly:     details.WatchOnly,
+		Used:          details.Used,
+		Label:         details.Label,
+	}
+	switch ma := ma.(type) {
+	case waddrmgr.ManagedPubKeyAddress:
+		resp.PubKey = ma.PubKey().SerializeCompressed()
+		if !ma.Compressed() {
+			resp.PubKey = ma.PubKey().SerializeUncompressed()
+		}
+		resp.Compressed = ma.Compressed()
+
+		_, path, ok := ma.DerivationInfo()
+		if ok {
+			resp.HasDerivationInfo = true
+			resp.Account = path.Account
+			if path.Account >= hdkeychain.HardenedKeyStart {
+				resp.Account -= hdkeychain.HardenedKeyStart
+			}
+			resp.Branch = path.Branch
+			resp.Index = path.Index
+			resp.MasterKeyFingerprint = path.MasterKeyFingerprint
+		}
+
+	case waddrmgr.ManagedScriptAddress:
+		// The script is only available while the wallet is unlocked.
+		script, err := ma.Script()
+		if err == nil {
+			resp.Script = script
+		}
+	}
+
+	return resp, nil
+}
+
 func (s *walletServer) NextAccount(ctx context.Context, req *pb.NextAccountRequest) (
 	*pb.NextAccountResponse, error) {
 
@@ -280,6 +416,72 @@ func (s *walletServer) ImportPrivateKey(ctx context.Context, req *pb.ImportPriva
 	return &pb.ImportPrivateKeyResponse{}, nil
 }
 
+func (s *walletServer) ImportAccountPrivateKey(ctx context.Context,
+	req *pb.ImportAccountPrivateKeyRequest) (
+	*pb.ImportAccountPrivateKeyResponse, error) {
+
+	defer zero.Bytes(req.Passphrase)
+
+	accountKey, err := hdkeychain.NewKeyFromString(req.AccountPrivateKey)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid extended private key: %v", err)
+	}
+	if !accountKey.IsPrivate() {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Account key is not an extended private key")
+	}
+
+	var addrType *waddrmgr.AddressType
+	switch req.AddressType {
+	case pb.ImportAccountPrivateKeyRequest_UNSPECIFIED:
+	case pb.ImportAccountPrivateKeyRequest_NESTED_WITNESS_PUBKEY:
+		t := waddrmgr.NestedWitnessPubKey
+		addrType = &t
+	case pb.ImportAccountPrivateKeyRequest_WITNESS_PUBKEY:
+		t := waddrmgr.WitnessPubKey
+		addrType = &t
+	default:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Unknown address type %v", req.AddressType)
+	}
+
+	var bs *waddrmgr.BlockStamp
+	if req.Rescan {
+		if req.RescanStartHeight < 0 {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Rescan start height must not be negative")
+		}
+		bs = &waddrmgr.BlockStamp{Height: req.RescanStartHeight}
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{} // send matters, not the value
+	}()
+	err = s.wallet.Unlock(req.Passphrase, lock)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	props, err := s.wallet.ImportAccountPrivKey(
+		req.AccountName, accountKey, req.MasterKeyFingerprint, addrType,
+		bs, req.Rescan,
+	)
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &pb.ImportAccountPrivateKeyResponse{
+		AccountNumber:    props.AccountNumber,
+		Purpose:          props.KeyScope.Purpose,
+		CoinType:         props.KeyScope.Coin,
+		AccountXpub:      props.AccountPubKey.String(),
+		ExternalKeyCount: props.ExternalKeyCount,
+		InternalKeyCount: props.InternalKeyCount,
+	}, nil
+}
+
 func (s *walletServer) Balance(ctx context.Context, req *pb.BalanceRequest) (
 	*pb.BalanceResponse, error) {
 
@@ -300,6 +502,45 @@ func (s *walletServer) Balance(ctx context.Context, req *pb.BalanceRequest) (
 	return resp, nil
 }
 
+// balancesBreakdown returns the protobuf representation of a balance
+// breakdown.  The trusted balance includes the unconfirmed change.
+func balancesBreakdown(b *wallet.BalanceBreakdown) *pb.BalancesResponse_Breakdown {
+	return &pb.BalancesResponse_Breakdown{
+		Trusted:           int64(b.Trusted()),
+		UntrustedPending:  int64(b.UntrustedPending),
+		UnconfirmedChange: int64(b.UnconfirmedChange),
+		Immature:          int64(b.Immature),
+		Locked:            int64(b.Locked),
+	}
+}
+
+func (s *walletServer) Balances(ctx context.Context, req *pb.BalancesRequest) (
+	*pb.BalancesResponse, error) {
+
+	bals, err := s.wallet.CalculateBalanceBreakdowns()
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	resp := &pb.BalancesResponse{
+		Mine:      balancesBreakdown(&bals.Mine),
+		WatchOnly: balancesBreakdown(&bals.WatchOnly),
+		Accounts:  make([]*pb.BalancesResponse_AccountBalances, 0, len(bals.Accounts)),
+	}
+	for i := range bals.Accounts {
+		a := &bals.Accounts[i]
+		resp.Accounts = append(resp.Accounts, &pb.BalancesResponse_AccountBalances{
+			Purpose:       a.KeyScope.Purpose,
+			CoinType:      a.KeyScope.Coin,
+			AccountNumber: a.AccountNumber,
+			AccountName:   a.AccountName,
+			Mine:          balancesBreakdown(&a.Mine),
+			WatchOnly:     balancesBreakdown(&a.WatchOnly),
+		})
+	}
+	return resp, nil
+}
+
 func (s *walletServer) FundTransaction(ctx context.Context, req *pb.FundTransactionRequest) (
 	*pb.FundTransactionResponse, error) {
 
@@ -307,14 +548,11 @@ func (s *walletServer) FundTransaction(ctx context.Context, req *pb.FundTransact
 		Account:               req.Account,
 		RequiredConfirmations: req.RequiredConfirmations,
 	}
-	unspentOutputs, err := s.wallet.UnspentOutputs(policy)
-	if err != nil {
-		return nil, translateError(err)
-	}
-
-	selectedOutputs := make([]*pb.FundTransactionResponse_PreviousOutput, 0, len(unspentOutputs))
+	var selectedOutputs []*pb.FundTransactionResponse_PreviousOutput
 	var totalAmount btcutil.Amount
-	for _, output := range unspentOutputs {
+	err := s.wallet.ForEachUnspentOutput(policy, func(
+		output *wallet.TransactionOutput) (bool, error) {
+
 		selectedOutputs = append(selectedOutputs, &pb.FundTransactionResponse_PreviousOutput{
 			TransactionHash: output.OutPoint.Hash[:],
 			OutputIndex:     output.OutPoint.Index,
@@ -325,9 +563,12 @@ func (s *walletServer) FundTransaction(ctx context.Context, req *pb.FundTransact
 		})
 		totalAmount += btcutil.Amount(output.Output.Value)
 
-		if req.TargetAmount != 0 && totalAmount > btcutil.Amount(req.TargetAmount) {
-			break
-		}
+		// Stop reading outputs once the target amount is exceeded.
+		return req.TargetAmount != 0 &&
+			totalAmount > btcutil.Amount(req.TargetAmount), nil
+	})
+	if err != nil {
+		return nil, translateError(err)
 	}
 
 	var changeScript []byte
@@ -356,6 +597,9 @@ func marshalGetTransactionsResult(wresp *wallet.GetTransactionsResult) (
 		MinedTransactions:   marshalBlocks(wresp.MinedTransactions),
 		UnminedTransactions: marshalTransactionDetails(wresp.UnminedTransactions),
 	}
+	if wresp.NextCursor != nil {
+		resp.NextCursor = wresp.NextCursor.String()
+	}
 	return resp, nil
 }
 
@@ -408,7 +652,27 @@ func (s *walletServer) GetTransactions(ctx context.Context, req *pb.GetTransacti
 
 	_ = minRecentTxs
 
-	gtr, err := s.wallet.GetTransactions(startBlock, endBlock, "", ctx.Done())
+	page := &wallet.TransactionPage{
+		Limit: int(req.Limit),
+		Filter: wallet.TransactionFilter{
+			Account:   req.AccountName,
+			Category:  req.Category,
+			Label:     req.Label,
+			MinAmount: btcutil.Amount(req.MinAmount),
+			MaxAmount: btcutil.Amount(req.MaxAmount),
+		},
+	}
+	if req.Cursor != "" {
+		page.After, err = wallet.ParseTransactionCursor(req.Cursor)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+		}
+	}
+	if err := page.Filter.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err.Error())
+	}
+
+	gtr, err := s.wallet.GetTransactionsPage(startBlock, endBlock, page, ctx.Done())
 	if err != nil {
 		return nil, translateError(err)
 	}
@@ -510,6 +774,72 @@ func (s *walletServer) PublishTransaction(ctx context.Context, req *pb.PublishTr
 	return &pb.PublishTransactionResponse{}, nil
 }
 
+// Rescan rescans the requested blocks for all wallet addresses and outpoints,
+// streaming its progress.  The rescan is aborted if the client cancels the
+// stream.
+func (s *walletServer) Rescan(req *pb.RescanRequest,
+	svr pb.WalletService_RescanServer) error {
+
+	endHeight := req.EndHeight
+	if endHeight == 0 {
+		endHeight = -1
+	}
+
+	// Progress is buffered so it's not dropped while a response is sent.
+	type rescanResult struct {
+		stopStamp *waddrmgr.BlockStamp
+		err       error
+	}
+	progress := make(chan *wallet.RescanProgressMsg, 16)
+	result := make(chan rescanResult, 1)
+	go func() {
+		_, stopStamp, err := s.wallet.RescanBlockchain(
+			req.BeginHeight, endHeight, progress,
+			svr.Context().Done(),
+		)
+		result <- rescanResult{stopStamp, err}
+	}()
+
+	var rescannedThrough int32 = -1
+	send := func(height int32, hash *chainhash.Hash, t time.Time) error {
+		rescannedThrough = height
+		return svr.Send(&pb.RescanResponse{
+			RescannedThrough: height,
+			BlockHash:        hash[:],
+			BlockTime:        t.Unix(),
+		})
+	}
+
+	for {
+		select {
+		case msg := <-progress:
+			n := msg.Notification
+			err := send(n.Height, n.Hash, n.Time)
+			if err != nil {
+				return translateError(err)
+			}
+
+		case r := <-result:
+			if r.err != nil {
+				return translateError(r.err)
+			}
+
+			// The progress of the last block may not have been
+			// forwarded yet, so it is sent once the rescan
+			// finished.
+			stopStamp := r.stopStamp
+			if rescannedThrough < stopStamp.Height {
+				err := send(stopStamp.Height, &stopStamp.Hash,
+					stopStamp.Timestamp)
+				if err != nil {
+					return translateError(err)
+				}
+			}
+			return nil
+		}
+	}
+}
+
 func marshalTransactionInputs(v []wallet.TransactionSummaryInput) []*pb.TransactionDetails_Input {
 	inputs := make([]*pb.TransactionDetails_Input, len(v))
 	for i := range v {
@@ -547,6 +877,7 @@ func marshalTransactionDetails(v []wallet.TransactionSummary) []*pb.TransactionD
 			Credits:     marshalTransactionOutputs(tx.MyOutputs),
 			Fee:         int64(tx.Fee),
 			Timestamp:   tx.Timestamp,
+			Abandoned:   tx.Abandoned,
 		}
 	}
 	return txs
@@ -769,12 +1100,55 @@ func (s *loaderServer) StartConsensusRpc(ctx context.Context, // nolint:golint
 		return nil, status.Errorf(codes.FailedPrecondition, "RPC client already created")
 	}
 
-	networkAddress, err := cfgutil.NormalizeAddress(req.NetworkAddress,
-		s.activeNet.RPCClientPort)
+	backEnd := req.Backend
+	if backEnd == "" {
+		backEnd = "btcd"
+	}
+	backEndCfg := &chain.BackEndConfig{
+		ChainParams: s.activeNet.Params,
+		Host:        req.NetworkAddress,
+		User:        req.Username,
+		Pass:        string(req.Password),
+		Certs:       req.Certificate,
+		DisableTLS:  len(req.Certificate) == 0,
+		Options:     make(map[string]string),
+	}
+	var err error
+	switch backEnd {
+	case "btcd":
+		backEndCfg.Options["reconnectattempts"] = "1"
+		backEndCfg.Host, err = cfgutil.NormalizeAddress(
+			req.NetworkAddress, s.activeNet.RPCClientPort,
+		)
+
+	case "bitcoind":
+		// ZMQ addresses are not needed when bitcoind's RPC is polled
+		// for blocks instead, in which case the chain driver validates
+		// the polling options.
+		polling := req.BackendOptions["blockpollinginterval"] != ""
+		if !polling && (req.ZmqRawBlockAddress == "" ||
+			req.ZmqRawTxAddress == "") {
+
+			return nil, status.Errorf(codes.InvalidArgument,
+				"ZMQ addresses are required for bitcoind "+
+					"unless polling for blocks")
+		}
+		backEndCfg.Options["zmqpubrawblock"] = req.ZmqRawBlockAddress
+		backEndCfg.Options["zmqpubrawtx"] = req.ZmqRawTxAddress
+
+		// The bitcoind RPC server listens on the port of the wallet's
+		// own legacy RPC server by default.
+		backEndCfg.Host, err = cfgutil.NormalizeAddress(
+			req.NetworkAddress, s.activeNet.RPCServerPort,
+		)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument,
 			"Network address is ill-formed: %v", err)
 	}
+	for name, value := range req.BackendOptions {
+		backEndCfg.Options[name] = value
+	}
 
 	// Error if the wallet is already syncing with the network.
 	wallet, walletLoaded := s.loader.LoadedWallet()
@@ -783,14 +1157,23 @@ func (s *loaderServer) StartConsensusRpc(ctx context.Context, // nolint:golint
 			"wallet is loaded and already synchronizing")
 	}
 
-	rpcClient, err := chain.NewRPCClient(s.activeNet.Params, networkAddress, req.Username,
-		string(req.Password), req.Certificate, len(req.Certificate) == 0, 1)
-	if err != nil {
-		return nil, translateError(err)
+	rpcClient, err := chain.NewBackEnd(backEnd, backEndCfg)
+	switch {
+	case err == chain.ErrUnknownBackEnd:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Unknown consensus RPC backend %q", req.Backend)
+	case err != nil && backEnd == "bitcoind":
+		// The bitcoind driver connects when creating its client.
+		return nil, status.Errorf(codes.NotFound,
+			"Connection to bitcoind failed: %v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Invalid consensus RPC backend configuration: %v", err)
 	}
 
 	err = rpcClient.Start()
 	if err != nil {
+		rpcClient.Stop()
 		if err == rpcclient.ErrInvalidAuth {
 			return nil, status.Errorf(codes.InvalidArgument,
 				"Invalid RPC credentials: %v", err)
@@ -807,3 +1190,4 @@ func (s *loaderServer) StartConsensusRpc(ctx context.Context, // nolint:golint
 
 	return &pb.StartConsensusRpcResponse{}, nil
 }
+
@@ -0,0 +1,14 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+/*
+Package walletjson provides the JSON-RPC command and result types for the
+colxwallet extensions to the legacy RPC server that are not defined by the
+btcjson package.
+
+Importing this package registers its commands with btcjson, so requests for
+them can be created with btcjson.MarshalCmd and parsed with
+btcjson.UnmarshalCmd just like the reference commands.
+*/
+package walletjson
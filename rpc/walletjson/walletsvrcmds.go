@@ -0,0 +1,251 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+// NOTE: This file is intended to house the RPC commands that are supported by
+// a wallet server with colxwallet extensions.
+
+package walletjson
+
+import "github.com/tinhnguyenhn/colxd/btcjson"
+
+// SetLabelCmd defines the setlabel JSON-RPC command.
+type SetLabelCmd struct {
+	Address string
+	Label   string
+}
+
+// NewSetLabelCmd returns a new instance which can be used to issue a setlabel
+// JSON-RPC command.
+func NewSetLabelCmd(address, label string) *SetLabelCmd {
+	return &SetLabelCmd{
+		Address: address,
+		Label:   label,
+	}
+}
+
+// GetAddressesByLabelCmd defines the getaddressesbylabel JSON-RPC command.
+type GetAddressesByLabelCmd struct {
+	Label string
+}
+
+// NewGetAddressesByLabelCmd returns a new instance which can be used to issue
+// a getaddressesbylabel JSON-RPC command.
+func NewGetAddressesByLabelCmd(label string) *GetAddressesByLabelCmd {
+	return &GetAddressesByLabelCmd{
+		Label: label,
+	}
+}
+
+// ListLabelsCmd defines the listlabels JSON-RPC command.
+type ListLabelsCmd struct{}
+
+// NewListLabelsCmd returns a new instance which can be used to issue a
+// listlabels JSON-RPC command.
+func NewListLabelsCmd() *ListLabelsCmd {
+	return &ListLabelsCmd{}
+}
+
+// GetBackendHealthCmd defines the getbackendhealth JSON-RPC command.
+type GetBackendHealthCmd struct{}
+
+// NewGetBackendHealthCmd returns a new instance which can be used to issue a
+// getbackendhealth JSON-RPC command.
+func NewGetBackendHealthCmd() *GetBackendHealthCmd {
+	return &GetBackendHealthCmd{}
+}
+
+// GetRescanStatusCmd defines the getrescanstatus JSON-RPC command.
+type GetRescanStatusCmd struct{}
+
+// NewGetRescanStatusCmd returns a new instance which can be used to issue a
+// getrescanstatus JSON-RPC command.
+func NewGetRescanStatusCmd() *GetRescanStatusCmd {
+	return &GetRescanStatusCmd{}
+}
+
+// RescanBlockchainCmd defines the rescanblockchain JSON-RPC command.
+type RescanBlockchainCmd struct {
+	StartHeight *int32 `jsonrpcdefault:"0"`
+	StopHeight  *int32
+}
+
+// NewRescanBlockchainCmd returns a new instance which can be used to issue a
+// rescanblockchain JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewRescanBlockchainCmd(startHeight, stopHeight *int32) *RescanBlockchainCmd {
+	return &RescanBlockchainCmd{
+		StartHeight: startHeight,
+		StopHeight:  stopHeight,
+	}
+}
+
+// AbortRescanCmd defines the abortrescan JSON-RPC command.
+type AbortRescanCmd struct{}
+
+// NewAbortRescanCmd returns a new instance which can be used to issue an
+// abortrescan JSON-RPC command.
+func NewAbortRescanCmd() *AbortRescanCmd {
+	return &AbortRescanCmd{}
+}
+
+// GetAccountXpubCmd defines the getaccountxpub JSON-RPC command.
+type GetAccountXpubCmd struct {
+	Account string
+	Scope   *string `jsonrpcdefault:"\"bip44\""`
+}
+
+// NewGetAccountXpubCmd returns a new instance which can be used to issue a
+// getaccountxpub JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewGetAccountXpubCmd(account string, scope *string) *GetAccountXpubCmd {
+	return &GetAccountXpubCmd{
+		Account: account,
+		Scope:   scope,
+	}
+}
+
+// ImportAccountXprvCmd defines the importaccountxprv JSON-RPC command.
+type ImportAccountXprvCmd struct {
+	Account           string
+	XPrv              string
+	AddressType       *string
+	MasterFingerprint *string
+	Rescan            *bool  `jsonrpcdefault:"true"`
+	StartHeight       *int32 `jsonrpcdefault:"0"`
+}
+
+// NewImportAccountXprvCmd returns a new instance which can be used to issue an
+// importaccountxprv JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewImportAccountXprvCmd(account, xprv string, addressType,
+	masterFingerprint *string, rescan *bool,
+	startHeight *int32) *ImportAccountXprvCmd {
+
+	return &ImportAccountXprvCmd{
+		Account:           account,
+		XPrv:              xprv,
+		AddressType:       addressType,
+		MasterFingerprint: masterFingerprint,
+		Rescan:            rescan,
+		StartHeight:       startHeight,
+	}
+}
+
+// ListTransactionsPageCmd defines the listtransactionspage JSON-RPC command.
+type ListTransactionsPageCmd struct {
+	Cursor    *string
+	Count     *int `jsonrpcdefault:"10"`
+	Account   *string
+	Category  *string
+	Label     *string
+	MinAmount *float64
+	MaxAmount *float64
+}
+
+// NewListTransactionsPageCmd returns a new instance which can be used to issue
+// a listtransactionspage JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewListTransactionsPageCmd(cursor *string, count *int, account,
+	category, label *string, minAmount,
+	maxAmount *float64) *ListTransactionsPageCmd {
+
+	return &ListTransactionsPageCmd{
+		Cursor:    cursor,
+		Count:     count,
+		Account:   account,
+		Category:  category,
+		Label:     label,
+		MinAmount: minAmount,
+		MaxAmount: maxAmount,
+	}
+}
+
+// AbandonTransactionCmd defines the abandontransaction JSON-RPC command.
+type AbandonTransactionCmd struct {
+	TxID string
+}
+
+// NewAbandonTransactionCmd returns a new instance which can be used to issue an
+// abandontransaction JSON-RPC command.
+func NewAbandonTransactionCmd(txID string) *AbandonTransactionCmd {
+	return &AbandonTransactionCmd{
+		TxID: txID,
+	}
+}
+
+// SetOutputLabelCmd defines the setoutputlabel JSON-RPC command.
+type SetOutputLabelCmd struct {
+	TxID  string
+	Vout  uint32
+	Label string
+	Tags  *[]string
+}
+
+// NewSetOutputLabelCmd returns a new instance which can be used to issue a
+// setoutputlabel JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewSetOutputLabelCmd(txID string, vout uint32, label string,
+	tags *[]string) *SetOutputLabelCmd {
+
+	return &SetOutputLabelCmd{
+		TxID:  txID,
+		Vout:  vout,
+		Label: label,
+		Tags:  tags,
+	}
+}
+
+// ListUnspentCmd defines the listunspent JSON-RPC command, whose standard
+// parameters are followed by optional tag filters.  As btcjson registers the
+// standard command, the wallet server unmarshals the tag filters itself.
+type ListUnspentCmd struct {
+	btcjson.ListUnspentCmd
+	IncludeTags *[]string
+	ExcludeTags *[]string
+}
+
+// NewListUnspentCmd returns a new instance which can be used to issue a
+// listunspent JSON-RPC command with tag filters.
+//
+// The parameters which are pointers indicate they are optional.  Passing nil
+// for optional parameters will use the default value.
+func NewListUnspentCmd(minConf, maxConf *int, addresses, includeTags,
+	excludeTags *[]string) *ListUnspentCmd {
+
+	return &ListUnspentCmd{
+		ListUnspentCmd: *btcjson.NewListUnspentCmd(
+			minConf, maxConf, addresses,
+		),
+		IncludeTags: includeTags,
+		ExcludeTags: excludeTags,
+	}
+}
+
+func init() {
+	// The commands in this file are only usable with a wallet server.
+	flags := btcjson.UFWalletOnly
+
+	btcjson.MustRegisterCmd("setlabel", (*SetLabelCmd)(nil), flags)
+	btcjson.MustRegisterCmd("getaddressesbylabel", (*GetAddressesByLabelCmd)(nil), flags)
+	btcjson.MustRegisterCmd("listlabels", (*ListLabelsCmd)(nil), flags)
+	btcjson.MustRegisterCmd("getaccountxpub", (*GetAccountXpubCmd)(nil), flags)
+	btcjson.MustRegisterCmd("importaccountxprv", (*ImportAccountXprvCmd)(nil), flags)
+	btcjson.MustRegisterCmd("listtransactionspage", (*ListTransactionsPageCmd)(nil), flags)
+	btcjson.MustRegisterCmd("abandontransaction", (*AbandonTransactionCmd)(nil), flags)
+	btcjson.MustRegisterCmd("setoutputlabel", (*SetOutputLabelCmd)(nil), flags)
+	btcjson.MustRegisterCmd("getbackendhealth", (*GetBackendHealthCmd)(nil), flags)
+	btcjson.MustRegisterCmd("getrescanstatus", (*GetRescanStatusCmd)(nil), flags)
+	btcjson.MustRegisterCmd("rescanblockchain", (*RescanBlockchainCmd)(nil), flags)
+	btcjson.MustRegisterCmd("abortrescan", (*AbortRescanCmd)(nil), flags)
+}
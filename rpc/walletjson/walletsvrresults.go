@@ -0,0 +1,171 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package walletjson
+
+import "github.com/tinhnguyenhn/colxd/btcjson"
+
+// GetAddressesByLabelResult models the per-address data from the
+// getaddressesbylabel command.
+type GetAddressesByLabelResult struct {
+	Purpose string `json:"purpose"`
+}
+
+// ListUnspentResult models a successful response from the listunspent request.
+// It extends btcjson.ListUnspentResult with the label of the output's address,
+// and the label and tags of the output itself.
+type ListUnspentResult struct {
+	TxID          string   `json:"txid"`
+	Vout          uint32   `json:"vout"`
+	Address       string   `json:"address"`
+	Account       string   `json:"account"`
+	Label         string   `json:"label,omitempty"`
+	OutputLabel   string   `json:"outputlabel,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	ScriptPubKey  string   `json:"scriptPubKey"`
+	RedeemScript  string   `json:"redeemScript,omitempty"`
+	Amount        float64  `json:"amount"`
+	Confirmations int64    `json:"confirmations"`
+	Spendable     bool     `json:"spendable"`
+}
+
+// GetAddressInfoResult models the data from the getaddressinfo command.
+type GetAddressInfoResult struct {
+	Address             string   `json:"address"`
+	ScriptPubKey        string   `json:"scriptPubKey"`
+	IsMine              bool     `json:"ismine"`
+	IsWatchOnly         bool     `json:"iswatchonly"`
+	IsScript            bool     `json:"isscript"`
+	IsWitness           bool     `json:"iswitness"`
+	IsChange            bool     `json:"ischange"`
+	IsImported          bool     `json:"isimported"`
+	AddressType         string   `json:"addresstype,omitempty"`
+	Account             string   `json:"account,omitempty"`
+	KeyScope            string   `json:"keyscope,omitempty"`
+	HDKeyPath           string   `json:"hdkeypath,omitempty"`
+	HDMasterFingerprint string   `json:"hdmasterfingerprint,omitempty"`
+	Branch              *uint32  `json:"branch,omitempty"`
+	Index               *uint32  `json:"index,omitempty"`
+	PubKey              string   `json:"pubkey,omitempty"`
+	IsCompressed        *bool    `json:"iscompressed,omitempty"`
+	Script              string   `json:"script,omitempty"`
+	Hex                 string   `json:"hex,omitempty"`
+	Addresses           []string `json:"addresses,omitempty"`
+	SigsRequired        int32    `json:"sigsrequired,omitempty"`
+	Used                bool     `json:"used"`
+	Label               string   `json:"label"`
+}
+
+// GetAccountXpubResult models the data from the getaccountxpub command.
+type GetAccountXpubResult struct {
+	Account           string `json:"account"`
+	KeyScope          string `json:"keyscope"`
+	XPub              string `json:"xpub"`
+	MasterFingerprint string `json:"masterfingerprint"`
+	Path              string `json:"path"`
+	KeyOrigin         string `json:"keyorigin"`
+}
+
+// ImportAccountXprvResult models the data from the importaccountxprv command.
+type ImportAccountXprvResult struct {
+	Account           string `json:"account"`
+	KeyScope          string `json:"keyscope"`
+	XPub              string `json:"xpub"`
+	MasterFingerprint string `json:"masterfingerprint"`
+	ExternalKeyCount  uint32 `json:"externalkeycount"`
+	InternalKeyCount  uint32 `json:"internalkeycount"`
+}
+
+// ListTransactionsPageResult models the data from the listtransactionspage
+// command.
+type ListTransactionsPageResult struct {
+	Transactions []btcjson.ListTransactionsResult `json:"transactions"`
+	NextCursor   string                           `json:"nextcursor,omitempty"`
+}
+
+// RebroadcastResult models the rebroadcast state of an unmined transaction.
+type RebroadcastResult struct {
+	Attempts    uint32 `json:"attempts"`
+	LastAttempt int64  `json:"lastattempt"`
+	NextAttempt int64  `json:"nextattempt"`
+	Dropped     bool   `json:"dropped"`
+}
+
+// GetTransactionResult models the data from the gettransaction command.  It
+// extends btcjson.GetTransactionResult with the rebroadcast state of unmined
+// transactions.
+type GetTransactionResult struct {
+	Amount          float64                               `json:"amount"`
+	Fee             float64                               `json:"fee,omitempty"`
+	Confirmations   int64                                 `json:"confirmations"`
+	BlockHash       string                                `json:"blockhash"`
+	BlockIndex      int64                                 `json:"blockindex"`
+	BlockTime       int64                                 `json:"blocktime"`
+	TxID            string                                `json:"txid"`
+	WalletConflicts []string                              `json:"walletconflicts"`
+	Time            int64                                 `json:"time"`
+	TimeReceived    int64                                 `json:"timereceived"`
+	Details         []btcjson.GetTransactionDetailsResult `json:"details"`
+	Hex             string                                `json:"hex"`
+	Rebroadcast     *RebroadcastResult                    `json:"rebroadcast,omitempty"`
+}
+
+// BalanceDetailsResult models a balance breakdown of the getbalances command.
+// It extends btcjson.BalanceDetailsResult with the unconfirmed change counted
+// in the trusted balance and the balance of locked outputs.
+type BalanceDetailsResult struct {
+	Trusted           float64 `json:"trusted"`
+	UntrustedPending  float64 `json:"untrusted_pending"`
+	Immature          float64 `json:"immature"`
+	UnconfirmedChange float64 `json:"unconfirmed_change"`
+	Locked            float64 `json:"locked"`
+}
+
+// AccountBalancesResult models the balances of a single account from the
+// getbalances command.
+type AccountBalancesResult struct {
+	KeyScope  string                `json:"keyscope"`
+	Account   uint32                `json:"account"`
+	Name      string                `json:"name"`
+	Mine      BalanceDetailsResult  `json:"mine"`
+	WatchOnly *BalanceDetailsResult `json:"watchonly,omitempty"`
+}
+
+// GetBalancesResult models the data from the getbalances command.  It extends
+// btcjson.GetBalancesResult with the balances of each account.
+type GetBalancesResult struct {
+	Mine      BalanceDetailsResult    `json:"mine"`
+	WatchOnly *BalanceDetailsResult   `json:"watchonly,omitempty"`
+	Accounts  []AccountBalancesResult `json:"accounts"`
+}
+
+// BackendHealthResult models the health of a chain backend from the
+// getbackendhealth command.
+type BackendHealthResult struct {
+	Name        string `json:"name"`
+	Backend     string `json:"backend"`
+	Healthy     bool   `json:"healthy"`
+	Active      bool   `json:"active"`
+	BestHash    string `json:"besthash,omitempty"`
+	BestHeight  int32  `json:"bestheight"`
+	LastChecked int64  `json:"lastchecked,omitempty"`
+	LastError   string `json:"lasterror,omitempty"`
+}
+
+// RescanBlockchainResult models the data from the rescanblockchain command.
+type RescanBlockchainResult struct {
+	StartHeight int32 `json:"start_height"`
+	StopHeight  int32 `json:"stop_height"`
+}
+
+// GetRescanStatusResult models the data from the getrescanstatus command.
+type GetRescanStatusResult struct {
+	Active        bool    `json:"active"`
+	StartHeight   int32   `json:"startheight,omitempty"`
+	CurrentHeight int32   `json:"currentheight,omitempty"`
+	TargetHeight  int32   `json:"targetheight,omitempty"`
+	StartTime     int64   `json:"starttime,omitempty"`
+	Percent       float64 `json:"percent"`
+	ETA           int64   `json:"eta,omitempty"`
+}
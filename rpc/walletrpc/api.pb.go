@@ -23,14 +23,24 @@ It has these top-level messages:
 	AccountsResponse
 	RenameAccountRequest
 	RenameAccountResponse
+	SetAddressLabelRequest
+	SetAddressLabelResponse
+	AddressLabelsRequest
+	AddressLabelsResponse
 	NextAccountRequest
 	NextAccountResponse
 	NextAddressRequest
 	NextAddressResponse
 	ImportPrivateKeyRequest
 	ImportPrivateKeyResponse
+	ImportAccountPrivateKeyRequest
+	ImportAccountPrivateKeyResponse
+	AddressInfoRequest
+	AddressInfoResponse
 	BalanceRequest
 	BalanceResponse
+	BalancesRequest
+	BalancesResponse
 	GetTransactionsRequest
 	GetTransactionsResponse
 	ChangePassphraseRequest
@@ -41,6 +51,8 @@ It has these top-level messages:
 	SignTransactionResponse
 	PublishTransactionRequest
 	PublishTransactionResponse
+	RescanRequest
+	RescanResponse
 	TransactionNotificationsRequest
 	TransactionNotificationsResponse
 	SpentnessNotificationsRequest
@@ -57,6 +69,11 @@ It has these top-level messages:
 	WalletExistsResponse
 	StartConsensusRpcRequest
 	StartConsensusRpcResponse
+	KeyDescriptor
+	SignInputRequest
+	SignInputResponse
+	SignMessageRequest
+	SignMessageResponse
 */
 package walletrpc
 
@@ -102,7 +119,65 @@ var NextAddressRequest_Kind_value = map[string]int32{
 func (x NextAddressRequest_Kind) String() string {
 	return proto.EnumName(NextAddressRequest_Kind_name, int32(x))
 }
-func (NextAddressRequest_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 0} }
+func (NextAddressRequest_Kind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{21, 0} }
+
+type ImportAccountPrivateKeyRequest_AddressType int32
+
+const (
+	ImportAccountPrivateKeyRequest_UNSPECIFIED           ImportAccountPrivateKeyRequest_AddressType = 0
+	ImportAccountPrivateKeyRequest_NESTED_WITNESS_PUBKEY ImportAccountPrivateKeyRequest_AddressType = 1
+	ImportAccountPrivateKeyRequest_WITNESS_PUBKEY        ImportAccountPrivateKeyRequest_AddressType = 2
+)
+
+var ImportAccountPrivateKeyRequest_AddressType_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "NESTED_WITNESS_PUBKEY",
+	2: "WITNESS_PUBKEY",
+}
+var ImportAccountPrivateKeyRequest_AddressType_value = map[string]int32{
+	"UNSPECIFIED":           0,
+	"NESTED_WITNESS_PUBKEY": 1,
+	"WITNESS_PUBKEY":        2,
+}
+
+func (x ImportAccountPrivateKeyRequest_AddressType) String() string {
+	return proto.EnumName(ImportAccountPrivateKeyRequest_AddressType_name, int32(x))
+}
+func (ImportAccountPrivateKeyRequest_AddressType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{25, 0}
+}
+
+type AddressInfoResponse_AddressType int32
+
+const (
+	AddressInfoResponse_PUBKEY_HASH           AddressInfoResponse_AddressType = 0
+	AddressInfoResponse_SCRIPT                AddressInfoResponse_AddressType = 1
+	AddressInfoResponse_RAW_PUBKEY            AddressInfoResponse_AddressType = 2
+	AddressInfoResponse_NESTED_WITNESS_PUBKEY AddressInfoResponse_AddressType = 3
+	AddressInfoResponse_WITNESS_PUBKEY        AddressInfoResponse_AddressType = 4
+)
+
+var AddressInfoResponse_AddressType_name = map[int32]string{
+	0: "PUBKEY_HASH",
+	1: "SCRIPT",
+	2: "RAW_PUBKEY",
+	3: "NESTED_WITNESS_PUBKEY",
+	4: "WITNESS_PUBKEY",
+}
+var AddressInfoResponse_AddressType_value = map[string]int32{
+	"PUBKEY_HASH":           0,
+	"SCRIPT":                1,
+	"RAW_PUBKEY":            2,
+	"NESTED_WITNESS_PUBKEY": 3,
+	"WITNESS_PUBKEY":        4,
+}
+
+func (x AddressInfoResponse_AddressType) String() string {
+	return proto.EnumName(AddressInfoResponse_AddressType_name, int32(x))
+}
+func (AddressInfoResponse_AddressType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{28, 0}
+}
 
 type ChangePassphraseRequest_Key int32
 
@@ -124,7 +199,7 @@ func (x ChangePassphraseRequest_Key) String() string {
 	return proto.EnumName(ChangePassphraseRequest_Key_name, int32(x))
 }
 func (ChangePassphraseRequest_Key) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{25, 0}
+	return fileDescriptor0, []int{35, 0}
 }
 
 type VersionRequest struct {
@@ -198,6 +273,7 @@ type TransactionDetails struct {
 	Credits     []*TransactionDetails_Output `protobuf:"bytes,4,rep,name=credits" json:"credits,omitempty"`
 	Fee         int64                        `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
 	Timestamp   int64                        `protobuf:"varint,6,opt,name=timestamp" json:"timestamp,omitempty"`
+	Abandoned   bool                         `protobuf:"varint,7,opt,name=abandoned" json:"abandoned,omitempty"`
 }
 
 func (m *TransactionDetails) Reset()                    { *m = TransactionDetails{} }
@@ -247,6 +323,13 @@ func (m *TransactionDetails) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *TransactionDetails) GetAbandoned() bool {
+	if m != nil {
+		return m.Abandoned
+	}
+	return false
+}
+
 type TransactionDetails_Input struct {
 	Index           uint32 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
 	PreviousAccount uint32 `protobuf:"varint,2,opt,name=previous_account,json=previousAccount" json:"previous_account,omitempty"`
@@ -448,6 +531,10 @@ func (m *AccountNumberResponse) GetAccountNumber() uint32 {
 }
 
 type AccountsRequest struct {
+	// Optionally select the key scope of the accounts.  When both fields
+	// are zero, the BIP0044 accounts are returned.
+	Purpose  uint32 `protobuf:"varint,1,opt,name=purpose" json:"purpose,omitempty"`
+	CoinType uint32 `protobuf:"varint,2,opt,name=coin_type,json=coinType" json:"coin_type,omitempty"`
 }
 
 func (m *AccountsRequest) Reset()                    { *m = AccountsRequest{} }
@@ -455,6 +542,20 @@ func (m *AccountsRequest) String() string            { return proto.CompactTextS
 func (*AccountsRequest) ProtoMessage()               {}
 func (*AccountsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
+func (m *AccountsRequest) GetPurpose() uint32 {
+	if m != nil {
+		return m.Purpose
+	}
+	return 0
+}
+
+func (m *AccountsRequest) GetCoinType() uint32 {
+	if m != nil {
+		return m.CoinType
+	}
+	return 0
+}
+
 type AccountsResponse struct {
 	Accounts           []*AccountsResponse_Account `protobuf:"bytes,1,rep,name=accounts" json:"accounts,omitempty"`
 	CurrentBlockHash   []byte                      `protobuf:"bytes,2,opt,name=current_block_hash,json=currentBlockHash,proto3" json:"current_block_hash,omitempty"`
@@ -488,12 +589,15 @@ func (m *AccountsResponse) GetCurrentBlockHeight() int32 {
 }
 
 type AccountsResponse_Account struct {
-	AccountNumber    uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	AccountName      string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
-	TotalBalance     int64  `protobuf:"varint,3,opt,name=total_balance,json=totalBalance" json:"total_balance,omitempty"`
-	ExternalKeyCount uint32 `protobuf:"varint,4,opt,name=external_key_count,json=externalKeyCount" json:"external_key_count,omitempty"`
-	InternalKeyCount uint32 `protobuf:"varint,5,opt,name=internal_key_count,json=internalKeyCount" json:"internal_key_count,omitempty"`
-	ImportedKeyCount uint32 `protobuf:"varint,6,opt,name=imported_key_count,json=importedKeyCount" json:"imported_key_count,omitempty"`
+	AccountNumber        uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	AccountName          string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	TotalBalance         int64  `protobuf:"varint,3,opt,name=total_balance,json=totalBalance" json:"total_balance,omitempty"`
+	ExternalKeyCount     uint32 `protobuf:"varint,4,opt,name=external_key_count,json=externalKeyCount" json:"external_key_count,omitempty"`
+	InternalKeyCount     uint32 `protobuf:"varint,5,opt,name=internal_key_count,json=internalKeyCount" json:"internal_key_count,omitempty"`
+	ImportedKeyCount     uint32 `protobuf:"varint,6,opt,name=imported_key_count,json=importedKeyCount" json:"imported_key_count,omitempty"`
+	AccountXpub          string `protobuf:"bytes,7,opt,name=account_xpub,json=accountXpub" json:"account_xpub,omitempty"`
+	MasterKeyFingerprint uint32 `protobuf:"varint,8,opt,name=master_key_fingerprint,json=masterKeyFingerprint" json:"master_key_fingerprint,omitempty"`
+	KeyOrigin            string `protobuf:"bytes,9,opt,name=key_origin,json=keyOrigin" json:"key_origin,omitempty"`
 }
 
 func (m *AccountsResponse_Account) Reset()                    { *m = AccountsResponse_Account{} }
@@ -543,6 +647,27 @@ func (m *AccountsResponse_Account) GetImportedKeyCount() uint32 {
 	return 0
 }
 
+func (m *AccountsResponse_Account) GetAccountXpub() string {
+	if m != nil {
+		return m.AccountXpub
+	}
+	return ""
+}
+
+func (m *AccountsResponse_Account) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *AccountsResponse_Account) GetKeyOrigin() string {
+	if m != nil {
+		return m.KeyOrigin
+	}
+	return ""
+}
+
 type RenameAccountRequest struct {
 	AccountNumber uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
 	NewName       string `protobuf:"bytes,2,opt,name=new_name,json=newName" json:"new_name,omitempty"`
@@ -575,6 +700,97 @@ func (m *RenameAccountResponse) String() string            { return proto.Compac
 func (*RenameAccountResponse) ProtoMessage()               {}
 func (*RenameAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
+type SetAddressLabelRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Label   string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *SetAddressLabelRequest) Reset()                    { *m = SetAddressLabelRequest{} }
+func (m *SetAddressLabelRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetAddressLabelRequest) ProtoMessage()               {}
+func (*SetAddressLabelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SetAddressLabelRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SetAddressLabelRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type SetAddressLabelResponse struct {
+}
+
+func (m *SetAddressLabelResponse) Reset()                    { *m = SetAddressLabelResponse{} }
+func (m *SetAddressLabelResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetAddressLabelResponse) ProtoMessage()               {}
+func (*SetAddressLabelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+type AddressLabelsRequest struct {
+	// Optionally only return addresses carrying this label.
+	Label string `protobuf:"bytes,1,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *AddressLabelsRequest) Reset()                    { *m = AddressLabelsRequest{} }
+func (m *AddressLabelsRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddressLabelsRequest) ProtoMessage()               {}
+func (*AddressLabelsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *AddressLabelsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type AddressLabelsResponse struct {
+	AddressLabels []*AddressLabelsResponse_AddressLabel `protobuf:"bytes,1,rep,name=address_labels,json=addressLabels" json:"address_labels,omitempty"`
+}
+
+func (m *AddressLabelsResponse) Reset()                    { *m = AddressLabelsResponse{} }
+func (m *AddressLabelsResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddressLabelsResponse) ProtoMessage()               {}
+func (*AddressLabelsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *AddressLabelsResponse) GetAddressLabels() []*AddressLabelsResponse_AddressLabel {
+	if m != nil {
+		return m.AddressLabels
+	}
+	return nil
+}
+
+type AddressLabelsResponse_AddressLabel struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	Label   string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *AddressLabelsResponse_AddressLabel) Reset()         { *m = AddressLabelsResponse_AddressLabel{} }
+func (m *AddressLabelsResponse_AddressLabel) String() string { return proto.CompactTextString(m) }
+func (*AddressLabelsResponse_AddressLabel) ProtoMessage()    {}
+func (*AddressLabelsResponse_AddressLabel) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{18, 0}
+}
+
+func (m *AddressLabelsResponse_AddressLabel) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddressLabelsResponse_AddressLabel) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type NextAccountRequest struct {
 	Passphrase  []byte `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
 	AccountName string `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
@@ -583,7 +799,7 @@ type NextAccountRequest struct {
 func (m *NextAccountRequest) Reset()                    { *m = NextAccountRequest{} }
 func (m *NextAccountRequest) String() string            { return proto.CompactTextString(m) }
 func (*NextAccountRequest) ProtoMessage()               {}
-func (*NextAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*NextAccountRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *NextAccountRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -606,7 +822,7 @@ type NextAccountResponse struct {
 func (m *NextAccountResponse) Reset()                    { *m = NextAccountResponse{} }
 func (m *NextAccountResponse) String() string            { return proto.CompactTextString(m) }
 func (*NextAccountResponse) ProtoMessage()               {}
-func (*NextAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*NextAccountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *NextAccountResponse) GetAccountNumber() uint32 {
 	if m != nil {
@@ -623,7 +839,7 @@ type NextAddressRequest struct {
 func (m *NextAddressRequest) Reset()                    { *m = NextAddressRequest{} }
 func (m *NextAddressRequest) String() string            { return proto.CompactTextString(m) }
 func (*NextAddressRequest) ProtoMessage()               {}
-func (*NextAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*NextAddressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *NextAddressRequest) GetAccount() uint32 {
 	if m != nil {
@@ -646,7 +862,7 @@ type NextAddressResponse struct {
 func (m *NextAddressResponse) Reset()                    { *m = NextAddressResponse{} }
 func (m *NextAddressResponse) String() string            { return proto.CompactTextString(m) }
 func (*NextAddressResponse) ProtoMessage()               {}
-func (*NextAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*NextAddressResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *NextAddressResponse) GetAddress() string {
 	if m != nil {
@@ -665,7 +881,7 @@ type ImportPrivateKeyRequest struct {
 func (m *ImportPrivateKeyRequest) Reset()                    { *m = ImportPrivateKeyRequest{} }
 func (m *ImportPrivateKeyRequest) String() string            { return proto.CompactTextString(m) }
 func (*ImportPrivateKeyRequest) ProtoMessage()               {}
-func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*ImportPrivateKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *ImportPrivateKeyRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -701,228 +917,747 @@ type ImportPrivateKeyResponse struct {
 func (m *ImportPrivateKeyResponse) Reset()                    { *m = ImportPrivateKeyResponse{} }
 func (m *ImportPrivateKeyResponse) String() string            { return proto.CompactTextString(m) }
 func (*ImportPrivateKeyResponse) ProtoMessage()               {}
-func (*ImportPrivateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*ImportPrivateKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
-type BalanceRequest struct {
-	AccountNumber         uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
-	RequiredConfirmations int32  `protobuf:"varint,2,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
+type ImportAccountPrivateKeyRequest struct {
+	Passphrase           []byte                                     `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	AccountName          string                                     `protobuf:"bytes,2,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	AccountPrivateKey    string                                     `protobuf:"bytes,3,opt,name=account_private_key,json=accountPrivateKey" json:"account_private_key,omitempty"`
+	AddressType          ImportAccountPrivateKeyRequest_AddressType `protobuf:"varint,4,opt,name=address_type,json=addressType,enum=walletrpc.ImportAccountPrivateKeyRequest_AddressType" json:"address_type,omitempty"`
+	MasterKeyFingerprint uint32                                     `protobuf:"varint,5,opt,name=master_key_fingerprint,json=masterKeyFingerprint" json:"master_key_fingerprint,omitempty"`
+	Rescan               bool                                       `protobuf:"varint,6,opt,name=rescan" json:"rescan,omitempty"`
+	RescanStartHeight    int32                                      `protobuf:"varint,7,opt,name=rescan_start_height,json=rescanStartHeight" json:"rescan_start_height,omitempty"`
 }
 
-func (m *BalanceRequest) Reset()                    { *m = BalanceRequest{} }
-func (m *BalanceRequest) String() string            { return proto.CompactTextString(m) }
-func (*BalanceRequest) ProtoMessage()               {}
-func (*BalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (m *ImportAccountPrivateKeyRequest) Reset()         { *m = ImportAccountPrivateKeyRequest{} }
+func (m *ImportAccountPrivateKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountPrivateKeyRequest) ProtoMessage()    {}
+func (*ImportAccountPrivateKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{25}
+}
 
-func (m *BalanceRequest) GetAccountNumber() uint32 {
+func (m *ImportAccountPrivateKeyRequest) GetPassphrase() []byte {
 	if m != nil {
-		return m.AccountNumber
+		return m.Passphrase
 	}
-	return 0
+	return nil
 }
 
-func (m *BalanceRequest) GetRequiredConfirmations() int32 {
+func (m *ImportAccountPrivateKeyRequest) GetAccountName() string {
 	if m != nil {
-		return m.RequiredConfirmations
+		return m.AccountName
 	}
-	return 0
+	return ""
 }
 
-type BalanceResponse struct {
-	Total          int64 `protobuf:"varint,1,opt,name=total" json:"total,omitempty"`
-	Spendable      int64 `protobuf:"varint,2,opt,name=spendable" json:"spendable,omitempty"`
-	ImmatureReward int64 `protobuf:"varint,3,opt,name=immature_reward,json=immatureReward" json:"immature_reward,omitempty"`
+func (m *ImportAccountPrivateKeyRequest) GetAccountPrivateKey() string {
+	if m != nil {
+		return m.AccountPrivateKey
+	}
+	return ""
 }
 
-func (m *BalanceResponse) Reset()                    { *m = BalanceResponse{} }
-func (m *BalanceResponse) String() string            { return proto.CompactTextString(m) }
-func (*BalanceResponse) ProtoMessage()               {}
-func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (m *ImportAccountPrivateKeyRequest) GetAddressType() ImportAccountPrivateKeyRequest_AddressType {
+	if m != nil {
+		return m.AddressType
+	}
+	return ImportAccountPrivateKeyRequest_UNSPECIFIED
+}
 
-func (m *BalanceResponse) GetTotal() int64 {
+func (m *ImportAccountPrivateKeyRequest) GetMasterKeyFingerprint() uint32 {
 	if m != nil {
-		return m.Total
+		return m.MasterKeyFingerprint
 	}
 	return 0
 }
 
-func (m *BalanceResponse) GetSpendable() int64 {
+func (m *ImportAccountPrivateKeyRequest) GetRescan() bool {
 	if m != nil {
-		return m.Spendable
+		return m.Rescan
 	}
-	return 0
+	return false
 }
 
-func (m *BalanceResponse) GetImmatureReward() int64 {
+func (m *ImportAccountPrivateKeyRequest) GetRescanStartHeight() int32 {
 	if m != nil {
-		return m.ImmatureReward
+		return m.RescanStartHeight
 	}
 	return 0
 }
 
-type GetTransactionsRequest struct {
-	// Optionally specify the starting block from which to begin including all transactions.
-	// Either the starting block hash or height may be specified, but not both.
-	// If a block height is specified and is negative, the absolute value becomes the number of
-	// last blocks to include.  That is, given a current chain height of 1000 and a starting block
-	// height of -3, transaction notifications will be created for blocks 998, 999, and 1000.
-	// If both options are excluded, transaction results are created for transactions since the
-	// genesis block.
-	StartingBlockHash   []byte `protobuf:"bytes,1,opt,name=starting_block_hash,json=startingBlockHash,proto3" json:"starting_block_hash,omitempty"`
-	StartingBlockHeight int32  `protobuf:"zigzag32,2,opt,name=starting_block_height,json=startingBlockHeight" json:"starting_block_height,omitempty"`
-	// Optionally specify the last block that transaction results may appear in.
-	// Either the ending block hash or height may be specified, but not both.
-	// If both are excluded, transaction results are created for all transactions
-	// through the best block, and include all unmined transactions.
-	EndingBlockHash   []byte `protobuf:"bytes,3,opt,name=ending_block_hash,json=endingBlockHash,proto3" json:"ending_block_hash,omitempty"`
-	EndingBlockHeight int32  `protobuf:"varint,4,opt,name=ending_block_height,json=endingBlockHeight" json:"ending_block_height,omitempty"`
-	// Include at least this many of the newest transactions if they exist.
-	// Cannot be used when the ending block hash is specified.
-	//
-	// TODO: remove until spec adds it back in some way.
-	MinimumRecentTransactions int32 `protobuf:"varint,5,opt,name=minimum_recent_transactions,json=minimumRecentTransactions" json:"minimum_recent_transactions,omitempty"`
+type ImportAccountPrivateKeyResponse struct {
+	AccountNumber    uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	Purpose          uint32 `protobuf:"varint,2,opt,name=purpose" json:"purpose,omitempty"`
+	CoinType         uint32 `protobuf:"varint,3,opt,name=coin_type,json=coinType" json:"coin_type,omitempty"`
+	AccountXpub      string `protobuf:"bytes,4,opt,name=account_xpub,json=accountXpub" json:"account_xpub,omitempty"`
+	ExternalKeyCount uint32 `protobuf:"varint,5,opt,name=external_key_count,json=externalKeyCount" json:"external_key_count,omitempty"`
+	InternalKeyCount uint32 `protobuf:"varint,6,opt,name=internal_key_count,json=internalKeyCount" json:"internal_key_count,omitempty"`
 }
 
-func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
-func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionsRequest) ProtoMessage()               {}
-func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
-
-func (m *GetTransactionsRequest) GetStartingBlockHash() []byte {
-	if m != nil {
-		return m.StartingBlockHash
-	}
-	return nil
+func (m *ImportAccountPrivateKeyResponse) Reset()         { *m = ImportAccountPrivateKeyResponse{} }
+func (m *ImportAccountPrivateKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountPrivateKeyResponse) ProtoMessage()    {}
+func (*ImportAccountPrivateKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26}
 }
 
-func (m *GetTransactionsRequest) GetStartingBlockHeight() int32 {
+func (m *ImportAccountPrivateKeyResponse) GetAccountNumber() uint32 {
 	if m != nil {
-		return m.StartingBlockHeight
+		return m.AccountNumber
 	}
 	return 0
 }
 
-func (m *GetTransactionsRequest) GetEndingBlockHash() []byte {
+func (m *ImportAccountPrivateKeyResponse) GetPurpose() uint32 {
 	if m != nil {
-		return m.EndingBlockHash
+		return m.Purpose
 	}
-	return nil
+	return 0
 }
 
-func (m *GetTransactionsRequest) GetEndingBlockHeight() int32 {
+func (m *ImportAccountPrivateKeyResponse) GetCoinType() uint32 {
 	if m != nil {
-		return m.EndingBlockHeight
+		return m.CoinType
 	}
 	return 0
 }
 
-func (m *GetTransactionsRequest) GetMinimumRecentTransactions() int32 {
+func (m *ImportAccountPrivateKeyResponse) GetAccountXpub() string {
 	if m != nil {
-		return m.MinimumRecentTransactions
+		return m.AccountXpub
 	}
-	return 0
-}
-
-type GetTransactionsResponse struct {
-	MinedTransactions   []*BlockDetails       `protobuf:"bytes,1,rep,name=mined_transactions,json=minedTransactions" json:"mined_transactions,omitempty"`
-	UnminedTransactions []*TransactionDetails `protobuf:"bytes,2,rep,name=unmined_transactions,json=unminedTransactions" json:"unmined_transactions,omitempty"`
+	return ""
 }
 
-func (m *GetTransactionsResponse) Reset()                    { *m = GetTransactionsResponse{} }
-func (m *GetTransactionsResponse) String() string            { return proto.CompactTextString(m) }
-func (*GetTransactionsResponse) ProtoMessage()               {}
-func (*GetTransactionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
-
-func (m *GetTransactionsResponse) GetMinedTransactions() []*BlockDetails {
+func (m *ImportAccountPrivateKeyResponse) GetExternalKeyCount() uint32 {
 	if m != nil {
-		return m.MinedTransactions
+		return m.ExternalKeyCount
 	}
-	return nil
+	return 0
 }
 
-func (m *GetTransactionsResponse) GetUnminedTransactions() []*TransactionDetails {
+func (m *ImportAccountPrivateKeyResponse) GetInternalKeyCount() uint32 {
 	if m != nil {
-		return m.UnminedTransactions
+		return m.InternalKeyCount
 	}
-	return nil
+	return 0
 }
 
-type ChangePassphraseRequest struct {
-	Key           ChangePassphraseRequest_Key `protobuf:"varint,1,opt,name=key,enum=walletrpc.ChangePassphraseRequest_Key" json:"key,omitempty"`
-	OldPassphrase []byte                      `protobuf:"bytes,2,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
-	NewPassphrase []byte                      `protobuf:"bytes,3,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+type AddressInfoRequest struct {
+	Address string `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
 }
 
-func (m *ChangePassphraseRequest) Reset()                    { *m = ChangePassphraseRequest{} }
-func (m *ChangePassphraseRequest) String() string            { return proto.CompactTextString(m) }
-func (*ChangePassphraseRequest) ProtoMessage()               {}
-func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (m *AddressInfoRequest) Reset()                    { *m = AddressInfoRequest{} }
+func (m *AddressInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddressInfoRequest) ProtoMessage()               {}
+func (*AddressInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
-func (m *ChangePassphraseRequest) GetKey() ChangePassphraseRequest_Key {
+func (m *AddressInfoRequest) GetAddress() string {
 	if m != nil {
-		return m.Key
+		return m.Address
 	}
-	return ChangePassphraseRequest_PRIVATE
+	return ""
 }
 
-func (m *ChangePassphraseRequest) GetOldPassphrase() []byte {
+type AddressInfoResponse struct {
+	Address              string                          `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	AddressType          AddressInfoResponse_AddressType `protobuf:"varint,2,opt,name=address_type,json=addressType,enum=walletrpc.AddressInfoResponse_AddressType" json:"address_type,omitempty"`
+	Purpose              uint32                          `protobuf:"varint,3,opt,name=purpose" json:"purpose,omitempty"`
+	CoinType             uint32                          `protobuf:"varint,4,opt,name=coin_type,json=coinType" json:"coin_type,omitempty"`
+	AccountNumber        uint32                          `protobuf:"varint,5,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	AccountName          string                          `protobuf:"bytes,6,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	Internal             bool                            `protobuf:"varint,7,opt,name=internal" json:"internal,omitempty"`
+	Imported             bool                            `protobuf:"varint,8,opt,name=imported" json:"imported,omitempty"`
+	WatchOnly            bool                            `protobuf:"varint,9,opt,name=watch_only,json=watchOnly" json:"watch_only,omitempty"`
+	HasDerivationInfo    bool                            `protobuf:"varint,10,opt,name=has_derivation_info,json=hasDerivationInfo" json:"has_derivation_info,omitempty"`
+	Account              uint32                          `protobuf:"varint,11,opt,name=account" json:"account,omitempty"`
+	Branch               uint32                          `protobuf:"varint,12,opt,name=branch" json:"branch,omitempty"`
+	Index                uint32                          `protobuf:"varint,13,opt,name=index" json:"index,omitempty"`
+	MasterKeyFingerprint uint32                          `protobuf:"varint,14,opt,name=master_key_fingerprint,json=masterKeyFingerprint" json:"master_key_fingerprint,omitempty"`
+	PubKey               []byte                          `protobuf:"bytes,15,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Compressed           bool                            `protobuf:"varint,16,opt,name=compressed" json:"compressed,omitempty"`
+	Script               []byte                          `protobuf:"bytes,17,opt,name=script,proto3" json:"script,omitempty"`
+	Used                 bool                            `protobuf:"varint,18,opt,name=used" json:"used,omitempty"`
+	Label                string                          `protobuf:"bytes,19,opt,name=label" json:"label,omitempty"`
+}
+
+func (m *AddressInfoResponse) Reset()                    { *m = AddressInfoResponse{} }
+func (m *AddressInfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddressInfoResponse) ProtoMessage()               {}
+func (*AddressInfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *AddressInfoResponse) GetAddress() string {
 	if m != nil {
-		return m.OldPassphrase
+		return m.Address
 	}
-	return nil
+	return ""
 }
 
-func (m *ChangePassphraseRequest) GetNewPassphrase() []byte {
+func (m *AddressInfoResponse) GetAddressType() AddressInfoResponse_AddressType {
 	if m != nil {
-		return m.NewPassphrase
+		return m.AddressType
 	}
-	return nil
-}
-
-type ChangePassphraseResponse struct {
-}
-
-func (m *ChangePassphraseResponse) Reset()                    { *m = ChangePassphraseResponse{} }
-func (m *ChangePassphraseResponse) String() string            { return proto.CompactTextString(m) }
-func (*ChangePassphraseResponse) ProtoMessage()               {}
-func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
-
-type FundTransactionRequest struct {
-	Account                  uint32 `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
-	TargetAmount             int64  `protobuf:"varint,2,opt,name=target_amount,json=targetAmount" json:"target_amount,omitempty"`
-	RequiredConfirmations    int32  `protobuf:"varint,3,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
-	IncludeImmatureCoinbases bool   `protobuf:"varint,4,opt,name=include_immature_coinbases,json=includeImmatureCoinbases" json:"include_immature_coinbases,omitempty"`
-	IncludeChangeScript      bool   `protobuf:"varint,5,opt,name=include_change_script,json=includeChangeScript" json:"include_change_script,omitempty"`
+	return AddressInfoResponse_PUBKEY_HASH
 }
 
-func (m *FundTransactionRequest) Reset()                    { *m = FundTransactionRequest{} }
-func (m *FundTransactionRequest) String() string            { return proto.CompactTextString(m) }
-func (*FundTransactionRequest) ProtoMessage()               {}
-func (*FundTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
-
-func (m *FundTransactionRequest) GetAccount() uint32 {
+func (m *AddressInfoResponse) GetPurpose() uint32 {
 	if m != nil {
-		return m.Account
+		return m.Purpose
 	}
 	return 0
 }
 
-func (m *FundTransactionRequest) GetTargetAmount() int64 {
+func (m *AddressInfoResponse) GetCoinType() uint32 {
 	if m != nil {
-		return m.TargetAmount
+		return m.CoinType
 	}
 	return 0
 }
 
-func (m *FundTransactionRequest) GetRequiredConfirmations() int32 {
+func (m *AddressInfoResponse) GetAccountNumber() uint32 {
 	if m != nil {
-		return m.RequiredConfirmations
+		return m.AccountNumber
 	}
 	return 0
 }
 
-func (m *FundTransactionRequest) GetIncludeImmatureCoinbases() bool {
+func (m *AddressInfoResponse) GetAccountName() string {
 	if m != nil {
-		return m.IncludeImmatureCoinbases
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *AddressInfoResponse) GetInternal() bool {
+	if m != nil {
+		return m.Internal
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetImported() bool {
+	if m != nil {
+		return m.Imported
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetWatchOnly() bool {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetHasDerivationInfo() bool {
+	if m != nil {
+		return m.HasDerivationInfo
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *AddressInfoResponse) GetBranch() uint32 {
+	if m != nil {
+		return m.Branch
+	}
+	return 0
+}
+
+func (m *AddressInfoResponse) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *AddressInfoResponse) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *AddressInfoResponse) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *AddressInfoResponse) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetScript() []byte {
+	if m != nil {
+		return m.Script
+	}
+	return nil
+}
+
+func (m *AddressInfoResponse) GetUsed() bool {
+	if m != nil {
+		return m.Used
+	}
+	return false
+}
+
+func (m *AddressInfoResponse) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+type BalanceRequest struct {
+	AccountNumber         uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	RequiredConfirmations int32  `protobuf:"varint,2,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
+}
+
+func (m *BalanceRequest) Reset()                    { *m = BalanceRequest{} }
+func (m *BalanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*BalanceRequest) ProtoMessage()               {}
+func (*BalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *BalanceRequest) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *BalanceRequest) GetRequiredConfirmations() int32 {
+	if m != nil {
+		return m.RequiredConfirmations
+	}
+	return 0
+}
+
+type BalanceResponse struct {
+	Total          int64 `protobuf:"varint,1,opt,name=total" json:"total,omitempty"`
+	Spendable      int64 `protobuf:"varint,2,opt,name=spendable" json:"spendable,omitempty"`
+	ImmatureReward int64 `protobuf:"varint,3,opt,name=immature_reward,json=immatureReward" json:"immature_reward,omitempty"`
+}
+
+func (m *BalanceResponse) Reset()                    { *m = BalanceResponse{} }
+func (m *BalanceResponse) String() string            { return proto.CompactTextString(m) }
+func (*BalanceResponse) ProtoMessage()               {}
+func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *BalanceResponse) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *BalanceResponse) GetSpendable() int64 {
+	if m != nil {
+		return m.Spendable
+	}
+	return 0
+}
+
+func (m *BalanceResponse) GetImmatureReward() int64 {
+	if m != nil {
+		return m.ImmatureReward
+	}
+	return 0
+}
+
+type BalancesRequest struct {
+}
+
+func (m *BalancesRequest) Reset()                    { *m = BalancesRequest{} }
+func (m *BalancesRequest) String() string            { return proto.CompactTextString(m) }
+func (*BalancesRequest) ProtoMessage()               {}
+func (*BalancesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+type BalancesResponse struct {
+	Mine      *BalancesResponse_Breakdown         `protobuf:"bytes,1,opt,name=mine" json:"mine,omitempty"`
+	WatchOnly *BalancesResponse_Breakdown         `protobuf:"bytes,2,opt,name=watch_only,json=watchOnly" json:"watch_only,omitempty"`
+	Accounts  []*BalancesResponse_AccountBalances `protobuf:"bytes,3,rep,name=accounts" json:"accounts,omitempty"`
+}
+
+func (m *BalancesResponse) Reset()                    { *m = BalancesResponse{} }
+func (m *BalancesResponse) String() string            { return proto.CompactTextString(m) }
+func (*BalancesResponse) ProtoMessage()               {}
+func (*BalancesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *BalancesResponse) GetMine() *BalancesResponse_Breakdown {
+	if m != nil {
+		return m.Mine
+	}
+	return nil
+}
+
+func (m *BalancesResponse) GetWatchOnly() *BalancesResponse_Breakdown {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return nil
+}
+
+func (m *BalancesResponse) GetAccounts() []*BalancesResponse_AccountBalances {
+	if m != nil {
+		return m.Accounts
+	}
+	return nil
+}
+
+type BalancesResponse_Breakdown struct {
+	Trusted           int64 `protobuf:"varint,1,opt,name=trusted" json:"trusted,omitempty"`
+	UntrustedPending  int64 `protobuf:"varint,2,opt,name=untrusted_pending,json=untrustedPending" json:"untrusted_pending,omitempty"`
+	UnconfirmedChange int64 `protobuf:"varint,3,opt,name=unconfirmed_change,json=unconfirmedChange" json:"unconfirmed_change,omitempty"`
+	Immature          int64 `protobuf:"varint,4,opt,name=immature" json:"immature,omitempty"`
+	Locked            int64 `protobuf:"varint,5,opt,name=locked" json:"locked,omitempty"`
+}
+
+func (m *BalancesResponse_Breakdown) Reset()                    { *m = BalancesResponse_Breakdown{} }
+func (m *BalancesResponse_Breakdown) String() string            { return proto.CompactTextString(m) }
+func (*BalancesResponse_Breakdown) ProtoMessage()               {}
+func (*BalancesResponse_Breakdown) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32, 0} }
+
+func (m *BalancesResponse_Breakdown) GetTrusted() int64 {
+	if m != nil {
+		return m.Trusted
+	}
+	return 0
+}
+
+func (m *BalancesResponse_Breakdown) GetUntrustedPending() int64 {
+	if m != nil {
+		return m.UntrustedPending
+	}
+	return 0
+}
+
+func (m *BalancesResponse_Breakdown) GetUnconfirmedChange() int64 {
+	if m != nil {
+		return m.UnconfirmedChange
+	}
+	return 0
+}
+
+func (m *BalancesResponse_Breakdown) GetImmature() int64 {
+	if m != nil {
+		return m.Immature
+	}
+	return 0
+}
+
+func (m *BalancesResponse_Breakdown) GetLocked() int64 {
+	if m != nil {
+		return m.Locked
+	}
+	return 0
+}
+
+type BalancesResponse_AccountBalances struct {
+	Purpose       uint32                      `protobuf:"varint,1,opt,name=purpose" json:"purpose,omitempty"`
+	CoinType      uint32                      `protobuf:"varint,2,opt,name=coin_type,json=coinType" json:"coin_type,omitempty"`
+	AccountNumber uint32                      `protobuf:"varint,3,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
+	AccountName   string                      `protobuf:"bytes,4,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	Mine          *BalancesResponse_Breakdown `protobuf:"bytes,5,opt,name=mine" json:"mine,omitempty"`
+	WatchOnly     *BalancesResponse_Breakdown `protobuf:"bytes,6,opt,name=watch_only,json=watchOnly" json:"watch_only,omitempty"`
+}
+
+func (m *BalancesResponse_AccountBalances) Reset()         { *m = BalancesResponse_AccountBalances{} }
+func (m *BalancesResponse_AccountBalances) String() string { return proto.CompactTextString(m) }
+func (*BalancesResponse_AccountBalances) ProtoMessage()    {}
+func (*BalancesResponse_AccountBalances) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{32, 1}
+}
+
+func (m *BalancesResponse_AccountBalances) GetPurpose() uint32 {
+	if m != nil {
+		return m.Purpose
+	}
+	return 0
+}
+
+func (m *BalancesResponse_AccountBalances) GetCoinType() uint32 {
+	if m != nil {
+		return m.CoinType
+	}
+	return 0
+}
+
+func (m *BalancesResponse_AccountBalances) GetAccountNumber() uint32 {
+	if m != nil {
+		return m.AccountNumber
+	}
+	return 0
+}
+
+func (m *BalancesResponse_AccountBalances) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *BalancesResponse_AccountBalances) GetMine() *BalancesResponse_Breakdown {
+	if m != nil {
+		return m.Mine
+	}
+	return nil
+}
+
+func (m *BalancesResponse_AccountBalances) GetWatchOnly() *BalancesResponse_Breakdown {
+	if m != nil {
+		return m.WatchOnly
+	}
+	return nil
+}
+
+type GetTransactionsRequest struct {
+	// Optionally specify the starting block from which to begin including all transactions.
+	// Either the starting block hash or height may be specified, but not both.
+	// If a block height is specified and is negative, the absolute value becomes the number of
+	// last blocks to include.  That is, given a current chain height of 1000 and a starting block
+	// height of -3, transaction notifications will be created for blocks 998, 999, and 1000.
+	// If both options are excluded, transaction results are created for transactions since the
+	// genesis block.
+	StartingBlockHash   []byte `protobuf:"bytes,1,opt,name=starting_block_hash,json=startingBlockHash,proto3" json:"starting_block_hash,omitempty"`
+	StartingBlockHeight int32  `protobuf:"zigzag32,2,opt,name=starting_block_height,json=startingBlockHeight" json:"starting_block_height,omitempty"`
+	// Optionally specify the last block that transaction results may appear in.
+	// Either the ending block hash or height may be specified, but not both.
+	// If both are excluded, transaction results are created for all transactions
+	// through the best block, and include all unmined transactions.
+	EndingBlockHash   []byte `protobuf:"bytes,3,opt,name=ending_block_hash,json=endingBlockHash,proto3" json:"ending_block_hash,omitempty"`
+	EndingBlockHeight int32  `protobuf:"varint,4,opt,name=ending_block_height,json=endingBlockHeight" json:"ending_block_height,omitempty"`
+	// Include at least this many of the newest transactions if they exist.
+	// Cannot be used when the ending block hash is specified.
+	//
+	// TODO: remove until spec adds it back in some way.
+	MinimumRecentTransactions int32 `protobuf:"varint,5,opt,name=minimum_recent_transactions,json=minimumRecentTransactions" json:"minimum_recent_transactions,omitempty"`
+	// Optionally continue listing after the last transaction returned by a
+	// previous request with the same block range and filters, using the
+	// next_cursor of its response.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor" json:"cursor,omitempty"`
+	// The maximum number of transactions to return, or zero for no limit.
+	Limit uint32 `protobuf:"varint,7,opt,name=limit" json:"limit,omitempty"`
+	// Optional filters restricting the transactions returned to those
+	// involving an account, having an output in a listtransactions category,
+	// carrying a transaction or address label, or with a net amount (in
+	// atoms) within a range.
+	AccountName string `protobuf:"bytes,8,opt,name=account_name,json=accountName" json:"account_name,omitempty"`
+	Category    string `protobuf:"bytes,9,opt,name=category" json:"category,omitempty"`
+	Label       string `protobuf:"bytes,10,opt,name=label" json:"label,omitempty"`
+	MinAmount   int64  `protobuf:"varint,11,opt,name=min_amount,json=minAmount" json:"min_amount,omitempty"`
+	MaxAmount   int64  `protobuf:"varint,12,opt,name=max_amount,json=maxAmount" json:"max_amount,omitempty"`
+}
+
+func (m *GetTransactionsRequest) Reset()                    { *m = GetTransactionsRequest{} }
+func (m *GetTransactionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetTransactionsRequest) ProtoMessage()               {}
+func (*GetTransactionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *GetTransactionsRequest) GetStartingBlockHash() []byte {
+	if m != nil {
+		return m.StartingBlockHash
+	}
+	return nil
+}
+
+func (m *GetTransactionsRequest) GetStartingBlockHeight() int32 {
+	if m != nil {
+		return m.StartingBlockHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetEndingBlockHash() []byte {
+	if m != nil {
+		return m.EndingBlockHash
+	}
+	return nil
+}
+
+func (m *GetTransactionsRequest) GetEndingBlockHeight() int32 {
+	if m != nil {
+		return m.EndingBlockHeight
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetMinimumRecentTransactions() int32 {
+	if m != nil {
+		return m.MinimumRecentTransactions
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *GetTransactionsRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetAccountName() string {
+	if m != nil {
+		return m.AccountName
+	}
+	return ""
+}
+
+func (m *GetTransactionsRequest) GetCategory() string {
+	if m != nil {
+		return m.Category
+	}
+	return ""
+}
+
+func (m *GetTransactionsRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *GetTransactionsRequest) GetMinAmount() int64 {
+	if m != nil {
+		return m.MinAmount
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetMaxAmount() int64 {
+	if m != nil {
+		return m.MaxAmount
+	}
+	return 0
+}
+
+type GetTransactionsResponse struct {
+	MinedTransactions   []*BlockDetails       `protobuf:"bytes,1,rep,name=mined_transactions,json=minedTransactions" json:"mined_transactions,omitempty"`
+	UnminedTransactions []*TransactionDetails `protobuf:"bytes,2,rep,name=unmined_transactions,json=unminedTransactions" json:"unmined_transactions,omitempty"`
+	// Set when more transactions follow the ones returned.
+	NextCursor string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor" json:"next_cursor,omitempty"`
+}
+
+func (m *GetTransactionsResponse) Reset()                    { *m = GetTransactionsResponse{} }
+func (m *GetTransactionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetTransactionsResponse) ProtoMessage()               {}
+func (*GetTransactionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *GetTransactionsResponse) GetMinedTransactions() []*BlockDetails {
+	if m != nil {
+		return m.MinedTransactions
+	}
+	return nil
+}
+
+func (m *GetTransactionsResponse) GetUnminedTransactions() []*TransactionDetails {
+	if m != nil {
+		return m.UnminedTransactions
+	}
+	return nil
+}
+
+func (m *GetTransactionsResponse) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type ChangePassphraseRequest struct {
+	Key           ChangePassphraseRequest_Key `protobuf:"varint,1,opt,name=key,enum=walletrpc.ChangePassphraseRequest_Key" json:"key,omitempty"`
+	OldPassphrase []byte                      `protobuf:"bytes,2,opt,name=old_passphrase,json=oldPassphrase,proto3" json:"old_passphrase,omitempty"`
+	NewPassphrase []byte                      `protobuf:"bytes,3,opt,name=new_passphrase,json=newPassphrase,proto3" json:"new_passphrase,omitempty"`
+}
+
+func (m *ChangePassphraseRequest) Reset()                    { *m = ChangePassphraseRequest{} }
+func (m *ChangePassphraseRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChangePassphraseRequest) ProtoMessage()               {}
+func (*ChangePassphraseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *ChangePassphraseRequest) GetKey() ChangePassphraseRequest_Key {
+	if m != nil {
+		return m.Key
+	}
+	return ChangePassphraseRequest_PRIVATE
+}
+
+func (m *ChangePassphraseRequest) GetOldPassphrase() []byte {
+	if m != nil {
+		return m.OldPassphrase
+	}
+	return nil
+}
+
+func (m *ChangePassphraseRequest) GetNewPassphrase() []byte {
+	if m != nil {
+		return m.NewPassphrase
+	}
+	return nil
+}
+
+type ChangePassphraseResponse struct {
+}
+
+func (m *ChangePassphraseResponse) Reset()                    { *m = ChangePassphraseResponse{} }
+func (m *ChangePassphraseResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChangePassphraseResponse) ProtoMessage()               {}
+func (*ChangePassphraseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type FundTransactionRequest struct {
+	Account                  uint32 `protobuf:"varint,1,opt,name=account" json:"account,omitempty"`
+	TargetAmount             int64  `protobuf:"varint,2,opt,name=target_amount,json=targetAmount" json:"target_amount,omitempty"`
+	RequiredConfirmations    int32  `protobuf:"varint,3,opt,name=required_confirmations,json=requiredConfirmations" json:"required_confirmations,omitempty"`
+	IncludeImmatureCoinbases bool   `protobuf:"varint,4,opt,name=include_immature_coinbases,json=includeImmatureCoinbases" json:"include_immature_coinbases,omitempty"`
+	IncludeChangeScript      bool   `protobuf:"varint,5,opt,name=include_change_script,json=includeChangeScript" json:"include_change_script,omitempty"`
+}
+
+func (m *FundTransactionRequest) Reset()                    { *m = FundTransactionRequest{} }
+func (m *FundTransactionRequest) String() string            { return proto.CompactTextString(m) }
+func (*FundTransactionRequest) ProtoMessage()               {}
+func (*FundTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *FundTransactionRequest) GetAccount() uint32 {
+	if m != nil {
+		return m.Account
+	}
+	return 0
+}
+
+func (m *FundTransactionRequest) GetTargetAmount() int64 {
+	if m != nil {
+		return m.TargetAmount
+	}
+	return 0
+}
+
+func (m *FundTransactionRequest) GetRequiredConfirmations() int32 {
+	if m != nil {
+		return m.RequiredConfirmations
+	}
+	return 0
+}
+
+func (m *FundTransactionRequest) GetIncludeImmatureCoinbases() bool {
+	if m != nil {
+		return m.IncludeImmatureCoinbases
 	}
 	return false
 }
@@ -943,7 +1678,7 @@ type FundTransactionResponse struct {
 func (m *FundTransactionResponse) Reset()                    { *m = FundTransactionResponse{} }
 func (m *FundTransactionResponse) String() string            { return proto.CompactTextString(m) }
 func (*FundTransactionResponse) ProtoMessage()               {}
-func (*FundTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*FundTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
 
 func (m *FundTransactionResponse) GetSelectedOutputs() []*FundTransactionResponse_PreviousOutput {
 	if m != nil {
@@ -981,7 +1716,7 @@ func (m *FundTransactionResponse_PreviousOutput) Reset() {
 func (m *FundTransactionResponse_PreviousOutput) String() string { return proto.CompactTextString(m) }
 func (*FundTransactionResponse_PreviousOutput) ProtoMessage()    {}
 func (*FundTransactionResponse_PreviousOutput) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{28, 0}
+	return fileDescriptor0, []int{38, 0}
 }
 
 func (m *FundTransactionResponse_PreviousOutput) GetTransactionHash() []byte {
@@ -1040,7 +1775,7 @@ type SignTransactionRequest struct {
 func (m *SignTransactionRequest) Reset()                    { *m = SignTransactionRequest{} }
 func (m *SignTransactionRequest) String() string            { return proto.CompactTextString(m) }
 func (*SignTransactionRequest) ProtoMessage()               {}
-func (*SignTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*SignTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
 
 func (m *SignTransactionRequest) GetPassphrase() []byte {
 	if m != nil {
@@ -1071,7 +1806,7 @@ type SignTransactionResponse struct {
 func (m *SignTransactionResponse) Reset()                    { *m = SignTransactionResponse{} }
 func (m *SignTransactionResponse) String() string            { return proto.CompactTextString(m) }
 func (*SignTransactionResponse) ProtoMessage()               {}
-func (*SignTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*SignTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
 
 func (m *SignTransactionResponse) GetTransaction() []byte {
 	if m != nil {
@@ -1094,7 +1829,7 @@ type PublishTransactionRequest struct {
 func (m *PublishTransactionRequest) Reset()                    { *m = PublishTransactionRequest{} }
 func (m *PublishTransactionRequest) String() string            { return proto.CompactTextString(m) }
 func (*PublishTransactionRequest) ProtoMessage()               {}
-func (*PublishTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*PublishTransactionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
 
 func (m *PublishTransactionRequest) GetSignedTransaction() []byte {
 	if m != nil {
@@ -1109,7 +1844,64 @@ type PublishTransactionResponse struct {
 func (m *PublishTransactionResponse) Reset()                    { *m = PublishTransactionResponse{} }
 func (m *PublishTransactionResponse) String() string            { return proto.CompactTextString(m) }
 func (*PublishTransactionResponse) ProtoMessage()               {}
-func (*PublishTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*PublishTransactionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+type RescanRequest struct {
+	BeginHeight int32 `protobuf:"varint,1,opt,name=begin_height,json=beginHeight" json:"begin_height,omitempty"`
+	// Zero rescans through the block the wallet is synced to.
+	EndHeight int32 `protobuf:"varint,2,opt,name=end_height,json=endHeight" json:"end_height,omitempty"`
+}
+
+func (m *RescanRequest) Reset()                    { *m = RescanRequest{} }
+func (m *RescanRequest) String() string            { return proto.CompactTextString(m) }
+func (*RescanRequest) ProtoMessage()               {}
+func (*RescanRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *RescanRequest) GetBeginHeight() int32 {
+	if m != nil {
+		return m.BeginHeight
+	}
+	return 0
+}
+
+func (m *RescanRequest) GetEndHeight() int32 {
+	if m != nil {
+		return m.EndHeight
+	}
+	return 0
+}
+
+type RescanResponse struct {
+	RescannedThrough int32  `protobuf:"varint,1,opt,name=rescanned_through,json=rescannedThrough" json:"rescanned_through,omitempty"`
+	BlockHash        []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTime        int64  `protobuf:"varint,3,opt,name=block_time,json=blockTime" json:"block_time,omitempty"`
+}
+
+func (m *RescanResponse) Reset()                    { *m = RescanResponse{} }
+func (m *RescanResponse) String() string            { return proto.CompactTextString(m) }
+func (*RescanResponse) ProtoMessage()               {}
+func (*RescanResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *RescanResponse) GetRescannedThrough() int32 {
+	if m != nil {
+		return m.RescannedThrough
+	}
+	return 0
+}
+
+func (m *RescanResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
+func (m *RescanResponse) GetBlockTime() int64 {
+	if m != nil {
+		return m.BlockTime
+	}
+	return 0
+}
 
 type TransactionNotificationsRequest struct {
 }
@@ -1118,7 +1910,7 @@ func (m *TransactionNotificationsRequest) Reset()         { *m = TransactionNoti
 func (m *TransactionNotificationsRequest) String() string { return proto.CompactTextString(m) }
 func (*TransactionNotificationsRequest) ProtoMessage()    {}
 func (*TransactionNotificationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{33}
+	return fileDescriptor0, []int{45}
 }
 
 type TransactionNotificationsResponse struct {
@@ -1144,7 +1936,7 @@ func (m *TransactionNotificationsResponse) Reset()         { *m = TransactionNot
 func (m *TransactionNotificationsResponse) String() string { return proto.CompactTextString(m) }
 func (*TransactionNotificationsResponse) ProtoMessage()    {}
 func (*TransactionNotificationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{34}
+	return fileDescriptor0, []int{46}
 }
 
 func (m *TransactionNotificationsResponse) GetAttachedBlocks() []*BlockDetails {
@@ -1184,7 +1976,7 @@ type SpentnessNotificationsRequest struct {
 func (m *SpentnessNotificationsRequest) Reset()                    { *m = SpentnessNotificationsRequest{} }
 func (m *SpentnessNotificationsRequest) String() string            { return proto.CompactTextString(m) }
 func (*SpentnessNotificationsRequest) ProtoMessage()               {}
-func (*SpentnessNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+func (*SpentnessNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
 
 func (m *SpentnessNotificationsRequest) GetAccount() uint32 {
 	if m != nil {
@@ -1217,7 +2009,7 @@ func (m *SpentnessNotificationsResponse) Reset()         { *m = SpentnessNotific
 func (m *SpentnessNotificationsResponse) String() string { return proto.CompactTextString(m) }
 func (*SpentnessNotificationsResponse) ProtoMessage()    {}
 func (*SpentnessNotificationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{36}
+	return fileDescriptor0, []int{48}
 }
 
 func (m *SpentnessNotificationsResponse) GetTransactionHash() []byte {
@@ -1252,7 +2044,7 @@ func (m *SpentnessNotificationsResponse_Spender) Reset() {
 func (m *SpentnessNotificationsResponse_Spender) String() string { return proto.CompactTextString(m) }
 func (*SpentnessNotificationsResponse_Spender) ProtoMessage()    {}
 func (*SpentnessNotificationsResponse_Spender) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{36, 0}
+	return fileDescriptor0, []int{48, 0}
 }
 
 func (m *SpentnessNotificationsResponse_Spender) GetTransactionHash() []byte {
@@ -1275,7 +2067,7 @@ type AccountNotificationsRequest struct {
 func (m *AccountNotificationsRequest) Reset()                    { *m = AccountNotificationsRequest{} }
 func (m *AccountNotificationsRequest) String() string            { return proto.CompactTextString(m) }
 func (*AccountNotificationsRequest) ProtoMessage()               {}
-func (*AccountNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+func (*AccountNotificationsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
 
 type AccountNotificationsResponse struct {
 	AccountNumber    uint32 `protobuf:"varint,1,opt,name=account_number,json=accountNumber" json:"account_number,omitempty"`
@@ -1288,7 +2080,7 @@ type AccountNotificationsResponse struct {
 func (m *AccountNotificationsResponse) Reset()                    { *m = AccountNotificationsResponse{} }
 func (m *AccountNotificationsResponse) String() string            { return proto.CompactTextString(m) }
 func (*AccountNotificationsResponse) ProtoMessage()               {}
-func (*AccountNotificationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+func (*AccountNotificationsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
 
 func (m *AccountNotificationsResponse) GetAccountNumber() uint32 {
 	if m != nil {
@@ -1334,7 +2126,7 @@ type CreateWalletRequest struct {
 func (m *CreateWalletRequest) Reset()                    { *m = CreateWalletRequest{} }
 func (m *CreateWalletRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateWalletRequest) ProtoMessage()               {}
-func (*CreateWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+func (*CreateWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
 
 func (m *CreateWalletRequest) GetPublicPassphrase() []byte {
 	if m != nil {
@@ -1363,7 +2155,7 @@ type CreateWalletResponse struct {
 func (m *CreateWalletResponse) Reset()                    { *m = CreateWalletResponse{} }
 func (m *CreateWalletResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateWalletResponse) ProtoMessage()               {}
-func (*CreateWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+func (*CreateWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
 
 type OpenWalletRequest struct {
 	PublicPassphrase []byte `protobuf:"bytes,1,opt,name=public_passphrase,json=publicPassphrase,proto3" json:"public_passphrase,omitempty"`
@@ -1372,7 +2164,7 @@ type OpenWalletRequest struct {
 func (m *OpenWalletRequest) Reset()                    { *m = OpenWalletRequest{} }
 func (m *OpenWalletRequest) String() string            { return proto.CompactTextString(m) }
 func (*OpenWalletRequest) ProtoMessage()               {}
-func (*OpenWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+func (*OpenWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
 
 func (m *OpenWalletRequest) GetPublicPassphrase() []byte {
 	if m != nil {
@@ -1387,7 +2179,7 @@ type OpenWalletResponse struct {
 func (m *OpenWalletResponse) Reset()                    { *m = OpenWalletResponse{} }
 func (m *OpenWalletResponse) String() string            { return proto.CompactTextString(m) }
 func (*OpenWalletResponse) ProtoMessage()               {}
-func (*OpenWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+func (*OpenWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
 
 type CloseWalletRequest struct {
 }
@@ -1395,7 +2187,7 @@ type CloseWalletRequest struct {
 func (m *CloseWalletRequest) Reset()                    { *m = CloseWalletRequest{} }
 func (m *CloseWalletRequest) String() string            { return proto.CompactTextString(m) }
 func (*CloseWalletRequest) ProtoMessage()               {}
-func (*CloseWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+func (*CloseWalletRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
 
 type CloseWalletResponse struct {
 }
@@ -1403,79 +2195,287 @@ type CloseWalletResponse struct {
 func (m *CloseWalletResponse) Reset()                    { *m = CloseWalletResponse{} }
 func (m *CloseWalletResponse) String() string            { return proto.CompactTextString(m) }
 func (*CloseWalletResponse) ProtoMessage()               {}
-func (*CloseWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+func (*CloseWalletResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
 
 type WalletExistsRequest struct {
 }
 
-func (m *WalletExistsRequest) Reset()                    { *m = WalletExistsRequest{} }
-func (m *WalletExistsRequest) String() string            { return proto.CompactTextString(m) }
-func (*WalletExistsRequest) ProtoMessage()               {}
-func (*WalletExistsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
-
-type WalletExistsResponse struct {
-	Exists bool `protobuf:"varint,1,opt,name=exists" json:"exists,omitempty"`
+func (m *WalletExistsRequest) Reset()                    { *m = WalletExistsRequest{} }
+func (m *WalletExistsRequest) String() string            { return proto.CompactTextString(m) }
+func (*WalletExistsRequest) ProtoMessage()               {}
+func (*WalletExistsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+type WalletExistsResponse struct {
+	Exists bool `protobuf:"varint,1,opt,name=exists" json:"exists,omitempty"`
+}
+
+func (m *WalletExistsResponse) Reset()                    { *m = WalletExistsResponse{} }
+func (m *WalletExistsResponse) String() string            { return proto.CompactTextString(m) }
+func (*WalletExistsResponse) ProtoMessage()               {}
+func (*WalletExistsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *WalletExistsResponse) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+type StartConsensusRpcRequest struct {
+	NetworkAddress     string            `protobuf:"bytes,1,opt,name=network_address,json=networkAddress" json:"network_address,omitempty"`
+	Username           string            `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	Password           []byte            `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Certificate        []byte            `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Backend            string            `protobuf:"bytes,5,opt,name=backend" json:"backend,omitempty"`
+	ZmqRawBlockAddress string            `protobuf:"bytes,6,opt,name=zmq_raw_block_address,json=zmqRawBlockAddress" json:"zmq_raw_block_address,omitempty"`
+	ZmqRawTxAddress    string            `protobuf:"bytes,7,opt,name=zmq_raw_tx_address,json=zmqRawTxAddress" json:"zmq_raw_tx_address,omitempty"`
+	BackendOptions     map[string]string `protobuf:"bytes,8,rep,name=backend_options,json=backendOptions" json:"backend_options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *StartConsensusRpcRequest) Reset()                    { *m = StartConsensusRpcRequest{} }
+func (m *StartConsensusRpcRequest) String() string            { return proto.CompactTextString(m) }
+func (*StartConsensusRpcRequest) ProtoMessage()               {}
+func (*StartConsensusRpcRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *StartConsensusRpcRequest) GetNetworkAddress() string {
+	if m != nil {
+		return m.NetworkAddress
+	}
+	return ""
+}
+
+func (m *StartConsensusRpcRequest) GetUsername() string {
+	if m != nil {
+		return m.Username
+	}
+	return ""
+}
+
+func (m *StartConsensusRpcRequest) GetPassword() []byte {
+	if m != nil {
+		return m.Password
+	}
+	return nil
+}
+
+func (m *StartConsensusRpcRequest) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *StartConsensusRpcRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+func (m *StartConsensusRpcRequest) GetZmqRawBlockAddress() string {
+	if m != nil {
+		return m.ZmqRawBlockAddress
+	}
+	return ""
+}
+
+func (m *StartConsensusRpcRequest) GetZmqRawTxAddress() string {
+	if m != nil {
+		return m.ZmqRawTxAddress
+	}
+	return ""
+}
+
+func (m *StartConsensusRpcRequest) GetBackendOptions() map[string]string {
+	if m != nil {
+		return m.BackendOptions
+	}
+	return nil
+}
+
+type StartConsensusRpcResponse struct {
+}
+
+func (m *StartConsensusRpcResponse) Reset()                    { *m = StartConsensusRpcResponse{} }
+func (m *StartConsensusRpcResponse) String() string            { return proto.CompactTextString(m) }
+func (*StartConsensusRpcResponse) ProtoMessage()               {}
+func (*StartConsensusRpcResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+type KeyDescriptor struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address" json:"address,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Compressed           bool     `protobuf:"varint,3,opt,name=compressed" json:"compressed,omitempty"`
+	MasterKeyFingerprint uint32   `protobuf:"varint,4,opt,name=master_key_fingerprint,json=masterKeyFingerprint" json:"master_key_fingerprint,omitempty"`
+	DerivationPath       []uint32 `protobuf:"varint,5,rep,packed,name=derivation_path,json=derivationPath" json:"derivation_path,omitempty"`
+}
+
+func (m *KeyDescriptor) Reset()                    { *m = KeyDescriptor{} }
+func (m *KeyDescriptor) String() string            { return proto.CompactTextString(m) }
+func (*KeyDescriptor) ProtoMessage()               {}
+func (*KeyDescriptor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *KeyDescriptor) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *KeyDescriptor) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+func (m *KeyDescriptor) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+func (m *KeyDescriptor) GetMasterKeyFingerprint() uint32 {
+	if m != nil {
+		return m.MasterKeyFingerprint
+	}
+	return 0
+}
+
+func (m *KeyDescriptor) GetDerivationPath() []uint32 {
+	if m != nil {
+		return m.DerivationPath
+	}
+	return nil
+}
+
+type SignInputRequest struct {
+	Key          *KeyDescriptor `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Transaction  []byte         `protobuf:"bytes,2,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	InputIndex   uint32         `protobuf:"varint,3,opt,name=input_index,json=inputIndex" json:"input_index,omitempty"`
+	OutputValue  int64          `protobuf:"varint,4,opt,name=output_value,json=outputValue" json:"output_value,omitempty"`
+	OutputScript []byte         `protobuf:"bytes,5,opt,name=output_script,json=outputScript,proto3" json:"output_script,omitempty"`
+	SubScript    []byte         `protobuf:"bytes,6,opt,name=sub_script,json=subScript,proto3" json:"sub_script,omitempty"`
+	HashType     uint32         `protobuf:"varint,7,opt,name=hash_type,json=hashType" json:"hash_type,omitempty"`
+	Witness      bool           `protobuf:"varint,8,opt,name=witness" json:"witness,omitempty"`
+}
+
+func (m *SignInputRequest) Reset()                    { *m = SignInputRequest{} }
+func (m *SignInputRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignInputRequest) ProtoMessage()               {}
+func (*SignInputRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *SignInputRequest) GetKey() *KeyDescriptor {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *SignInputRequest) GetTransaction() []byte {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func (m *SignInputRequest) GetInputIndex() uint32 {
+	if m != nil {
+		return m.InputIndex
+	}
+	return 0
+}
+
+func (m *SignInputRequest) GetOutputValue() int64 {
+	if m != nil {
+		return m.OutputValue
+	}
+	return 0
+}
+
+func (m *SignInputRequest) GetOutputScript() []byte {
+	if m != nil {
+		return m.OutputScript
+	}
+	return nil
+}
+
+func (m *SignInputRequest) GetSubScript() []byte {
+	if m != nil {
+		return m.SubScript
+	}
+	return nil
 }
 
-func (m *WalletExistsResponse) Reset()                    { *m = WalletExistsResponse{} }
-func (m *WalletExistsResponse) String() string            { return proto.CompactTextString(m) }
-func (*WalletExistsResponse) ProtoMessage()               {}
-func (*WalletExistsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+func (m *SignInputRequest) GetHashType() uint32 {
+	if m != nil {
+		return m.HashType
+	}
+	return 0
+}
 
-func (m *WalletExistsResponse) GetExists() bool {
+func (m *SignInputRequest) GetWitness() bool {
 	if m != nil {
-		return m.Exists
+		return m.Witness
 	}
 	return false
 }
 
-type StartConsensusRpcRequest struct {
-	NetworkAddress string `protobuf:"bytes,1,opt,name=network_address,json=networkAddress" json:"network_address,omitempty"`
-	Username       string `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
-	Password       []byte `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Certificate    []byte `protobuf:"bytes,4,opt,name=certificate,proto3" json:"certificate,omitempty"`
+type SignInputResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *StartConsensusRpcRequest) Reset()                    { *m = StartConsensusRpcRequest{} }
-func (m *StartConsensusRpcRequest) String() string            { return proto.CompactTextString(m) }
-func (*StartConsensusRpcRequest) ProtoMessage()               {}
-func (*StartConsensusRpcRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+func (m *SignInputResponse) Reset()                    { *m = SignInputResponse{} }
+func (m *SignInputResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignInputResponse) ProtoMessage()               {}
+func (*SignInputResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
 
-func (m *StartConsensusRpcRequest) GetNetworkAddress() string {
+func (m *SignInputResponse) GetSignature() []byte {
 	if m != nil {
-		return m.NetworkAddress
+		return m.Signature
 	}
-	return ""
+	return nil
 }
 
-func (m *StartConsensusRpcRequest) GetUsername() string {
-	if m != nil {
-		return m.Username
-	}
-	return ""
+type SignMessageRequest struct {
+	Key     *KeyDescriptor `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Message string         `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
 }
 
-func (m *StartConsensusRpcRequest) GetPassword() []byte {
+func (m *SignMessageRequest) Reset()                    { *m = SignMessageRequest{} }
+func (m *SignMessageRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageRequest) ProtoMessage()               {}
+func (*SignMessageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *SignMessageRequest) GetKey() *KeyDescriptor {
 	if m != nil {
-		return m.Password
+		return m.Key
 	}
 	return nil
 }
 
-func (m *StartConsensusRpcRequest) GetCertificate() []byte {
+func (m *SignMessageRequest) GetMessage() string {
 	if m != nil {
-		return m.Certificate
+		return m.Message
 	}
-	return nil
+	return ""
 }
 
-type StartConsensusRpcResponse struct {
+type SignMessageResponse struct {
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *StartConsensusRpcResponse) Reset()                    { *m = StartConsensusRpcResponse{} }
-func (m *StartConsensusRpcResponse) String() string            { return proto.CompactTextString(m) }
-func (*StartConsensusRpcResponse) ProtoMessage()               {}
-func (*StartConsensusRpcResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+func (m *SignMessageResponse) Reset()                    { *m = SignMessageResponse{} }
+func (m *SignMessageResponse) String() string            { return proto.CompactTextString(m) }
+func (*SignMessageResponse) ProtoMessage()               {}
+func (*SignMessageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+func (m *SignMessageResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
 
 func init() {
 	proto.RegisterType((*VersionRequest)(nil), "walletrpc.VersionRequest")
@@ -1496,14 +2496,27 @@ func init() {
 	proto.RegisterType((*AccountsResponse_Account)(nil), "walletrpc.AccountsResponse.Account")
 	proto.RegisterType((*RenameAccountRequest)(nil), "walletrpc.RenameAccountRequest")
 	proto.RegisterType((*RenameAccountResponse)(nil), "walletrpc.RenameAccountResponse")
+	proto.RegisterType((*SetAddressLabelRequest)(nil), "walletrpc.SetAddressLabelRequest")
+	proto.RegisterType((*SetAddressLabelResponse)(nil), "walletrpc.SetAddressLabelResponse")
+	proto.RegisterType((*AddressLabelsRequest)(nil), "walletrpc.AddressLabelsRequest")
+	proto.RegisterType((*AddressLabelsResponse)(nil), "walletrpc.AddressLabelsResponse")
+	proto.RegisterType((*AddressLabelsResponse_AddressLabel)(nil), "walletrpc.AddressLabelsResponse.AddressLabel")
 	proto.RegisterType((*NextAccountRequest)(nil), "walletrpc.NextAccountRequest")
 	proto.RegisterType((*NextAccountResponse)(nil), "walletrpc.NextAccountResponse")
 	proto.RegisterType((*NextAddressRequest)(nil), "walletrpc.NextAddressRequest")
 	proto.RegisterType((*NextAddressResponse)(nil), "walletrpc.NextAddressResponse")
 	proto.RegisterType((*ImportPrivateKeyRequest)(nil), "walletrpc.ImportPrivateKeyRequest")
 	proto.RegisterType((*ImportPrivateKeyResponse)(nil), "walletrpc.ImportPrivateKeyResponse")
+	proto.RegisterType((*ImportAccountPrivateKeyRequest)(nil), "walletrpc.ImportAccountPrivateKeyRequest")
+	proto.RegisterType((*ImportAccountPrivateKeyResponse)(nil), "walletrpc.ImportAccountPrivateKeyResponse")
+	proto.RegisterType((*AddressInfoRequest)(nil), "walletrpc.AddressInfoRequest")
+	proto.RegisterType((*AddressInfoResponse)(nil), "walletrpc.AddressInfoResponse")
 	proto.RegisterType((*BalanceRequest)(nil), "walletrpc.BalanceRequest")
 	proto.RegisterType((*BalanceResponse)(nil), "walletrpc.BalanceResponse")
+	proto.RegisterType((*BalancesRequest)(nil), "walletrpc.BalancesRequest")
+	proto.RegisterType((*BalancesResponse)(nil), "walletrpc.BalancesResponse")
+	proto.RegisterType((*BalancesResponse_Breakdown)(nil), "walletrpc.BalancesResponse.Breakdown")
+	proto.RegisterType((*BalancesResponse_AccountBalances)(nil), "walletrpc.BalancesResponse.AccountBalances")
 	proto.RegisterType((*GetTransactionsRequest)(nil), "walletrpc.GetTransactionsRequest")
 	proto.RegisterType((*GetTransactionsResponse)(nil), "walletrpc.GetTransactionsResponse")
 	proto.RegisterType((*ChangePassphraseRequest)(nil), "walletrpc.ChangePassphraseRequest")
@@ -1515,6 +2528,8 @@ func init() {
 	proto.RegisterType((*SignTransactionResponse)(nil), "walletrpc.SignTransactionResponse")
 	proto.RegisterType((*PublishTransactionRequest)(nil), "walletrpc.PublishTransactionRequest")
 	proto.RegisterType((*PublishTransactionResponse)(nil), "walletrpc.PublishTransactionResponse")
+	proto.RegisterType((*RescanRequest)(nil), "walletrpc.RescanRequest")
+	proto.RegisterType((*RescanResponse)(nil), "walletrpc.RescanResponse")
 	proto.RegisterType((*TransactionNotificationsRequest)(nil), "walletrpc.TransactionNotificationsRequest")
 	proto.RegisterType((*TransactionNotificationsResponse)(nil), "walletrpc.TransactionNotificationsResponse")
 	proto.RegisterType((*SpentnessNotificationsRequest)(nil), "walletrpc.SpentnessNotificationsRequest")
@@ -1532,7 +2547,14 @@ func init() {
 	proto.RegisterType((*WalletExistsResponse)(nil), "walletrpc.WalletExistsResponse")
 	proto.RegisterType((*StartConsensusRpcRequest)(nil), "walletrpc.StartConsensusRpcRequest")
 	proto.RegisterType((*StartConsensusRpcResponse)(nil), "walletrpc.StartConsensusRpcResponse")
+	proto.RegisterType((*KeyDescriptor)(nil), "walletrpc.KeyDescriptor")
+	proto.RegisterType((*SignInputRequest)(nil), "walletrpc.SignInputRequest")
+	proto.RegisterType((*SignInputResponse)(nil), "walletrpc.SignInputResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "walletrpc.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "walletrpc.SignMessageResponse")
 	proto.RegisterEnum("walletrpc.NextAddressRequest_Kind", NextAddressRequest_Kind_name, NextAddressRequest_Kind_value)
+	proto.RegisterEnum("walletrpc.ImportAccountPrivateKeyRequest_AddressType", ImportAccountPrivateKeyRequest_AddressType_name, ImportAccountPrivateKeyRequest_AddressType_value)
+	proto.RegisterEnum("walletrpc.AddressInfoResponse_AddressType", AddressInfoResponse_AddressType_name, AddressInfoResponse_AddressType_value)
 	proto.RegisterEnum("walletrpc.ChangePassphraseRequest_Key", ChangePassphraseRequest_Key_name, ChangePassphraseRequest_Key_value)
 }
 
@@ -1617,7 +2639,10 @@ type WalletServiceClient interface {
 	AccountNumber(ctx context.Context, in *AccountNumberRequest, opts ...grpc.CallOption) (*AccountNumberResponse, error)
 	Accounts(ctx context.Context, in *AccountsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
 	Balance(ctx context.Context, in *BalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	Balances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error)
 	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	AddressLabels(ctx context.Context, in *AddressLabelsRequest, opts ...grpc.CallOption) (*AddressLabelsResponse, error)
+	AddressInfo(ctx context.Context, in *AddressInfoRequest, opts ...grpc.CallOption) (*AddressInfoResponse, error)
 	// Notifications
 	TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error)
 	SpentnessNotifications(ctx context.Context, in *SpentnessNotificationsRequest, opts ...grpc.CallOption) (WalletService_SpentnessNotificationsClient, error)
@@ -1625,12 +2650,15 @@ type WalletServiceClient interface {
 	// Control
 	ChangePassphrase(ctx context.Context, in *ChangePassphraseRequest, opts ...grpc.CallOption) (*ChangePassphraseResponse, error)
 	RenameAccount(ctx context.Context, in *RenameAccountRequest, opts ...grpc.CallOption) (*RenameAccountResponse, error)
+	SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error)
 	NextAccount(ctx context.Context, in *NextAccountRequest, opts ...grpc.CallOption) (*NextAccountResponse, error)
 	NextAddress(ctx context.Context, in *NextAddressRequest, opts ...grpc.CallOption) (*NextAddressResponse, error)
 	ImportPrivateKey(ctx context.Context, in *ImportPrivateKeyRequest, opts ...grpc.CallOption) (*ImportPrivateKeyResponse, error)
+	ImportAccountPrivateKey(ctx context.Context, in *ImportAccountPrivateKeyRequest, opts ...grpc.CallOption) (*ImportAccountPrivateKeyResponse, error)
 	FundTransaction(ctx context.Context, in *FundTransactionRequest, opts ...grpc.CallOption) (*FundTransactionResponse, error)
 	SignTransaction(ctx context.Context, in *SignTransactionRequest, opts ...grpc.CallOption) (*SignTransactionResponse, error)
 	PublishTransaction(ctx context.Context, in *PublishTransactionRequest, opts ...grpc.CallOption) (*PublishTransactionResponse, error)
+	Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletService_RescanClient, error)
 }
 
 type walletServiceClient struct {
@@ -1686,6 +2714,15 @@ func (c *walletServiceClient) Balance(ctx context.Context, in *BalanceRequest, o
 	return out, nil
 }
 
+func (c *walletServiceClient) Balances(ctx context.Context, in *BalancesRequest, opts ...grpc.CallOption) (*BalancesResponse, error) {
+	out := new(BalancesResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletService/Balances", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
 	out := new(GetTransactionsResponse)
 	err := grpc.Invoke(ctx, "/walletrpc.WalletService/GetTransactions", in, out, c.cc, opts...)
@@ -1695,6 +2732,24 @@ func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransa
 	return out, nil
 }
 
+func (c *walletServiceClient) AddressLabels(ctx context.Context, in *AddressLabelsRequest, opts ...grpc.CallOption) (*AddressLabelsResponse, error) {
+	out := new(AddressLabelsResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletService/AddressLabels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AddressInfo(ctx context.Context, in *AddressInfoRequest, opts ...grpc.CallOption) (*AddressInfoResponse, error) {
+	out := new(AddressInfoResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletService/AddressInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) TransactionNotifications(ctx context.Context, in *TransactionNotificationsRequest, opts ...grpc.CallOption) (WalletService_TransactionNotificationsClient, error) {
 	stream, err := grpc.NewClientStream(ctx, &_WalletService_serviceDesc.Streams[0], c.cc, "/walletrpc.WalletService/TransactionNotifications", opts...)
 	if err != nil {
@@ -1809,6 +2864,15 @@ func (c *walletServiceClient) RenameAccount(ctx context.Context, in *RenameAccou
 	return out, nil
 }
 
+func (c *walletServiceClient) SetAddressLabel(ctx context.Context, in *SetAddressLabelRequest, opts ...grpc.CallOption) (*SetAddressLabelResponse, error) {
+	out := new(SetAddressLabelResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletService/SetAddressLabel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) NextAccount(ctx context.Context, in *NextAccountRequest, opts ...grpc.CallOption) (*NextAccountResponse, error) {
 	out := new(NextAccountResponse)
 	err := grpc.Invoke(ctx, "/walletrpc.WalletService/NextAccount", in, out, c.cc, opts...)
@@ -1836,6 +2900,15 @@ func (c *walletServiceClient) ImportPrivateKey(ctx context.Context, in *ImportPr
 	return out, nil
 }
 
+func (c *walletServiceClient) ImportAccountPrivateKey(ctx context.Context, in *ImportAccountPrivateKeyRequest, opts ...grpc.CallOption) (*ImportAccountPrivateKeyResponse, error) {
+	out := new(ImportAccountPrivateKeyResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.WalletService/ImportAccountPrivateKey", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *walletServiceClient) FundTransaction(ctx context.Context, in *FundTransactionRequest, opts ...grpc.CallOption) (*FundTransactionResponse, error) {
 	out := new(FundTransactionResponse)
 	err := grpc.Invoke(ctx, "/walletrpc.WalletService/FundTransaction", in, out, c.cc, opts...)
@@ -1863,6 +2936,38 @@ func (c *walletServiceClient) PublishTransaction(ctx context.Context, in *Publis
 	return out, nil
 }
 
+func (c *walletServiceClient) Rescan(ctx context.Context, in *RescanRequest, opts ...grpc.CallOption) (WalletService_RescanClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_WalletService_serviceDesc.Streams[3], c.cc, "/walletrpc.WalletService/Rescan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &walletServiceRescanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WalletService_RescanClient interface {
+	Recv() (*RescanResponse, error)
+	grpc.ClientStream
+}
+
+type walletServiceRescanClient struct {
+	grpc.ClientStream
+}
+
+func (x *walletServiceRescanClient) Recv() (*RescanResponse, error) {
+	m := new(RescanResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for WalletService service
 
 type WalletServiceServer interface {
@@ -1872,7 +2977,10 @@ type WalletServiceServer interface {
 	AccountNumber(context.Context, *AccountNumberRequest) (*AccountNumberResponse, error)
 	Accounts(context.Context, *AccountsRequest) (*AccountsResponse, error)
 	Balance(context.Context, *BalanceRequest) (*BalanceResponse, error)
+	Balances(context.Context, *BalancesRequest) (*BalancesResponse, error)
 	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	AddressLabels(context.Context, *AddressLabelsRequest) (*AddressLabelsResponse, error)
+	AddressInfo(context.Context, *AddressInfoRequest) (*AddressInfoResponse, error)
 	// Notifications
 	TransactionNotifications(*TransactionNotificationsRequest, WalletService_TransactionNotificationsServer) error
 	SpentnessNotifications(*SpentnessNotificationsRequest, WalletService_SpentnessNotificationsServer) error
@@ -1880,12 +2988,15 @@ type WalletServiceServer interface {
 	// Control
 	ChangePassphrase(context.Context, *ChangePassphraseRequest) (*ChangePassphraseResponse, error)
 	RenameAccount(context.Context, *RenameAccountRequest) (*RenameAccountResponse, error)
+	SetAddressLabel(context.Context, *SetAddressLabelRequest) (*SetAddressLabelResponse, error)
 	NextAccount(context.Context, *NextAccountRequest) (*NextAccountResponse, error)
 	NextAddress(context.Context, *NextAddressRequest) (*NextAddressResponse, error)
 	ImportPrivateKey(context.Context, *ImportPrivateKeyRequest) (*ImportPrivateKeyResponse, error)
+	ImportAccountPrivateKey(context.Context, *ImportAccountPrivateKeyRequest) (*ImportAccountPrivateKeyResponse, error)
 	FundTransaction(context.Context, *FundTransactionRequest) (*FundTransactionResponse, error)
 	SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionResponse, error)
 	PublishTransaction(context.Context, *PublishTransactionRequest) (*PublishTransactionResponse, error)
+	Rescan(*RescanRequest, WalletService_RescanServer) error
 }
 
 func RegisterWalletServiceServer(s *grpc.Server, srv WalletServiceServer) {
@@ -1982,6 +3093,24 @@ func _WalletService_Balance_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_Balances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Balances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/Balances",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Balances(ctx, req.(*BalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTransactionsRequest)
 	if err := dec(in); err != nil {
@@ -2000,6 +3129,42 @@ func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_AddressLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AddressLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/AddressLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AddressLabels(ctx, req.(*AddressLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_AddressInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AddressInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/AddressInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AddressInfo(ctx, req.(*AddressInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_TransactionNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(TransactionNotificationsRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -2099,6 +3264,24 @@ func _WalletService_RenameAccount_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_SetAddressLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAddressLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/SetAddressLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetAddressLabel(ctx, req.(*SetAddressLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_NextAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NextAccountRequest)
 	if err := dec(in); err != nil {
@@ -2153,6 +3336,24 @@ func _WalletService_ImportPrivateKey_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_ImportAccountPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountPrivateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ImportAccountPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.WalletService/ImportAccountPrivateKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ImportAccountPrivateKey(ctx, req.(*ImportAccountPrivateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WalletService_FundTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FundTransactionRequest)
 	if err := dec(in); err != nil {
@@ -2207,6 +3408,27 @@ func _WalletService_PublishTransaction_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WalletService_Rescan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RescanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).Rescan(m, &walletServiceRescanServer{stream})
+}
+
+type WalletService_RescanServer interface {
+	Send(*RescanResponse) error
+	grpc.ServerStream
+}
+
+type walletServiceRescanServer struct {
+	grpc.ServerStream
+}
+
+func (x *walletServiceRescanServer) Send(m *RescanResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _WalletService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "walletrpc.WalletService",
 	HandlerType: (*WalletServiceServer)(nil),
@@ -2231,10 +3453,22 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Balance",
 			Handler:    _WalletService_Balance_Handler,
 		},
+		{
+			MethodName: "Balances",
+			Handler:    _WalletService_Balances_Handler,
+		},
 		{
 			MethodName: "GetTransactions",
 			Handler:    _WalletService_GetTransactions_Handler,
 		},
+		{
+			MethodName: "AddressLabels",
+			Handler:    _WalletService_AddressLabels_Handler,
+		},
+		{
+			MethodName: "AddressInfo",
+			Handler:    _WalletService_AddressInfo_Handler,
+		},
 		{
 			MethodName: "ChangePassphrase",
 			Handler:    _WalletService_ChangePassphrase_Handler,
@@ -2243,6 +3477,10 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RenameAccount",
 			Handler:    _WalletService_RenameAccount_Handler,
 		},
+		{
+			MethodName: "SetAddressLabel",
+			Handler:    _WalletService_SetAddressLabel_Handler,
+		},
 		{
 			MethodName: "NextAccount",
 			Handler:    _WalletService_NextAccount_Handler,
@@ -2255,6 +3493,10 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ImportPrivateKey",
 			Handler:    _WalletService_ImportPrivateKey_Handler,
 		},
+		{
+			MethodName: "ImportAccountPrivateKey",
+			Handler:    _WalletService_ImportAccountPrivateKey_Handler,
+		},
 		{
 			MethodName: "FundTransaction",
 			Handler:    _WalletService_FundTransaction_Handler,
@@ -2284,6 +3526,11 @@ var _WalletService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _WalletService_AccountNotifications_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Rescan",
+			Handler:       _WalletService_Rescan_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -2484,158 +3731,343 @@ var _WalletLoaderService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "api.proto",
 }
 
+// Client API for SignerService service
+
+type SignerServiceClient interface {
+	SignInput(ctx context.Context, in *SignInputRequest, opts ...grpc.CallOption) (*SignInputResponse, error)
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+}
+
+type signerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerServiceClient(cc *grpc.ClientConn) SignerServiceClient {
+	return &signerServiceClient{cc}
+}
+
+func (c *signerServiceClient) SignInput(ctx context.Context, in *SignInputRequest, opts ...grpc.CallOption) (*SignInputResponse, error) {
+	out := new(SignInputResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.SignerService/SignInput", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerServiceClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := grpc.Invoke(ctx, "/walletrpc.SignerService/SignMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SignerService service
+
+type SignerServiceServer interface {
+	SignInput(context.Context, *SignInputRequest) (*SignInputResponse, error)
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+}
+
+func RegisterSignerServiceServer(s *grpc.Server, srv SignerServiceServer) {
+	s.RegisterService(&_SignerService_serviceDesc, srv)
+}
+
+func _SignerService_SignInput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignInputRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignInput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.SignerService/SignInput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignInput(ctx, req.(*SignInputRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SignerService_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServiceServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/walletrpc.SignerService/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServiceServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SignerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "walletrpc.SignerService",
+	HandlerType: (*SignerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SignInput",
+			Handler:    _SignerService_SignInput_Handler,
+		},
+		{
+			MethodName: "SignMessage",
+			Handler:    _SignerService_SignMessage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api.proto",
+}
+
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xac, 0x59, 0x5b, 0x73, 0xdc, 0x48,
-	0x15, 0x66, 0x2c, 0xdf, 0x72, 0xe6, 0xde, 0xbe, 0x4d, 0x94, 0x38, 0xc9, 0x2a, 0xbb, 0xd9, 0xec,
-	0x2e, 0x98, 0x60, 0x02, 0x2c, 0xc5, 0x56, 0xd8, 0xc4, 0x64, 0xd9, 0x21, 0xc1, 0x99, 0x92, 0x93,
-	0x4d, 0xaa, 0xa0, 0x98, 0x92, 0x35, 0xed, 0x58, 0x78, 0x46, 0x9a, 0x48, 0x9a, 0x38, 0xe1, 0x89,
-	0xa2, 0x8a, 0x47, 0x5e, 0x80, 0x07, 0x0a, 0x6a, 0x5f, 0xf8, 0x05, 0x54, 0xf1, 0xc2, 0x23, 0xfc,
-	0x06, 0x1e, 0xf9, 0x17, 0xfc, 0x02, 0xfa, 0x72, 0x7a, 0xd4, 0x2d, 0x69, 0xc6, 0xf6, 0x16, 0x6f,
-	0xa3, 0xaf, 0xcf, 0x39, 0x7d, 0xfa, 0xf4, 0xb9, 0xf6, 0xc0, 0x25, 0x6f, 0x1c, 0xec, 0x8c, 0xe3,
-	0x28, 0x8d, 0xc8, 0xa5, 0x53, 0x6f, 0x38, 0xa4, 0x69, 0x3c, 0xf6, 0x9d, 0x16, 0x34, 0xbe, 0xa0,
-	0x71, 0x12, 0x44, 0xa1, 0x4b, 0x5f, 0x4d, 0x68, 0x92, 0x3a, 0xff, 0xaa, 0x40, 0x73, 0x0a, 0x25,
-	0xe3, 0x28, 0x4c, 0x28, 0x79, 0x0f, 0x1a, 0xaf, 0x25, 0xd4, 0x4f, 0xd2, 0x38, 0x08, 0x5f, 0x76,
-	0x2a, 0x37, 0x2a, 0xb7, 0x2f, 0xb9, 0x75, 0x44, 0x0f, 0x04, 0x48, 0xd6, 0x61, 0x69, 0xe4, 0xfd,
-	0x32, 0x8a, 0x3b, 0x0b, 0x6c, 0xb5, 0xee, 0xca, 0x0f, 0x81, 0x06, 0x21, 0x43, 0x2d, 0x44, 0xf9,
-	0x07, 0x47, 0xc7, 0x5e, 0xea, 0x1f, 0x77, 0x16, 0x25, 0x2a, 0x3e, 0xc8, 0x35, 0x80, 0x71, 0x4c,
-	0x63, 0x3a, 0xa4, 0x5e, 0x42, 0x3b, 0x4b, 0x62, 0x13, 0x0d, 0xe1, 0x8a, 0x1c, 0x4e, 0x82, 0xe1,
-	0xa0, 0x3f, 0xa2, 0xa9, 0x37, 0xf0, 0x52, 0xaf, 0xb3, 0x2c, 0x15, 0x11, 0xe8, 0x4f, 0x11, 0x74,
-	0xfe, 0x69, 0x01, 0x79, 0x1a, 0x7b, 0x61, 0xe2, 0xf9, 0x29, 0x53, 0xef, 0x47, 0x0c, 0x0f, 0x86,
-	0x09, 0x21, 0xb0, 0x78, 0xec, 0x25, 0xc7, 0x42, 0xf9, 0x9a, 0x2b, 0x7e, 0x93, 0x1b, 0x50, 0x4d,
-	0x33, 0x4a, 0xa1, 0x79, 0xcd, 0xd5, 0x21, 0xf2, 0x03, 0x58, 0x1e, 0xd0, 0xc3, 0x20, 0x4d, 0xd8,
-	0x01, 0xac, 0xdb, 0xd5, 0xdd, 0x9b, 0x3b, 0x53, 0xf3, 0xed, 0x14, 0x37, 0xd9, 0xe9, 0x86, 0xe3,
-	0x49, 0xea, 0x22, 0x0b, 0xb9, 0x07, 0x2b, 0x7e, 0x4c, 0x07, 0x9c, 0x7b, 0x51, 0x70, 0xbf, 0x3b,
-	0x9f, 0xfb, 0xc9, 0x24, 0xe5, 0xec, 0x8a, 0x89, 0xb4, 0xc0, 0x3a, 0xa2, 0xd2, 0x12, 0x96, 0xcb,
-	0x7f, 0x92, 0xab, 0x70, 0x29, 0x0d, 0x46, 0xec, 0xa6, 0xbc, 0xd1, 0x58, 0x9c, 0xde, 0x72, 0x33,
-	0xc0, 0x7e, 0x05, 0x4b, 0x42, 0x01, 0x6e, 0xdf, 0x20, 0x1c, 0xd0, 0x37, 0xe2, 0xb0, 0xcc, 0xbe,
-	0xe2, 0x83, 0x7c, 0x00, 0x2d, 0x66, 0xcd, 0xd7, 0x41, 0x34, 0x49, 0xfa, 0x9e, 0xef, 0x47, 0x93,
-	0x30, 0xc5, 0xcb, 0x6a, 0x2a, 0xfc, 0xbe, 0x84, 0xc9, 0xfb, 0xd0, 0xcc, 0x48, 0x47, 0x82, 0xd2,
-	0x12, 0xbb, 0x35, 0xa6, 0x94, 0x02, 0xb5, 0x9f, 0xc2, 0xb2, 0xd4, 0x7a, 0xc6, 0x9e, 0x1d, 0x58,
-	0x31, 0xb7, 0x52, 0x9f, 0xc4, 0x86, 0xd5, 0x20, 0x4c, 0x69, 0x1c, 0x7a, 0x43, 0x21, 0x7b, 0xd5,
-	0x9d, 0x7e, 0x3b, 0x7f, 0xa9, 0x40, 0xed, 0xc1, 0x30, 0xf2, 0x4f, 0xe6, 0x5d, 0xde, 0x26, 0x2c,
-	0x1f, 0xd3, 0xe0, 0xe5, 0xb1, 0x94, 0xbc, 0xe4, 0xe2, 0x97, 0x69, 0x23, 0x2b, 0x67, 0x23, 0x72,
-	0x1f, 0x6a, 0xda, 0xfd, 0xaa, 0x8b, 0xd9, 0x9e, 0x7b, 0x31, 0xae, 0xc1, 0xe2, 0x3c, 0x81, 0x06,
-	0xda, 0xe9, 0x81, 0x37, 0xf4, 0x42, 0x9f, 0xea, 0xa7, 0xac, 0x98, 0xa7, 0xbc, 0x09, 0xf5, 0x34,
-	0x4a, 0xbd, 0x61, 0xff, 0x50, 0x92, 0x0a, 0x5d, 0x2d, 0x26, 0x90, 0x83, 0xc8, 0xee, 0xd4, 0xa1,
-	0xda, 0x63, 0x21, 0xa4, 0x82, 0xb0, 0x01, 0x35, 0xf9, 0x29, 0x03, 0x90, 0x87, 0xe9, 0x3e, 0x4d,
-	0x4f, 0xa3, 0xf8, 0x44, 0x51, 0x7c, 0x0c, 0xcd, 0x29, 0x92, 0x45, 0x29, 0xd7, 0xef, 0x35, 0xed,
-	0x87, 0x72, 0x05, 0x35, 0xa9, 0x4b, 0x14, 0xc9, 0x9d, 0xef, 0xc3, 0x3a, 0xea, 0xbe, 0x3f, 0x19,
-	0x1d, 0xd2, 0x18, 0x25, 0x92, 0x77, 0xa0, 0x86, 0x2a, 0xf7, 0x43, 0x6f, 0x44, 0x31, 0xc4, 0xab,
-	0x88, 0xed, 0x33, 0xc8, 0xb9, 0x07, 0x1b, 0x39, 0x56, 0x7d, 0x6b, 0xe4, 0x15, 0x2b, 0xd9, 0xd6,
-	0x1a, 0xb9, 0xd3, 0x86, 0x26, 0xf2, 0x27, 0xea, 0x1c, 0xff, 0xb0, 0xa0, 0x95, 0x61, 0x28, 0xee,
-	0x87, 0xb0, 0x8a, 0x8c, 0x09, 0x13, 0x94, 0x0f, 0xba, 0x3c, 0xb9, 0x02, 0xdc, 0x29, 0x13, 0xf9,
-	0x3a, 0x10, 0x7f, 0x12, 0xc7, 0x94, 0xe9, 0x73, 0xc8, 0x9d, 0xa8, 0x2f, 0x5c, 0x47, 0x06, 0x77,
-	0x0b, 0x57, 0x84, 0x77, 0x7d, 0xce, 0xdd, 0xe8, 0x0e, 0xac, 0xe7, 0xa8, 0xa5, 0x53, 0x59, 0xc2,
-	0xa9, 0x88, 0x41, 0x2f, 0x56, 0xec, 0xdf, 0x2c, 0xc0, 0x8a, 0x0a, 0x94, 0xf3, 0x9d, 0xbd, 0x60,
-	0xde, 0x85, 0x82, 0x79, 0x8b, 0x9e, 0x62, 0x15, 0x3d, 0x85, 0x1f, 0x8d, 0xbe, 0x91, 0x41, 0xd2,
-	0x3f, 0xa1, 0x6f, 0xfb, 0xd2, 0xe7, 0x64, 0x16, 0x6d, 0xa9, 0x95, 0x47, 0xf4, 0xed, 0x9e, 0x50,
-	0x8e, 0x51, 0xab, 0x90, 0xd2, 0xa8, 0x97, 0x24, 0xb5, 0x5a, 0x31, 0xa8, 0x47, 0xe3, 0x28, 0x4e,
-	0xe9, 0x40, 0xa3, 0x5e, 0x46, 0x6a, 0x5c, 0x51, 0xd4, 0xce, 0x0b, 0x58, 0x77, 0x29, 0x3f, 0x8b,
-	0xb2, 0x3f, 0x3a, 0xd2, 0x39, 0x0d, 0x72, 0x19, 0x56, 0x43, 0x7a, 0xaa, 0x1b, 0x63, 0x85, 0x7d,
-	0x0b, 0x3f, 0xdb, 0x82, 0x8d, 0x9c, 0x64, 0x8c, 0x83, 0xe7, 0x40, 0xf6, 0xd9, 0x19, 0x73, 0x1b,
-	0xf2, 0xaa, 0xe1, 0x25, 0xc9, 0xf8, 0x38, 0xe6, 0x55, 0x43, 0x26, 0x08, 0x0d, 0x39, 0x87, 0xe9,
-	0x9d, 0x4f, 0x60, 0xcd, 0x10, 0x7c, 0x31, 0xbf, 0xfe, 0x73, 0x05, 0xf5, 0x1a, 0x0c, 0x62, 0x9a,
-	0x28, 0xdf, 0x9e, 0x93, 0x13, 0xbe, 0x0b, 0x8b, 0x27, 0x2c, 0x3b, 0x0a, 0x4d, 0x1a, 0xbb, 0x8e,
-	0xe6, 0xdc, 0x45, 0x31, 0x3b, 0x8f, 0x18, 0xa5, 0x2b, 0xe8, 0x9d, 0x5d, 0x58, 0xe4, 0x5f, 0x2c,
-	0xd3, 0xb6, 0x1e, 0x74, 0x7b, 0x77, 0xee, 0xdc, 0xbd, 0xdb, 0x7f, 0xf8, 0xe2, 0xe9, 0x43, 0x77,
-	0xff, 0xfe, 0xe3, 0xd6, 0xd7, 0x74, 0xb4, 0xbb, 0x8f, 0x68, 0xc5, 0xf9, 0x26, 0x1e, 0x4d, 0x09,
-	0xc5, 0xa3, 0x71, 0xe5, 0x24, 0x84, 0x91, 0xae, 0x3e, 0x9d, 0x3f, 0x54, 0x60, 0xab, 0x2b, 0x2e,
-	0xbb, 0x17, 0x07, 0xaf, 0xbd, 0x94, 0xb2, 0x1b, 0x3f, 0xaf, 0xa9, 0x67, 0x27, 0xfb, 0x5b, 0xbc,
-	0x9e, 0x08, 0x71, 0xc2, 0xb5, 0x4e, 0x83, 0x23, 0xe1, 0xde, 0xac, 0x76, 0x8f, 0xa7, 0xbb, 0x3c,
-	0x0f, 0x8e, 0x78, 0x4e, 0x67, 0x5a, 0xf8, 0x5e, 0x28, 0x7c, 0x7a, 0xd5, 0xc5, 0x2f, 0xc7, 0x86,
-	0x4e, 0x51, 0x29, 0x74, 0x8b, 0x10, 0x1a, 0x18, 0x1e, 0x17, 0xf4, 0xc1, 0xef, 0xc0, 0x66, 0xcc,
-	0x38, 0x02, 0x56, 0x6d, 0x99, 0xb3, 0x87, 0x47, 0x41, 0x3c, 0xf2, 0x64, 0x51, 0x90, 0x05, 0x65,
-	0x43, 0xad, 0xee, 0xe9, 0x8b, 0x6c, 0xbf, 0xe6, 0x74, 0x3f, 0x34, 0x27, 0xab, 0x7d, 0x22, 0x4c,
-	0xc5, 0x3e, 0x96, 0x2b, 0x3f, 0x78, 0x21, 0x4a, 0xc6, 0x34, 0x1c, 0x78, 0x87, 0x43, 0x95, 0xf7,
-	0x33, 0x80, 0x97, 0xd8, 0x60, 0xc4, 0x64, 0x4e, 0x62, 0xda, 0x8f, 0xe9, 0xa9, 0x17, 0x0f, 0x54,
-	0x89, 0x55, 0xb0, 0x2b, 0x50, 0xe7, 0x4f, 0x0b, 0xb0, 0xf9, 0x63, 0x9a, 0x6a, 0x65, 0x69, 0xea,
-	0x63, 0x3b, 0xb0, 0xc6, 0xaa, 0x5a, 0x9c, 0xb2, 0x6a, 0xa1, 0xa7, 0x3a, 0x79, 0x33, 0x6d, 0xb5,
-	0x94, 0xe5, 0xba, 0x5d, 0xd8, 0xc8, 0xd3, 0x67, 0x15, 0xb4, 0xed, 0xae, 0x99, 0x1c, 0xb2, 0x9c,
-	0x7e, 0x08, 0x6d, 0xa6, 0x72, 0x6e, 0x07, 0x4b, 0xec, 0xd0, 0x94, 0x0b, 0x99, 0x7c, 0xa6, 0x8f,
-	0x49, 0x2b, 0xa5, 0x2f, 0x0a, 0x73, 0xb6, 0x75, 0x6a, 0x29, 0xfb, 0x1e, 0x5c, 0x61, 0x0d, 0x61,
-	0x30, 0x9a, 0x8c, 0x98, 0x09, 0x7c, 0x9e, 0x82, 0x8d, 0xda, 0xbc, 0x24, 0xf8, 0x2e, 0x23, 0x89,
-	0x2b, 0x28, 0x74, 0x33, 0x38, 0x7f, 0x67, 0xce, 0x5a, 0x30, 0x0d, 0xde, 0xc9, 0x67, 0x40, 0x18,
-	0x23, 0xbb, 0x5a, 0x43, 0xa4, 0x2c, 0x28, 0x5b, 0x5a, 0xcc, 0xe9, 0x7d, 0x86, 0xdb, 0x16, 0x2c,
-	0xba, 0x3c, 0xd2, 0x83, 0xf5, 0x49, 0x58, 0x22, 0x69, 0xe1, 0x3c, 0x8d, 0xc3, 0x1a, 0xb2, 0x1a,
-	0x5a, 0xb3, 0x26, 0x7b, 0x6b, 0xef, 0xd8, 0x0b, 0x5f, 0xd2, 0xde, 0x34, 0x76, 0xd4, 0x8d, 0x7e,
-	0x0c, 0x16, 0x0b, 0x10, 0x71, 0x83, 0x8d, 0xdd, 0x5b, 0x9a, 0xf0, 0x19, 0x0c, 0x3b, 0x3c, 0x12,
-	0x38, 0x0b, 0x77, 0xfa, 0x88, 0xf5, 0xc6, 0x5a, 0x80, 0xca, 0x8a, 0x57, 0x67, 0x68, 0xc6, 0xc6,
-	0xc9, 0x78, 0xe2, 0xd5, 0xc8, 0xe4, 0x5d, 0xd6, 0x19, 0x9a, 0x91, 0x39, 0xd7, 0xc0, 0x62, 0x92,
-	0x49, 0x15, 0x56, 0x7a, 0x6e, 0xf7, 0x8b, 0xfb, 0x4f, 0x1f, 0xb2, 0x0c, 0x03, 0xb0, 0xdc, 0x7b,
-	0xf6, 0xe0, 0x71, 0x77, 0x8f, 0xe5, 0x15, 0x16, 0x90, 0x45, 0x8d, 0x30, 0x20, 0x7f, 0xcd, 0x1c,
-	0xf6, 0xb3, 0x49, 0xa8, 0x1f, 0xfa, 0xec, 0xa4, 0xc8, 0xcb, 0x9f, 0x17, 0xbf, 0xa4, 0xa9, 0xea,
-	0x37, 0x55, 0xa3, 0x24, 0x40, 0xd9, 0x6d, 0xce, 0x89, 0x58, 0x6b, 0x4e, 0xc4, 0x92, 0x4f, 0xc0,
-	0x0e, 0x42, 0x7f, 0x38, 0x19, 0xd0, 0xfe, 0x34, 0xe4, 0xfc, 0x28, 0x08, 0x0f, 0x99, 0xd6, 0x09,
-	0x66, 0x9a, 0x0e, 0x52, 0x74, 0x91, 0x60, 0x4f, 0xad, 0xf3, 0xa0, 0x51, 0xdc, 0xbe, 0x38, 0x72,
-	0x3f, 0xf1, 0xe3, 0x60, 0x2c, 0x0b, 0xe9, 0xaa, 0xbb, 0x86, 0x8b, 0xd2, 0x1c, 0x07, 0x62, 0xc9,
-	0xf9, 0xab, 0x05, 0x5b, 0x05, 0x13, 0xa0, 0x63, 0xfe, 0x1c, 0x5a, 0x09, 0x9b, 0x68, 0x7c, 0x5e,
-	0x67, 0x23, 0xd1, 0x3b, 0x2b, 0xb7, 0xfc, 0x96, 0x76, 0xdf, 0x33, 0xb8, 0x77, 0x7a, 0xd8, 0x7f,
-	0xe3, 0xac, 0xd0, 0x54, 0xa2, 0xe4, 0x77, 0xc2, 0xcb, 0x9d, 0x6c, 0x23, 0x0c, 0x33, 0x56, 0x05,
-	0x86, 0x56, 0xbc, 0x0d, 0x2d, 0x3c, 0xc8, 0xf8, 0x44, 0x9d, 0x45, 0x3a, 0x41, 0x43, 0xe2, 0xbd,
-	0x13, 0x79, 0x0c, 0xfb, 0x3f, 0x15, 0x68, 0x98, 0x1b, 0xf2, 0x21, 0x42, 0x0b, 0x03, 0x3d, 0xdf,
-	0x34, 0x35, 0x5c, 0x64, 0x03, 0xa6, 0x8a, 0x3c, 0x5f, 0x5f, 0x0e, 0x06, 0xb2, 0x26, 0x54, 0x25,
-	0xd6, 0x15, 0xe3, 0x01, 0xcb, 0xf7, 0xc6, 0x78, 0x81, 0x5f, 0xe4, 0x0a, 0x5c, 0xca, 0x74, 0x5b,
-	0x14, 0xe2, 0x57, 0xc7, 0xa8, 0x15, 0x97, 0xcb, 0xb3, 0x05, 0xef, 0x75, 0x79, 0x5f, 0x8f, 0xf3,
-	0x51, 0x15, 0xb1, 0xa7, 0x81, 0x6c, 0xa6, 0x8e, 0xe2, 0x68, 0x34, 0xbd, 0x65, 0xd1, 0xc6, 0xac,
-	0xba, 0x35, 0x0e, 0xaa, 0x9b, 0x75, 0xfe, 0x58, 0x81, 0xcd, 0x83, 0xe0, 0x65, 0x58, 0xe2, 0xa7,
-	0x67, 0x55, 0x3a, 0xe6, 0x88, 0x09, 0x8d, 0x03, 0x6f, 0x18, 0xfc, 0xca, 0xcc, 0x0b, 0x18, 0x74,
-	0x1b, 0xd9, 0xaa, 0x26, 0x9d, 0xab, 0x15, 0x84, 0x53, 0x83, 0x50, 0x39, 0x54, 0xd6, 0xdd, 0x9a,
-	0x00, 0xbb, 0x12, 0x73, 0x5e, 0xc1, 0x56, 0x41, 0x2b, 0x74, 0x9d, 0xdc, 0xbc, 0x5a, 0x29, 0xce,
-	0xab, 0x77, 0x61, 0x73, 0x12, 0x26, 0x8c, 0x9d, 0xa9, 0x65, 0x6e, 0xb5, 0x20, 0xb6, 0x5a, 0x57,
-	0xab, 0x5d, 0x7d, 0xcb, 0x9f, 0xc0, 0xe5, 0xde, 0xe4, 0x70, 0x18, 0x24, 0xc7, 0x25, 0xb6, 0xf8,
-	0x06, 0x10, 0x14, 0x58, 0xdc, 0xbb, 0x2d, 0x57, 0x34, 0x2e, 0xe7, 0x2a, 0xd8, 0x65, 0xb2, 0x30,
-	0x37, 0xbc, 0x03, 0xd7, 0x35, 0x78, 0x3f, 0x4a, 0x83, 0xa3, 0xc0, 0xf7, 0xf4, 0xa2, 0xe6, 0x7c,
-	0xb9, 0x00, 0x37, 0x66, 0xd3, 0xa0, 0x25, 0x3e, 0x85, 0xa6, 0x97, 0xa6, 0x9e, 0x7f, 0xcc, 0xd4,
-	0x12, 0xb5, 0xe6, 0xcc, 0xd4, 0xde, 0x50, 0xf4, 0x02, 0x4d, 0x78, 0xfd, 0x1d, 0x50, 0x53, 0x02,
-	0x37, 0x11, 0x0b, 0x02, 0x05, 0x23, 0xe1, 0xac, 0x02, 0x60, 0x7d, 0xd5, 0x02, 0xc0, 0xf3, 0x51,
-	0x89, 0x44, 0x11, 0x4b, 0x54, 0x4e, 0xa4, 0x35, 0xb7, 0x53, 0x64, 0xfc, 0x5c, 0xac, 0x3b, 0xbf,
-	0xab, 0xc0, 0xf6, 0x01, 0x6b, 0x23, 0xd2, 0x90, 0xf5, 0x6b, 0x65, 0x16, 0x9c, 0x93, 0x65, 0x59,
-	0x31, 0x0f, 0xa3, 0x7e, 0xc8, 0x99, 0xde, 0xf6, 0x99, 0x2b, 0x70, 0x31, 0xc2, 0x65, 0x57, 0xdd,
-	0x66, 0x18, 0x09, 0x61, 0x6f, 0x9f, 0x49, 0x98, 0xf7, 0x6c, 0x19, 0xad, 0xa4, 0x94, 0x73, 0x7a,
-	0x5d, 0x51, 0x0a, 0x2d, 0x9c, 0xdf, 0x2f, 0xc0, 0xb5, 0x59, 0xfa, 0xe0, 0x6d, 0xfd, 0x7f, 0x93,
-	0xc6, 0x23, 0x58, 0x11, 0x6d, 0x14, 0x95, 0xaf, 0x4a, 0x66, 0xde, 0x9c, 0xaf, 0x89, 0x58, 0x66,
-	0x8c, 0xae, 0x92, 0x60, 0x3f, 0x83, 0x15, 0xc4, 0x2e, 0xa2, 0xe5, 0x75, 0xa8, 0x6a, 0xd1, 0x85,
-	0x4a, 0x42, 0x16, 0xc6, 0xce, 0x36, 0x5c, 0x51, 0xc3, 0x72, 0x99, 0x8f, 0xff, 0xb7, 0x02, 0x57,
-	0xcb, 0xd7, 0x2f, 0x34, 0x7b, 0x9c, 0x67, 0xae, 0x2c, 0x1f, 0x19, 0xad, 0x0b, 0x8d, 0x8c, 0x8b,
-	0x17, 0x1a, 0x19, 0x97, 0x66, 0x8c, 0x8c, 0xbf, 0xad, 0xc0, 0xda, 0x5e, 0x4c, 0x59, 0xfb, 0xfe,
-	0x5c, 0x5c, 0x97, 0x72, 0xd7, 0x8f, 0xa0, 0x3d, 0xe6, 0x19, 0xc3, 0xef, 0x17, 0x72, 0x6e, 0x4b,
-	0x2e, 0x68, 0xfd, 0x0b, 0xcb, 0x46, 0x6a, 0x92, 0x28, 0xb4, 0x3a, 0x6d, 0x5c, 0xd1, 0xc8, 0x09,
-	0x2c, 0x26, 0x94, 0x0e, 0xb0, 0xbe, 0x89, 0xdf, 0xce, 0x26, 0xac, 0x9b, 0x6a, 0x60, 0x6e, 0xfa,
-	0x14, 0xda, 0x4f, 0x98, 0x2b, 0x7c, 0x75, 0xe5, 0x9c, 0x75, 0x20, 0xba, 0x04, 0x94, 0xcb, 0xd0,
-	0xbd, 0x61, 0x94, 0x98, 0xa7, 0x76, 0x36, 0x98, 0x31, 0x74, 0x14, 0x89, 0x19, 0x2c, 0x91, 0x87,
-	0x6f, 0x82, 0x24, 0x7b, 0x29, 0xd9, 0x81, 0x75, 0x13, 0x46, 0x3f, 0x61, 0x05, 0x94, 0x0a, 0x44,
-	0xe8, 0xc4, 0x06, 0x26, 0xf9, 0xe5, 0x7c, 0x59, 0x81, 0xce, 0x01, 0xef, 0xe6, 0xf7, 0x38, 0x59,
-	0x98, 0x4c, 0x12, 0x77, 0xec, 0xab, 0x33, 0xb1, 0xd4, 0x87, 0x8f, 0x44, 0x7d, 0x73, 0x0a, 0x6c,
-	0x20, 0x8c, 0xe3, 0x22, 0x7f, 0xa3, 0x9b, 0x24, 0xfc, 0xca, 0xa7, 0xae, 0x35, 0xfd, 0xe6, 0x6b,
-	0xdc, 0x22, 0x8c, 0x5c, 0x59, 0x77, 0xfa, 0xcd, 0xeb, 0x94, 0x4f, 0x63, 0xf4, 0x6b, 0x8a, 0x05,
-	0x5c, 0x87, 0x9c, 0x2b, 0x70, 0xb9, 0x44, 0x3d, 0x79, 0xa8, 0x5d, 0x77, 0xfa, 0x2e, 0x7d, 0x40,
-	0xe3, 0xd7, 0x81, 0xcf, 0xd3, 0xfd, 0x0a, 0x22, 0xe4, 0xb2, 0x16, 0xec, 0xe6, 0xeb, 0xb5, 0x6d,
-	0x97, 0x2d, 0xa1, 0xcc, 0x7f, 0x57, 0xa1, 0x2e, 0x2d, 0xa8, 0x64, 0x7e, 0x0f, 0x16, 0xf9, 0x33,
-	0x1b, 0xd9, 0xd4, 0xb8, 0xb4, 0x67, 0x38, 0x7b, 0xab, 0x80, 0x4f, 0x6b, 0xcf, 0x0a, 0x3e, 0xa7,
-	0x19, 0xca, 0x98, 0x6f, 0x74, 0x86, 0x32, 0xf9, 0xc7, 0x3a, 0x17, 0xea, 0xc6, 0x53, 0x1a, 0xb9,
-	0x5e, 0x7c, 0xe1, 0x32, 0xde, 0xe7, 0xec, 0x1b, 0xb3, 0x09, 0x50, 0xe6, 0x1e, 0xac, 0xaa, 0xb7,
-	0x31, 0x62, 0x97, 0x3e, 0x98, 0x49, 0x49, 0x57, 0xe6, 0x3c, 0xa6, 0xf1, 0xa3, 0xa9, 0xa7, 0x26,
-	0xfd, 0x68, 0xe6, 0x7c, 0x6d, 0x1c, 0x2d, 0x3f, 0x0a, 0xbf, 0x80, 0x66, 0x6e, 0x22, 0x23, 0xef,
-	0x68, 0xe4, 0xe5, 0x83, 0xac, 0xed, 0xcc, 0x23, 0x41, 0xc9, 0x13, 0xe8, 0xcc, 0x6a, 0x0b, 0xc8,
-	0x87, 0xe5, 0x55, 0xb8, 0x2c, 0xf7, 0xda, 0x1f, 0x9d, 0x8b, 0x56, 0x6e, 0x7a, 0xa7, 0x42, 0x22,
-	0xd6, 0x24, 0x96, 0xd6, 0x14, 0x72, 0xfb, 0x1c, 0x65, 0x47, 0x6e, 0xf9, 0xc1, 0xb9, 0x0b, 0x14,
-	0xdb, 0x30, 0xc8, 0x9e, 0x68, 0x8d, 0xed, 0x6e, 0x95, 0xb8, 0x40, 0xd9, 0x66, 0xef, 0x9f, 0x49,
-	0x37, 0xdd, 0xea, 0x67, 0xd0, 0xca, 0x4f, 0x71, 0xc4, 0x39, 0x7b, 0xe8, 0xb4, 0x6f, 0xce, 0xa5,
-	0xc9, 0x9c, 0xdc, 0x78, 0xc7, 0x33, 0x9c, 0xbc, 0xec, 0xed, 0xd0, 0x70, 0xf2, 0xd2, 0x27, 0x40,
-	0xf2, 0x18, 0xaa, 0xda, 0x4b, 0x1d, 0xd9, 0xce, 0xbf, 0x9d, 0x99, 0xf2, 0xae, 0xcd, 0x5a, 0xce,
-	0x49, 0xc3, 0x6c, 0xb7, 0x3d, 0xf7, 0x25, 0xae, 0x28, 0x2d, 0xf7, 0xa6, 0xc6, 0x8c, 0x99, 0x7f,
-	0xa3, 0x32, 0x8c, 0x39, 0xe3, 0x55, 0xcd, 0x30, 0xe6, 0xac, 0x47, 0x2e, 0x1e, 0x56, 0xb9, 0x89,
-	0xd0, 0x08, 0xab, 0xf2, 0x71, 0xdb, 0x08, 0xab, 0x59, 0xe3, 0x28, 0x93, 0x9c, 0x1b, 0x37, 0x0c,
-	0xc9, 0xe5, 0x03, 0x92, 0x21, 0x79, 0xd6, 0xb4, 0xe2, 0x01, 0x29, 0x4e, 0x02, 0x44, 0xff, 0x0f,
-	0x6c, 0xe6, 0xd0, 0x61, 0xbf, 0x77, 0x06, 0x15, 0x66, 0xf5, 0xbf, 0x59, 0xaa, 0x5c, 0x3e, 0x8e,
-	0x3c, 0xd6, 0xc3, 0xa9, 0xdc, 0xfe, 0x04, 0x6a, 0x7a, 0xb9, 0x24, 0xfa, 0xdd, 0x95, 0x94, 0x57,
-	0xfb, 0xfa, 0xcc, 0x75, 0x3c, 0x0b, 0x13, 0xa8, 0xf7, 0x0c, 0x86, 0xc0, 0x92, 0x9e, 0xc6, 0x10,
-	0x58, 0xd6, 0x6c, 0x90, 0x2e, 0x40, 0xd6, 0x2a, 0x90, 0xab, 0x1a, 0x79, 0xa1, 0x07, 0xb1, 0xb7,
-	0x67, 0xac, 0x66, 0x6e, 0xac, 0x75, 0x12, 0x86, 0x1b, 0x17, 0xfb, 0x0e, 0xc3, 0x8d, 0x4b, 0x1a,
-	0x10, 0xf2, 0x0b, 0x68, 0x17, 0x2a, 0x33, 0xd1, 0x7d, 0x74, 0x56, 0x5b, 0x61, 0xbf, 0x3b, 0x9f,
-	0x48, 0xca, 0x3f, 0x5c, 0x16, 0x7f, 0x43, 0x7f, 0xfb, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x22,
-	0xfc, 0x70, 0xb3, 0x93, 0x1e, 0x00, 0x00,
+	// 3798 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x3b, 0x4d, 0x73, 0x1c, 0xc9,
+	0x52, 0x3b, 0x9a, 0x19, 0x69, 0x94, 0xf3, 0x5d, 0xfa, 0x1a, 0xb5, 0x2d, 0x59, 0xdb, 0x7e, 0xbb,
+	0xeb, 0xb5, 0x77, 0x85, 0x57, 0x6f, 0x97, 0xb7, 0x0f, 0x36, 0x96, 0x67, 0xcb, 0xf2, 0xb3, 0xb0,
+	0x2d, 0x89, 0x96, 0xbc, 0x36, 0x3c, 0x82, 0xa6, 0x67, 0xa6, 0x24, 0x35, 0x9a, 0xa9, 0x1e, 0xf7,
+	0x87, 0x25, 0x2d, 0x17, 0x2e, 0x1c, 0xb9, 0xf0, 0x38, 0x41, 0x10, 0x44, 0x10, 0xc1, 0x85, 0x08,
+	0x22, 0xde, 0x85, 0x0b, 0xc1, 0x85, 0xd3, 0xfe, 0x01, 0x2e, 0x04, 0x11, 0xfc, 0x00, 0x8e, 0x1c,
+	0x39, 0x10, 0x44, 0x55, 0x65, 0x75, 0x57, 0x4f, 0xf7, 0x8c, 0x24, 0xc3, 0x6d, 0x2a, 0x33, 0x2b,
+	0x2b, 0x2b, 0x2b, 0x2b, 0xbf, 0xba, 0x06, 0xe6, 0x9d, 0x91, 0xbb, 0x39, 0xf2, 0xbd, 0xd0, 0x23,
+	0xf3, 0xe7, 0xce, 0x60, 0x40, 0x43, 0x7f, 0xd4, 0x33, 0x5b, 0xd0, 0xf8, 0x8e, 0xfa, 0x81, 0xeb,
+	0x31, 0x8b, 0xbe, 0x8d, 0x68, 0x10, 0x9a, 0xff, 0x52, 0x80, 0x66, 0x0c, 0x0a, 0x46, 0x1e, 0x0b,
+	0x28, 0xf9, 0x08, 0x1a, 0xef, 0x24, 0xc8, 0x0e, 0x42, 0xdf, 0x65, 0x27, 0x9d, 0xc2, 0x46, 0xe1,
+	0xde, 0xbc, 0x55, 0x47, 0xe8, 0xa1, 0x00, 0x92, 0x45, 0x28, 0x0f, 0x9d, 0x3f, 0xf2, 0xfc, 0xce,
+	0xcc, 0x46, 0xe1, 0x5e, 0xdd, 0x92, 0x03, 0x01, 0x75, 0x99, 0xe7, 0x77, 0x8a, 0x08, 0x75, 0x99,
+	0x84, 0x8e, 0x9c, 0xb0, 0x77, 0xda, 0x29, 0x49, 0xa8, 0x18, 0x90, 0x75, 0x80, 0x91, 0x4f, 0x7d,
+	0x3a, 0xa0, 0x4e, 0x40, 0x3b, 0x65, 0xb1, 0x88, 0x06, 0xe1, 0x82, 0x74, 0x23, 0x77, 0xd0, 0xb7,
+	0x87, 0x34, 0x74, 0xfa, 0x4e, 0xe8, 0x74, 0x66, 0xa5, 0x20, 0x02, 0xfa, 0x12, 0x81, 0xe6, 0x7f,
+	0x14, 0x81, 0x1c, 0xf9, 0x0e, 0x0b, 0x9c, 0x5e, 0xe8, 0x7a, 0xec, 0x09, 0x0d, 0x1d, 0x77, 0x10,
+	0x10, 0x02, 0xa5, 0x53, 0x27, 0x38, 0x15, 0xc2, 0xd7, 0x2c, 0xf1, 0x9b, 0x6c, 0x40, 0x35, 0x4c,
+	0x28, 0x85, 0xe4, 0x35, 0x4b, 0x07, 0x91, 0xdf, 0x84, 0xd9, 0x3e, 0xed, 0xba, 0x61, 0xd0, 0x29,
+	0x6e, 0x14, 0xef, 0x55, 0xb7, 0xee, 0x6e, 0xc6, 0xea, 0xdb, 0xcc, 0x2e, 0xb2, 0xb9, 0xcb, 0x46,
+	0x51, 0x68, 0xe1, 0x14, 0xf2, 0x2d, 0xcc, 0xf5, 0x7c, 0xda, 0xe7, 0xb3, 0x4b, 0x62, 0xf6, 0x8f,
+	0xa6, 0xcf, 0xde, 0x8f, 0x42, 0x3e, 0x5d, 0x4d, 0x22, 0x2d, 0x28, 0x1e, 0x53, 0xa9, 0x89, 0xa2,
+	0xc5, 0x7f, 0x92, 0xdb, 0x30, 0x1f, 0xba, 0x43, 0x1a, 0x84, 0xce, 0x70, 0x24, 0x76, 0x5f, 0xb4,
+	0x12, 0x00, 0xc7, 0x3a, 0x5d, 0x87, 0xf5, 0x3d, 0x46, 0xfb, 0x9d, 0xb9, 0x8d, 0xc2, 0xbd, 0x8a,
+	0x95, 0x00, 0x8c, 0xb7, 0x50, 0x16, 0xe2, 0x71, 0xed, 0xbb, 0xac, 0x4f, 0x2f, 0x84, 0x2a, 0xea,
+	0x96, 0x1c, 0x90, 0x4f, 0xa1, 0x35, 0xf2, 0xe9, 0x3b, 0xd7, 0x8b, 0x02, 0xdb, 0xe9, 0xf5, 0xbc,
+	0x88, 0x85, 0x78, 0x94, 0x4d, 0x05, 0x7f, 0x24, 0xc1, 0xe4, 0x13, 0x68, 0x26, 0xa4, 0x43, 0x41,
+	0x59, 0x14, 0xb2, 0x34, 0x62, 0x4a, 0x01, 0x35, 0x8e, 0x60, 0x56, 0xee, 0x69, 0xc2, 0x9a, 0x1d,
+	0x98, 0x4b, 0x2f, 0xa5, 0x86, 0xc4, 0x80, 0x8a, 0xcb, 0x42, 0xea, 0x33, 0x67, 0x20, 0x78, 0x57,
+	0xac, 0x78, 0x6c, 0xfe, 0x55, 0x01, 0x6a, 0x8f, 0x07, 0x5e, 0xef, 0x6c, 0xda, 0xd1, 0x2e, 0xc3,
+	0xec, 0x29, 0x75, 0x4f, 0x4e, 0x25, 0xe7, 0xb2, 0x85, 0xa3, 0xb4, 0x06, 0x8b, 0xe3, 0x1a, 0x7c,
+	0x04, 0x35, 0xed, 0xf4, 0xd5, 0xb1, 0xad, 0x4d, 0x3d, 0x36, 0x2b, 0x35, 0xc5, 0xdc, 0x87, 0x06,
+	0xea, 0xe9, 0xb1, 0x33, 0x70, 0x58, 0x8f, 0xea, 0xbb, 0x2c, 0xa4, 0x77, 0x79, 0x17, 0xea, 0xa1,
+	0x17, 0x3a, 0x03, 0xbb, 0x2b, 0x49, 0x85, 0xac, 0x45, 0xab, 0x26, 0x80, 0x38, 0xdd, 0xac, 0x43,
+	0xf5, 0xc0, 0x65, 0x27, 0xea, 0x8a, 0x36, 0xa0, 0x26, 0x87, 0xf2, 0x7a, 0xf2, 0x4b, 0xbc, 0x47,
+	0xc3, 0x73, 0xcf, 0x3f, 0x53, 0x14, 0x5f, 0x43, 0x33, 0x86, 0x24, 0x77, 0x98, 0xcb, 0xf7, 0x8e,
+	0xda, 0x4c, 0x62, 0x50, 0x92, 0xba, 0x84, 0x22, 0xb9, 0xf9, 0x53, 0x58, 0x44, 0xd9, 0xf7, 0xa2,
+	0x61, 0x97, 0xfa, 0xc8, 0x91, 0x7c, 0x08, 0x35, 0x14, 0xd9, 0x66, 0xce, 0x90, 0xa2, 0x03, 0xa8,
+	0x22, 0x6c, 0xcf, 0x19, 0x52, 0xf3, 0x5b, 0x58, 0x1a, 0x9b, 0xaa, 0x2f, 0x8d, 0x73, 0x05, 0x26,
+	0x59, 0x5a, 0x23, 0x37, 0x9f, 0x41, 0x13, 0xe7, 0x07, 0x6a, 0xd5, 0x0e, 0xcc, 0x8d, 0x22, 0x7f,
+	0xe4, 0x05, 0x54, 0xe9, 0x0d, 0x87, 0xe4, 0x16, 0xcc, 0xf7, 0x3c, 0x97, 0xd9, 0xe1, 0xe5, 0x88,
+	0xa2, 0xe5, 0x54, 0x38, 0xe0, 0xe8, 0x72, 0x44, 0xcd, 0x5f, 0x95, 0xa0, 0x95, 0xb0, 0x42, 0x29,
+	0x7e, 0x0b, 0x2a, 0xb8, 0x5e, 0xd0, 0x29, 0x64, 0x6e, 0xf2, 0x38, 0xb9, 0x02, 0x58, 0xf1, 0x24,
+	0xf2, 0x19, 0x90, 0x5e, 0xe4, 0xfb, 0x94, 0x85, 0x76, 0x97, 0xdb, 0x9e, 0x2d, 0x2c, 0x4e, 0x7a,
+	0x8c, 0x16, 0x62, 0x84, 0x51, 0x3e, 0xe3, 0xd6, 0xf7, 0x10, 0x16, 0xc7, 0xa8, 0xa5, 0x2d, 0x16,
+	0x85, 0x2d, 0x92, 0x14, 0xbd, 0xc0, 0x18, 0xff, 0x3d, 0x03, 0x73, 0xea, 0x7e, 0x5d, 0x4f, 0x65,
+	0x99, 0x53, 0x99, 0xc9, 0x9c, 0x4a, 0xd6, 0xc0, 0x8a, 0x59, 0x03, 0xe3, 0x5b, 0xa3, 0x17, 0xf2,
+	0x6e, 0xd9, 0x67, 0xf4, 0xd2, 0x96, 0xa6, 0x2a, 0x5d, 0x73, 0x4b, 0x61, 0x9e, 0xd3, 0xcb, 0x6d,
+	0x21, 0xdc, 0x67, 0x40, 0x5c, 0x96, 0xa1, 0x2e, 0x4b, 0x6a, 0x97, 0xe5, 0x50, 0x0f, 0x47, 0x9e,
+	0x1f, 0xd2, 0xbe, 0x46, 0x3d, 0x8b, 0xd4, 0x88, 0x89, 0xa9, 0xb5, 0x1d, 0x5d, 0x8c, 0xa2, 0x6e,
+	0x67, 0x2e, 0xb5, 0xa3, 0x37, 0xa3, 0xa8, 0x4b, 0xbe, 0x84, 0xe5, 0xa1, 0x13, 0x84, 0xd4, 0x17,
+	0xec, 0x8e, 0x5d, 0x76, 0x42, 0xfd, 0x91, 0xef, 0xb2, 0xb0, 0x53, 0x11, 0x4c, 0x17, 0x25, 0xf6,
+	0x39, 0xbd, 0x7c, 0x9a, 0xe0, 0xc8, 0x1a, 0x00, 0x27, 0xf7, 0x7c, 0xf7, 0xc4, 0x65, 0x9d, 0x79,
+	0xc1, 0x76, 0xfe, 0x8c, 0x5e, 0xee, 0x0b, 0x80, 0xf9, 0x06, 0x16, 0x2d, 0xca, 0x75, 0xa8, 0xce,
+	0x1d, 0x2d, 0xf0, 0x9a, 0x07, 0xb1, 0x0a, 0x15, 0x46, 0xcf, 0xf5, 0x43, 0x98, 0x63, 0xf4, 0x5c,
+	0x5c, 0x8b, 0x15, 0x58, 0x1a, 0xe3, 0x8c, 0xd7, 0xf6, 0x19, 0x2c, 0x1f, 0xd2, 0xf0, 0x51, 0xbf,
+	0xef, 0xd3, 0x20, 0x78, 0xe1, 0x74, 0xe9, 0x40, 0x33, 0x7b, 0x47, 0x82, 0xf1, 0x9e, 0xa9, 0x21,
+	0x77, 0xa2, 0x03, 0x4e, 0x89, 0x8b, 0xc8, 0x81, 0xb9, 0x0a, 0x2b, 0x19, 0x4e, 0xb8, 0xc8, 0x67,
+	0xb0, 0xa8, 0xc3, 0xe3, 0x9b, 0x15, 0x33, 0x2a, 0xe8, 0x8c, 0xfe, 0xa1, 0x00, 0x4b, 0x63, 0xe4,
+	0x78, 0x7b, 0x8e, 0xa0, 0x81, 0x32, 0xd8, 0x82, 0x54, 0xdd, 0xa1, 0xcf, 0xf5, 0x3b, 0x94, 0x37,
+	0x33, 0x05, 0xb5, 0xea, 0x8e, 0x4e, 0x63, 0x7c, 0x0b, 0x35, 0x1d, 0x7d, 0xe3, 0x8d, 0xbf, 0x06,
+	0xb2, 0x47, 0x2f, 0xc2, 0xb1, 0x33, 0xe3, 0x59, 0x84, 0x13, 0x04, 0xa3, 0x53, 0xdf, 0x41, 0xc7,
+	0x51, 0xb3, 0x34, 0xc8, 0x35, 0x6e, 0x8d, 0xf9, 0x0d, 0x2c, 0xa4, 0x18, 0xdf, 0xcc, 0x93, 0xfd,
+	0x65, 0x01, 0xe5, 0x92, 0xc2, 0xeb, 0xc7, 0x9a, 0x1f, 0x05, 0x7e, 0x1d, 0x4a, 0x67, 0x2e, 0xeb,
+	0x0b, 0x49, 0x1a, 0x5b, 0xa6, 0xa6, 0xd3, 0x2c, 0x9b, 0xcd, 0xe7, 0x2e, 0xeb, 0x5b, 0x82, 0xde,
+	0xdc, 0x82, 0x12, 0x1f, 0x91, 0x45, 0x68, 0x3d, 0xde, 0x3d, 0x78, 0xf8, 0xf0, 0xcb, 0x2f, 0xed,
+	0x9d, 0x37, 0x47, 0x3b, 0xd6, 0xde, 0xa3, 0x17, 0xad, 0x0f, 0x74, 0xe8, 0xee, 0x1e, 0x42, 0x0b,
+	0xe6, 0xaf, 0xc1, 0x42, 0x8a, 0x29, 0x6e, 0x6d, 0xa2, 0xea, 0xcd, 0x5f, 0x16, 0x60, 0x65, 0x57,
+	0xdc, 0xd3, 0x03, 0xdf, 0x7d, 0xe7, 0x84, 0xf4, 0x39, 0xbd, 0xbc, 0xae, 0xaa, 0x27, 0x87, 0xf7,
+	0x8f, 0x79, 0x06, 0x21, 0xd8, 0x89, 0x6b, 0x7c, 0xee, 0x1e, 0x0b, 0xcf, 0x34, 0x6f, 0xd5, 0x47,
+	0xf1, 0x2a, 0xaf, 0xdd, 0x63, 0x1e, 0xc5, 0x7d, 0x1a, 0xf4, 0x1c, 0x26, 0xdc, 0x51, 0xc5, 0xc2,
+	0x91, 0x69, 0x40, 0x27, 0x2b, 0x14, 0x1a, 0xfd, 0x3f, 0x15, 0x61, 0x5d, 0x22, 0xf1, 0x00, 0x6f,
+	0x2e, 0xf8, 0x35, 0x3c, 0xeb, 0x26, 0x2c, 0x28, 0x12, 0x6d, 0x27, 0xb8, 0x8b, 0xb6, 0x33, 0xbe,
+	0x32, 0x79, 0x03, 0x35, 0x75, 0x85, 0x44, 0xd4, 0x2a, 0x89, 0xc3, 0xfe, 0x4a, 0x3b, 0xec, 0xe9,
+	0x32, 0xab, 0x9b, 0xc4, 0x43, 0x9c, 0x55, 0x75, 0x92, 0xc1, 0x14, 0x8f, 0x58, 0x9e, 0xe2, 0x11,
+	0x13, 0xcd, 0xce, 0xea, 0x9a, 0xe5, 0xfb, 0x92, 0xbf, 0xec, 0x20, 0x74, 0xfc, 0x50, 0x05, 0xae,
+	0x39, 0x11, 0xb8, 0xda, 0x12, 0x75, 0xc8, 0x31, 0x32, 0x6e, 0x99, 0x2f, 0xa1, 0xaa, 0x49, 0x46,
+	0x9a, 0x50, 0x7d, 0xb5, 0x77, 0x78, 0xb0, 0xb3, 0xbd, 0xfb, 0x74, 0x77, 0xe7, 0x49, 0xeb, 0x03,
+	0xb2, 0x0a, 0x4b, 0x7b, 0x3b, 0x87, 0x47, 0x3b, 0x4f, 0xec, 0xd7, 0xbb, 0x47, 0x7b, 0x3b, 0x87,
+	0x87, 0xf6, 0xc1, 0xab, 0xc7, 0xcf, 0x77, 0x7e, 0xb7, 0x55, 0x20, 0x04, 0x1a, 0x63, 0xb0, 0x19,
+	0xf3, 0x7f, 0x0a, 0x70, 0x67, 0xa2, 0x22, 0x6e, 0x74, 0x0f, 0xf5, 0xf4, 0x61, 0x66, 0x4a, 0xfa,
+	0x50, 0x4c, 0xa7, 0x0f, 0x99, 0x18, 0x54, 0xca, 0xc6, 0xa0, 0xfc, 0x80, 0x59, 0xbe, 0x51, 0xc0,
+	0x9c, 0xcd, 0x0f, 0x98, 0xe6, 0x26, 0x10, 0xd4, 0xe7, 0x2e, 0x3b, 0xf6, 0xae, 0x8c, 0x09, 0xe6,
+	0x7f, 0x96, 0x61, 0x21, 0x35, 0xe1, 0xaa, 0x1b, 0x4d, 0x5e, 0x8e, 0x59, 0xa2, 0x74, 0x3b, 0xf7,
+	0xb3, 0xae, 0x5c, 0xe7, 0x37, 0xd9, 0xfc, 0x34, 0x35, 0x17, 0xa7, 0xa8, 0xb9, 0x34, 0xa6, 0xe6,
+	0xec, 0x21, 0x96, 0xaf, 0x93, 0xe3, 0xcc, 0x66, 0x6f, 0xa2, 0x5e, 0x2a, 0xcc, 0xa5, 0x4b, 0x05,
+	0x81, 0xc3, 0x24, 0xa3, 0x53, 0x41, 0x1c, 0x8e, 0x79, 0x4e, 0x70, 0xce, 0xeb, 0x4e, 0xdb, 0x63,
+	0x83, 0x4b, 0x91, 0x13, 0x54, 0xac, 0x79, 0x01, 0xd9, 0x67, 0x83, 0x4b, 0x7e, 0x11, 0x4e, 0x9d,
+	0xc0, 0xee, 0x53, 0x61, 0x80, 0xbc, 0xfa, 0x75, 0xd9, 0xb1, 0xd7, 0x01, 0x41, 0xd7, 0x3e, 0x75,
+	0x82, 0x27, 0x31, 0x86, 0x2b, 0x4a, 0x77, 0x76, 0xd5, 0xb4, 0xb3, 0x5b, 0x86, 0xd9, 0xae, 0xef,
+	0xb0, 0xde, 0x69, 0xa7, 0x26, 0x10, 0x38, 0x4a, 0x6a, 0xa2, 0xba, 0x5e, 0x13, 0x4d, 0xbe, 0xce,
+	0x8d, 0x29, 0xd7, 0x79, 0x85, 0x9f, 0x42, 0x57, 0xb8, 0xa0, 0xa6, 0x70, 0x67, 0xb3, 0xa3, 0xa8,
+	0xcb, 0xfd, 0xce, 0x3a, 0x40, 0xcf, 0x1b, 0x8e, 0xf8, 0x71, 0xd1, 0x7e, 0xa7, 0x25, 0xa4, 0xd7,
+	0x20, 0x5c, 0xb8, 0xa0, 0xe7, 0xbb, 0xa3, 0xb0, 0xd3, 0x96, 0xf3, 0xe4, 0x88, 0xd7, 0x54, 0x11,
+	0x9f, 0x41, 0xc4, 0x0c, 0xf1, 0x3b, 0x09, 0xc3, 0x0b, 0x7a, 0x18, 0x76, 0x33, 0x1e, 0x40, 0xde,
+	0x66, 0xfb, 0xd9, 0xa3, 0xc3, 0x67, 0xad, 0x0f, 0x08, 0xc0, 0xec, 0xe1, 0xb6, 0xb5, 0x7b, 0x70,
+	0xd4, 0x2a, 0x90, 0x06, 0x80, 0xf5, 0xe8, 0x75, 0x7c, 0xdd, 0x27, 0x7b, 0x87, 0x62, 0x8e, 0x77,
+	0x28, 0x99, 0x0c, 0x1a, 0x98, 0xb4, 0xde, 0x30, 0x43, 0xfb, 0x0a, 0x96, 0x7d, 0xfa, 0x36, 0x72,
+	0x7d, 0xda, 0xb7, 0x7b, 0x1e, 0x3b, 0x76, 0xfd, 0xa1, 0x23, 0x2b, 0x3c, 0x59, 0x1d, 0x2e, 0x29,
+	0xec, 0xb6, 0x8e, 0x34, 0x19, 0x34, 0xe3, 0xf5, 0xf0, 0x5e, 0x2d, 0x42, 0x59, 0x24, 0xcf, 0x62,
+	0x9d, 0xa2, 0x25, 0x07, 0xbc, 0xaa, 0x0c, 0x46, 0x94, 0xf5, 0x9d, 0xee, 0x40, 0x15, 0x71, 0x09,
+	0x80, 0xd7, 0xcb, 0xee, 0x70, 0xe8, 0x84, 0x91, 0x4f, 0x6d, 0x9f, 0x9e, 0x3b, 0x7e, 0x5f, 0xd5,
+	0xcb, 0x0a, 0x6c, 0x09, 0xa8, 0xd9, 0x8e, 0xd7, 0x53, 0xf1, 0xde, 0xfc, 0xfb, 0x32, 0xb4, 0x12,
+	0x18, 0x0a, 0xf1, 0x53, 0x28, 0x0d, 0x5d, 0x26, 0x23, 0x57, 0x75, 0xeb, 0x23, 0xed, 0xea, 0x8e,
+	0x93, 0x6e, 0x3e, 0xf6, 0xa9, 0x73, 0xd6, 0xf7, 0xce, 0x99, 0x25, 0xa6, 0x90, 0x27, 0x29, 0xab,
+	0x9f, 0xb9, 0x09, 0x03, 0xed, 0x72, 0xfc, 0x5c, 0x2b, 0xa7, 0x64, 0x63, 0xe4, 0xc1, 0x34, 0x1e,
+	0xe9, 0x82, 0x38, 0x48, 0xca, 0x2a, 0xe3, 0x1f, 0x0b, 0x30, 0x1f, 0xaf, 0xc0, 0xef, 0x50, 0xe8,
+	0x47, 0x01, 0xbf, 0xad, 0x52, 0xbd, 0x6a, 0x48, 0x1e, 0x40, 0x3b, 0x62, 0x38, 0xb0, 0xb9, 0x62,
+	0x79, 0x1f, 0x4a, 0x2a, 0xba, 0x15, 0x23, 0x0e, 0x24, 0x9c, 0x7c, 0x0e, 0x24, 0x62, 0x78, 0xcc,
+	0xfc, 0xc0, 0x4f, 0x1d, 0x76, 0xa2, 0x4a, 0x9f, 0xb6, 0x86, 0xd9, 0x16, 0x08, 0xe9, 0x24, 0xe4,
+	0x39, 0x08, 0x37, 0x55, 0xb4, 0xe2, 0x31, 0xbf, 0x1e, 0xbc, 0x48, 0xa3, 0x7d, 0xec, 0xc2, 0xe0,
+	0xc8, 0xf8, 0xe5, 0x0c, 0x34, 0xc7, 0x76, 0xf5, 0x9e, 0xf5, 0x6a, 0x8e, 0x09, 0x17, 0xaf, 0xe3,
+	0x09, 0x4b, 0x59, 0x4f, 0xa8, 0xcc, 0xa2, 0xfc, 0x7f, 0x35, 0x8b, 0xd9, 0xf7, 0x33, 0x0b, 0xf3,
+	0xdf, 0x8a, 0xb0, 0xfc, 0x73, 0x1a, 0x6a, 0x3d, 0x92, 0x38, 0xfd, 0xdd, 0x84, 0x05, 0x91, 0x50,
+	0xb8, 0xec, 0x44, 0x2f, 0xa0, 0x65, 0xee, 0xd5, 0x56, 0xa8, 0xa4, 0x82, 0xde, 0x82, 0xa5, 0x71,
+	0xfa, 0xa4, 0x9d, 0xd3, 0xb6, 0x16, 0xd2, 0x33, 0x04, 0x8a, 0xdc, 0x87, 0xb6, 0xb4, 0x00, 0x7d,
+	0x85, 0xa2, 0x58, 0xa1, 0x29, 0x11, 0x09, 0xff, 0x4d, 0x58, 0x48, 0xd3, 0x4a, 0xee, 0x25, 0x99,
+	0xe7, 0xe8, 0xd4, 0x92, 0xf7, 0xb7, 0x70, 0x6b, 0xe8, 0x32, 0x77, 0x18, 0x0d, 0x6d, 0x9f, 0xf6,
+	0x78, 0x61, 0x9f, 0x6a, 0x14, 0x95, 0xc5, 0xbc, 0x55, 0x24, 0xb1, 0x04, 0x85, 0xae, 0x06, 0x6e,
+	0x48, 0xbd, 0xc8, 0x0f, 0x3c, 0x1f, 0x43, 0x18, 0x8e, 0x84, 0x4f, 0x75, 0x87, 0xae, 0xcc, 0xb0,
+	0xea, 0x96, 0x1c, 0x64, 0x0e, 0xbb, 0x92, 0x1b, 0xf6, 0x7a, 0x4e, 0x48, 0x4f, 0x3c, 0xff, 0x12,
+	0x0b, 0xda, 0x78, 0x9c, 0x38, 0x6a, 0xd0, 0x1c, 0x35, 0x0f, 0x78, 0x43, 0x97, 0xa9, 0x8e, 0x5d,
+	0x55, 0x7a, 0xa9, 0xa1, 0xcb, 0x64, 0xb3, 0x4e, 0xa0, 0x9d, 0x0b, 0x85, 0xae, 0x21, 0xda, 0xb9,
+	0x90, 0x68, 0xf3, 0x5f, 0x0b, 0xb0, 0x92, 0x39, 0x5b, 0xf4, 0x47, 0x4f, 0x81, 0x70, 0x2b, 0xea,
+	0xa7, 0x75, 0x22, 0x6b, 0xc4, 0x15, 0xdd, 0x8a, 0xb4, 0xae, 0x9d, 0xd5, 0x16, 0x53, 0x52, 0x4a,
+	0x3a, 0x80, 0xc5, 0x88, 0xe5, 0x70, 0x9a, 0xb9, 0x4e, 0x1b, 0x6e, 0x01, 0xa7, 0xa6, 0x38, 0xde,
+	0x81, 0x2a, 0xa3, 0x17, 0xa1, 0x8d, 0xba, 0x97, 0xe9, 0x39, 0x70, 0xd0, 0xb6, 0x80, 0xf0, 0x8e,
+	0xf7, 0x8a, 0xf4, 0x03, 0x07, 0x71, 0xfe, 0xaf, 0x6c, 0xf6, 0x6b, 0x28, 0xf2, 0x80, 0x5a, 0x10,
+	0x09, 0xd2, 0xc7, 0xda, 0xea, 0x13, 0x26, 0x6c, 0xf2, 0x2c, 0x95, 0x4f, 0xe1, 0x77, 0xda, 0x1b,
+	0xf4, 0x6d, 0xad, 0xc8, 0x90, 0x9d, 0xa2, 0xba, 0x37, 0xe8, 0x27, 0xd3, 0x38, 0x19, 0x6f, 0x1c,
+	0x68, 0x64, 0xd2, 0x5a, 0xeb, 0x8c, 0x9e, 0x27, 0x64, 0xe6, 0x3a, 0x14, 0x79, 0x28, 0xaf, 0xc2,
+	0xdc, 0x81, 0xb5, 0xfb, 0xdd, 0xa3, 0xa3, 0x1d, 0x19, 0x55, 0x0f, 0x5e, 0x3d, 0x7e, 0xb1, 0xbb,
+	0xdd, 0x2a, 0xf0, 0x6a, 0x28, 0x2b, 0x11, 0x56, 0x43, 0x7f, 0x32, 0x03, 0xcb, 0x4f, 0x23, 0xa6,
+	0x6b, 0xe5, 0xea, 0x8a, 0x94, 0xb7, 0x8d, 0x1c, 0xff, 0x84, 0x86, 0xca, 0x1a, 0x54, 0x5f, 0x52,
+	0x00, 0xd1, 0x5e, 0x26, 0xc7, 0xd4, 0xe2, 0x94, 0x98, 0x4a, 0xbe, 0x01, 0xc3, 0x65, 0xbd, 0x41,
+	0xd4, 0xa7, 0x76, 0x1c, 0x14, 0xb9, 0x2f, 0xec, 0x3a, 0x01, 0x0d, 0xb0, 0xcc, 0xeb, 0x20, 0xc5,
+	0x2e, 0x12, 0x6c, 0x2b, 0x3c, 0x77, 0x0b, 0x6a, 0xb6, 0x74, 0xeb, 0x36, 0x66, 0x2f, 0x65, 0x31,
+	0x71, 0x01, 0x91, 0x52, 0x1d, 0x87, 0x02, 0x65, 0xfe, 0x6d, 0x11, 0x56, 0x32, 0x2a, 0x40, 0xcb,
+	0xfd, 0x7d, 0x68, 0x05, 0x74, 0x40, 0x7b, 0x3c, 0xac, 0x78, 0xa2, 0x55, 0xad, 0xec, 0xf6, 0x0b,
+	0xed, 0xbc, 0x27, 0xcc, 0xde, 0x3c, 0xc0, 0x76, 0x37, 0x36, 0xee, 0x9b, 0x8a, 0x95, 0x1c, 0x07,
+	0xfc, 0x1a, 0xcb, 0xf6, 0x5b, 0x4a, 0x8d, 0x55, 0x01, 0x43, 0x2d, 0xde, 0x83, 0x16, 0x6e, 0x64,
+	0x74, 0xa6, 0xf6, 0x22, 0x8d, 0xa0, 0x21, 0xe1, 0x07, 0x67, 0x72, 0x1b, 0xc6, 0xbf, 0x17, 0xa0,
+	0x91, 0x5e, 0x90, 0xf7, 0xec, 0xb5, 0x7b, 0xa2, 0x7b, 0xd4, 0xa6, 0x06, 0x17, 0xfe, 0xee, 0x43,
+	0xa8, 0xc9, 0xfd, 0xd9, 0x32, 0xe7, 0x94, 0x51, 0xa8, 0x2a, 0x61, 0xbb, 0x1c, 0xc4, 0x5d, 0x54,
+	0xaa, 0x9b, 0x8f, 0x23, 0x1e, 0xbd, 0x12, 0xd9, 0x4a, 0x82, 0x7d, 0x65, 0x84, 0x52, 0x71, 0xbe,
+	0xdc, 0x1f, 0xf2, 0xd6, 0x32, 0x6f, 0xa3, 0x63, 0x98, 0xac, 0x22, 0xec, 0xc8, 0x95, 0x4d, 0xc8,
+	0x63, 0xdf, 0x1b, 0xc6, 0xa7, 0x8c, 0x15, 0x67, 0x8d, 0x03, 0xd5, 0xc9, 0x9a, 0x7f, 0x51, 0x80,
+	0xe5, 0x43, 0xf7, 0x84, 0xe5, 0xd8, 0xe9, 0x55, 0xd5, 0xfa, 0x57, 0xb0, 0x1c, 0x50, 0xdf, 0x75,
+	0x06, 0xee, 0xf7, 0x69, 0xc7, 0x81, 0x97, 0x6e, 0x29, 0xc1, 0x6a, 0xdc, 0xb9, 0x58, 0x2e, 0x8b,
+	0x15, 0x42, 0x65, 0x22, 0x53, 0xb7, 0x6a, 0x2e, 0x53, 0x1a, 0xa1, 0x81, 0xf9, 0x16, 0x56, 0x32,
+	0x52, 0xa1, 0xe9, 0x8c, 0x7d, 0x3c, 0x2a, 0x64, 0x3f, 0x1e, 0x7d, 0x09, 0xcb, 0x11, 0x0b, 0xdc,
+	0x13, 0xee, 0xcf, 0xd2, 0x4b, 0xcd, 0x88, 0xa5, 0x16, 0x15, 0x76, 0x57, 0x5f, 0xf2, 0xb7, 0x61,
+	0xf5, 0x20, 0xea, 0x0e, 0xdc, 0xe0, 0x34, 0x47, 0x17, 0x9f, 0x03, 0x41, 0x86, 0xd9, 0xb5, 0xdb,
+	0x12, 0xa3, 0xcd, 0x32, 0x6f, 0x83, 0x91, 0xc7, 0x0b, 0x7d, 0xc3, 0xef, 0x40, 0xdd, 0x12, 0x05,
+	0xbd, 0xd6, 0xe7, 0xef, 0xd2, 0x13, 0x97, 0xa9, 0x68, 0x58, 0x10, 0x17, 0xb9, 0x2a, 0x60, 0x18,
+	0x07, 0xd7, 0x00, 0x28, 0xeb, 0xdb, 0xa9, 0x6f, 0x2b, 0xf3, 0x94, 0xf5, 0x25, 0xda, 0xfc, 0x63,
+	0x68, 0x28, 0x96, 0xa8, 0xa6, 0x07, 0x80, 0x5d, 0x03, 0x21, 0xf4, 0xa9, 0xef, 0x45, 0x27, 0xa7,
+	0xc8, 0xb8, 0x15, 0x23, 0x8e, 0x24, 0x9c, 0x73, 0xcf, 0x74, 0xd7, 0xe7, 0xbb, 0x71, 0xd0, 0x8e,
+	0xd1, 0xc2, 0xd4, 0xf0, 0xeb, 0x8d, 0x80, 0x70, 0x43, 0x33, 0x3f, 0x84, 0x3b, 0xda, 0x36, 0xf7,
+	0xbc, 0xd0, 0x3d, 0x76, 0x7b, 0x8e, 0x9e, 0x86, 0x98, 0x7f, 0x3d, 0x03, 0x1b, 0x93, 0x69, 0x50,
+	0xe4, 0x9f, 0x41, 0xd3, 0x09, 0x43, 0xa7, 0x77, 0x4a, 0xfb, 0x32, 0x3b, 0xb8, 0x32, 0x96, 0x35,
+	0x14, 0xbd, 0x80, 0x06, 0x3c, 0xe3, 0xef, 0xd3, 0x34, 0x07, 0x7e, 0xe4, 0x35, 0xab, 0xd1, 0xa7,
+	0x29, 0xc2, 0x49, 0x11, 0xaf, 0xf8, 0xde, 0x11, 0xef, 0x1b, 0x30, 0x72, 0x38, 0x0a, 0x85, 0x52,
+	0xf9, 0x41, 0xab, 0x66, 0x75, 0xb2, 0x13, 0x9f, 0x09, 0xbc, 0xf9, 0x67, 0x05, 0x58, 0x3b, 0x1c,
+	0x51, 0x16, 0x32, 0x1a, 0x04, 0x79, 0x1a, 0x9c, 0x12, 0x35, 0xee, 0x43, 0x9b, 0x79, 0x36, 0xe3,
+	0x93, 0x2e, 0xed, 0x88, 0x05, 0x9c, 0x8d, 0x38, 0xc3, 0x8a, 0xd5, 0x64, 0x9e, 0x60, 0x76, 0xf9,
+	0x4a, 0x82, 0x79, 0x03, 0x30, 0xa1, 0x95, 0x94, 0xf2, 0x33, 0x5f, 0x5d, 0x51, 0x0a, 0x29, 0xcc,
+	0x3f, 0x9f, 0x81, 0xf5, 0x49, 0xf2, 0xe0, 0x69, 0xfd, 0xff, 0x3a, 0xc1, 0xe7, 0x30, 0x27, 0x0a,
+	0x37, 0x4c, 0xc3, 0xd3, 0x71, 0x60, 0xba, 0x24, 0x02, 0xdd, 0xa7, 0xbe, 0xa5, 0x38, 0x18, 0xaf,
+	0x60, 0x0e, 0x61, 0x37, 0x91, 0xf2, 0x0e, 0x54, 0x5d, 0x36, 0x2e, 0x24, 0x24, 0x6e, 0xc9, 0x5c,
+	0x83, 0x5b, 0xea, 0x5b, 0x5b, 0x9e, 0x8d, 0xff, 0x57, 0x01, 0x6e, 0xe7, 0xe3, 0x6f, 0xd6, 0x40,
+	0xbb, 0x46, 0x17, 0x34, 0xbf, 0x13, 0x56, 0xbc, 0x51, 0x27, 0xac, 0x74, 0xa3, 0x4f, 0x47, 0xe5,
+	0xfc, 0x4f, 0x47, 0xe6, 0x9f, 0x16, 0x60, 0x61, 0xdb, 0xa7, 0x4e, 0x48, 0x5f, 0x8b, 0xe3, 0x52,
+	0xe6, 0xfa, 0x00, 0xda, 0x23, 0xee, 0x01, 0x7b, 0x76, 0x26, 0x86, 0xb4, 0x24, 0x42, 0xcb, 0xc7,
+	0x3e, 0x07, 0xa2, 0x9a, 0xb9, 0x99, 0xd4, 0xad, 0x8d, 0x18, 0x8d, 0x9c, 0x40, 0x29, 0xa0, 0xb4,
+	0x8f, 0xf1, 0x5a, 0xfc, 0x36, 0x97, 0x61, 0x31, 0x2d, 0x06, 0xfa, 0xda, 0x9f, 0x41, 0x7b, 0x7f,
+	0x44, 0xd9, 0xfb, 0x0b, 0x67, 0x2e, 0x02, 0xd1, 0x39, 0x20, 0xdf, 0x45, 0x20, 0xdb, 0x03, 0x2f,
+	0x48, 0xef, 0xda, 0x5c, 0x82, 0x85, 0x14, 0x14, 0x89, 0x97, 0x60, 0x41, 0x42, 0x76, 0x2e, 0xdc,
+	0x20, 0xfe, 0xd0, 0x6a, 0x6e, 0xc2, 0x62, 0x1a, 0x8c, 0x76, 0xb2, 0x0c, 0xb3, 0x54, 0x40, 0x84,
+	0x4c, 0x15, 0x0b, 0x47, 0xe6, 0x3f, 0x17, 0xa1, 0x23, 0x7a, 0xc0, 0xdb, 0x9c, 0x8c, 0x05, 0x51,
+	0x60, 0x8d, 0x7a, 0x6a, 0x4f, 0x9f, 0x40, 0x13, 0xbf, 0x31, 0xdb, 0xe9, 0x06, 0x64, 0x03, 0xc1,
+	0xd8, 0x2c, 0xe2, 0x05, 0x4c, 0x14, 0x50, 0x5f, 0x33, 0xad, 0x78, 0xcc, 0x71, 0x5c, 0x23, 0xe7,
+	0x9e, 0xaf, 0xb4, 0x1b, 0x8f, 0x79, 0xdc, 0xed, 0x51, 0x1f, 0xed, 0x9a, 0x62, 0x42, 0xa2, 0x83,
+	0xb8, 0x8b, 0xea, 0x3a, 0xbd, 0x33, 0xca, 0xfa, 0xf8, 0x8a, 0x44, 0x0d, 0xc9, 0x17, 0xb0, 0xf4,
+	0xfd, 0xf0, 0xad, 0xed, 0x3b, 0xe7, 0x58, 0xf6, 0x29, 0x11, 0x65, 0x51, 0x46, 0xbe, 0x1f, 0xbe,
+	0xb5, 0x9c, 0x73, 0xe1, 0x9b, 0x95, 0x98, 0x0f, 0x80, 0xa8, 0x29, 0xe1, 0x45, 0x4c, 0x2f, 0xbf,
+	0x4c, 0x36, 0x25, 0xfd, 0xd1, 0x85, 0x22, 0xfe, 0x43, 0x68, 0xe2, 0x52, 0xb6, 0x37, 0x92, 0x9e,
+	0xbc, 0x22, 0x3c, 0xf9, 0x4f, 0x74, 0x2f, 0x32, 0x41, 0x75, 0x9b, 0x8f, 0xe5, 0xd4, 0x7d, 0x39,
+	0x73, 0x87, 0x85, 0xfe, 0xa5, 0xd5, 0xe8, 0xa6, 0x80, 0xc6, 0x23, 0x58, 0xc8, 0x21, 0x23, 0xad,
+	0xa4, 0x54, 0x99, 0x97, 0x25, 0xc8, 0x22, 0x94, 0xdf, 0x39, 0x83, 0x48, 0xe9, 0x56, 0x0e, 0x7e,
+	0x63, 0xe6, 0xeb, 0x82, 0x79, 0x0b, 0x56, 0x73, 0x44, 0x40, 0x13, 0xf9, 0xa1, 0x00, 0xf5, 0xe7,
+	0xf4, 0xf2, 0x09, 0x95, 0xf9, 0x9e, 0xe7, 0x4f, 0xe9, 0x24, 0xaf, 0x01, 0xa0, 0xf9, 0xf2, 0xb5,
+	0x31, 0x5a, 0x4b, 0x48, 0xb6, 0xf5, 0x58, 0xcc, 0xb4, 0x1e, 0x27, 0x77, 0x3a, 0x4b, 0x53, 0x3a,
+	0x9d, 0x22, 0xb4, 0xc6, 0x3d, 0xd9, 0x91, 0x13, 0x9e, 0x76, 0xca, 0x22, 0x9b, 0x6a, 0x24, 0xe0,
+	0x03, 0x27, 0x3c, 0x35, 0xff, 0x66, 0x06, 0x5a, 0x3c, 0x77, 0x93, 0x6f, 0x72, 0xd0, 0x3a, 0xef,
+	0x27, 0x7a, 0xaa, 0x6e, 0x75, 0xb4, 0x43, 0x49, 0xed, 0x59, 0x6a, 0xf0, 0xea, 0xd7, 0x41, 0x63,
+	0x9e, 0xba, 0x38, 0xee, 0xa9, 0xb5, 0x80, 0x23, 0xcf, 0x42, 0xb6, 0x97, 0x30, 0xe0, 0x7c, 0xc7,
+	0x41, 0x3c, 0x0d, 0x45, 0x12, 0xad, 0x92, 0xa9, 0x59, 0x38, 0x0f, 0xb3, 0xec, 0x35, 0x80, 0x20,
+	0xea, 0x2a, 0x8a, 0x59, 0xa9, 0xe9, 0x20, 0xea, 0x22, 0xfa, 0x16, 0xcc, 0xf3, 0x80, 0x22, 0xfb,
+	0x4b, 0xb2, 0x91, 0x50, 0xe1, 0x00, 0xd5, 0xa0, 0x3f, 0x77, 0x45, 0xd4, 0xc2, 0x16, 0xb8, 0x1a,
+	0x9a, 0x5f, 0x40, 0x5b, 0x53, 0x10, 0x5e, 0x7a, 0xde, 0xca, 0x74, 0x4f, 0x98, 0x6c, 0x87, 0x15,
+	0x70, 0x25, 0x05, 0x30, 0x7f, 0x0f, 0x08, 0x9f, 0xf2, 0x92, 0x06, 0x81, 0x73, 0x42, 0xdf, 0x47,
+	0xab, 0x1d, 0x98, 0x1b, 0xca, 0xd9, 0xea, 0x5b, 0x39, 0x0e, 0xcd, 0x1f, 0xc3, 0x42, 0x8a, 0xf7,
+	0x75, 0x04, 0xda, 0xb2, 0xe2, 0x37, 0x6c, 0x87, 0xd4, 0x7f, 0xe7, 0xf6, 0x78, 0xf6, 0x36, 0x87,
+	0x10, 0xb2, 0xaa, 0x89, 0x92, 0x7e, 0xe9, 0x66, 0x18, 0x79, 0x28, 0xb9, 0xe2, 0xd6, 0x0f, 0x4d,
+	0xa8, 0x4b, 0x87, 0xa8, 0x78, 0xfe, 0x04, 0x4a, 0xfc, 0xd1, 0x0d, 0x59, 0xd6, 0x66, 0x69, 0x8f,
+	0x72, 0x8c, 0x95, 0x0c, 0x3c, 0x4e, 0x25, 0xe7, 0xf0, 0x71, 0x4d, 0x4a, 0x98, 0xf4, 0x8b, 0x1d,
+	0xc3, 0xc8, 0x43, 0x21, 0x07, 0x0b, 0xea, 0xa9, 0x87, 0x35, 0xe4, 0x4e, 0xf6, 0xe1, 0x4a, 0xea,
+	0xb5, 0x8e, 0xb1, 0x31, 0x99, 0x00, 0x79, 0x6e, 0x43, 0x05, 0x11, 0x01, 0x31, 0x72, 0xdf, 0xc1,
+	0x48, 0x4e, 0xb7, 0xa6, 0xbc, 0x91, 0xe1, 0x5b, 0x53, 0x2f, 0x48, 0x56, 0xb3, 0x9d, 0xc2, 0xbc,
+	0xad, 0x8d, 0xf7, 0xd2, 0xb7, 0xa1, 0x12, 0x37, 0x4f, 0x8d, 0xdc, 0x66, 0x63, 0x56, 0x8c, 0x4c,
+	0x2f, 0xfc, 0x0d, 0x34, 0xc7, 0xda, 0x52, 0xe4, 0x43, 0x8d, 0x3e, 0xbf, 0x1d, 0x69, 0x98, 0xd3,
+	0x48, 0x34, 0xcd, 0xeb, 0x0f, 0x16, 0xd2, 0x9a, 0xcf, 0x79, 0x57, 0x61, 0x6c, 0x4c, 0x26, 0x40,
+	0x9e, 0x2f, 0xe2, 0x8f, 0x25, 0xe2, 0xa3, 0xd1, 0xda, 0xa4, 0xaf, 0x6e, 0x92, 0xdf, 0xfa, 0xf4,
+	0x8f, 0x72, 0x24, 0x82, 0xce, 0xa4, 0x62, 0x86, 0xdc, 0xcf, 0xaf, 0x1d, 0xf2, 0x32, 0x46, 0xe3,
+	0xc1, 0xb5, 0x68, 0xe5, 0xa2, 0x0f, 0x0b, 0xc4, 0x83, 0xe5, 0xfc, 0x4c, 0x98, 0xdc, 0xbb, 0x46,
+	0xb2, 0x2c, 0x97, 0xfc, 0xf4, 0xda, 0x69, 0xf5, 0xc3, 0x02, 0x71, 0x93, 0x77, 0x69, 0xa9, 0xe5,
+	0x3e, 0xce, 0xb1, 0xf4, 0xbc, 0xc5, 0x3e, 0xb9, 0x92, 0x2e, 0x5e, 0xea, 0x17, 0xd0, 0x1a, 0xef,
+	0xa5, 0x11, 0xf3, 0xea, 0xd6, 0x9f, 0x71, 0x77, 0x2a, 0x4d, 0x62, 0x51, 0xa9, 0xd7, 0x40, 0x29,
+	0x8b, 0xca, 0x7b, 0x81, 0x64, 0x6c, 0x4c, 0x26, 0x48, 0xec, 0x7f, 0xec, 0xf9, 0x4f, 0xca, 0xfe,
+	0xf3, 0x1f, 0x19, 0x19, 0xe6, 0x34, 0x92, 0xc4, 0x56, 0xb5, 0x67, 0x30, 0x29, 0x5b, 0xcd, 0xbe,
+	0xbb, 0x31, 0xd6, 0x27, 0xa1, 0xc7, 0xb8, 0xa9, 0xdc, 0x61, 0xea, 0x33, 0x17, 0x63, 0x7d, 0x12,
+	0x1a, 0xb9, 0xfd, 0x02, 0x5a, 0xe3, 0x0f, 0x40, 0x52, 0xc7, 0x34, 0xe1, 0xc9, 0x8a, 0x71, 0x77,
+	0x2a, 0x0d, 0x32, 0x1f, 0xa9, 0x27, 0x2f, 0x99, 0x37, 0x08, 0xe4, 0xd3, 0x6b, 0x3f, 0xd8, 0x30,
+	0xee, 0x5f, 0x87, 0x34, 0x39, 0xc4, 0xb1, 0x1e, 0x63, 0xea, 0x10, 0xf3, 0x1b, 0xb8, 0x86, 0x39,
+	0x8d, 0x44, 0x33, 0x8f, 0x74, 0x03, 0x2b, 0x6d, 0x1e, 0xb9, 0x2d, 0x37, 0xc3, 0x9c, 0x46, 0x82,
+	0x9c, 0x1d, 0x20, 0xd9, 0xde, 0x12, 0xd1, 0x9f, 0x38, 0x4f, 0x6c, 0x63, 0x19, 0x1f, 0x5d, 0x41,
+	0x15, 0xbf, 0xda, 0x9c, 0x95, 0xdd, 0x24, 0xd2, 0x49, 0xdd, 0x03, 0xad, 0x67, 0x65, 0xac, 0xe6,
+	0x60, 0xd4, 0x6d, 0xde, 0xfa, 0x55, 0x51, 0x55, 0x3c, 0x2f, 0x3c, 0xa7, 0x4f, 0x7d, 0x15, 0xcf,
+	0xf7, 0xa1, 0xa6, 0x57, 0x3c, 0x44, 0x37, 0xb7, 0x9c, 0x0a, 0xc9, 0xb8, 0x33, 0x11, 0x8f, 0x92,
+	0xee, 0x43, 0x4d, 0x2f, 0xfb, 0x52, 0x0c, 0x73, 0xca, 0x52, 0xe3, 0xce, 0x44, 0x3c, 0x32, 0xdc,
+	0x05, 0x48, 0xaa, 0x3d, 0x72, 0x5b, 0x23, 0xcf, 0x94, 0x91, 0xc6, 0xda, 0x04, 0x6c, 0x72, 0xf3,
+	0xb4, 0x62, 0x30, 0x75, 0xf3, 0xb2, 0xa5, 0xa3, 0xb1, 0x3e, 0x09, 0x8d, 0xdc, 0xfe, 0x00, 0xda,
+	0x99, 0xea, 0x81, 0xdc, 0xbd, 0x46, 0x79, 0x63, 0xfc, 0x68, 0x3a, 0x11, 0x26, 0x5f, 0x7f, 0x57,
+	0x80, 0x3a, 0x37, 0xb9, 0xe4, 0xb0, 0x9e, 0xc2, 0x7c, 0x9c, 0xa6, 0x92, 0x5b, 0x63, 0x96, 0xa9,
+	0x67, 0xf7, 0xc6, 0xed, 0x7c, 0x64, 0xa2, 0x07, 0x2d, 0xbf, 0x4c, 0xe9, 0x21, 0x9b, 0xd3, 0x1a,
+	0xeb, 0x93, 0xd0, 0x92, 0x5b, 0x77, 0x56, 0xfc, 0x9d, 0xe2, 0xc7, 0xff, 0x3b, 0x00, 0x89, 0xa4,
+	0xfa, 0x4a, 0x5b, 0x31, 0x00, 0x00,
 }
@@ -109,6 +109,11 @@ type ManagedPubKeyAddress interface {
 	// imported keys, the first value will be set to false to indicate that
 	// we don't know exactly how the key was derived.
 	DerivationInfo() (KeyScope, DerivationPath, bool)
+
+	// WatchOnly returns true if no private key is available for the
+	// address, either because it belongs to a watch-only manager or
+	// account, or because only its public key was imported.
+	WatchOnly() bool
 }
 
 // ManagedScriptAddress extends ManagedAddress and represents a pay-to-script-hash
@@ -322,6 +327,30 @@ func (a *managedAddress) ExportPrivKey() (*btcutil.WIF, error) {
 	return btcutil.NewWIF(pk, a.manager.rootManager.chainParams, a.compressed)
 }
 
+// WatchOnly returns true if no private key is available for the address.
+//
+// This is part of the ManagedPubKeyAddress interface implementation.
+func (a *managedAddress) WatchOnly() bool {
+	if a.manager.rootManager.WatchOnly() {
+		return true
+	}
+
+	// Imported keys carry their own encrypted private key, while derived
+	// keys can only be spent from if their account's private key is known.
+	if a.imported {
+		a.privKeyMutex.Lock()
+		defer a.privKeyMutex.Unlock()
+
+		return len(a.privKeyEncrypted) == 0
+	}
+
+	a.manager.mtx.RLock()
+	defer a.manager.mtx.RUnlock()
+
+	acctInfo, ok := a.manager.acctInfo[a.derivationPath.InternalAccount]
+	return !ok || len(acctInfo.acctKeyEncrypted) == 0
+}
+
 // Derivationinfo contains the information required to derive the key that
 // backs the address via traditional methods from the HD root. For imported
 // keys, the first value will be set to false to indicate that we don't know
@@ -90,6 +90,12 @@ const (
 	// derivation schema of BIP0044-like accounts and does not store private
 	// keys.
 	accountWatchOnly accountType = 1
+
+	// accountImported is the account type used for storing accounts
+	// imported from an extended private key within the database. This is
+	// an account that re-uses the key derivation schema of BIP0044-like
+	// accounts, but its keys are not derived from the wallet's master key.
+	accountImported accountType = 2
 )
 
 // dbAccountRow houses information stored about an account in the database.
@@ -121,6 +127,13 @@ type dbWatchOnlyAccountRow struct {
 	addrSchema           *ScopeAddrSchema
 }
 
+// dbImportedAccountRow houses additional information stored about an account
+// imported from an extended private key in the database.
+type dbImportedAccountRow struct {
+	dbWatchOnlyAccountRow
+	privKeyEncrypted []byte
+}
+
 // dbAddressRow houses common information stored about an address in the
 // database.
 type dbAddressRow struct {
@@ -266,6 +279,16 @@ var (
 	// sync state of the root manager.
 	syncBucketName = []byte("sync")
 
+	// addrLabelBucketName is the name of the bucket that maps an encoded
+	// address string to a user-defined label. The bucket is created the
+	// first time a label is written.
+	addrLabelBucketName = []byte("addrlabels")
+
+	// rescanBucketName is the name of the bucket that maps the set hash of
+	// an unfinished rescan to its checkpoint. The bucket is created the
+	// first time a checkpoint is written.
+	rescanBucketName = []byte("rescans")
+
 	// Db related key names (main bucket).
 	mgrVersionName    = []byte("mgrver")
 	mgrCreateDateName = []byte("mgrcreated")
@@ -948,6 +971,78 @@ func serializeWatchOnlyAccountRow(encryptedPubKey []byte, masterKeyFingerprint,
 	return buf.Bytes(), nil
 }
 
+// deserializeImportedAccountRow deserializes the raw data from the passed
+// account row as an account imported from an extended private key.
+func deserializeImportedAccountRow(accountID []byte,
+	row *dbAccountRow) (*dbImportedAccountRow, error) {
+
+	// The serialized imported account raw data format is:
+	//   <encprivkeylen><encprivkey><watchonlyrawdata>
+	//
+	// 4 bytes encrypted privkey len + encrypted privkey + the raw data of a
+	// watch-only account
+
+	// Given the above, the length of the entry must be at a minimum
+	// the constant value sizes.
+	if len(row.rawData) < 4 {
+		str := fmt.Sprintf("malformed serialized imported account "+
+			"for key %x", accountID)
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+
+	privLen := binary.LittleEndian.Uint32(row.rawData[0:4])
+	if uint32(len(row.rawData)) < 4+privLen {
+		str := fmt.Sprintf("malformed serialized imported account "+
+			"for key %x", accountID)
+		return nil, managerError(ErrDatabase, str, nil)
+	}
+	privKeyEncrypted := make([]byte, privLen)
+	copy(privKeyEncrypted, row.rawData[4:4+privLen])
+
+	watchOnlyRow, err := deserializeWatchOnlyAccountRow(
+		accountID, &dbAccountRow{
+			acctType: row.acctType,
+			rawData:  row.rawData[4+privLen:],
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	watchOnlyRow.dbAccountRow = *row
+
+	return &dbImportedAccountRow{
+		dbWatchOnlyAccountRow: *watchOnlyRow,
+		privKeyEncrypted:      privKeyEncrypted,
+	}, nil
+}
+
+// serializeImportedAccountRow returns the serialization of the raw data field
+// for an account imported from an extended private key.
+func serializeImportedAccountRow(encryptedPubKey, encryptedPrivKey []byte,
+	masterKeyFingerprint, nextExternalIndex, nextInternalIndex uint32,
+	name string, addrSchema *ScopeAddrSchema) ([]byte, error) {
+
+	// The serialized imported account raw data format is:
+	//   <encprivkeylen><encprivkey><watchonlyrawdata>
+	//
+	// 4 bytes encrypted privkey len + encrypted privkey + the raw data of a
+	// watch-only account
+	watchOnlyData, err := serializeWatchOnlyAccountRow(
+		encryptedPubKey, masterKeyFingerprint, nextExternalIndex,
+		nextInternalIndex, name, addrSchema,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	privLen := uint32(len(encryptedPrivKey))
+	rawData := make([]byte, 4+privLen+uint32(len(watchOnlyData)))
+	binary.LittleEndian.PutUint32(rawData[0:4], privLen)
+	copy(rawData[4:4+privLen], encryptedPrivKey)
+	copy(rawData[4+privLen:], watchOnlyData)
+	return rawData, nil
+}
+
 // forEachKeyScope calls the given function for each known manager scope
 // within the set of scopes known by the root manager.
 func forEachKeyScope(ns walletdb.ReadBucket, fn func(KeyScope) error) error {
@@ -1088,6 +1183,8 @@ func fetchAccountInfo(ns walletdb.ReadBucket, scope *KeyScope,
 		return deserializeDefaultAccountRow(accountID, row)
 	case accountWatchOnly:
 		return deserializeWatchOnlyAccountRow(accountID, row)
+	case accountImported:
+		return deserializeImportedAccountRow(accountID, row)
 	}
 
 	str := fmt.Sprintf("unsupported account type '%d'", row.acctType)
@@ -1272,6 +1369,28 @@ func putWatchOnlyAccountInfo(ns walletdb.ReadWriteBucket, scope *KeyScope,
 	return putAccountInfo(ns, scope, account, &acctRow, name)
 }
 
+// putImportedAccountInfo stores the provided information of an account
+// imported from an extended private key to the database.
+func putImportedAccountInfo(ns walletdb.ReadWriteBucket, scope *KeyScope,
+	account uint32, encryptedPubKey, encryptedPrivKey []byte,
+	masterKeyFingerprint, nextExternalIndex, nextInternalIndex uint32,
+	name string, addrSchema *ScopeAddrSchema) error {
+
+	rawData, err := serializeImportedAccountRow(
+		encryptedPubKey, encryptedPrivKey, masterKeyFingerprint,
+		nextExternalIndex, nextInternalIndex, name, addrSchema,
+	)
+	if err != nil {
+		return err
+	}
+
+	acctRow := dbAccountRow{
+		acctType: accountImported,
+		rawData:  rawData,
+	}
+	return putAccountInfo(ns, scope, account, &acctRow, name)
+}
+
 // putAccountInfo stores the provided account information to the database.
 func putAccountInfo(ns walletdb.ReadWriteBucket, scope *KeyScope,
 	account uint32, acctRow *dbAccountRow, name string) error {
@@ -1697,6 +1816,32 @@ func putChainedAddress(ns walletdb.ReadWriteBucket, scope *KeyScope,
 		if err != nil {
 			return err
 		}
+
+	case accountImported:
+		arow, err := deserializeImportedAccountRow(accountID, row)
+		if err != nil {
+			return err
+		}
+
+		// Increment the appropriate next index depending on whether the
+		// branch is internal or external.
+		nextExternalIndex := arow.nextExternalIndex
+		nextInternalIndex := arow.nextInternalIndex
+		if branch == InternalBranch {
+			nextInternalIndex = index + 1
+		} else {
+			nextExternalIndex = index + 1
+		}
+
+		// Reserialize the account with the updated index and store it.
+		row.rawData, err = serializeImportedAccountRow(
+			arow.pubKeyEncrypted, arow.privKeyEncrypted,
+			arow.masterKeyFingerprint, nextExternalIndex,
+			nextInternalIndex, arow.name, arow.addrSchema,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = bucket.Put(accountID, serializeAccountRow(row))
@@ -1943,6 +2088,30 @@ func deletePrivateKeys(ns walletdb.ReadWriteBucket) error {
 					return managerError(ErrDatabase, str, err)
 				}
 
+			// Imported accounts are stored as watch-only accounts
+			// once their private key is removed.
+			case accountImported:
+				arow, err := deserializeImportedAccountRow(k, row)
+				if err != nil {
+					return err
+				}
+
+				row.acctType = accountWatchOnly
+				row.rawData, err = serializeWatchOnlyAccountRow(
+					arow.pubKeyEncrypted,
+					arow.masterKeyFingerprint,
+					arow.nextExternalIndex, arow.nextInternalIndex,
+					arow.name, arow.addrSchema,
+				)
+				if err != nil {
+					return err
+				}
+				err = bucket.Put(k, serializeAccountRow(row))
+				if err != nil {
+					str := "failed to delete account private key"
+					return managerError(ErrDatabase, str, err)
+				}
+
 			// Watch-only accounts don't contain any private keys.
 			case accountWatchOnly:
 			}
@@ -2344,6 +2513,168 @@ func putBirthdayBlockVerification(ns walletdb.ReadWriteBucket, verified bool) er
 	return nil
 }
 
+// fetchAddrLabel returns the label stored for the encoded address, or an
+// empty string if the address has no label.
+func fetchAddrLabel(ns walletdb.ReadBucket, encodedAddr string) string {
+	bucket := ns.NestedReadBucket(addrLabelBucketName)
+	if bucket == nil {
+		return ""
+	}
+
+	return string(bucket.Get([]byte(encodedAddr)))
+}
+
+// putAddrLabel stores the label for the encoded address, replacing any label
+// that was previously set.
+func putAddrLabel(ns walletdb.ReadWriteBucket, encodedAddr, label string) error {
+	bucket, err := ns.CreateBucketIfNotExists(addrLabelBucketName)
+	if err != nil {
+		str := "failed to create address label bucket"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	err = bucket.Put([]byte(encodedAddr), []byte(label))
+	if err != nil {
+		str := fmt.Sprintf("failed to store label for address %s",
+			encodedAddr)
+		return managerError(ErrDatabase, str, err)
+	}
+
+	return nil
+}
+
+// deleteAddrLabel removes the label of the encoded address. Removing the label
+// of an address without one is not an error.
+func deleteAddrLabel(ns walletdb.ReadWriteBucket, encodedAddr string) error {
+	bucket := ns.NestedReadWriteBucket(addrLabelBucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	err := bucket.Delete([]byte(encodedAddr))
+	if err != nil {
+		str := fmt.Sprintf("failed to delete label for address %s",
+			encodedAddr)
+		return managerError(ErrDatabase, str, err)
+	}
+
+	return nil
+}
+
+// forEachAddrLabel calls the given function with every labelled encoded
+// address and its label, in byte order of the encoded address.
+func forEachAddrLabel(ns walletdb.ReadBucket,
+	fn func(encodedAddr, label string) error) error {
+
+	bucket := ns.NestedReadBucket(addrLabelBucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	return bucket.ForEach(func(k, v []byte) error {
+		return fn(string(k), string(v))
+	})
+}
+
+// serializeBlockStamp serializes a block stamp as follows:
+//   [0:4]   block height
+//   [4:36]  block hash
+//   [36:44] block timestamp
+func serializeBlockStamp(buf []byte, block *BlockStamp) {
+	binary.BigEndian.PutUint32(buf[:4], uint32(block.Height))
+	copy(buf[4:36], block.Hash[:])
+	binary.BigEndian.PutUint64(buf[36:44], uint64(block.Timestamp.Unix()))
+}
+
+// deserializeBlockStamp deserializes a block stamp serialized by
+// serializeBlockStamp.
+func deserializeBlockStamp(buf []byte) BlockStamp {
+	var block BlockStamp
+	block.Height = int32(binary.BigEndian.Uint32(buf[:4]))
+	copy(block.Hash[:], buf[4:36])
+	block.Timestamp = time.Unix(int64(binary.BigEndian.Uint64(buf[36:44])), 0)
+	return block
+}
+
+// FetchRescanCheckpoints returns the checkpoints of all unfinished rescans.
+//
+// The checkpoints are keyed by their set hash, and serialized as follows:
+//   [0:44]  start block
+//   [44:88] last processed block
+//   [88:96] start time
+func FetchRescanCheckpoints(ns walletdb.ReadBucket) ([]RescanCheckpoint, error) {
+	bucket := ns.NestedReadBucket(rescanBucketName)
+	if bucket == nil {
+		return nil, nil
+	}
+
+	var checkpoints []RescanCheckpoint
+	err := bucket.ForEach(func(k, v []byte) error {
+		if len(k) != chainhash.HashSize || len(v) != 96 {
+			str := "malformed rescan checkpoint stored in database"
+			return managerError(ErrDatabase, str, nil)
+		}
+
+		var checkpoint RescanCheckpoint
+		copy(checkpoint.SetHash[:], k)
+		checkpoint.Start = deserializeBlockStamp(v[:44])
+		checkpoint.LastProcessed = deserializeBlockStamp(v[44:88])
+		checkpoint.StartTime = time.Unix(
+			int64(binary.BigEndian.Uint64(v[88:])), 0,
+		)
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+// PutRescanCheckpoint stores the checkpoint of a rescan, replacing any
+// checkpoint stored for the same set hash.
+func PutRescanCheckpoint(ns walletdb.ReadWriteBucket,
+	checkpoint *RescanCheckpoint) error {
+
+	bucket, err := ns.CreateBucketIfNotExists(rescanBucketName)
+	if err != nil {
+		str := "failed to create rescan checkpoint bucket"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	var v [96]byte
+	serializeBlockStamp(v[:44], &checkpoint.Start)
+	serializeBlockStamp(v[44:88], &checkpoint.LastProcessed)
+	binary.BigEndian.PutUint64(v[88:], uint64(checkpoint.StartTime.Unix()))
+
+	err = bucket.Put(checkpoint.SetHash[:], v[:])
+	if err != nil {
+		str := "failed to store rescan checkpoint"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	return nil
+}
+
+// DeleteRescanCheckpoint removes the checkpoint of the rescan with the given
+// set hash. Removing a checkpoint that doesn't exist is not an error.
+func DeleteRescanCheckpoint(ns walletdb.ReadWriteBucket,
+	setHash *chainhash.Hash) error {
+
+	bucket := ns.NestedReadWriteBucket(rescanBucketName)
+	if bucket == nil {
+		return nil
+	}
+
+	if err := bucket.Delete(setHash[:]); err != nil {
+		str := "failed to remove rescan checkpoint"
+		return managerError(ErrDatabase, str, err)
+	}
+
+	return nil
+}
+
 // managerExists returns whether or not the manager has already been created
 // in the given database namespace.
 func managerExists(ns walletdb.ReadBucket) bool {
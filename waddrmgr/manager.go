@@ -7,6 +7,7 @@ package waddrmgr
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"time"
@@ -79,6 +80,9 @@ const (
 	// saltSize is the number of bytes of the salt used when hashing
 	// private passphrases.
 	saltSize = 32
+
+	// AddrLabelLimit is the length limit we impose on address labels.
+	AddrLabelLimit = 500
 )
 
 // isReservedAccountName returns true if the account name is reserved.
@@ -216,6 +220,10 @@ type AccountProperties struct {
 	// doesn't contain any private key information.
 	IsWatchOnly bool
 
+	// IsImported indicates whether the account was imported from an
+	// extended key rather than derived from the wallet's master key.
+	IsImported bool
+
 	// AddrSchema, if non-nil, specifies an address schema override for
 	// address generation only applicable to the account.
 	AddrSchema *ScopeAddrSchema
@@ -428,6 +436,42 @@ func (m *Manager) IsWatchOnlyAccount(ns walletdb.ReadBucket, keyScope KeyScope,
 	return scopedMgr.IsWatchOnlyAccount(ns, account)
 }
 
+// MasterKeyFingerprint returns the BIP0032 fingerprint of the wallet's master
+// root key, interpreted as a little-endian uint32 as done in PSBT key paths.
+// The fingerprint is computed from the root public key, so it is available
+// while the manager is locked.  ErrNoExist is returned for managers without a
+// root key, such as those created before root keys were stored.
+func (m *Manager) MasterKeyFingerprint(ns walletdb.ReadBucket) (uint32, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	_, masterRootPubEnc := fetchMasterHDKeys(ns)
+	if masterRootPubEnc == nil {
+		str := "master root public key is not stored"
+		return 0, managerError(ErrNoExist, str, nil)
+	}
+
+	serializedKey, err := m.cryptoKeyPub.Decrypt(masterRootPubEnc)
+	if err != nil {
+		str := "failed to decrypt master root public key"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	rootPub, err := hdkeychain.NewKeyFromString(string(serializedKey))
+	zero.Bytes(serializedKey)
+	if err != nil {
+		str := "failed to parse master root public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	pubKey, err := rootPub.ECPubKey()
+	if err != nil {
+		str := "failed to derive master root public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+
+	hash := btcutil.Hash160(pubKey.SerializeCompressed())
+	return binary.LittleEndian.Uint32(hash[:4]), nil
+}
+
 // lock performs a best try effort to remove and zero all secret keys associated
 // with the address manager.
 //
@@ -866,6 +910,61 @@ func (m *Manager) ForEachAccountAddress(ns walletdb.ReadBucket, account uint32,
 	return nil
 }
 
+// SetAddrLabel attaches a user-defined label to an address known to the
+// manager, replacing any existing label. An empty label removes the label
+// from the address.
+func (m *Manager) SetAddrLabel(ns walletdb.ReadWriteBucket,
+	address btcutil.Address, label string) error {
+
+	if len(label) > AddrLabelLimit {
+		str := fmt.Sprintf("label exceeds %d bytes", AddrLabelLimit)
+		return managerError(ErrInvalidLabel, str, nil)
+	}
+
+	// Only addresses controlled by the wallet may be labelled.
+	if _, err := m.Address(ns, address); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if label == "" {
+		return deleteAddrLabel(ns, address.EncodeAddress())
+	}
+	return putAddrLabel(ns, address.EncodeAddress(), label)
+}
+
+// AddrLabel returns the label attached to an address, or an empty string if
+// the address has not been labelled.
+func (m *Manager) AddrLabel(ns walletdb.ReadBucket,
+	address btcutil.Address) string {
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return fetchAddrLabel(ns, address.EncodeAddress())
+}
+
+// ForEachAddrLabel calls the given function with every labelled address and
+// its label, breaking early on error.
+func (m *Manager) ForEachAddrLabel(ns walletdb.ReadBucket,
+	fn func(addr btcutil.Address, label string) error) error {
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	return forEachAddrLabel(ns, func(encodedAddr, label string) error {
+		addr, err := btcutil.DecodeAddress(encodedAddr, m.chainParams)
+		if err != nil {
+			str := fmt.Sprintf("failed to decode labelled address %s",
+				encodedAddr)
+			return managerError(ErrDatabase, str, err)
+		}
+		return fn(addr, label)
+	})
+}
+
 // ChainParams returns the chain parameters for this address manager.
 func (m *Manager) ChainParams() *chaincfg.Params {
 	// NOTE: No need for mutex here since the net field does not change
@@ -6,6 +6,7 @@ package waddrmgr
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -2763,3 +2764,152 @@ func testNewRawAccount(t *testing.T, _ *Manager, db walletdb.DB,
 			accountTargetAddr.AddrHash())
 	}
 }
+
+// TestAddrLabels ensures that labels can be attached to, replaced on and
+// removed from wallet addresses, and that unknown addresses and overly long
+// labels are rejected.
+func TestAddrLabels(t *testing.T) {
+	t.Parallel()
+
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+
+	scopedMgr, err := mgr.FetchScopedKeyManager(KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to fetch scope %v: %v", KeyScopeBIP0084, err)
+	}
+
+	var addrs []ManagedAddress
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		addrs, err = scopedMgr.NextExternalAddresses(
+			ns, DefaultAccountNum, 2,
+		)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to derive addresses: %v", err)
+	}
+	addr1, addr2 := addrs[0].Address(), addrs[1].Address()
+
+	unknownAddr, err := btcutil.NewAddressPubKeyHash(
+		make([]byte, 20), &chaincfg.MainNetParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		// An address without a label reports an empty label.
+		if label := mgr.AddrLabel(ns, addr1); label != "" {
+			return fmt.Errorf("unexpected label %q", label)
+		}
+
+		if err := mgr.SetAddrLabel(ns, addr1, "customer-1"); err != nil {
+			return err
+		}
+		if err := mgr.SetAddrLabel(ns, addr2, "customer-2"); err != nil {
+			return err
+		}
+
+		// Setting a label again replaces the previous one.
+		if err := mgr.SetAddrLabel(ns, addr2, "customer-3"); err != nil {
+			return err
+		}
+
+		err := mgr.SetAddrLabel(ns, unknownAddr, "foreign")
+		if !IsError(err, ErrAddressNotFound) {
+			return fmt.Errorf("expected ErrAddressNotFound, got %v",
+				err)
+		}
+
+		longLabel := string(make([]byte, AddrLabelLimit+1))
+		err = mgr.SetAddrLabel(ns, addr1, longLabel)
+		if !IsError(err, ErrInvalidLabel) {
+			return fmt.Errorf("expected ErrInvalidLabel, got %v",
+				err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		addr1.EncodeAddress(): "customer-1",
+		addr2.EncodeAddress(): "customer-3",
+	}
+	got := make(map[string]string)
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		return mgr.ForEachAddrLabel(ns, func(a btcutil.Address,
+			label string) error {
+
+			got[a.EncodeAddress()] = label
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate labels: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected labels: got %v, want %v", got, want)
+	}
+
+	// Removing a label with an empty string leaves only the other one.
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		if err := mgr.SetAddrLabel(ns, addr1, ""); err != nil {
+			return err
+		}
+		if label := mgr.AddrLabel(ns, addr1); label != "" {
+			return fmt.Errorf("label %q not removed", label)
+		}
+		if label := mgr.AddrLabel(ns, addr2); label != "customer-3" {
+			return fmt.Errorf("unexpected label %q", label)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestMasterKeyFingerprint ensures the master key fingerprint is computed from
+// the stored root public key and matches the one derived from the seed.
+func TestMasterKeyFingerprint(t *testing.T) {
+	t.Parallel()
+
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+
+	root, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to derive master key: %v", err)
+	}
+	rootPub, err := root.ECPubKey()
+	if err != nil {
+		t.Fatalf("unable to derive master public key: %v", err)
+	}
+	hash := btcutil.Hash160(rootPub.SerializeCompressed())
+	want := binary.LittleEndian.Uint32(hash[:4])
+
+	// The fingerprint only requires the root public key, so it must be
+	// available while the manager is locked.
+	var fingerprint uint32
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		var err error
+		fingerprint, err = mgr.MasterKeyFingerprint(ns)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch fingerprint: %v", err)
+	}
+	if fingerprint != want {
+		t.Fatalf("expected fingerprint %08x, got %08x", want,
+			fingerprint)
+	}
+}
@@ -43,6 +43,30 @@ const (
 	// HDVersionTestNetBIP0084 is the HDVersion for BIP-0084 on the test
 	// network.
 	HDVersionTestNetBIP0084 HDVersion = 0x045f1cf6 // vpub
+
+	// HDVersionMainNetBIP0044Priv is the private HDVersion for BIP-0044
+	// on the main network.
+	HDVersionMainNetBIP0044Priv HDVersion = 0x0488ade4 // xprv
+
+	// HDVersionMainNetBIP0049Priv is the private HDVersion for BIP-0049
+	// on the main network.
+	HDVersionMainNetBIP0049Priv HDVersion = 0x049d7878 // yprv
+
+	// HDVersionMainNetBIP0084Priv is the private HDVersion for BIP-0084
+	// on the main network.
+	HDVersionMainNetBIP0084Priv HDVersion = 0x04b2430c // zprv
+
+	// HDVersionTestNetBIP0044Priv is the private HDVersion for BIP-0044
+	// on the test network.
+	HDVersionTestNetBIP0044Priv HDVersion = 0x04358394 // tprv
+
+	// HDVersionTestNetBIP0049Priv is the private HDVersion for BIP-0049
+	// on the test network.
+	HDVersionTestNetBIP0049Priv HDVersion = 0x044a4e28 // uprv
+
+	// HDVersionTestNetBIP0084Priv is the private HDVersion for BIP-0084
+	// on the test network.
+	HDVersionTestNetBIP0084Priv HDVersion = 0x045f18bc // vprv
 )
 
 // DerivationPath represents a derivation path from a particular key manager's
@@ -439,6 +463,41 @@ func (s *ScopedKeyManager) loadAccountInfo(ns walletdb.ReadBucket,
 
 		hasPrivateKey = false
 
+	case *dbImportedAccountRow:
+		acctInfo = &accountInfo{
+			acctName:             row.name,
+			acctType:             row.acctType,
+			acctKeyEncrypted:     row.privKeyEncrypted,
+			nextExternalIndex:    row.nextExternalIndex,
+			nextInternalIndex:    row.nextInternalIndex,
+			addrSchema:           row.addrSchema,
+			masterKeyFingerprint: row.masterKeyFingerprint,
+		}
+
+		// Use the crypto public key to decrypt the account public
+		// extended key.
+		acctInfo.acctKeyPub, err = decryptKey(
+			s.rootManager.cryptoKeyPub, row.pubKeyEncrypted,
+		)
+		if err != nil {
+			str := fmt.Sprintf("failed to decrypt public key for "+
+				"account %d", account)
+			return nil, managerError(ErrCrypto, str, err)
+		}
+
+		if hasPrivateKey {
+			// Use the crypto private key to decrypt the account
+			// private extended key.
+			acctInfo.acctKeyPriv, err = decryptKey(
+				s.rootManager.cryptoKeyPriv, row.privKeyEncrypted,
+			)
+			if err != nil {
+				str := fmt.Sprintf("failed to decrypt private "+
+					"key for account %d", account)
+				return nil, managerError(ErrCrypto, str, err)
+			}
+		}
+
 	default:
 		str := fmt.Sprintf("unsupported account type %T", row)
 		return nil, managerError(ErrDatabase, str, nil)
@@ -529,14 +588,18 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.MasterKeyFingerprint = acctInfo.masterKeyFingerprint
 		props.IsWatchOnly = s.rootManager.WatchOnly() ||
 			acctInfo.acctKeyPriv == nil
+		props.IsImported = acctInfo.acctType != accountDefault
 		props.AddrSchema = acctInfo.addrSchema
 
 		// Export the account public key with the correct version
 		// corresponding to the manager's key scope for non-watch-only
 		// accounts. This isn't done for watch-only accounts to maintain
 		// the account public key consistent with what the caller
-		// provided. Note that his is only done for the default key
-		// scopes, as we only know the HD versions for those.
+		// provided. Accounts imported from an extended private key are
+		// stored with the network's default version, so they're
+		// exported like derived accounts. Note that his is only done
+		// for the default key scopes, as we only know the HD versions
+		// for those.
 		isDefaultKeyScope := false
 		for _, scope := range DefaultKeyScopes {
 			if s.scope == scope {
@@ -544,7 +607,9 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 				break
 			}
 		}
-		if acctInfo.acctType == accountDefault && isDefaultKeyScope {
+		exportVersion := acctInfo.acctType == accountDefault ||
+			acctInfo.acctType == accountImported
+		if exportVersion && isDefaultKeyScope {
 			props.AccountPubKey, err = s.cloneKeyWithVersion(
 				acctInfo.acctKeyPub,
 			)
@@ -1621,6 +1686,108 @@ func (s *ScopedKeyManager) newAccountWatchingOnly(ns walletdb.ReadWriteBucket,
 	return putLastAccount(ns, &s.scope, account)
 }
 
+// NewAccountFromPrivKey creates a new spendable account from an extended
+// private key. The account is stored along with its private key, which is
+// encrypted with the wallet's private crypto key, so the manager must be
+// unlocked.
+//
+// The master key fingerprint denotes the fingerprint of the root key
+// corresponding to the account key (also known as the key with derivation
+// path m/). It can be zero if unknown.
+//
+// An optional address schema may also be provided to override the
+// ScopedKeyManager's address schema. This will affect all addresses derived
+// from the account.
+func (s *ScopedKeyManager) NewAccountFromPrivKey(ns walletdb.ReadWriteBucket,
+	name string, privKey *hdkeychain.ExtendedKey, masterKeyFingerprint uint32,
+	addrSchema *ScopeAddrSchema) (uint32, error) {
+
+	if s.rootManager.WatchOnly() {
+		return 0, managerError(ErrWatchingOnly, errWatchingOnly, nil)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.rootManager.IsLocked() {
+		return 0, managerError(ErrLocked, errLocked, nil)
+	}
+
+	if !privKey.IsPrivate() {
+		str := "account key is not a private extended key"
+		return 0, managerError(ErrKeyChain, str, nil)
+	}
+
+	// Store the key with the network's default versions so that it can
+	// be neutered and exported regardless of the version it was encoded
+	// with.
+	acctKeyPriv, err := privKey.CloneWithVersion(
+		s.rootManager.chainParams.HDPrivateKeyID[:],
+	)
+	if err != nil {
+		str := "failed to clone account private key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	acctKeyPub, err := acctKeyPriv.Neuter()
+	if err != nil {
+		str := "failed to convert private key for account"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+
+	// Validate the account name.
+	if err := ValidateAccountName(name); err != nil {
+		return 0, err
+	}
+
+	// Check that account with the same name does not exist
+	_, err = s.lookupAccount(ns, name)
+	if err == nil {
+		str := fmt.Sprintf("account with the same name already exists")
+		return 0, managerError(ErrDuplicateAccount, str, err)
+	}
+
+	// Fetch the latest account number to generate the next account
+	// number.
+	account, err := fetchLastAccount(ns, &s.scope)
+	if err != nil {
+		return 0, err
+	}
+	account++
+
+	// Encrypt the account keys with the associated crypto keys.
+	acctPubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(acctKeyPub.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt public key for account"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	acctPrivEnc, err := s.rootManager.cryptoKeyPriv.Encrypt(
+		[]byte(acctKeyPriv.String()),
+	)
+	if err != nil {
+		str := "failed to encrypt private key for account"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+
+	// We have the encrypted account extended keys, so save them to the
+	// database
+	err = putImportedAccountInfo(
+		ns, &s.scope, account, acctPubEnc, acctPrivEnc,
+		masterKeyFingerprint, 0, 0, name, addrSchema,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	// Save last account metadata
+	if err := putLastAccount(ns, &s.scope, account); err != nil {
+		return 0, err
+	}
+
+	return account, nil
+}
+
 // RenameAccount renames an account stored in the manager based on the given
 // account number with the given name.  If an account with the same name
 // already exists, ErrDuplicateAccount will be returned.
@@ -1689,6 +1856,22 @@ func (s *ScopedKeyManager) RenameAccount(ns walletdb.ReadWriteBucket,
 			return err
 		}
 
+	case *dbImportedAccountRow:
+		// Remove the old name key from the account name index.
+		if err = deleteAccountNameIndex(ns, &s.scope, row.name); err != nil {
+			return err
+		}
+
+		err = putImportedAccountInfo(
+			ns, &s.scope, account, row.pubKeyEncrypted,
+			row.privKeyEncrypted, row.masterKeyFingerprint,
+			row.nextExternalIndex, row.nextInternalIndex, name,
+			row.addrSchema,
+		)
+		if err != nil {
+			return err
+		}
+
 	default:
 		str := fmt.Sprintf("unsupported account type %T", row)
 		return managerError(ErrDatabase, str, nil)
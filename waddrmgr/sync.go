@@ -20,6 +20,24 @@ type BlockStamp struct {
 	Timestamp time.Time
 }
 
+// RescanCheckpoint is the persisted progress of a rescan, used to resume it
+// from its last processed block rather than its start if it is interrupted.
+type RescanCheckpoint struct {
+	// SetHash identifies the rescan by the addresses and outpoints it
+	// rescans for.
+	SetHash chainhash.Hash
+
+	// Start is the block the rescan started from.
+	Start BlockStamp
+
+	// LastProcessed is the last block the rescan is known to have
+	// processed.
+	LastProcessed BlockStamp
+
+	// StartTime is the time the rescan was started at.
+	StartTime time.Time
+}
+
 // syncState houses the sync state of the manager.  It consists of the recently
 // seen blocks as height, as well as the start and current sync block stamps.
 type syncState struct {
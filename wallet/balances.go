@@ -0,0 +1,266 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"sort"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// BalanceBreakdown splits a balance by how far the unspent outputs it sums can
+// be trusted and spent.  Every output is counted in exactly one field.
+type BalanceBreakdown struct {
+	// Confirmed sums the mined outputs that are not immature or locked.
+	Confirmed btcutil.Amount
+
+	// UnconfirmedChange sums the unconfirmed outputs of transactions
+	// spending only wallet outputs, such as the change of transactions
+	// sent by the wallet.
+	UnconfirmedChange btcutil.Amount
+
+	// UntrustedPending sums the unconfirmed outputs of all other
+	// transactions, which were received from others.
+	UntrustedPending btcutil.Amount
+
+	// Immature sums the coinbase outputs that have not reached maturity.
+	Immature btcutil.Amount
+
+	// Locked sums the outputs locked with LockOutpoint or LeaseOutput,
+	// regardless of their confirmations.
+	Locked btcutil.Amount
+}
+
+// Trusted returns the balance of the outputs that can be spent without relying
+// on transactions of others to confirm: confirmed outputs and unconfirmed
+// change.
+func (b *BalanceBreakdown) Trusted() btcutil.Amount {
+	return b.Confirmed + b.UnconfirmedChange
+}
+
+// Total returns the sum of all fields of the breakdown.
+func (b *BalanceBreakdown) Total() btcutil.Amount {
+	return b.Confirmed + b.UnconfirmedChange + b.UntrustedPending +
+		b.Immature + b.Locked
+}
+
+// add adds the fields of another breakdown to the breakdown.
+func (b *BalanceBreakdown) add(o *BalanceBreakdown) {
+	b.Confirmed += o.Confirmed
+	b.UnconfirmedChange += o.UnconfirmedChange
+	b.UntrustedPending += o.UntrustedPending
+	b.Immature += o.Immature
+	b.Locked += o.Locked
+}
+
+// AccountBalanceBreakdown is the balance breakdown of a single account, split
+// between the outputs the wallet holds private keys for and watch-only outputs.
+type AccountBalanceBreakdown struct {
+	KeyScope      waddrmgr.KeyScope
+	AccountNumber uint32
+	AccountName   string
+	Mine          BalanceBreakdown
+	WatchOnly     BalanceBreakdown
+}
+
+// BalanceBreakdowns is the result of the Wallet.CalculateBalanceBreakdowns
+// method.
+type BalanceBreakdowns struct {
+	// Mine and WatchOnly sum the breakdowns of all accounts.
+	Mine      BalanceBreakdown
+	WatchOnly BalanceBreakdown
+
+	// Accounts holds the breakdown of every account of every active key
+	// scope, ordered by key scope and account number.
+	Accounts []AccountBalanceBreakdown
+}
+
+// CalculateBalanceBreakdowns sums the unspent outputs of the wallet into a
+// balance breakdown for each account of each key scope, and for the wallet as a
+// whole.  Outputs paying to scripts that aren't wallet addresses are not
+// counted.
+//
+// Like CalculateAccountBalances, this iterates every unspent output.
+func (w *Wallet) CalculateBalanceBreakdowns() (*BalanceBreakdowns, error) {
+	type accountKey struct {
+		scope   waddrmgr.KeyScope
+		account uint32
+	}
+	accounts := make(map[accountKey]*AccountBalanceBreakdown)
+
+	// Take a snapshot of the outputs locked in memory.
+	w.lockedOutpointsMtx.Lock()
+	lockedOutpoints := make(map[wire.OutPoint]struct{}, len(w.lockedOutpoints))
+	for op := range w.lockedOutpoints {
+		lockedOutpoints[op] = struct{}{}
+	}
+	w.lockedOutpointsMtx.Unlock()
+
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		for _, manager := range w.Manager.ActiveScopedKeyManagers() {
+			scope := manager.Scope()
+			err := manager.ForEachAccount(addrmgrNs, func(acct uint32) error {
+				name, err := manager.AccountName(addrmgrNs, acct)
+				if err != nil {
+					return err
+				}
+				accounts[accountKey{scope, acct}] = &AccountBalanceBreakdown{
+					KeyScope:      scope,
+					AccountNumber: acct,
+					AccountName:   name,
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// trusted caches whether unmined transactions spend only
+		// wallet outputs.
+		trusted := make(map[chainhash.Hash]bool)
+		isTrusted := func(txHash *chainhash.Hash) (bool, error) {
+			if t, ok := trusted[*txHash]; ok {
+				return t, nil
+			}
+			details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+			if err != nil {
+				return false, err
+			}
+			t := details != nil && len(details.Debits) != 0 &&
+				len(details.Debits) == len(details.MsgTx.TxIn)
+			trusted[*txHash] = t
+			return t, nil
+		}
+
+		syncHeight := w.Manager.SyncedTo().Height
+		addOutput := func(output *wtxmgr.Credit, locked bool) error {
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+				output.PkScript, w.chainParams,
+			)
+			if err != nil || len(addrs) == 0 {
+				return nil
+			}
+			manager, acct, err := w.Manager.AddrAccount(
+				addrmgrNs, addrs[0],
+			)
+			if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+				// Outputs paying to addresses that aren't in
+				// the address manager belong to no account.
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			ma, err := manager.Address(addrmgrNs, addrs[0])
+			if err != nil {
+				return err
+			}
+
+			key := accountKey{manager.Scope(), acct}
+			a, ok := accounts[key]
+			if !ok {
+				name, err := manager.AccountName(addrmgrNs, acct)
+				if err != nil {
+					return err
+				}
+				a = &AccountBalanceBreakdown{
+					KeyScope:      key.scope,
+					AccountNumber: acct,
+					AccountName:   name,
+				}
+				accounts[key] = a
+			}
+
+			bal := &a.Mine
+			watchOnly := w.Manager.WatchOnly()
+			if pka, ok := ma.(waddrmgr.ManagedPubKeyAddress); ok {
+				watchOnly = pka.WatchOnly()
+			}
+			if watchOnly {
+				bal = &a.WatchOnly
+			}
+
+			switch {
+			case locked:
+				bal.Locked += output.Amount
+
+			case output.FromCoinBase && !confirmed(
+				int32(w.chainParams.CoinbaseMaturity),
+				output.Height, syncHeight,
+			):
+				bal.Immature += output.Amount
+
+			case confirmed(1, output.Height, syncHeight):
+				bal.Confirmed += output.Amount
+
+			default:
+				t, err := isTrusted(&output.Hash)
+				if err != nil {
+					return err
+				}
+				if t {
+					bal.UnconfirmedChange += output.Amount
+				} else {
+					bal.UntrustedPending += output.Amount
+				}
+			}
+			return nil
+		}
+
+		err := w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(
+			output *wtxmgr.Credit) (bool, error) {
+
+			_, locked := lockedOutpoints[output.OutPoint]
+			return false, addOutput(output, locked)
+		})
+		if err != nil {
+			return err
+		}
+
+		leased, err := w.TxStore.LockedUnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for i := range leased {
+			if err := addOutput(&leased[i], true); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BalanceBreakdowns{
+		Accounts: make([]AccountBalanceBreakdown, 0, len(accounts)),
+	}
+	for _, a := range accounts {
+		result.Mine.add(&a.Mine)
+		result.WatchOnly.add(&a.WatchOnly)
+		result.Accounts = append(result.Accounts, *a)
+	}
+	sort.Slice(result.Accounts, func(i, j int) bool {
+		a, b := &result.Accounts[i], &result.Accounts[j]
+		if a.KeyScope.Purpose != b.KeyScope.Purpose {
+			return a.KeyScope.Purpose < b.KeyScope.Purpose
+		}
+		if a.KeyScope.Coin != b.KeyScope.Coin {
+			return a.KeyScope.Coin < b.KeyScope.Coin
+		}
+		return a.AccountNumber < b.AccountNumber
+	})
+	return result, nil
+}
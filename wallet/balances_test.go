@@ -0,0 +1,91 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// TestCalculateBalanceBreakdowns ensures that every unspent output is counted
+// in the right field of the balance breakdown of its account.
+func TestCalculateBalanceBreakdowns(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    200,
+			Timestamp: time.Unix(200, 0),
+		})
+	})
+	require.NoError(t, err)
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// A confirmed output spent by an unconfirmed transaction of the
+	// wallet, whose output is unconfirmed change.
+	spentHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	addTestSpend(t, w, &spentHash, pkScript, 9e7, -1)
+
+	// An unconfirmed output received from others.
+	addTestCredit(t, w, pkScript, 2e7, -1, 1)
+
+	// A confirmed output.
+	addTestCredit(t, w, pkScript, 5e7, 100, 2)
+
+	// Outputs locked in memory and leased.
+	lockedHash := addTestCredit(t, w, pkScript, 3e7, 100, 3)
+	w.LockOutpoint(wire.OutPoint{Hash: lockedHash})
+	leasedHash := addTestCredit(t, w, pkScript, 4e7, 100, 4)
+	_, err = w.LeaseOutput(
+		wtxmgr.LockID{1}, wire.OutPoint{Hash: leasedHash}, time.Hour,
+	)
+	require.NoError(t, err)
+
+	// An immature coinbase output.
+	addTestCredit(t, w, pkScript, 6e7, 150, math.MaxUint32)
+
+	bals, err := w.CalculateBalanceBreakdowns()
+	require.NoError(t, err)
+
+	expected := BalanceBreakdown{
+		Confirmed:         5e7,
+		UnconfirmedChange: 9e7,
+		UntrustedPending:  2e7,
+		Immature:          6e7,
+		Locked:            7e7,
+	}
+	require.Equal(t, expected, bals.Mine)
+	require.Equal(t, BalanceBreakdown{}, bals.WatchOnly)
+	require.Equal(t, expected.Confirmed+expected.UnconfirmedChange,
+		bals.Mine.Trusted())
+
+	var found bool
+	for _, a := range bals.Accounts {
+		if a.KeyScope != waddrmgr.KeyScopeBIP0084 || a.AccountNumber != 0 {
+			require.Equal(t, BalanceBreakdown{}, a.Mine)
+			continue
+		}
+		found = true
+		require.Equal(t, "default", a.AccountName)
+		require.Equal(t, expected, a.Mine)
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// TagPolicy restricts the unspent outputs that may be selected as inputs of a
+// new transaction based on their tags.
+type TagPolicy struct {
+	// IncludeTags, when not empty, only allows outputs tagged with at
+	// least one of these tags to be spent.
+	IncludeTags []string
+
+	// ExcludeTags prevents outputs tagged with any of these tags from
+	// being spent.
+	ExcludeTags []string
+
+	// CarryTagsToChange tags the change output of a sent transaction with
+	// every tag of the outputs it spends.
+	CarryTagsToChange bool
+}
+
+// Allows returns whether an output with the given label and tags may be spent
+// under the policy.  A nil policy allows every output.
+func (p *TagPolicy) Allows(l *wtxmgr.OutputLabel) bool {
+	if p == nil {
+		return true
+	}
+	if l == nil {
+		return len(p.IncludeTags) == 0
+	}
+	for _, tag := range p.ExcludeTags {
+		if l.HasTag(tag) {
+			return false
+		}
+	}
+	if len(p.IncludeTags) == 0 {
+		return true
+	}
+	for _, tag := range p.IncludeTags {
+		if l.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOutputLabel replaces the label and tags of an unspent wallet output.
+// Passing an empty label and no tags removes them.
+func (w *Wallet) SetOutputLabel(op wire.OutPoint, label string,
+	tags []string) error {
+
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+		return w.TxStore.PutOutputLabel(txmgrNs, op, label, tags)
+	})
+}
+
+// OutputLabel returns the label and tags of a wallet output, or nil if it has
+// neither.
+func (w *Wallet) OutputLabel(op wire.OutPoint) (*wtxmgr.OutputLabel, error) {
+	var l *wtxmgr.OutputLabel
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		l, err = w.TxStore.FetchOutputLabel(txmgrNs, op)
+		return err
+	})
+	return l, err
+}
+
+// OutputLabels returns the label and tags of every labeled or tagged wallet
+// output.
+func (w *Wallet) OutputLabels() (map[wire.OutPoint]*wtxmgr.OutputLabel, error) {
+	labels := make(map[wire.OutPoint]*wtxmgr.OutputLabel)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		return w.TxStore.ForEachOutputLabel(txmgrNs,
+			func(op wire.OutPoint, l *wtxmgr.OutputLabel) error {
+				labels[op] = l
+				return nil
+			})
+	})
+	return labels, err
+}
+
+// carryTagsToChange tags the change output of a published transaction with
+// the tags of the outputs it spends.
+func (w *Wallet) carryTagsToChange(tx *wire.MsgTx, changeIndex int) error {
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+
+		var tags []string
+		for _, txIn := range tx.TxIn {
+			l, err := w.TxStore.FetchOutputLabel(
+				txmgrNs, txIn.PreviousOutPoint,
+			)
+			if err != nil {
+				return err
+			}
+			if l != nil {
+				tags = append(tags, l.Tags...)
+			}
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+
+		change := wire.OutPoint{
+			Hash:  tx.TxHash(),
+			Index: uint32(changeIndex),
+		}
+		return w.TxStore.PutOutputLabel(txmgrNs, change, "", tags)
+	})
+}
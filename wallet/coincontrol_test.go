@@ -0,0 +1,116 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// TestTagPolicy ensures that coin selection honours the tags of outputs, and
+// that tags can be carried over to the change output.
+func TestTagPolicy(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// Add two confirmed outputs, tagged differently.
+	var outPoints []wire.OutPoint
+	for i := 0; i < 2; i++ {
+		incomingTx := &wire.MsgTx{
+			TxIn: []*wire.TxIn{{
+				PreviousOutPoint: wire.OutPoint{Index: uint32(i)},
+			}},
+			TxOut: []*wire.TxOut{wire.NewTxOut(100000, pkScript)},
+		}
+		addUtxo(t, w, incomingTx)
+		outPoints = append(outPoints, wire.OutPoint{
+			Hash: incomingTx.TxHash(),
+		})
+	}
+	reserve, verified := outPoints[0], outPoints[1]
+	err = w.SetOutputLabel(reserve, "reserve", []string{
+		"cold-reserve", "do-not-spend",
+	})
+	require.NoError(t, err)
+	err = w.SetOutputLabel(verified, "", []string{"kyc-verified"})
+	require.NoError(t, err)
+
+	labels, err := w.OutputLabels()
+	require.NoError(t, err)
+	require.Equal(t, map[wire.OutPoint]*wtxmgr.OutputLabel{
+		reserve: {
+			Label: "reserve",
+			Tags:  []string{"cold-reserve", "do-not-spend"},
+		},
+		verified: {Tags: []string{"kyc-verified"}},
+	}, labels)
+
+	txOuts := []*wire.TxOut{wire.NewTxOut(50000, pkScript)}
+	inputs := func(policy *TagPolicy) []wire.OutPoint {
+		t.Helper()
+
+		tx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, policy, true)
+		require.NoError(t, err)
+		var ops []wire.OutPoint
+		for _, txIn := range tx.Tx.TxIn {
+			ops = append(ops, txIn.PreviousOutPoint)
+		}
+		return ops
+	}
+
+	require.Equal(t, []wire.OutPoint{verified}, inputs(&TagPolicy{
+		ExcludeTags: []string{"do-not-spend"},
+	}))
+	require.Equal(t, []wire.OutPoint{reserve}, inputs(&TagPolicy{
+		IncludeTags: []string{"cold-reserve", "unused"},
+	}))
+
+	// No output is eligible when all of them are excluded, or when the
+	// included tags are excluded as well.
+	policies := []*TagPolicy{
+		{ExcludeTags: []string{"do-not-spend", "kyc-verified"}},
+		{
+			IncludeTags: []string{"cold-reserve"},
+			ExcludeTags: []string{"do-not-spend"},
+		},
+	}
+	for _, policy := range policies {
+		_, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, policy, true)
+		require.Error(t, err)
+	}
+
+	// The tags of the spent output are carried over to the change output
+	// once the transaction is published.
+	policy := &TagPolicy{
+		IncludeTags:       []string{"kyc-verified"},
+		CarryTagsToChange: true,
+	}
+	tx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, policy, false)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, tx.ChangeIndex, 0)
+	_, err = w.reliablyPublishTransaction(tx.Tx, "")
+	require.NoError(t, err)
+	require.NoError(t, w.carryTagsToChange(tx.Tx, tx.ChangeIndex))
+
+	change := wire.OutPoint{
+		Hash:  tx.Tx.TxHash(),
+		Index: uint32(tx.ChangeIndex),
+	}
+	l, err := w.OutputLabel(change)
+	require.NoError(t, err)
+	require.Equal(t, &wtxmgr.OutputLabel{Tags: []string{"kyc-verified"}}, l)
+}
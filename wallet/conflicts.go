@@ -0,0 +1,141 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// ConflictedCategory is the category under which listtransactions and
+// gettransaction RPC responses report transactions that were removed because
+// a conflicting transaction was mined.
+const ConflictedCategory = "conflicted"
+
+// AbandonedCategory is the category under which listtransactions and
+// gettransaction RPC responses report transactions that were abandoned.
+const AbandonedCategory = "abandoned"
+
+// ConflictedTxDetails returns the details of a conflicted transaction in the
+// form used for recorded transactions.  Conflicted transactions are never
+// mined.
+func ConflictedTxDetails(c *wtxmgr.ConflictedTx) *wtxmgr.TxDetails {
+	return &wtxmgr.TxDetails{
+		TxRecord: c.TxRecord,
+		Block: wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Height: -1},
+		},
+		Credits: c.Credits,
+		Debits:  c.Debits,
+	}
+}
+
+// AbandonedTxDetails returns the details of an abandoned transaction in the
+// form used for recorded transactions.  Abandoned transactions are never mined.
+func AbandonedTxDetails(a *wtxmgr.AbandonedTx) *wtxmgr.TxDetails {
+	return &wtxmgr.TxDetails{
+		TxRecord: a.TxRecord,
+		Block: wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Height: -1},
+		},
+		Credits: a.Credits,
+		Debits:  a.Debits,
+	}
+}
+
+// AbandonTransaction removes an unmined transaction, and every unmined
+// transaction spending its outputs, from the wallet.  The outputs spent by the
+// removed transactions become spendable again and the removed transactions are
+// no longer rebroadcast.  This is intended for transactions that were evicted
+// from mempools and will never confirm.  The abandoned transactions are kept as
+// records of the wallet history.
+//
+// ErrTxConfirmed is returned if the transaction was mined, and
+// wtxmgr.ErrUnminedTxNotFound if it is not an unmined wallet transaction.
+func (w *Wallet) AbandonTransaction(txHash *chainhash.Hash) error {
+	return walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+
+		details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+		if err != nil {
+			return err
+		}
+		if details != nil && details.Block.Height != -1 {
+			return ErrTxConfirmed
+		}
+
+		return w.TxStore.AbandonTx(txmgrNs, txHash)
+	})
+}
+
+// listTransactionsWithConflicts returns the listtransactions results of a
+// recorded transaction followed by those of every transaction that was removed
+// because it conflicted with it.  The results of both name the transactions
+// they conflict with.  Conflicted transactions are listed under the conflicted
+// category with the negated confirmations of the conflicting transaction.
+// Abandoned transactions are listed under the abandoned category.
+func (w *Wallet) listTransactionsWithConflicts(dbtx walletdb.ReadTx,
+	details *wtxmgr.TxDetails,
+	syncHeight int32) []btcjson.ListTransactionsResult {
+
+	results := listTransactions(
+		dbtx, details, w.Manager, syncHeight, w.chainParams,
+	)
+
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+	if details.Block.Height == -1 {
+		abandoned, err := w.TxStore.AbandonedTx(txmgrNs, &details.Hash)
+		if err != nil {
+			log.Errorf("Cannot fetch abandoned transaction %v: %v",
+				details.Hash, err)
+			return results
+		}
+		if abandoned != nil {
+			for i := range results {
+				results[i].Category = AbandonedCategory
+			}
+			return results
+		}
+	}
+
+	conflicts, err := w.TxStore.ConflictsOf(txmgrNs, &details.Hash)
+	if err != nil {
+		log.Errorf("Cannot fetch conflicts of transaction %v: %v",
+			details.Hash, err)
+		return results
+	}
+	if len(conflicts) == 0 {
+		return results
+	}
+
+	conflictHashes := make([]string, 0, len(conflicts))
+	for i := range conflicts {
+		conflictHashes = append(conflictHashes, conflicts[i].Hash.String())
+	}
+	for i := range results {
+		results[i].WalletConflicts = conflictHashes
+	}
+
+	var confirmations int64
+	if details.Block.Height != -1 {
+		confirmations = -int64(confirms(details.Block.Height, syncHeight))
+	}
+	winner := []string{details.Hash.String()}
+	for i := range conflicts {
+		conflictResults := listTransactions(
+			dbtx, ConflictedTxDetails(&conflicts[i]), w.Manager,
+			syncHeight, w.chainParams,
+		)
+		for _, result := range conflictResults {
+			result.Category = ConflictedCategory
+			result.Confirmations = confirmations
+			result.WalletConflicts = winner
+			results = append(results, result)
+		}
+	}
+	return results
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// addTestSpend records a transaction spending the first output of prevHash to
+// pkScript, mined at the given height or unmined if height is -1.
+func addTestSpend(t *testing.T, w *Wallet, prevHash *chainhash.Hash,
+	pkScript []byte, value int64, height int32) *wtxmgr.TxRecord {
+
+	t.Helper()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(prevHash, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(value, pkScript))
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	require.NoError(t, err)
+
+	var block *wtxmgr.BlockMeta
+	if height != -1 {
+		block = &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{Height: height},
+			Time:  time.Unix(int64(height), 0),
+		}
+	}
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		return w.addRelevantTx(dbtx, rec, block)
+	})
+	require.NoError(t, err)
+
+	return rec
+}
+
+// TestListConflictedTransactions ensures that a transaction removed because a
+// double spend was mined is notified and listed as conflicted alongside the
+// transaction it conflicted with.
+func TestListConflictedTransactions(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+
+	ntfns := w.NtfnServer.ConflictNotifications()
+	defer ntfns.Done()
+	received := make(chan *ConflictNotification, 1)
+	go func() {
+		received <- <-ntfns.C
+	}()
+
+	replaced := addTestSpend(t, w, &fundingHash, pkScript, 9e7, -1)
+	replacement := addTestSpend(t, w, &fundingHash, pkScript, 8e7, 101)
+
+	select {
+	case n := <-received:
+		require.Equal(t, replaced.Hash, *n.Hash)
+		require.Equal(t, replacement.Hash, *n.ConflictingTx)
+	case <-time.After(5 * time.Second):
+		t.Fatal("conflict notification not received")
+	}
+
+	results, err := w.ListAllTransactions()
+	require.NoError(t, err)
+
+	var conflicted, winning int
+	for _, result := range results {
+		switch result.TxID {
+		case replaced.Hash.String():
+			conflicted++
+			require.Equal(t, ConflictedCategory, result.Category)
+			require.Equal(t, []string{
+				replacement.Hash.String(),
+			}, result.WalletConflicts)
+
+		case replacement.Hash.String():
+			winning++
+			require.NotEqual(t, ConflictedCategory, result.Category)
+			require.Equal(t, []string{
+				replaced.Hash.String(),
+			}, result.WalletConflicts)
+		}
+	}
+	require.NotZero(t, conflicted)
+	require.NotZero(t, winning)
+}
+
+// TestConflictNotificationsQueued ensures that conflict notifications are
+// queued for clients that are not receiving them, rather than blocking the
+// removal of the conflicted transactions.
+func TestConflictNotificationsQueued(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	ntfns := w.NtfnServer.ConflictNotifications()
+	defer ntfns.Done()
+
+	// Both the replaced transaction and its child are removed, while the
+	// client is not receiving.
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	replaced := addTestSpend(t, w, &fundingHash, pkScript, 9e7, -1)
+	child := addTestSpend(t, w, &replaced.Hash, pkScript, 8e7, -1)
+	addTestSpend(t, w, &fundingHash, pkScript, 7e7, 101)
+
+	var notified []chainhash.Hash
+	for len(notified) < 2 {
+		select {
+		case n := <-ntfns.C:
+			notified = append(notified, *n.Hash)
+		case <-time.After(5 * time.Second):
+			t.Fatal("conflict notification not received")
+		}
+	}
+	require.ElementsMatch(
+		t, []chainhash.Hash{replaced.Hash, child.Hash}, notified,
+	)
+}
+
+// TestAbandonTransaction ensures that abandoning an unmined transaction and its
+// descendants restores the outputs they spent, while mined transactions can't
+// be abandoned.
+func TestAbandonTransaction(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	spend := addTestSpend(t, w, &fundingHash, pkScript, 9e7, -1)
+	child := addTestSpend(t, w, &spend.Hash, pkScript, 8e7, -1)
+
+	// unspent returns the outpoints of the wallet's unspent outputs.
+	unspent := func() []wire.OutPoint {
+		t.Helper()
+
+		var ops []wire.OutPoint
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+			ns := dbtx.ReadBucket(wtxmgrNamespaceKey)
+			credits, err := w.TxStore.UnspentOutputs(ns)
+			for _, credit := range credits {
+				ops = append(ops, credit.OutPoint)
+			}
+			return err
+		})
+		require.NoError(t, err)
+		return ops
+	}
+	require.Equal(t, []wire.OutPoint{{Hash: child.Hash}}, unspent())
+
+	require.Equal(t, ErrTxConfirmed, w.AbandonTransaction(&fundingHash))
+	require.NoError(t, w.AbandonTransaction(&spend.Hash))
+	require.Equal(
+		t, wtxmgr.ErrUnminedTxNotFound, w.AbandonTransaction(&child.Hash),
+	)
+
+	require.Equal(t, []wire.OutPoint{{Hash: fundingHash}}, unspent())
+
+	abandoned, err := UnstableAPI(w).AbandonedTx(&child.Hash)
+	require.NoError(t, err)
+	require.NotNil(t, abandoned)
+	require.Equal(t, spend.Hash, abandoned.Root)
+}
+
+// TestListAbandonedTransactions ensures that abandoned transactions are listed
+// under the abandoned category by the transaction history RPCs and marked as
+// abandoned in the transaction summaries.
+func TestListAbandonedTransactions(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	spend := addTestSpend(t, w, &fundingHash, pkScript, 9e7, -1)
+	child := addTestSpend(t, w, &spend.Hash, pkScript, 8e7, -1)
+	require.NoError(t, w.AbandonTransaction(&spend.Hash))
+
+	// requireCategories checks that the results of the abandoned
+	// transactions, and only those, are under the abandoned category.
+	requireCategories := func(results []btcjson.ListTransactionsResult) {
+		t.Helper()
+
+		abandoned := make(map[string]int)
+		for _, result := range results {
+			switch result.TxID {
+			case spend.Hash.String(), child.Hash.String():
+				abandoned[result.TxID]++
+				require.Equal(t, AbandonedCategory, result.Category)
+
+			default:
+				require.NotEqual(t, AbandonedCategory, result.Category)
+			}
+		}
+		require.Len(t, abandoned, 2)
+	}
+
+	results, err := w.ListTransactions(0, 10)
+	require.NoError(t, err)
+	requireCategories(results)
+
+	results, err = w.ListSinceBlock(0, -1, 100)
+	require.NoError(t, err)
+	requireCategories(results)
+
+	results, _, err = w.ListTransactionsPage(&TransactionPage{})
+	require.NoError(t, err)
+	requireCategories(results)
+
+	res, err := w.GetTransactions(nil, nil, "", nil)
+	require.NoError(t, err)
+	require.Len(t, res.MinedTransactions, 1)
+	require.False(t, res.MinedTransactions[0].Transactions[0].Abandoned)
+
+	abandoned := make(map[chainhash.Hash]bool)
+	for _, tx := range res.UnminedTransactions {
+		abandoned[*tx.Hash] = tx.Abandoned
+	}
+	require.Equal(t, map[chainhash.Hash]bool{
+		spend.Hash: true,
+		child.Hash: true,
+	}, abandoned)
+}
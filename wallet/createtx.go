@@ -6,6 +6,7 @@
 package wallet
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 
@@ -97,6 +98,61 @@ func (s secretSource) GetScript(addr btcutil.Address) ([]byte, error) {
 	return msa.Script()
 }
 
+// addInputScripts signs every input of an authored transaction. Inputs spending
+// outputs paid to wallet public key addresses are signed by the given signer,
+// while pay-to-script-hash inputs are signed with the secrets stored by the
+// address manager.
+func (w *Wallet) addInputScripts(addrmgrNs walletdb.ReadBucket, signer Signer,
+	tx *txauthor.AuthoredTx) error {
+
+	inputs := tx.Tx.TxIn
+	if len(inputs) != len(tx.PrevScripts) ||
+		len(inputs) != len(tx.PrevInputValues) {
+
+		return errors.New("tx.TxIn and prevPkScripts slices must " +
+			"have equal length")
+	}
+
+	secrets := secretSource{w.Manager, addrmgrNs}
+	sigHashes := txscript.NewTxSigHashes(tx.Tx)
+	for i, pkScript := range tx.PrevScripts {
+		addr, err := w.outputPubKeyAddr(addrmgrNs, pkScript)
+		switch {
+		case err == nil:
+			output := wire.NewTxOut(
+				int64(tx.PrevInputValues[i]), pkScript,
+			)
+			witness, sigScript, err := w.signPubKeyInput(
+				addrmgrNs, signer, addr, tx.Tx, i, output,
+				sigHashes, txscript.SigHashAll,
+			)
+			if err != nil {
+				return err
+			}
+			inputs[i].Witness = witness
+			inputs[i].SignatureScript = sigScript
+
+		case txscript.GetScriptClass(pkScript) == txscript.ScriptHashTy:
+			sigScript, err := txscript.SignTxOutput(
+				w.chainParams, tx.Tx, i, pkScript,
+				txscript.SigHashAll,
+				txscript.KeyClosure(secrets.GetKey),
+				txscript.ScriptClosure(secrets.GetScript),
+				inputs[i].SignatureScript,
+			)
+			if err != nil {
+				return err
+			}
+			inputs[i].SignatureScript = sigScript
+
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
 // txToOutputs creates a signed transaction which includes each output from
 // outputs.  Previous outputs to reedeem are chosen from the passed account's
 // UTXO set and minconf policy. An additional output may be added to return
@@ -104,14 +160,15 @@ func (s secretSource) GetScript(addr btcutil.Address) ([]byte, error) {
 // given key scope and account. If a key scope is not specified, the address
 // will always be generated from the P2WKH key scope. An appropriate fee is
 // included based on the wallet's current relay fee. The wallet must be
-// unlocked to create the transaction.
+// unlocked to create the transaction.  Outputs not allowed by the tag policy,
+// if any, are never selected.
 //
 // NOTE: The dryRun argument can be set true to create a tx that doesn't alter
 // the database. A tx created with this set to true will intentionally have no
 // input scripts added and SHOULD NOT be broadcasted.
 func (w *Wallet) txToOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
-	account uint32, minconf int32, feeSatPerKb btcutil.Amount, dryRun bool) (
-	tx *txauthor.AuthoredTx, err error) {
+	account uint32, minconf int32, feeSatPerKb btcutil.Amount,
+	tagPolicy *TagPolicy, dryRun bool) (tx *txauthor.AuthoredTx, err error) {
 
 	chainClient, err := w.requireChainClient()
 	if err != nil {
@@ -138,7 +195,7 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	}
 
 	eligible, err := w.findEligibleOutputs(
-		dbtx, keyScope, account, minconf, bs,
+		dbtx, keyScope, account, minconf, bs, tagPolicy,
 	)
 	if err != nil {
 		return nil, err
@@ -169,7 +226,8 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 
 	// Before committing the transaction, we'll sign our inputs. If the
 	// inputs are part of a watch-only account, there's no private key
-	// information stored, so we'll skip signing such.
+	// information stored, so we'll skip signing such unless an external
+	// signer holding the keys was set.
 	var watchOnly bool
 	if keyScope == nil {
 		// If a key scope wasn't specified, then coin selection was
@@ -187,8 +245,9 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	if err != nil {
 		return nil, err
 	}
-	if !watchOnly {
-		err = tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+	signer, externalSigner := w.currentSigner()
+	if !watchOnly || externalSigner {
+		err = w.addInputScripts(addrmgrNs, signer, tx)
 		if err != nil {
 			return nil, err
 		}
@@ -229,41 +288,49 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 
 func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx,
 	keyScope *waddrmgr.KeyScope, account uint32, minconf int32,
-	bs *waddrmgr.BlockStamp) ([]wtxmgr.Credit, error) {
+	bs *waddrmgr.BlockStamp, tagPolicy *TagPolicy) ([]wtxmgr.Credit, error) {
 
 	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
 	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
 
-	unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
-	if err != nil {
-		return nil, err
-	}
-
 	// TODO: Eventually all of these filters (except perhaps output locking)
-	// should be handled by the call to UnspentOutputs (or similar).
+	// should be handled by the call to ForEachUnspentOutput (or similar).
 	// Because one of these filters requires matching the output script to
 	// the desired account, this change depends on making wtxmgr a waddrmgr
 	// dependency and requesting unspent outputs for a single account.
-	eligible := make([]wtxmgr.Credit, 0, len(unspent))
-	for i := range unspent {
-		output := &unspent[i]
+	var eligible []wtxmgr.Credit
+	err := w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(
+		output *wtxmgr.Credit) (bool, error) {
 
 		// Only include this output if it meets the required number of
 		// confirmations.  Coinbase transactions must have have reached
 		// maturity before their outputs may be spent.
 		if !confirmed(minconf, output.Height, bs.Height) {
-			continue
+			return false, nil
 		}
 		if output.FromCoinBase {
 			target := int32(w.chainParams.CoinbaseMaturity)
 			if !confirmed(target, output.Height, bs.Height) {
-				continue
+				return false, nil
 			}
 		}
 
 		// Locked unspent outputs are skipped.
 		if w.LockedOutpoint(output.OutPoint) {
-			continue
+			return false, nil
+		}
+
+		// Outputs not allowed by the tag policy are skipped.
+		if tagPolicy != nil {
+			l, err := w.TxStore.FetchOutputLabel(
+				txmgrNs, output.OutPoint,
+			)
+			if err != nil {
+				return false, err
+			}
+			if !tagPolicy.Allows(l) {
+				return false, nil
+			}
 		}
 
 		// Only include the output if it is associated with the passed
@@ -274,19 +341,23 @@ func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx,
 		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
 			output.PkScript, w.chainParams)
 		if err != nil || len(addrs) != 1 {
-			continue
+			return false, nil
 		}
 		scopedMgr, addrAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
 		if err != nil {
-			continue
+			return false, nil
 		}
 		if keyScope != nil && scopedMgr.Scope() != *keyScope {
-			continue
+			return false, nil
 		}
 		if addrAcct != account {
-			continue
+			return false, nil
 		}
 		eligible = append(eligible, *output)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return eligible, nil
 }
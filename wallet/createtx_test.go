@@ -71,7 +71,7 @@ func TestTxToOutputsDryRun(t *testing.T) {
 
 	// First do a few dry-runs, making sure the number of addresses in the
 	// database us not inflated.
-	dryRunTx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, true)
+	dryRunTx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, nil, true)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
 	}
@@ -86,7 +86,7 @@ func TestTxToOutputsDryRun(t *testing.T) {
 		t.Fatalf("expected 1 address, found %v", len(addresses))
 	}
 
-	dryRunTx2, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, true)
+	dryRunTx2, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, nil, true)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
 	}
@@ -119,7 +119,7 @@ func TestTxToOutputsDryRun(t *testing.T) {
 
 	// Now we do a proper, non-dry run. This should add a change address
 	// to the database.
-	tx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, false)
+	tx, err := w.txToOutputs(txOuts, nil, 0, 1, 1000, nil, false)
 	if err != nil {
 		t.Fatalf("unable to author tx: %v", err)
 	}
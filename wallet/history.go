@@ -18,12 +18,18 @@ var (
 	// top level bucket that stores the mapping between a txid and a
 	// user-defined transaction label.
 	bucketTxLabels = []byte("l")
+
+	// bucketOutputLabels is the name of the sub bucket of the wtxmgr top
+	// level bucket that stores the labels and tags of outputs.
+	bucketOutputLabels = []byte("ol")
 )
 
 // DropTransactionHistory completely removes and re-creates the transaction
 // manager namespace from the given wallet database. This can be used to force
 // a full chain rescan of all wallet transaction and UTXO data. User-defined
-// transaction labels can optionally be kept by setting keepLabels to true.
+// transaction and output labels can optionally be kept by setting keepLabels
+// to true.
+// Address labels are stored by the address manager and are always kept.
 func DropTransactionHistory(db walletdb.DB, keepLabels bool) error {
 	log.Infof("Dropping btcwallet transaction history")
 
@@ -31,14 +37,19 @@ func DropTransactionHistory(db walletdb.DB, keepLabels bool) error {
 		// If we want to keep our tx labels, we read them out so we
 		// can re-add them after we have deleted our wtxmgr.
 		var (
-			labels map[chainhash.Hash]string
-			err    error
+			labels       map[chainhash.Hash]string
+			outputLabels map[string][]byte
+			err          error
 		)
 		if keepLabels {
 			labels, err = fetchAllLabels(tx)
 			if err != nil {
 				return err
 			}
+			outputLabels, err = fetchRawOutputLabels(tx)
+			if err != nil {
+				return err
+			}
 		}
 
 		err = tx.DeleteTopLevelBucket(wtxmgrNamespaceKey)
@@ -59,9 +70,28 @@ func DropTransactionHistory(db walletdb.DB, keepLabels bool) error {
 			if err := putTxLabels(ns, labels); err != nil {
 				return err
 			}
+			err := putRawOutputLabels(ns, outputLabels)
+			if err != nil {
+				return err
+			}
 		}
 
+		// The checkpoints of interrupted rescans are removed, as the
+		// blocks they processed need to be rescanned.
 		ns = tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		checkpoints, err := waddrmgr.FetchRescanCheckpoints(ns)
+		if err != nil {
+			return err
+		}
+		for i := range checkpoints {
+			err := waddrmgr.DeleteRescanCheckpoint(
+				ns, &checkpoints[i].SetHash,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
 		birthdayBlock, err := waddrmgr.FetchBirthdayBlock(ns)
 		if err != nil {
 			log.Warnf("Wallet does not have a birthday block " +
@@ -94,6 +124,47 @@ func DropTransactionHistory(db walletdb.DB, keepLabels bool) error {
 	return nil
 }
 
+// VerifyTransactionHistory checks the consistency of the transaction manager
+// namespace of the given wallet database, and repairs the inconsistencies found
+// when repair is true.  Unlike DropTransactionHistory, transaction records are
+// kept, so no rescan is needed after a repair.
+func VerifyTransactionHistory(db walletdb.DB, repair bool) (
+	*wtxmgr.VerifyReport, error) {
+
+	var report *wtxmgr.VerifyReport
+	if !repair {
+		err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+			var err error
+			report, err = wtxmgr.Verify(
+				tx.ReadBucket(wtxmgrNamespaceKey),
+			)
+			return err
+		})
+		return report, err
+	}
+
+	log.Infof("Repairing btcwallet transaction history")
+
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		var err error
+		report, err = wtxmgr.Repair(tx.ReadWriteBucket(wtxmgrNamespaceKey))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Problems {
+		log.Infof("Repaired %v", &report.Problems[i])
+	}
+	return report, nil
+}
+
+// VerifyTxStore checks the consistency of the transaction store of the wallet,
+// and repairs the inconsistencies found when repair is true.
+func (w *Wallet) VerifyTxStore(repair bool) (*wtxmgr.VerifyReport, error) {
+	return VerifyTransactionHistory(w.db, repair)
+}
+
 // fetchAllLabels returns a map of hex-encoded txid to label.
 func fetchAllLabels(tx walletdb.ReadWriteTx) (map[chainhash.Hash]string,
 	error) {
@@ -159,3 +230,44 @@ func putTxLabels(ns walletdb.ReadWriteBucket,
 
 	return nil
 }
+
+// fetchRawOutputLabels returns the serialized output labels of the wtxmgr
+// namespace, keyed by their serialized outpoint.
+func fetchRawOutputLabels(tx walletdb.ReadWriteTx) (map[string][]byte, error) {
+	txBucket := tx.ReadBucket(wtxmgrNamespaceKey)
+	if txBucket == nil {
+		return nil, nil
+	}
+	labelsBucket := txBucket.NestedReadBucket(bucketOutputLabels)
+	if labelsBucket == nil {
+		return nil, nil
+	}
+
+	labels := make(map[string][]byte)
+	err := labelsBucket.ForEach(func(k, v []byte) error {
+		labels[string(k)] = append([]byte(nil), v...)
+		return nil
+	})
+	return labels, err
+}
+
+// putRawOutputLabels re-adds serialized output labels to the output labels
+// bucket of the wtxmgr namespace.
+func putRawOutputLabels(ns walletdb.ReadWriteBucket,
+	labels map[string][]byte) error {
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	labelsBucket, err := ns.CreateBucketIfNotExists(bucketOutputLabels)
+	if err != nil {
+		return err
+	}
+	for k, v := range labels {
+		if err := labelsBucket.Put([]byte(k), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
 	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/walletdb"
 )
@@ -20,6 +21,11 @@ const (
 
 	// pubKeyDepth is the depth of an extended key for a derived public key.
 	pubKeyDepth = 5
+
+	// defaultAccountRecoveryWindow is the recovery window used to scan for
+	// the addresses of an imported account if the wallet wasn't opened
+	// with one.
+	defaultAccountRecoveryWindow = 250
 )
 
 // keyScopeFromPubKey returns the corresponding wallet key scope for the given
@@ -213,6 +219,266 @@ func (w *Wallet) ImportAccount(name string, accountPubKey *hdkeychain.ExtendedKe
 	return accountProps, err
 }
 
+// hdPubKeyVersions maps the versions of account extended private keys to the
+// versions of their corresponding extended public keys.
+var hdPubKeyVersions = map[waddrmgr.HDVersion]waddrmgr.HDVersion{
+	waddrmgr.HDVersionMainNetBIP0044Priv: waddrmgr.HDVersionMainNetBIP0044,
+	waddrmgr.HDVersionMainNetBIP0049Priv: waddrmgr.HDVersionMainNetBIP0049,
+	waddrmgr.HDVersionMainNetBIP0084Priv: waddrmgr.HDVersionMainNetBIP0084,
+	waddrmgr.HDVersionTestNetBIP0044Priv: waddrmgr.HDVersionTestNetBIP0044,
+	waddrmgr.HDVersionTestNetBIP0049Priv: waddrmgr.HDVersionTestNetBIP0049,
+	waddrmgr.HDVersionTestNetBIP0084Priv: waddrmgr.HDVersionTestNetBIP0084,
+}
+
+// accountPubKeyFromPrivKey returns the extended public key of an account
+// extended private key, encoded with the public version corresponding to the
+// private key's version.
+func (w *Wallet) accountPubKeyFromPrivKey(privKey *hdkeychain.ExtendedKey) (
+	*hdkeychain.ExtendedKey, error) {
+
+	if !privKey.IsPrivate() {
+		return nil, errors.New("expected extended private key")
+	}
+
+	privVersion := waddrmgr.HDVersion(binary.BigEndian.Uint32(privKey.Version()))
+	pubVersion, ok := hdPubKeyVersions[privVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown version %x", privKey.Version())
+	}
+
+	// Neutering requires the key's version to be registered with the
+	// network, so we'll use the network's default version before applying
+	// the public version corresponding to the private key.
+	key, err := privKey.CloneWithVersion(w.chainParams.HDPrivateKeyID[:])
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := key.Neuter()
+	if err != nil {
+		return nil, err
+	}
+
+	var version [4]byte
+	binary.BigEndian.PutUint32(version[:], uint32(pubVersion))
+	return pubKey.CloneWithVersion(version[:])
+}
+
+// ImportAccountPrivKey imports a spendable account backed by an account
+// extended private key. The private key is stored encrypted with the wallet's
+// private crypto key, so the wallet must be unlocked. The master key
+// fingerprint and address type are handled as in ImportAccount, with the key
+// scope determined from the version of the extended private key.
+//
+// If rescan is true, the chain is scanned from the given block for
+// transactions involving the account's addresses, and the account is extended
+// to include all addresses found. If a block stamp is not provided, the scan
+// starts at the genesis block.
+func (w *Wallet) ImportAccountPrivKey(name string,
+	accountPrivKey *hdkeychain.ExtendedKey, masterKeyFingerprint uint32,
+	addrType *waddrmgr.AddressType, bs *waddrmgr.BlockStamp,
+	rescan bool) (*waddrmgr.AccountProperties, error) {
+
+	// Ensure we have a valid account key by validating its public
+	// counterpart.
+	accountPubKey, err := w.accountPubKeyFromPrivKey(accountPrivKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.validateExtendedPubKey(accountPubKey, true); err != nil {
+		return nil, err
+	}
+
+	// Determine what key scope the account key should belong to and
+	// whether it should use a custom address schema.
+	keyScope, addrSchema, err := keyScopeFromPubKey(accountPubKey, addrType)
+	if err != nil {
+		return nil, err
+	}
+	scopedMgr, err := w.Manager.FetchScopedKeyManager(keyScope)
+	if err != nil {
+		return nil, err
+	}
+
+	var chainClient chain.Interface
+	if rescan {
+		chainClient, err = w.requireChainClient()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Store the account along with its encrypted private key within the
+	// database.
+	var account uint32
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		account, err = scopedMgr.NewAccountFromPrivKey(
+			ns, name, accountPrivKey, masterKeyFingerprint,
+			addrSchema,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Imported account %q (%d) with key scope %v", name,
+		account, keyScope)
+
+	if rescan {
+		// The addresses the scan starts looking for are registered for
+		// notifications before it starts, as the scan stops at the
+		// current best block and would otherwise miss transactions of
+		// blocks connected in the meantime.
+		lookahead, err := w.accountLookaheadAddrs(
+			scopedMgr, account, w.accountRecoveryWindow(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		err = chainClient.NotifyReceived(lookahead)
+		if err != nil {
+			return nil, fmt.Errorf("unable to subscribe for address "+
+				"notifications: %v", err)
+		}
+
+		var startHeight int32
+		if bs != nil {
+			startHeight = bs.Height
+		}
+		err = w.recoverAccount(chainClient, scopedMgr, account, startHeight)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch the account properties, which reflect any addresses found
+	// while scanning, and the addresses we should be notified about.
+	var (
+		accountProps *waddrmgr.AccountProperties
+		addrs        []btcutil.Address
+	)
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		accountProps, err = scopedMgr.AccountProperties(ns, account)
+		if err != nil {
+			return err
+		}
+		return scopedMgr.ForEachAccountAddress(ns, account,
+			func(maddr waddrmgr.ManagedAddress) error {
+				addrs = append(addrs, maddr.Address())
+				return nil
+			})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) > 0 && chainClient != nil {
+		err := chainClient.NotifyReceived(addrs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to subscribe for address "+
+				"notifications: %v", err)
+		}
+	}
+
+	w.NtfnServer.notifyAccountProperties(accountProps)
+
+	return accountProps, nil
+}
+
+// accountRecoveryWindow returns the recovery window used to scan for the
+// addresses of an imported account.
+func (w *Wallet) accountRecoveryWindow() uint32 {
+	if w.recoveryWindow == 0 {
+		return defaultAccountRecoveryWindow
+	}
+	return w.recoveryWindow
+}
+
+// accountLookaheadAddrs returns the addresses of the first window indexes of
+// both branches of an account, without adding them to the account.
+func (w *Wallet) accountLookaheadAddrs(scopedMgr *waddrmgr.ScopedKeyManager,
+	account, window uint32) ([]btcutil.Address, error) {
+
+	addrs := make([]btcutil.Address, 0, 2*window)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		for _, branch := range []uint32{
+			waddrmgr.ExternalBranch, waddrmgr.InternalBranch,
+		} {
+			for index := uint32(0); index < window; index++ {
+				addr, err := scopedMgr.DeriveFromKeyPath(
+					ns, waddrmgr.DerivationPath{
+						InternalAccount: account,
+						Branch:          branch,
+						Index:           index,
+					},
+				)
+				if err != nil {
+					return err
+				}
+				addrs = append(addrs, addr.Address())
+			}
+		}
+		return nil
+	})
+	return addrs, err
+}
+
+// recoverAccount scans the chain from the given height for transactions
+// involving the addresses of a single account. Addresses are derived up to the
+// recovery window past the last one found on each branch, and the account is
+// extended to include every address found. Any relevant transactions are
+// recorded as they would be while recovering the wallet.
+func (w *Wallet) recoverAccount(chainClient chain.Interface,
+	scopedMgr *waddrmgr.ScopedKeyManager, account uint32,
+	startHeight int32) error {
+
+	recoveryState := NewRecoveryState(w.accountRecoveryWindow())
+	scopedMgrs := map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager{
+		scopedMgr.Scope(): scopedMgr,
+	}
+
+	// Fetch the best height from the backend to determine when we should
+	// stop.
+	_, bestHeight, err := chainClient.GetBestBlock()
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Scanning blocks %d-%d for addresses of account %d",
+		startHeight, bestHeight, account)
+
+	// As with wallet recovery, we'll fetch the blocks ahead of time and
+	// scan them in batches.
+	fetcher := newRecoveryFetcher(
+		chainClient, startHeight, bestHeight, startHeight,
+	)
+	defer fetcher.stop()
+
+	for {
+		blocks, err := fetcher.next()
+		if err != nil {
+			return err
+		}
+		if blocks == nil {
+			return nil
+		}
+
+		err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			return w.recoverScopedAddresses(
+				fetcher, tx, ns, blocks.filterBlocks, blocks,
+				recoveryState, scopedMgrs, account,
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // ImportPublicKey imports a single derived public key into the address manager.
 // The address type can usually be inferred from the key's version, but in the
 // case of legacy versions (xpub, tpub), an address type must be specified as we
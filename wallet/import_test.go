@@ -4,12 +4,16 @@ import (
 	"encoding/binary"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tinhnguyenhn/colxd/chaincfg"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
 	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 )
 
@@ -278,3 +282,261 @@ func testImportAccount(t *testing.T, w *Wallet, tc *testCase, watchOnly bool,
 	require.NoError(t, err)
 	require.Equal(t, true, addrManaged.Imported())
 }
+
+func deriveAcctPrivKey(t *testing.T, root *hdkeychain.ExtendedKey,
+	scope waddrmgr.KeyScope, paths ...uint32) *hdkeychain.ExtendedKey {
+
+	path := []uint32{hardenedKey(scope.Purpose), hardenedKey(scope.Coin)}
+	path = append(path, paths...)
+
+	currentKey := root
+	for _, pathPart := range path {
+		var err error
+		currentKey, err = currentKey.Derive(pathPart)
+		require.NoError(t, err)
+	}
+
+	return currentKey
+}
+
+// TestImportAccountPrivKey tests that extended private keys can be imported as
+// spendable accounts into normal wallets, but not into watch-only wallets.
+func TestImportAccountPrivKey(t *testing.T) {
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w, cleanup := testWallet(t)
+			defer cleanup()
+
+			testImportAccountPrivKey(t, w, tc)
+		})
+	}
+
+	t.Run("watch-only", func(t *testing.T) {
+		t.Parallel()
+
+		w, cleanup := testWalletWatchingOnly(t)
+		defer cleanup()
+
+		tc := testCases[len(testCases)-1]
+		root, err := hdkeychain.NewKeyFromString(tc.masterPriv)
+		require.NoError(t, err)
+		acctPriv := deriveAcctPrivKey(
+			t, root, tc.expectedScope, hardenedKey(tc.accountIndex),
+		)
+
+		_, err = w.ImportAccountPrivKey(
+			tc.name, acctPriv, root.ParentFingerprint(),
+			&tc.addrType, nil, false,
+		)
+		require.True(t, waddrmgr.IsError(err, waddrmgr.ErrWatchingOnly))
+	})
+}
+
+func testImportAccountPrivKey(t *testing.T, w *Wallet, tc *testCase) {
+	root, err := hdkeychain.NewKeyFromString(tc.masterPriv)
+	require.NoError(t, err)
+	acctPriv := deriveAcctPrivKey(
+		t, root, tc.expectedScope, hardenedKey(tc.accountIndex),
+	)
+
+	// Public keys can't be imported as spendable accounts.
+	acctPub := deriveAcctPubKey(
+		t, root, tc.expectedScope, hardenedKey(tc.accountIndex),
+	)
+	_, err = w.ImportAccountPrivKey(
+		tc.name, acctPub, root.ParentFingerprint(), &tc.addrType, nil,
+		false,
+	)
+	require.Error(t, err)
+
+	acct, err := w.ImportAccountPrivKey(
+		tc.name, acctPriv, root.ParentFingerprint(), &tc.addrType, nil,
+		false,
+	)
+	require.NoError(t, err)
+	require.Equal(t, tc.expectedScope, acct.KeyScope)
+	require.Equal(t, tc.name, acct.AccountName)
+	require.False(t, acct.IsWatchOnly)
+	require.True(t, acct.IsImported)
+	require.Equal(t, root.ParentFingerprint(), acct.MasterKeyFingerprint)
+	require.NotNil(t, acct.AccountPubKey)
+	require.Equal(t, uint32(0), acct.ExternalKeyCount)
+	require.Equal(t, uint32(0), acct.InternalKeyCount)
+
+	// The account must derive the same addresses as the extended key.
+	addr, err := w.NewAddress(acct.AccountNumber, tc.expectedScope)
+	require.NoError(t, err)
+	require.Equal(t, tc.expectedAddr, addr.String())
+	changeAddr, err := w.NewChangeAddress(
+		acct.AccountNumber, tc.expectedScope,
+	)
+	require.NoError(t, err)
+	require.Equal(t, tc.expectedChangeAddr, changeAddr.String())
+
+	// Unlike watch-only accounts, the private keys of the account's
+	// addresses must be available.
+	privKey, err := w.PrivKeyForAddress(addr)
+	require.NoError(t, err)
+	external, err := acctPriv.Derive(waddrmgr.ExternalBranch)
+	require.NoError(t, err)
+	child, err := external.Derive(0)
+	require.NoError(t, err)
+	expectedPrivKey, err := child.ECPrivKey()
+	require.NoError(t, err)
+	require.Equal(t, expectedPrivKey.Serialize(), privKey.Serialize())
+
+	// Renaming the account must preserve its private key.
+	err = w.RenameAccount(tc.expectedScope, acct.AccountNumber, "renamed")
+	require.NoError(t, err)
+	props, err := w.AccountProperties(tc.expectedScope, acct.AccountNumber)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", props.AccountName)
+	require.False(t, props.IsWatchOnly)
+	require.Equal(t, uint32(1), props.ExternalKeyCount)
+	require.Equal(t, uint32(1), props.InternalKeyCount)
+
+	// Importing the same account name twice must fail.
+	_, err = w.ImportAccountPrivKey(
+		"renamed", acctPriv, 0, &tc.addrType, nil, false,
+	)
+	require.True(t, waddrmgr.IsError(err, waddrmgr.ErrDuplicateAccount))
+}
+
+// accountRecoveryChainClient is a chain client serving a chain of empty blocks
+// in which a single address is reported as found.
+type accountRecoveryChainClient struct {
+	mockChainClient
+
+	bestHeight int32
+	foundAddr  btcutil.Address
+	found      bool
+	notified   []btcutil.Address
+
+	// notifiedBeforeScan is the number of addresses notified before the
+	// first block was filtered, or -1 if no block was filtered yet.
+	notifiedBeforeScan int
+}
+
+func (c *accountRecoveryChainClient) GetBestBlock() (*chainhash.Hash, int32,
+	error) {
+
+	hash, _ := c.GetBlockHash(int64(c.bestHeight))
+	return hash, c.bestHeight, nil
+}
+
+func (c *accountRecoveryChainClient) GetBlockHash(height int64) (
+	*chainhash.Hash, error) {
+
+	var hash chainhash.Hash
+	binary.BigEndian.PutUint64(hash[:], uint64(height))
+	return &hash, nil
+}
+
+func (c *accountRecoveryChainClient) GetBlockHeader(*chainhash.Hash) (
+	*wire.BlockHeader, error) {
+
+	return &wire.BlockHeader{Timestamp: time.Unix(1234, 0)}, nil
+}
+
+func (c *accountRecoveryChainClient) FilterBlocks(
+	req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+
+	if c.notifiedBeforeScan == -1 {
+		c.notifiedBeforeScan = len(c.notified)
+	}
+	if c.found {
+		return nil, nil
+	}
+	for scopedIndex, addr := range req.ExternalAddrs {
+		if addr.String() != c.foundAddr.String() {
+			continue
+		}
+
+		c.found = true
+		return &chain.FilterBlocksResponse{
+			BatchIndex: 0,
+			BlockMeta:  req.Blocks[0],
+			FoundExternalAddrs: map[waddrmgr.KeyScope]map[uint32]struct{}{
+				scopedIndex.Scope: {scopedIndex.Index: {}},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (c *accountRecoveryChainClient) NotifyReceived(
+	addrs []btcutil.Address) error {
+
+	c.notified = append(c.notified, addrs...)
+	return nil
+}
+
+// TestImportAccountPrivKeyRescan tests that the addresses of an account
+// imported from an extended private key are recovered when requested.
+func TestImportAccountPrivKeyRescan(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	tc := testCases[len(testCases)-1]
+	root, err := hdkeychain.NewKeyFromString(tc.masterPriv)
+	require.NoError(t, err)
+	acctPriv := deriveAcctPrivKey(
+		t, root, tc.expectedScope, hardenedKey(tc.accountIndex),
+	)
+
+	// Derive the external address at index 20, which is the one the chain
+	// will report as used.
+	const foundIndex = 20
+	external, err := acctPriv.Derive(waddrmgr.ExternalBranch)
+	require.NoError(t, err)
+	child, err := external.Derive(foundIndex)
+	require.NoError(t, err)
+	pubKey, err := child.ECPubKey()
+	require.NoError(t, err)
+	foundAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), w.ChainParams(),
+	)
+	require.NoError(t, err)
+
+	chainClient := &accountRecoveryChainClient{
+		bestHeight:         10,
+		foundAddr:          foundAddr,
+		notifiedBeforeScan: -1,
+	}
+	w.chainClient = chainClient
+
+	acct, err := w.ImportAccountPrivKey(
+		tc.name, acctPriv, root.ParentFingerprint(), &tc.addrType,
+		&waddrmgr.BlockStamp{Height: 5}, true,
+	)
+	require.NoError(t, err)
+	require.True(t, chainClient.found)
+
+	// The account must have been extended up to and including the found
+	// address, which must be marked as used.
+	require.Equal(t, uint32(foundIndex+1), acct.ExternalKeyCount)
+	require.Equal(t, uint32(0), acct.InternalKeyCount)
+
+	// The addresses scanned for, including the found one, must have been
+	// registered for notifications before the scan, and the addresses of
+	// the extended account once it completed.
+	before := chainClient.notifiedBeforeScan
+	require.Equal(t, 2*defaultAccountRecoveryWindow, before)
+	var registered []string
+	for _, addr := range chainClient.notified[:before] {
+		registered = append(registered, addr.String())
+	}
+	require.Contains(t, registered, foundAddr.String())
+	require.Len(t, chainClient.notified[before:], foundIndex+1)
+
+	details, err := w.AddressDetails(foundAddr)
+	require.NoError(t, err)
+	require.Equal(t, acct.AccountName, details.AccountName)
+	require.True(t, details.Used)
+}
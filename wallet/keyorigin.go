@@ -0,0 +1,118 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// AccountKeyOrigin is an account's extended public key together with the
+// origin information needed to use it from a watch-only wallet.
+type AccountKeyOrigin struct {
+	// PubKey is the account's extended public key. For the default key
+	// scopes it is encoded with the SLIP-0132 version of the scope
+	// (xpub/ypub/zpub or their testnet equivalents).
+	PubKey *hdkeychain.ExtendedKey
+
+	// MasterKeyFingerprint is the fingerprint of the master key the
+	// account was derived from, or zero if it is unknown.
+	MasterKeyFingerprint uint32
+
+	// Path is the derivation path of the account key from the master
+	// key, with hardened indexes offset by hdkeychain.HardenedKeyStart.
+	Path []uint32
+}
+
+// PathString returns the derivation path of the account key in the
+// "m/purpose'/coin'/account'" notation.
+func (o *AccountKeyOrigin) PathString() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, index := range o.Path {
+		if index >= hdkeychain.HardenedKeyStart {
+			fmt.Fprintf(&b, "/%d'", index-hdkeychain.HardenedKeyStart)
+		} else {
+			fmt.Fprintf(&b, "/%d", index)
+		}
+	}
+	return b.String()
+}
+
+// String returns the key origin in the "[fingerprint/purpose'/coin'/account']"
+// format used by output descriptors.
+func (o *AccountKeyOrigin) String() string {
+	var fingerprint [4]byte
+	binary.LittleEndian.PutUint32(fingerprint[:], o.MasterKeyFingerprint)
+
+	path := strings.TrimPrefix(o.PathString(), "m")
+	return "[" + hex.EncodeToString(fingerprint[:]) + path + "]"
+}
+
+// AccountKeyOrigin returns the extended public key and key origin of an
+// account. The imported account has no extended key, so an error with the
+// ErrNoExist code is returned for it.
+func (w *Wallet) AccountKeyOrigin(scope waddrmgr.KeyScope,
+	account uint32) (*AccountKeyOrigin, error) {
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var origin *AccountKeyOrigin
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		props, err := manager.AccountProperties(addrmgrNs, account)
+		if err != nil {
+			return err
+		}
+		origin, err = w.accountKeyOrigin(addrmgrNs, props)
+		return err
+	})
+	return origin, err
+}
+
+// accountKeyOrigin returns the key origin of the account described by props.
+func (w *Wallet) accountKeyOrigin(addrmgrNs walletdb.ReadBucket,
+	props *waddrmgr.AccountProperties) (*AccountKeyOrigin, error) {
+
+	if props.AccountPubKey == nil {
+		str := fmt.Sprintf("account %d has no extended public key",
+			props.AccountNumber)
+		return nil, waddrmgr.ManagerError{
+			ErrorCode:   waddrmgr.ErrNoExist,
+			Description: str,
+		}
+	}
+
+	// Accounts imported from an extended key record the master key
+	// fingerprint they were imported with, while accounts derived by the
+	// wallet share its own master key.
+	fingerprint := props.MasterKeyFingerprint
+	if fingerprint == 0 && !props.IsImported {
+		var err error
+		fingerprint, err = w.Manager.MasterKeyFingerprint(addrmgrNs)
+		if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrNoExist) {
+			return nil, err
+		}
+	}
+
+	return &AccountKeyOrigin{
+		PubKey:               props.AccountPubKey,
+		MasterKeyFingerprint: fingerprint,
+		Path: []uint32{
+			props.KeyScope.Purpose + hdkeychain.HardenedKeyStart,
+			props.KeyScope.Coin + hdkeychain.HardenedKeyStart,
+			props.AccountPubKey.ChildIndex(),
+		},
+	}, nil
+}
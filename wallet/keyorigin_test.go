@@ -0,0 +1,52 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+)
+
+// TestAccountKeyOrigin ensures that account extended public keys are exported
+// with the version of their key scope and with a key origin that allows
+// deriving the wallet's addresses.
+func TestAccountKeyOrigin(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	scope := waddrmgr.KeyScopeBIP0084
+	origin, err := w.AccountKeyOrigin(scope, 0)
+	require.NoError(t, err)
+
+	// The test wallet uses testnet3, which exports BIP0084 accounts as
+	// vpub keys.
+	require.True(t, strings.HasPrefix(origin.PubKey.String(), "vpub"))
+	require.Equal(t, "m/84'/0'/0'", origin.PathString())
+	require.NotZero(t, origin.MasterKeyFingerprint)
+	require.True(t, strings.HasSuffix(origin.String(), "/84'/0'/0']"))
+
+	// The first external address derived from the exported key must match
+	// the first address handed out by the wallet.
+	addr, err := w.NewAddress(0, scope)
+	require.NoError(t, err)
+
+	external, err := origin.PubKey.Derive(waddrmgr.ExternalBranch)
+	require.NoError(t, err)
+	child, err := external.Derive(0)
+	require.NoError(t, err)
+	pubKey, err := child.ECPubKey()
+	require.NoError(t, err)
+	derived, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(pubKey.SerializeCompressed()), w.ChainParams(),
+	)
+	require.NoError(t, err)
+	require.Equal(t, addr.EncodeAddress(), derived.EncodeAddress())
+
+	// The imported account has no extended public key to export.
+	_, err = w.AccountKeyOrigin(scope, waddrmgr.ImportedAddrAccount)
+	require.True(t, waddrmgr.IsError(err, waddrmgr.ErrNoExist))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// SetAddressLabel attaches a label to an address controlled by the wallet,
+// replacing any label it already had. Passing an empty label removes the
+// address' label.
+//
+// Address labels are kept by the address manager rather than the transaction
+// store, so they survive DropTransactionHistory.
+func (w *Wallet) SetAddressLabel(a btcutil.Address, label string) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetAddrLabel(addrmgrNs, a, label)
+	})
+}
+
+// AddressLabel returns the label of a wallet address, or an empty string if
+// the address has not been labelled.
+func (w *Wallet) AddressLabel(a btcutil.Address) (string, error) {
+	var label string
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		label = w.Manager.AddrLabel(addrmgrNs, a)
+		return nil
+	})
+	return label, err
+}
+
+// AddressLabels returns the label of every labelled wallet address, keyed by
+// the address' string encoding.
+func (w *Wallet) AddressLabels() (map[string]string, error) {
+	labels := make(map[string]string)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		return w.Manager.ForEachAddrLabel(addrmgrNs,
+			func(a btcutil.Address, label string) error {
+				labels[a.EncodeAddress()] = label
+				return nil
+			})
+	})
+	return labels, err
+}
+
+// AddressesByLabel returns all wallet addresses carrying the given label.
+func (w *Wallet) AddressesByLabel(label string) ([]btcutil.Address, error) {
+	var addrs []btcutil.Address
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		return w.Manager.ForEachAddrLabel(addrmgrNs,
+			func(a btcutil.Address, l string) error {
+				if l == label {
+					addrs = append(addrs, a)
+				}
+				return nil
+			})
+	})
+	return addrs, err
+}
@@ -7,6 +7,7 @@ package wallet
 import (
 	"bytes"
 	"sync"
+	"time"
 
 	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxd/txscript"
@@ -35,6 +36,8 @@ type NotificationServer struct {
 	currentTxNtfn  *TransactionNotifications // coalesce this since wallet does not add mined txs together
 	spentness      map[uint32][]chan *SpentnessNotifications
 	accountClients []chan *AccountNotification
+	conflicts      []conflictClient
+	dropped        []droppedTxClient
 	mu             sync.Mutex // Only protects registered client channels
 	wallet         *Wallet    // smells like hacks
 }
@@ -140,6 +143,16 @@ func makeTxSummary(dbtx walletdb.ReadTx, w *Wallet, details *wtxmgr.TxDetails) T
 		}
 		outputs = append(outputs, output)
 	}
+	var abandoned bool
+	if details.Block.Height == -1 {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		rec, err := w.TxStore.AbandonedTx(txmgrNs, &details.Hash)
+		if err != nil {
+			log.Errorf("Cannot fetch abandoned transaction %v: %v",
+				details.Hash, err)
+		}
+		abandoned = rec != nil
+	}
 	return TransactionSummary{
 		Hash:        &details.Hash,
 		Transaction: serializedTx,
@@ -148,6 +161,7 @@ func makeTxSummary(dbtx walletdb.ReadTx, w *Wallet, details *wtxmgr.TxDetails) T
 		Fee:         fee,
 		Timestamp:   details.Received.Unix(),
 		Label:       details.Label,
+		Abandoned:   abandoned,
 	}
 }
 
@@ -357,7 +371,8 @@ type Block struct {
 }
 
 // TransactionSummary contains a transaction relevant to the wallet and marks
-// which inputs and outputs were relevant.
+// which inputs and outputs were relevant.  Abandoned is set for transactions
+// that were abandoned and are no longer recorded as unmined.
 type TransactionSummary struct {
 	Hash        *chainhash.Hash
 	Transaction []byte
@@ -366,6 +381,7 @@ type TransactionSummary struct {
 	Fee         btcutil.Amount
 	Timestamp   int64
 	Label       string
+	Abandoned   bool
 }
 
 // TransactionSummaryInput describes a transaction input that is relevant to the
@@ -613,3 +629,280 @@ func (c *AccountNotificationsClient) Done() {
 		s.mu.Unlock()
 	}()
 }
+
+// ConflictNotification is a notification that an unmined transaction was
+// removed from the wallet because a conflicting transaction was mined, such as
+// a replacement (RBF) or a double spend of one of its inputs.  Transactions
+// spending the outputs of a removed transaction are removed as well, each with
+// its own notification naming the same conflicting transaction.
+type ConflictNotification struct {
+	Hash          *chainhash.Hash
+	Transaction   []byte
+	ConflictingTx *chainhash.Hash
+	Removed       time.Time
+}
+
+func (s *NotificationServer) notifyConflict(c *wtxmgr.ConflictedTx) {
+	serializedTx := c.SerializedTx
+	if serializedTx == nil {
+		var buf bytes.Buffer
+		buf.Grow(c.MsgTx.SerializeSize())
+		err := c.MsgTx.Serialize(&buf)
+		if err != nil {
+			log.Errorf("Cannot serialize conflicted transaction "+
+				"%v: %v", c.Hash, err)
+			return
+		}
+		serializedTx = buf.Bytes()
+	}
+
+	hash, conflictingTx := c.Hash, c.ConflictingTx
+	n := &ConflictNotification{
+		Hash:          &hash,
+		Transaction:   serializedTx,
+		ConflictingTx: &conflictingTx,
+		Removed:       c.Removed,
+	}
+
+	// The notification is queued for every client, which receives it from
+	// its own goroutine, so neither the wallet nor the lock wait for
+	// clients to receive it.
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, client := range s.conflicts {
+		client.queue.push(n)
+	}
+}
+
+// conflictClient is a registered ConflictNotificationsClient along with the
+// queue of the notifications it is yet to receive.
+type conflictClient struct {
+	c     chan *ConflictNotification
+	queue *ntfnQueue
+}
+
+// ConflictNotificationsClient receives ConflictNotifications over the channel
+// C.
+type ConflictNotificationsClient struct {
+	C      <-chan *ConflictNotification
+	server *NotificationServer
+}
+
+// ConflictNotifications returns a client for receiving ConflictNotifications
+// over a channel.  The channel is unbuffered.  When finished, the client's Done
+// method should be called to disassociate the client from the server.
+func (s *NotificationServer) ConflictNotifications() ConflictNotificationsClient {
+	c := make(chan *ConflictNotification)
+	queue := newNtfnQueue()
+	go func() {
+		defer close(c)
+		queue.run(func(n interface{}) bool {
+			select {
+			case c <- n.(*ConflictNotification):
+				return true
+			case <-queue.quit:
+				return false
+			}
+		})
+	}()
+
+	s.mu.Lock()
+	s.conflicts = append(s.conflicts, conflictClient{c: c, queue: queue})
+	s.mu.Unlock()
+	return ConflictNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
+// Done deregisters the client from the server and drains any remaining
+// messages.  It must be called exactly once when the client is finished
+// receiving notifications.
+func (c *ConflictNotificationsClient) Done() {
+	go func() {
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.conflicts
+		for i, client := range clients {
+			if c.C == client.c {
+				clients[i] = clients[len(clients)-1]
+				s.conflicts = clients[:len(clients)-1]
+				client.queue.stop()
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
+
+// ntfnQueue is an unbounded queue of the notifications of a single client.
+// Notifications are pushed without blocking and passed in order to the
+// client's own goroutine, which delivers them.
+type ntfnQueue struct {
+	mu      sync.Mutex
+	pending []interface{}
+	signal  chan struct{}
+	quit    chan struct{}
+}
+
+func newNtfnQueue() *ntfnQueue {
+	return &ntfnQueue{
+		signal: make(chan struct{}, 1),
+		quit:   make(chan struct{}),
+	}
+}
+
+// push queues a notification.
+func (q *ntfnQueue) push(n interface{}) {
+	q.mu.Lock()
+	q.pending = append(q.pending, n)
+	q.mu.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// run calls deliver with every queued notification until the queue is
+// stopped.  deliver returns false when the queue was stopped before the
+// notification could be delivered.
+func (q *ntfnQueue) run(deliver func(interface{}) bool) {
+	for {
+		q.mu.Lock()
+		pending := q.pending
+		q.pending = nil
+		q.mu.Unlock()
+
+		for _, n := range pending {
+			if !deliver(n) {
+				return
+			}
+		}
+
+		select {
+		case <-q.signal:
+		case <-q.quit:
+			return
+		}
+	}
+}
+
+// stop stops the delivery of queued notifications.  It must be called exactly
+// once.
+func (q *ntfnQueue) stop() {
+	close(q.quit)
+}
+
+// DroppedTxNotification is a notification that an unmined transaction authored
+// by the wallet appears to have been dropped, as it was missing from the
+// mempool of the backend when it was due for rebroadcast.  The transaction is
+// rebroadcast regardless and remains in the wallet.
+type DroppedTxNotification struct {
+	Hash        *chainhash.Hash
+	Transaction []byte
+	Attempts    uint32
+	NextAttempt time.Time
+}
+
+func (s *NotificationServer) notifyDroppedTx(rec *wtxmgr.TxRecord,
+	state *wtxmgr.RebroadcastState) {
+
+	serializedTx := rec.SerializedTx
+	if serializedTx == nil {
+		var buf bytes.Buffer
+		buf.Grow(rec.MsgTx.SerializeSize())
+		err := rec.MsgTx.Serialize(&buf)
+		if err != nil {
+			log.Errorf("Cannot serialize dropped transaction %v: %v",
+				rec.Hash, err)
+			return
+		}
+		serializedTx = buf.Bytes()
+	}
+
+	hash := rec.Hash
+	n := &DroppedTxNotification{
+		Hash:        &hash,
+		Transaction: serializedTx,
+		Attempts:    state.Attempts,
+		NextAttempt: state.NextAttempt,
+	}
+
+	// Like conflicts, the notification is queued for every client rather
+	// than sent while holding the lock.
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, client := range s.dropped {
+		client.queue.push(n)
+	}
+}
+
+// droppedTxClient is a registered DroppedTxNotificationsClient along with the
+// queue of the notifications it is yet to receive.
+type droppedTxClient struct {
+	c     chan *DroppedTxNotification
+	queue *ntfnQueue
+}
+
+// DroppedTxNotificationsClient receives DroppedTxNotifications over the channel
+// C.
+type DroppedTxNotificationsClient struct {
+	C      <-chan *DroppedTxNotification
+	server *NotificationServer
+}
+
+// DroppedTxNotifications returns a client for receiving DroppedTxNotifications
+// over a channel.  The channel is unbuffered.  When finished, the client's Done
+// method should be called to disassociate the client from the server.
+func (s *NotificationServer) DroppedTxNotifications() DroppedTxNotificationsClient {
+	c := make(chan *DroppedTxNotification)
+	queue := newNtfnQueue()
+	go func() {
+		defer close(c)
+		queue.run(func(n interface{}) bool {
+			select {
+			case c <- n.(*DroppedTxNotification):
+				return true
+			case <-queue.quit:
+				return false
+			}
+		})
+	}()
+
+	s.mu.Lock()
+	s.dropped = append(s.dropped, droppedTxClient{c: c, queue: queue})
+	s.mu.Unlock()
+	return DroppedTxNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+
+// Done deregisters the client from the server and drains any remaining
+// messages.  It must be called exactly once when the client is finished
+// receiving notifications.
+func (c *DroppedTxNotificationsClient) Done() {
+	go func() {
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.dropped
+		for i, client := range clients {
+			if c.C == client.c {
+				clients[i] = clients[len(clients)-1]
+				s.dropped = clients[:len(clients)-1]
+				client.queue.stop()
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
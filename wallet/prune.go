@@ -0,0 +1,124 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+const (
+	// MinPruneConfirmations is the smallest number of confirmations after
+	// which transactions may be pruned from the transaction history.
+	// Blocks at or below the pruned height can't be rolled back, so this
+	// must exceed the depth of any reorg the wallet is expected to follow.
+	MinPruneConfirmations = 1000
+
+	// pruneInterval is the number of blocks between two automatic prunings
+	// of the transaction history.
+	pruneInterval = 144
+
+	// pruneBatchSize is the number of blocks whose transactions are pruned
+	// in a single database transaction, so that pruning a large history
+	// doesn't hold the database for long.
+	pruneBatchSize = 100
+)
+
+// SetHistoryPruning sets the number of confirmations after which transactions
+// whose outputs have all been spent are automatically pruned from the
+// transaction history while the wallet is synced.  Zero disables automatic
+// pruning.
+func (w *Wallet) SetHistoryPruning(confs uint32) {
+	w.pruneConfsMtx.Lock()
+	w.pruneConfs = confs
+	w.pruneConfsMtx.Unlock()
+}
+
+// PruneHistory prunes the mined transactions whose outputs, if any, have all
+// been spent for at least confs confirmations from the transaction history.
+// Only a summary of each pruned transaction is kept, which ListTransactions
+// reports in brief, and blocks at or below the pruned height can no longer be
+// rolled back.  The number of pruned transactions is returned.  At least
+// MinPruneConfirmations are required.
+func (w *Wallet) PruneHistory(confs uint32) (int, error) {
+	if confs < MinPruneConfirmations {
+		return 0, fmt.Errorf("unable to prune history with %d "+
+			"confirmations, minimum is %d", confs,
+			MinPruneConfirmations)
+	}
+
+	height := w.Manager.SyncedTo().Height - int32(confs) + 1
+	if height < 0 {
+		return 0, nil
+	}
+
+	// The history is pruned in batches of blocks, each in its own
+	// database transaction.
+	quit := w.quitChan()
+	var pruned int
+	for next := int32(0); next != -1; {
+		select {
+		case <-quit:
+			return pruned, ErrWalletShuttingDown
+		default:
+		}
+
+		err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+			txmgrNs := tx.ReadWriteBucket(wtxmgrNamespaceKey)
+
+			n, nextHeight, err := w.TxStore.PruneHistoryBatch(
+				txmgrNs, height, next, pruneBatchSize,
+			)
+			if err != nil {
+				return err
+			}
+			pruned += n
+			next = nextHeight
+			return nil
+		})
+		if err != nil {
+			return pruned, err
+		}
+	}
+
+	if pruned != 0 {
+		log.Infof("Pruned %d transactions mined at or below height %d",
+			pruned, height)
+	}
+
+	return pruned, nil
+}
+
+// pruneHistoryIfDue starts pruning the transaction history in the background
+// when automatic pruning is enabled, the wallet is synced, a block at the
+// given height is due for it and the history isn't already being pruned.
+func (w *Wallet) pruneHistoryIfDue(height int32) {
+	w.pruneConfsMtx.Lock()
+	defer w.pruneConfsMtx.Unlock()
+
+	confs := w.pruneConfs
+	if confs == 0 || height%pruneInterval != 0 || w.pruning ||
+		!w.ChainSynced() {
+
+		return
+	}
+	w.pruning = true
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		_, err := w.PruneHistory(confs)
+		if err != nil && err != ErrWalletShuttingDown {
+			log.Errorf("Unable to prune transaction history: %v",
+				err)
+		}
+
+		w.pruneConfsMtx.Lock()
+		w.pruning = false
+		w.pruneConfsMtx.Unlock()
+	}()
+}
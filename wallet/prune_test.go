@@ -0,0 +1,76 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// TestPruneHistory ensures that fully spent transactions are pruned once
+// buried deep enough, and are still listed in brief, in block order, by
+// ListTransactions.
+func TestPruneHistory(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// An output received at height 100 is spent at height 101, and
+	// another output is received at height 1500.
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	spend := addTestSpend(t, w, &fundingHash, pkScript, 9e7, 101)
+	recentHash := addTestCredit(t, w, pkScript, 5e7, 1500, 1)
+
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return w.Manager.SetSyncedTo(ns, &waddrmgr.BlockStamp{
+			Height:    2000,
+			Timestamp: time.Unix(2000, 0),
+		})
+	})
+	require.NoError(t, err)
+
+	_, err = w.PruneHistory(MinPruneConfirmations - 1)
+	require.Error(t, err)
+
+	pruned, err := w.PruneHistory(MinPruneConfirmations)
+	require.NoError(t, err)
+	require.Equal(t, 1, pruned)
+
+	txs, err := w.ListTransactions(0, 100)
+	require.NoError(t, err)
+	require.Len(t, txs, 4)
+
+	require.Equal(t, recentHash.String(), txs[0].TxID)
+	require.Equal(t, spend.Hash.String(), txs[1].TxID)
+	require.Equal(t, spend.Hash.String(), txs[2].TxID)
+
+	summary := txs[3]
+	require.Equal(t, fundingHash.String(), summary.TxID)
+	require.Equal(t, "receive", summary.Category)
+	require.Equal(t, 1.0, summary.Amount)
+	require.Equal(t, int64(1901), summary.Confirmations)
+
+	all, err := w.ListAllTransactions()
+	require.NoError(t, err)
+	require.Equal(t, txs, all)
+
+	// Transactions are counted the same way whether pruned or not.
+	txs, err = w.ListTransactions(2, 100)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, fundingHash.String(), txs[0].TxID)
+}
@@ -133,7 +133,7 @@ func (w *Wallet) FundPsbt(packet *psbt.Packet, keyScope *waddrmgr.KeyScope,
 		// change address creation.
 		tx, err = w.CreateSimpleTx(
 			keyScope, account, packet.UnsignedTx.TxOut, 1,
-			feeSatPerKB, false,
+			feeSatPerKB, nil, false,
 		)
 		if err != nil {
 			return 0, fmt.Errorf("error creating funding TX: %v",
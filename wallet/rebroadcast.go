@@ -0,0 +1,209 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/tinhnguyenhn/colxwallet/chain"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+const (
+	// rebroadcastBaseInterval is the delay before the first rebroadcast of
+	// an unmined transaction.  The delay doubles with every attempt.
+	rebroadcastBaseInterval = 10 * time.Minute
+
+	// rebroadcastMaxInterval caps the delay between two rebroadcasts of
+	// the same transaction.
+	rebroadcastMaxInterval = 24 * time.Hour
+
+	// rebroadcastTick is how often the wallet looks for unmined
+	// transactions that are due for rebroadcast.
+	rebroadcastTick = time.Minute
+)
+
+// rebroadcastDelay returns the delay before the next rebroadcast of a
+// transaction that was already rebroadcast the given number of times.  The
+// delay grows exponentially up to rebroadcastMaxInterval and is randomized
+// between half and all of it, so that rebroadcasts of transactions created
+// together don't happen together.
+func rebroadcastDelay(r *rand.Rand, attempts uint32) time.Duration {
+	delay := rebroadcastBaseInterval
+	for i := uint32(0); i < attempts && delay < rebroadcastMaxInterval; i++ {
+		delay *= 2
+	}
+	if delay > rebroadcastMaxInterval {
+		delay = rebroadcastMaxInterval
+	}
+
+	return delay/2 + time.Duration(r.Int63n(int64(delay/2)+1))
+}
+
+// newSeededRand returns a math/rand generator seeded from crypto/rand, so that
+// the rebroadcast delays of different wallets are not the same.
+func newSeededRand() (*rand.Rand, error) {
+	var seed [8]byte
+	if _, err := crand.Read(seed[:]); err != nil {
+		return nil, err
+	}
+	src := rand.NewSource(int64(binary.LittleEndian.Uint64(seed[:])))
+	return rand.New(src), nil
+}
+
+// rebroadcastHandler periodically rebroadcasts the unmined transactions
+// authored by the wallet while it is synced to the chain.
+func (w *Wallet) rebroadcastHandler() {
+	defer w.wg.Done()
+
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		log.Errorf("rebroadcastHandler called without an RPC client")
+		return
+	}
+
+	ticker := time.NewTicker(rebroadcastTick)
+	defer ticker.Stop()
+
+	quit := w.quitChan()
+	for {
+		select {
+		case <-ticker.C:
+			if !w.ChainSynced() {
+				continue
+			}
+			w.rebroadcastUnminedTxs(chainClient, time.Now())
+
+		case <-quit:
+			return
+		}
+	}
+}
+
+// rebroadcastCandidate is an unmined transaction due for rebroadcast along
+// with its current rebroadcast state, if any.
+type rebroadcastCandidate struct {
+	rec   wtxmgr.TxRecord
+	state *wtxmgr.RebroadcastState
+}
+
+// rebroadcastUnminedTxs rebroadcasts every unmined transaction authored by the
+// wallet whose next attempt is due at the given time, and records the attempt.
+// Transactions are first looked up in the mempool of the backend, if it can be
+// queried, and reported to NotificationServer clients when they appear to have
+// been dropped.
+func (w *Wallet) rebroadcastUnminedTxs(chainClient chain.Interface,
+	now time.Time) {
+
+	var due []rebroadcastCandidate
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		hashes, err := w.TxStore.UnminedTxHashes(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			details, err := w.TxStore.TxDetails(txmgrNs, hash)
+			if err != nil {
+				return err
+			}
+
+			// Only transactions spending wallet outputs were
+			// authored by the wallet.
+			if details == nil || len(details.Debits) == 0 {
+				continue
+			}
+
+			state, err := w.TxStore.RebroadcastState(txmgrNs, hash)
+			if err != nil {
+				return err
+			}
+			next := details.Received.Add(rebroadcastBaseInterval)
+			if state != nil {
+				next = state.NextAttempt
+			}
+			if now.Before(next) {
+				continue
+			}
+
+			due = append(due, rebroadcastCandidate{
+				rec:   details.TxRecord,
+				state: state,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("Unable to retrieve unconfirmed transactions to "+
+			"rebroadcast: %v", err)
+		return
+	}
+
+	querier, canQuery := chainClient.(chain.MempoolQuerier)
+	for i := range due {
+		rec, prev := &due[i].rec, due[i].state
+
+		var attempts uint32
+		var wasDropped bool
+		if prev != nil {
+			attempts, wasDropped = prev.Attempts, prev.Dropped
+		}
+
+		dropped := wasDropped
+		if canQuery {
+			inMempool, err := querier.HaveMempoolTx(&rec.Hash)
+			if err != nil {
+				log.Debugf("Unable to query mempool for "+
+					"transaction %v: %v", rec.Hash, err)
+			} else {
+				dropped = !inMempool
+			}
+		}
+
+		if _, err := w.publishTransaction(&rec.MsgTx); err != nil {
+			log.Debugf("Unable to rebroadcast transaction %v: %v",
+				rec.Hash, err)
+		} else {
+			log.Debugf("Successfully rebroadcast unconfirmed "+
+				"transaction %v", rec.Hash)
+		}
+
+		delay := rebroadcastDelay(w.rebroadcastRand, attempts+1)
+		state := &wtxmgr.RebroadcastState{
+			Attempts:    attempts + 1,
+			LastAttempt: now,
+			NextAttempt: now.Add(delay),
+			Dropped:     dropped,
+		}
+		err := walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+			txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+			return w.TxStore.PutRebroadcastState(
+				txmgrNs, &rec.Hash, state,
+			)
+		})
+		switch {
+		// The transaction was mined or removed in the meantime, possibly
+		// by the rebroadcast itself.
+		case err == wtxmgr.ErrUnminedTxNotFound:
+			continue
+
+		case err != nil:
+			log.Errorf("Unable to record rebroadcast of transaction "+
+				"%v: %v", rec.Hash, err)
+			continue
+		}
+
+		if dropped && !wasDropped {
+			log.Warnf("Unconfirmed transaction %v is missing from "+
+				"the mempool of the backend", rec.Hash)
+			w.NtfnServer.notifyDroppedTx(rec, state)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+)
+
+// TestRebroadcastDelay ensures that rebroadcast delays grow exponentially with
+// the number of attempts, stay within their jitter bounds and are capped.
+func TestRebroadcastDelay(t *testing.T) {
+	t.Parallel()
+
+	r, err := newSeededRand()
+	require.NoError(t, err)
+
+	for attempts := uint32(0); attempts < 40; attempts++ {
+		expected := rebroadcastMaxInterval
+		if attempts < 8 {
+			expected = rebroadcastBaseInterval << attempts
+		}
+
+		delay := rebroadcastDelay(r, attempts)
+		require.True(t, delay >= expected/2, "attempts %d: delay %v "+
+			"below %v", attempts, delay, expected/2)
+		require.True(t, delay <= expected, "attempts %d: delay %v "+
+			"above %v", attempts, delay, expected)
+	}
+}
+
+// mempoolChainClient is a chain client whose mempool never holds any
+// transaction and which records the transactions it is sent.
+type mempoolChainClient struct {
+	mockChainClient
+
+	sent []chainhash.Hash
+}
+
+func (c *mempoolChainClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (
+	*chainhash.Hash, error) {
+
+	hash := tx.TxHash()
+	c.sent = append(c.sent, hash)
+	return &hash, nil
+}
+
+func (c *mempoolChainClient) HaveMempoolTx(*chainhash.Hash) (bool, error) {
+	return false, nil
+}
+
+// TestRebroadcastUnminedTxs ensures that unmined transactions authored by the
+// wallet are rebroadcast once due, that attempts are recorded, and that
+// transactions missing from the mempool are notified as dropped once.
+func TestRebroadcastUnminedTxs(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	chainClient := &mempoolChainClient{}
+	w.chainClient = chainClient
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	fundingHash := addTestCredit(t, w, pkScript, 1e8, 100, 0)
+	spend := addTestSpend(t, w, &fundingHash, pkScript, 9e7, -1)
+
+	// Nothing is due before the base interval has passed.
+	start := time.Now()
+	w.rebroadcastUnminedTxs(chainClient, start)
+	require.Empty(t, chainClient.sent)
+
+	// The dropped transaction notification is queued for the client, so
+	// the rebroadcast doesn't wait for it to be received.
+	ntfns := w.NtfnServer.DroppedTxNotifications()
+	defer ntfns.Done()
+
+	now := time.Unix(start.Add(rebroadcastBaseInterval).Unix()+1, 0)
+	w.rebroadcastUnminedTxs(chainClient, now)
+	require.Equal(t, []chainhash.Hash{spend.Hash}, chainClient.sent)
+
+	select {
+	case n := <-ntfns.C:
+		require.Equal(t, spend.Hash, *n.Hash)
+		require.Equal(t, uint32(1), n.Attempts)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dropped transaction notification not received")
+	}
+
+	state, err := UnstableAPI(w).RebroadcastState(&spend.Hash)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	require.Equal(t, uint32(1), state.Attempts)
+	require.Equal(t, now, state.LastAttempt)
+	require.True(t, state.NextAttempt.After(now))
+	require.True(t, state.Dropped)
+
+	// The next attempt is only made once due.
+	w.rebroadcastUnminedTxs(chainClient, now)
+	require.Len(t, chainClient.sent, 1)
+
+	w.rebroadcastUnminedTxs(chainClient, state.NextAttempt)
+	require.Len(t, chainClient.sent, 2)
+
+	state, err = UnstableAPI(w).RebroadcastState(&spend.Hash)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), state.Attempts)
+}
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"sync"
 	"time"
 
 	"github.com/tinhnguyenhn/colxd/chaincfg"
@@ -9,6 +10,7 @@ import (
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
 	"github.com/tinhnguyenhn/colxutil/hdkeychain"
+	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/walletdb"
 	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
@@ -83,7 +85,7 @@ func (rm *RecoveryManager) Resurrect(ns walletdb.ReadBucket,
 		// deriving each address and adding it to the external branch
 		// recovery state's set of addresses to look for.
 		for i := uint32(0); i < externalCount; i++ {
-			keyPath := externalKeyPath(i)
+			keyPath := externalKeyPath(waddrmgr.DefaultAccountNum, i)
 			addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 			if err != nil && err != hdkeychain.ErrInvalidChild {
 				return err
@@ -103,7 +105,7 @@ func (rm *RecoveryManager) Resurrect(ns walletdb.ReadBucket,
 		// deriving each address and adding it to the internal branch
 		// recovery state's set of addresses to look for.
 		for i := uint32(0); i < internalCount; i++ {
-			keyPath := internalKeyPath(i)
+			keyPath := internalKeyPath(waddrmgr.DefaultAccountNum, i)
 			addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 			if err != nil && err != hdkeychain.ErrInvalidChild {
 				return err
@@ -408,3 +410,227 @@ func (brs *BranchRecoveryState) NumInvalidInHorizon() uint32 {
 
 	return nInvalid
 }
+
+// recoveryBlocks is a batch of blocks fetched ahead of their filtering during a
+// recovery, along with the result of filtering them speculatively.
+type recoveryBlocks struct {
+	// stamps holds the block stamps of every block of the batch.
+	stamps []*waddrmgr.BlockStamp
+
+	// filterBlocks holds the blocks of the batch that must be filtered for
+	// recoverable addresses.
+	filterBlocks []wtxmgr.BlockMeta
+
+	// filterReq is the filter request the blocks were filtered with, or
+	// nil if they weren't filtered ahead of time.
+	filterReq *chain.FilterBlocksRequest
+
+	// filterResp is the response of filtering the blocks with filterReq.
+	filterResp *chain.FilterBlocksResponse
+
+	// err is the error encountered while fetching the blocks.
+	err error
+}
+
+// recoveryFetcher fetches the blocks of a recovery in batches, ahead of their
+// filtering, with at most recoveryFetchers batches being fetched at once.
+// Batches are returned in order of height.
+//
+// Once fetched, the blocks of a batch are also filtered for the addresses of
+// the latest filter request set by the recovery.  As the addresses being
+// recovered only change when some are found, the response can be used as long
+// as the filter request isn't replaced, which saves filtering the blocks once
+// their batch is reached.
+type recoveryFetcher struct {
+	chainClient chain.Interface
+
+	// filterHeight is the height of the first block to filter. Blocks
+	// below it are fetched, but not filtered.
+	filterHeight int32
+
+	// batches delivers the result channel of each batch, in order.
+	batches chan chan *recoveryBlocks
+
+	filterReqMtx sync.Mutex
+	filterReq    *chain.FilterBlocksRequest
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newRecoveryFetcher creates a recoveryFetcher and starts fetching the blocks
+// from startHeight through stopHeight.
+func newRecoveryFetcher(chainClient chain.Interface, startHeight, stopHeight,
+	filterHeight int32) *recoveryFetcher {
+
+	f := &recoveryFetcher{
+		chainClient:  chainClient,
+		filterHeight: filterHeight,
+		batches:      make(chan chan *recoveryBlocks, recoveryFetchers-1),
+		quit:         make(chan struct{}),
+	}
+
+	f.wg.Add(1)
+	go f.dispatch(startHeight, stopHeight)
+
+	return f
+}
+
+// dispatch starts fetching each batch of blocks, as long as the batches that
+// haven't been returned yet don't exceed the number of concurrent fetchers.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *recoveryFetcher) dispatch(startHeight, stopHeight int32) {
+	defer f.wg.Done()
+	defer close(f.batches)
+
+	height := startHeight
+	for height <= stopHeight {
+		batchStop := height + recoveryBatchSize - 1
+		if batchStop > stopHeight {
+			batchStop = stopHeight
+		}
+
+		result := make(chan *recoveryBlocks, 1)
+		select {
+		case f.batches <- result:
+		case <-f.quit:
+			return
+		}
+
+		f.wg.Add(1)
+		go f.fetch(height, batchStop, result)
+
+		height = batchStop + 1
+	}
+}
+
+// fetch fetches a batch of blocks, and filters them with the current filter
+// request, if any.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *recoveryFetcher) fetch(startHeight, stopHeight int32,
+	result chan<- *recoveryBlocks) {
+
+	defer f.wg.Done()
+
+	blocks := &recoveryBlocks{}
+	for height := startHeight; height <= stopHeight; height++ {
+		select {
+		case <-f.quit:
+			return
+		default:
+		}
+
+		hash, err := f.chainClient.GetBlockHash(int64(height))
+		if err != nil {
+			blocks.err = err
+			result <- blocks
+			return
+		}
+		header, err := f.chainClient.GetBlockHeader(hash)
+		if err != nil {
+			blocks.err = err
+			result <- blocks
+			return
+		}
+		blocks.stamps = append(blocks.stamps, &waddrmgr.BlockStamp{
+			Hash:      *hash,
+			Height:    height,
+			Timestamp: header.Timestamp,
+		})
+
+		// It's possible for us to run into blocks before our birthday
+		// if our birthday is after our reorg safe height, so we'll
+		// make sure to not filter those.
+		if height >= f.filterHeight {
+			blocks.filterBlocks = append(
+				blocks.filterBlocks, wtxmgr.BlockMeta{
+					Block: wtxmgr.Block{
+						Hash:   *hash,
+						Height: height,
+					},
+					Time: header.Timestamp,
+				},
+			)
+		}
+	}
+
+	// Filter the blocks ahead of time if the addresses to look for are
+	// known. Failures are ignored, as the blocks will be filtered again.
+	filterReq := f.filterRequest()
+	if filterReq != nil && len(blocks.filterBlocks) > 0 {
+		req := *filterReq
+		req.Blocks = blocks.filterBlocks
+		filterResp, err := f.chainClient.FilterBlocks(&req)
+		if err == nil {
+			blocks.filterReq = filterReq
+			blocks.filterResp = filterResp
+		}
+	}
+
+	result <- blocks
+}
+
+// next returns the next batch of blocks, waiting for it to be fetched, or nil
+// once all blocks were returned.
+func (f *recoveryFetcher) next() (*recoveryBlocks, error) {
+	result, ok := <-f.batches
+	if !ok {
+		return nil, nil
+	}
+
+	blocks := <-result
+	if blocks.err != nil {
+		return nil, blocks.err
+	}
+	return blocks, nil
+}
+
+// filterRequest returns the current filter request, or nil if the addresses to
+// look for are changing.
+func (f *recoveryFetcher) filterRequest() *chain.FilterBlocksRequest {
+	f.filterReqMtx.Lock()
+	defer f.filterReqMtx.Unlock()
+
+	return f.filterReq
+}
+
+// setFilterRequest sets the filter request of the blocks fetched from now on
+// to look for the addresses and outpoints of the recovery state. The watched
+// outpoints are copied, as the recovery state keeps being updated while the
+// blocks are filtered.
+func (f *recoveryFetcher) setFilterRequest(
+	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager,
+	recoveryState *RecoveryState) *chain.FilterBlocksRequest {
+
+	filterReq := newFilterBlocksRequest(nil, scopedMgrs, recoveryState)
+	watchedOutPoints := make(
+		map[wire.OutPoint]btcutil.Address, len(filterReq.WatchedOutPoints),
+	)
+	for outPoint, addr := range filterReq.WatchedOutPoints {
+		watchedOutPoints[outPoint] = addr
+	}
+	filterReq.WatchedOutPoints = watchedOutPoints
+
+	f.filterReqMtx.Lock()
+	f.filterReq = filterReq
+	f.filterReqMtx.Unlock()
+
+	return filterReq
+}
+
+// resetFilterRequest clears the current filter request once addresses or
+// outpoints were found, so that the responses of the blocks filtered with it
+// are no longer used.
+func (f *recoveryFetcher) resetFilterRequest() {
+	f.filterReqMtx.Lock()
+	f.filterReq = nil
+	f.filterReqMtx.Unlock()
+}
+
+// stop stops fetching blocks and waits for the fetchers to exit.
+func (f *recoveryFetcher) stop() {
+	close(f.quit)
+	f.wg.Wait()
+}
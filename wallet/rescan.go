@@ -5,11 +5,19 @@
 package wallet
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxd/txscript"
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
 	"github.com/tinhnguyenhn/colxwallet/chain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
 	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
 )
 
@@ -18,6 +26,10 @@ import (
 type RescanProgressMsg struct {
 	Addresses    []btcutil.Address
 	Notification *chain.RescanProgress
+
+	// progress holds the channels of the rescan jobs to forward the
+	// message to.
+	progress []chan<- *RescanProgressMsg
 }
 
 // RescanFinishedMsg reports the addresses that were rescanned when a
@@ -38,6 +50,22 @@ type RescanJob struct {
 	OutPoints   map[wire.OutPoint]btcutil.Address
 	BlockStamp  waddrmgr.BlockStamp
 	err         chan error
+
+	// StopStamp is the optional last block to rescan.  Jobs without one
+	// rescan through the best block of the chain backend, while jobs with
+	// one are rescanned by filtering blocks and never merged.
+	StopStamp *waddrmgr.BlockStamp
+
+	// Progress optionally receives the progress of the rescan.  Progress
+	// is dropped when the channel isn't ready to receive it.
+	Progress chan<- *RescanProgressMsg
+
+	// Cancel optionally aborts a job with a stop block when closed.
+	Cancel <-chan struct{}
+
+	// supersedes holds the set hashes of the interrupted rescans covered
+	// by this job, whose checkpoints are replaced by its own.
+	supersedes []chainhash.Hash
 }
 
 // rescanBatch is a collection of one or more RescanJobs that were merged
@@ -48,6 +76,10 @@ type rescanBatch struct {
 	outpoints   map[wire.OutPoint]btcutil.Address
 	bs          waddrmgr.BlockStamp
 	errChans    []chan error
+	supersedes  []chainhash.Hash
+	stop        *waddrmgr.BlockStamp
+	progress    []chan<- *RescanProgressMsg
+	cancel      <-chan struct{}
 }
 
 // SubmitRescan submits a RescanJob to the RescanManager.  A channel is
@@ -66,18 +98,25 @@ func (w *Wallet) SubmitRescan(job *RescanJob) <-chan error {
 
 // batch creates the rescanBatch for a single rescan job.
 func (job *RescanJob) batch() *rescanBatch {
-	return &rescanBatch{
+	b := &rescanBatch{
 		initialSync: job.InitialSync,
 		addrs:       job.Addrs,
 		outpoints:   job.OutPoints,
 		bs:          job.BlockStamp,
 		errChans:    []chan error{job.err},
+		supersedes:  job.supersedes,
+		stop:        job.StopStamp,
+		cancel:      job.Cancel,
+	}
+	if job.Progress != nil {
+		b.progress = append(b.progress, job.Progress)
 	}
+	return b
 }
 
 // merge merges the work from k into j, setting the starting height to
 // the minimum of the two jobs.  This method does not check for
-// duplicate addresses or outpoints.
+// duplicate addresses or outpoints, and neither must have a stop block.
 func (b *rescanBatch) merge(job *RescanJob) {
 	if job.InitialSync {
 		b.initialSync = true
@@ -92,6 +131,10 @@ func (b *rescanBatch) merge(job *RescanJob) {
 		b.bs = job.BlockStamp
 	}
 	b.errChans = append(b.errChans, job.err)
+	b.supersedes = append(b.supersedes, job.supersedes...)
+	if job.Progress != nil {
+		b.progress = append(b.progress, job.Progress)
+	}
 }
 
 // done iterates through all error channels, duplicating sending the error
@@ -109,7 +152,10 @@ func (b *rescanBatch) done(err error) {
 func (w *Wallet) rescanBatchHandler() {
 	defer w.wg.Done()
 
-	var curBatch, nextBatch *rescanBatch
+	var (
+		curBatch    *rescanBatch
+		nextBatches []*rescanBatch
+	)
 	quit := w.quitChan()
 
 	for {
@@ -119,6 +165,7 @@ func (w *Wallet) rescanBatchHandler() {
 				// Set current batch as this job and send
 				// request.
 				curBatch = job.batch()
+				w.checkpointRescan(curBatch)
 				select {
 				case w.rescanBatch <- curBatch:
 				case <-quit:
@@ -126,12 +173,19 @@ func (w *Wallet) rescanBatchHandler() {
 					return
 				}
 			} else {
-				// Create next batch if it doesn't exist, or
-				// merge the job.
-				if nextBatch == nil {
-					nextBatch = job.batch()
-				} else {
-					nextBatch.merge(job)
+				// Merge the job into the first waiting batch
+				// without a stop block, or queue a new batch if
+				// there is none or the job has a stop block.
+				merged := false
+				for _, batch := range nextBatches {
+					if job.StopStamp == nil && batch.stop == nil {
+						batch.merge(job)
+						merged = true
+						break
+					}
+				}
+				if !merged {
+					nextBatches = append(nextBatches, job.batch())
 				}
 			}
 
@@ -148,6 +202,7 @@ func (w *Wallet) rescanBatchHandler() {
 				case w.rescanProgress <- &RescanProgressMsg{
 					Addresses:    curBatch.addrs,
 					Notification: n,
+					progress:     curBatch.progress,
 				}:
 				case <-quit:
 					for _, errChan := range curBatch.errChans {
@@ -175,9 +230,15 @@ func (w *Wallet) rescanBatchHandler() {
 					return
 				}
 
-				curBatch, nextBatch = nextBatch, nil
+				w.finishRescanCheckpoint()
+				curBatch = nil
+				if len(nextBatches) > 0 {
+					curBatch = nextBatches[0]
+					nextBatches = nextBatches[1:]
+				}
 
 				if curBatch != nil {
+					w.checkpointRescan(curBatch)
 					select {
 					case w.rescanBatch <- curBatch:
 					case <-quit:
@@ -214,6 +275,19 @@ out:
 			log.Infof("Rescanned through block %v (height %d)",
 				n.Hash, n.Height)
 
+			w.updateRescanCheckpoint(waddrmgr.BlockStamp{
+				Height:    n.Height,
+				Hash:      *n.Hash,
+				Timestamp: n.Time,
+			})
+
+			for _, c := range msg.progress {
+				select {
+				case c <- msg:
+				default:
+				}
+			}
+
 		case msg := <-w.rescanFinished:
 			n := msg.Notification
 			addrs := msg.Addresses
@@ -254,9 +328,18 @@ out:
 			log.Infof("Started rescan from block %v (height %d) for %d %s",
 				batch.bs.Hash, batch.bs.Height, numAddrs, noun)
 
-			err := chainClient.Rescan(&batch.bs.Hash, batch.addrs,
-				batch.outpoints)
-			if err != nil {
+			var err error
+			if batch.stop != nil {
+				err = w.rescanRange(chainClient, batch)
+			} else {
+				err = chainClient.Rescan(&batch.bs.Hash,
+					batch.addrs, batch.outpoints)
+			}
+			switch {
+			case err == ErrRescanAborted:
+				log.Infof("Aborted rescan for %d %s", numAddrs,
+					noun)
+			case err != nil:
 				log.Errorf("Rescan for %d %s failed: %v", numAddrs,
 					noun, err)
 			}
@@ -269,18 +352,575 @@ out:
 	w.wg.Done()
 }
 
+// rescanRange rescans the blocks from the start through the stop block of a
+// batch by filtering them with the chain backend, as its rescans can't stop
+// before its best block.  Progress is reported the same way the chain backend
+// reports it, and the rescan finishes early if it is aborted.  The outpoints
+// found are then registered with the chain backend, so that their later spends
+// are detected.
+func (w *Wallet) rescanRange(chainClient chain.Interface,
+	batch *rescanBatch) error {
+
+	abort := make(chan struct{})
+	w.rescanMtx.Lock()
+	w.rescanAbort = abort
+	w.rescanMtx.Unlock()
+
+	defer func() {
+		w.rescanMtx.Lock()
+		if w.rescanAbort == abort {
+			w.rescanAbort = nil
+		}
+		w.rescanMtx.Unlock()
+	}()
+
+	// Every address is watched as an external address, with an arbitrary
+	// but unique index, as the addresses found are not reported.
+	filterReq := &chain.FilterBlocksRequest{
+		ExternalAddrs:    make(map[waddrmgr.ScopedIndex]btcutil.Address),
+		InternalAddrs:    make(map[waddrmgr.ScopedIndex]btcutil.Address),
+		WatchedOutPoints: make(map[wire.OutPoint]btcutil.Address),
+	}
+	for i, addr := range batch.addrs {
+		filterReq.ExternalAddrs[waddrmgr.ScopedIndex{
+			Index: uint32(i),
+		}] = addr
+	}
+	for op, addr := range batch.outpoints {
+		filterReq.WatchedOutPoints[op] = addr
+	}
+
+	quit := w.quitChan()
+	notify := func(n interface{}) error {
+		select {
+		case w.rescanNotifications <- n:
+			return nil
+		case <-quit:
+			return ErrWalletShuttingDown
+		}
+	}
+
+	var (
+		rescanErr error
+		last      = batch.bs
+		blocks    = make([]wtxmgr.BlockMeta, 0, recoveryBatchSize)
+	)
+	for height := batch.bs.Height; height <= batch.stop.Height; {
+		select {
+		case <-abort:
+			rescanErr = ErrRescanAborted
+		case <-batch.cancel:
+			rescanErr = ErrRescanAborted
+		case <-quit:
+			return ErrWalletShuttingDown
+		default:
+		}
+		if rescanErr != nil {
+			break
+		}
+
+		blocks = blocks[:0]
+		for ; height <= batch.stop.Height &&
+			len(blocks) < recoveryBatchSize; height++ {
+
+			hash, err := chainClient.GetBlockHash(int64(height))
+			if err != nil {
+				return err
+			}
+			header, err := chainClient.GetBlockHeader(hash)
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, wtxmgr.BlockMeta{
+				Block: wtxmgr.Block{
+					Hash:   *hash,
+					Height: height,
+				},
+				Time: header.Timestamp,
+			})
+		}
+
+		err := w.filterRescanBlocks(chainClient, filterReq, blocks)
+		if err != nil {
+			return err
+		}
+
+		lastBlock := blocks[len(blocks)-1]
+		last = waddrmgr.BlockStamp{
+			Height:    lastBlock.Height,
+			Hash:      lastBlock.Hash,
+			Timestamp: lastBlock.Time,
+		}
+		err = notify(&chain.RescanProgress{
+			Hash:   &last.Hash,
+			Height: last.Height,
+			Time:   last.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// The outpoints found are watched for spends past the rescanned
+	// blocks, as some chain backends only detect the spends of outpoints
+	// registered with them.
+	if notifier, ok := chainClient.(chain.SpendNotifier); ok {
+		var found []*wire.OutPoint
+		for op := range filterReq.WatchedOutPoints {
+			if _, ok := batch.outpoints[op]; ok {
+				continue
+			}
+			op := op
+			found = append(found, &op)
+		}
+		if len(found) > 0 {
+			if err := notifier.NotifySpent(found); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The rescan is finished even if it was aborted, so that the next
+	// batch can be rescanned.
+	err := notify(&chain.RescanFinished{
+		Hash:   &last.Hash,
+		Height: last.Height,
+		Time:   last.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	return rescanErr
+}
+
+// filterRescanBlocks filters the blocks with the chain backend, adding the
+// relevant transactions found to the wallet and watching the outputs they pay
+// to the addresses of the request for spends in the following blocks.
+func (w *Wallet) filterRescanBlocks(chainClient chain.Interface,
+	filterReq *chain.FilterBlocksRequest, blocks []wtxmgr.BlockMeta) error {
+
+	for len(blocks) > 0 {
+		filterReq.Blocks = blocks
+		filterResp, err := chainClient.FilterBlocks(filterReq)
+		if err != nil {
+			return err
+		}
+
+		// An empty response signals that no other block of the batch
+		// is relevant.
+		if filterResp == nil {
+			return nil
+		}
+
+		for op, addr := range filterResp.FoundOutPoints {
+			filterReq.WatchedOutPoints[op] = addr
+		}
+
+		err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+			for _, txn := range filterResp.RelevantTxns {
+				txRecord, err := wtxmgr.NewTxRecordFromMsgTx(
+					txn, filterResp.BlockMeta.Time,
+				)
+				if err != nil {
+					return err
+				}
+
+				err = w.addRelevantTx(
+					tx, txRecord, &filterResp.BlockMeta,
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		blocks = blocks[filterResp.BatchIndex+1:]
+	}
+
+	return nil
+}
+
+// AbortRescan aborts the rescan with a stop block being performed, such as
+// one requested with RescanBlockchain, and returns whether there was one.
+// Rescans through the best block of the chain backend can't be aborted.
+func (w *Wallet) AbortRescan() bool {
+	w.rescanMtx.Lock()
+	defer w.rescanMtx.Unlock()
+
+	if w.rescanAbort == nil {
+		return false
+	}
+	close(w.rescanAbort)
+	w.rescanAbort = nil
+	return true
+}
+
+// RescanBlockchain rescans the blocks from the start through the stop height
+// for all active addresses and unspent outputs of the wallet, adding the
+// relevant transactions found without dropping the transaction history.  A
+// negative stop height rescans through the block the wallet is synced to.
+//
+// The progress of the rescan is sent on the optional progress channel, and the
+// rescan is aborted when the optional cancel channel is closed or AbortRescan
+// is called.  The first and last blocks of the range are returned once the
+// rescan finishes.
+func (w *Wallet) RescanBlockchain(startHeight, stopHeight int32,
+	progress chan<- *RescanProgressMsg, cancel <-chan struct{}) (
+	*waddrmgr.BlockStamp, *waddrmgr.BlockStamp, error) {
+
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	syncedTo := w.Manager.SyncedTo()
+	if stopHeight < 0 {
+		stopHeight = syncedTo.Height
+	}
+	if startHeight < 0 || startHeight > stopHeight ||
+		stopHeight > syncedTo.Height {
+
+		return nil, nil, ErrInvalidRescanRange
+	}
+
+	blockStamp := func(height int32) (*waddrmgr.BlockStamp, error) {
+		hash, err := chainClient.GetBlockHash(int64(height))
+		if err != nil {
+			return nil, err
+		}
+		header, err := chainClient.GetBlockHeader(hash)
+		if err != nil {
+			return nil, err
+		}
+		return &waddrmgr.BlockStamp{
+			Height:    height,
+			Hash:      *hash,
+			Timestamp: header.Timestamp,
+		}, nil
+	}
+	startStamp, err := blockStamp(startHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+	stopStamp, err := blockStamp(stopHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		addrs   []btcutil.Address
+		unspent []wtxmgr.Credit
+	)
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrs, unspent, err = w.activeData(dbtx)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	outpoints, err := w.unspentOutPoints(unspent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := &RescanJob{
+		Addrs:      addrs,
+		OutPoints:  outpoints,
+		BlockStamp: *startStamp,
+		StopStamp:  stopStamp,
+		Progress:   progress,
+		Cancel:     cancel,
+	}
+
+	// The job is left to finish in the background if it is cancelled
+	// while waiting for other rescans.
+	select {
+	case err := <-w.SubmitRescan(job):
+		if err != nil {
+			return nil, nil, err
+		}
+		return startStamp, stopStamp, nil
+	case <-cancel:
+		return nil, nil, ErrRescanAborted
+	case <-w.quitChan():
+		return nil, nil, ErrWalletShuttingDown
+	}
+}
+
+// rescanSetHash returns the hash identifying a rescan by the addresses and
+// outpoints it rescans for, regardless of their order.
+func rescanSetHash(addrs []btcutil.Address,
+	outpoints map[wire.OutPoint]btcutil.Address) chainhash.Hash {
+
+	encodedAddrs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		encodedAddrs = append(encodedAddrs, addr.EncodeAddress())
+	}
+	sort.Strings(encodedAddrs)
+
+	ops := make([]wire.OutPoint, 0, len(outpoints))
+	for op := range outpoints {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		cmp := bytes.Compare(ops[i].Hash[:], ops[j].Hash[:])
+		if cmp != 0 {
+			return cmp < 0
+		}
+		return ops[i].Index < ops[j].Index
+	})
+
+	// Duplicate addresses are skipped, as jobs merged into a batch may
+	// rescan for the same address.
+	var buf [4]byte
+	h := sha256.New()
+	for i, addr := range encodedAddrs {
+		if i > 0 && addr == encodedAddrs[i-1] {
+			continue
+		}
+		binary.BigEndian.PutUint32(buf[:], uint32(len(addr)))
+		h.Write(buf[:])
+		h.Write([]byte(addr))
+	}
+	for _, op := range ops {
+		h.Write(op.Hash[:])
+		binary.BigEndian.PutUint32(buf[:], op.Index)
+		h.Write(buf[:])
+	}
+
+	var setHash chainhash.Hash
+	copy(setHash[:], h.Sum(nil))
+	return setHash
+}
+
+// isMainChainBlock returns whether the block is known to the manager to be
+// part of the main chain. Blocks too deep to be reorganized out are assumed
+// to be.
+func (w *Wallet) isMainChainBlock(ns walletdb.ReadBucket,
+	bs *waddrmgr.BlockStamp) bool {
+
+	syncedTo := w.Manager.SyncedTo()
+	if bs.Height > syncedTo.Height {
+		return false
+	}
+
+	hash, err := w.Manager.BlockHash(ns, bs.Height)
+	if err != nil {
+		return bs.Height <= syncedTo.Height-waddrmgr.MaxReorgDepth
+	}
+	return *hash == bs.Hash
+}
+
+// checkpointRescan persists the checkpoint of a batch about to be rescanned.
+// If an interrupted rescan for the same addresses and outpoints covers the
+// start of the batch, the batch is resumed from its last processed block
+// instead.  The checkpoints of the rescans superseded by the batch are
+// removed.
+func (w *Wallet) checkpointRescan(batch *rescanBatch) {
+	now := time.Now()
+	checkpoint := &waddrmgr.RescanCheckpoint{
+		SetHash:       rescanSetHash(batch.addrs, batch.outpoints),
+		Start:         batch.bs,
+		LastProcessed: batch.bs,
+		StartTime:     now,
+	}
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+
+		checkpoints, err := waddrmgr.FetchRescanCheckpoints(ns)
+		if err != nil {
+			return err
+		}
+		for _, c := range checkpoints {
+			if c.SetHash != checkpoint.SetHash {
+				continue
+			}
+			if c.Start.Height > batch.bs.Height ||
+				c.LastProcessed.Height <= batch.bs.Height ||
+				!w.isMainChainBlock(ns, &c.LastProcessed) {
+
+				break
+			}
+
+			log.Infof("Resuming rescan started from block %v "+
+				"(height %d) at block %v (height %d)",
+				c.Start.Hash, c.Start.Height,
+				c.LastProcessed.Hash, c.LastProcessed.Height)
+
+			*checkpoint = c
+			batch.bs = c.LastProcessed
+			break
+		}
+
+		for i := range batch.supersedes {
+			setHash := &batch.supersedes[i]
+			if *setHash == checkpoint.SetHash {
+				continue
+			}
+			err := waddrmgr.DeleteRescanCheckpoint(ns, setHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		return waddrmgr.PutRescanCheckpoint(ns, checkpoint)
+	})
+	if err != nil {
+		log.Errorf("Unable to checkpoint rescan: %v", err)
+	}
+
+	w.rescanMtx.Lock()
+	w.rescanCheckpoint = checkpoint
+	w.rescanStop = batch.stop
+	w.rescanResumeHeight = batch.bs.Height
+	w.rescanResumeTime = now
+	w.rescanMtx.Unlock()
+}
+
+// updateRescanCheckpoint records the last block processed by the current
+// rescan.
+func (w *Wallet) updateRescanCheckpoint(bs waddrmgr.BlockStamp) {
+	// The mutex is held while the checkpoint is stored so that it can't
+	// be stored again once the rescan finishes and it is removed.
+	w.rescanMtx.Lock()
+	defer w.rescanMtx.Unlock()
+
+	if w.rescanCheckpoint == nil {
+		return
+	}
+	w.rescanCheckpoint.LastProcessed = bs
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return waddrmgr.PutRescanCheckpoint(ns, w.rescanCheckpoint)
+	})
+	if err != nil {
+		log.Errorf("Unable to checkpoint rescan: %v", err)
+	}
+}
+
+// finishRescanCheckpoint removes the checkpoint of the current rescan once it
+// finished.
+func (w *Wallet) finishRescanCheckpoint() {
+	w.rescanMtx.Lock()
+	defer w.rescanMtx.Unlock()
+
+	checkpoint := w.rescanCheckpoint
+	w.rescanCheckpoint = nil
+	if checkpoint == nil {
+		return
+	}
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		return waddrmgr.DeleteRescanCheckpoint(ns, &checkpoint.SetHash)
+	})
+	if err != nil {
+		log.Errorf("Unable to remove rescan checkpoint: %v", err)
+	}
+}
+
+// RescanStatus describes the progress of the rescan being performed.
+type RescanStatus struct {
+	// Active is whether a rescan is being performed.  The other fields
+	// are only set if it is.
+	Active bool
+
+	// StartHeight is the height the rescan started from, and
+	// CurrentHeight the height of the last block it processed.
+	StartHeight   int32
+	CurrentHeight int32
+
+	// TargetHeight is the height of the stop block of the rescan, or of
+	// the best block of the chain backend if it has none.
+	TargetHeight int32
+
+	// StartTime is the time the rescan was started at, which precedes the
+	// start of the wallet if the rescan was resumed.
+	StartTime time.Time
+
+	// Progress is the fraction of the blocks from the start to the target
+	// height that were rescanned.
+	Progress float64
+
+	// ETA is the estimated time left until the rescan finishes, or zero if
+	// it can't be estimated yet.
+	ETA time.Duration
+}
+
+// RescanStatus returns the progress of the rescan being performed, with its
+// estimated time left based on its rate since the wallet (re)started it.
+func (w *Wallet) RescanStatus() (*RescanStatus, error) {
+	w.rescanMtx.Lock()
+	if w.rescanCheckpoint == nil {
+		w.rescanMtx.Unlock()
+		return &RescanStatus{}, nil
+	}
+	checkpoint := *w.rescanCheckpoint
+	stop := w.rescanStop
+	resumeHeight := w.rescanResumeHeight
+	resumeTime := w.rescanResumeTime
+	w.rescanMtx.Unlock()
+
+	var targetHeight int32
+	if stop != nil {
+		targetHeight = stop.Height
+	} else {
+		chainClient, err := w.requireChainClient()
+		if err != nil {
+			return nil, err
+		}
+		_, targetHeight, err = chainClient.GetBestBlock()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	status := &RescanStatus{
+		Active:        true,
+		StartHeight:   checkpoint.Start.Height,
+		CurrentHeight: checkpoint.LastProcessed.Height,
+		TargetHeight:  targetHeight,
+		StartTime:     checkpoint.StartTime,
+		Progress:      1,
+	}
+	if status.TargetHeight < status.CurrentHeight {
+		status.TargetHeight = status.CurrentHeight
+	}
+
+	total := status.TargetHeight - status.StartHeight
+	if total > 0 {
+		status.Progress = float64(status.CurrentHeight-
+			status.StartHeight) / float64(total)
+	}
+
+	rescanned := status.CurrentHeight - resumeHeight
+	if rescanned > 0 {
+		left := status.TargetHeight - status.CurrentHeight
+		status.ETA = time.Duration(float64(time.Since(resumeTime)) *
+			float64(left) / float64(rescanned))
+	}
+
+	return status, nil
+}
+
 // Rescan begins a rescan for all active addresses and unspent outputs of
 // a wallet.  This is intended to be used to sync a wallet back up to the
 // current best block in the main chain, and is considered an initial sync
 // rescan.
 func (w *Wallet) Rescan(addrs []btcutil.Address, unspent []wtxmgr.Credit) error {
-	return w.rescanWithTarget(addrs, unspent, nil)
+	return w.rescanWithTarget(addrs, unspent, nil, nil)
 }
 
-// rescanWithTarget performs a rescan starting at the optional startStamp. If
-// none is provided, the rescan will begin from the manager's sync tip.
-func (w *Wallet) rescanWithTarget(addrs []btcutil.Address,
-	unspent []wtxmgr.Credit, startStamp *waddrmgr.BlockStamp) error {
+// unspentOutPoints returns the outpoints of the unspent outputs mapped to the
+// address they pay to.
+func (w *Wallet) unspentOutPoints(unspent []wtxmgr.Credit) (
+	map[wire.OutPoint]btcutil.Address, error) {
 
 	outpoints := make(map[wire.OutPoint]btcutil.Address, len(unspent))
 	for _, output := range unspent {
@@ -288,11 +928,26 @@ func (w *Wallet) rescanWithTarget(addrs []btcutil.Address,
 			output.PkScript, w.chainParams,
 		)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		outpoints[output.OutPoint] = outputAddrs[0]
 	}
+	return outpoints, nil
+}
+
+// rescanWithTarget performs a rescan starting at the optional startStamp. If
+// none is provided, the rescan will begin from the manager's sync tip. The
+// checkpoints of the interrupted rescans with the given set hashes are
+// replaced by the checkpoint of this rescan.
+func (w *Wallet) rescanWithTarget(addrs []btcutil.Address,
+	unspent []wtxmgr.Credit, startStamp *waddrmgr.BlockStamp,
+	supersedes []chainhash.Hash) error {
+
+	outpoints, err := w.unspentOutPoints(unspent)
+	if err != nil {
+		return err
+	}
 
 	// If a start block stamp was provided, we will use that as the initial
 	// starting point for the rescan.
@@ -306,6 +961,7 @@ func (w *Wallet) rescanWithTarget(addrs []btcutil.Address,
 		Addrs:       addrs,
 		OutPoints:   outpoints,
 		BlockStamp:  *startStamp,
+		supersedes:  supersedes,
 	}
 
 	// Submit merged job and block until rescan completes.
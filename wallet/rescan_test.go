@@ -0,0 +1,325 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/chain"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// rescanChainClient is a mock chain client with a chain of test blocks
+// through height 200, one of which holds a relevant transaction.  The
+// outpoints registered to be watched for spends are recorded.
+type rescanChainClient struct {
+	mockChainClient
+
+	relevantTx     *wire.MsgTx
+	relevantHeight int32
+
+	mtx          sync.Mutex
+	spendWatched []wire.OutPoint
+}
+
+func (c *rescanChainClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	hash := testBlockStamp(200).Hash
+	return &hash, 200, nil
+}
+
+func (c *rescanChainClient) GetBlockHash(height int64) (*chainhash.Hash,
+	error) {
+
+	hash := testBlockStamp(int32(height)).Hash
+	return &hash, nil
+}
+
+func (c *rescanChainClient) GetBlockHeader(hash *chainhash.Hash) (
+	*wire.BlockHeader, error) {
+
+	height := int32(binary.BigEndian.Uint32(hash[:]))
+	return &wire.BlockHeader{
+		Timestamp: testBlockStamp(height).Timestamp,
+	}, nil
+}
+
+func (c *rescanChainClient) FilterBlocks(req *chain.FilterBlocksRequest) (
+	*chain.FilterBlocksResponse, error) {
+
+	for i, block := range req.Blocks {
+		if c.relevantTx == nil || block.Height != c.relevantHeight {
+			continue
+		}
+		return &chain.FilterBlocksResponse{
+			BatchIndex: uint32(i),
+			BlockMeta:  block,
+			FoundOutPoints: map[wire.OutPoint]btcutil.Address{
+				{Hash: c.relevantTx.TxHash()}: nil,
+			},
+			RelevantTxns: []*wire.MsgTx{c.relevantTx},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (c *rescanChainClient) NotifySpent(outPoints []*wire.OutPoint) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for _, op := range outPoints {
+		c.spendWatched = append(c.spendWatched, *op)
+	}
+	return nil
+}
+
+// testBlockStamp returns the block stamp of a test block at the given height.
+func testBlockStamp(height int32) waddrmgr.BlockStamp {
+	var hash chainhash.Hash
+	binary.BigEndian.PutUint32(hash[:], uint32(height))
+	return waddrmgr.BlockStamp{
+		Height:    height,
+		Hash:      hash,
+		Timestamp: time.Unix(int64(height)*600, 0),
+	}
+}
+
+// fetchRescanCheckpoints returns the rescan checkpoints stored by the wallet.
+func fetchRescanCheckpoints(t *testing.T,
+	w *Wallet) []waddrmgr.RescanCheckpoint {
+
+	t.Helper()
+
+	var checkpoints []waddrmgr.RescanCheckpoint
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		var err error
+		checkpoints, err = waddrmgr.FetchRescanCheckpoints(
+			tx.ReadBucket(waddrmgrNamespaceKey),
+		)
+		return err
+	})
+	require.NoError(t, err)
+	return checkpoints
+}
+
+// TestRescanCheckpoint ensures that the progress of a rescan is persisted, so
+// that it is resumed from its last processed block if it is started again for
+// the same addresses, and that its status is reported.
+func TestRescanCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+	w.chainClient = &rescanChainClient{}
+
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		for height := int32(100); height <= 200; height++ {
+			bs := testBlockStamp(height)
+			if err := w.Manager.SetSyncedTo(ns, &bs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	otherAddr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+
+	status, err := w.RescanStatus()
+	require.NoError(t, err)
+	require.False(t, status.Active)
+
+	// Starting a rescan stores its checkpoint.
+	batch := &rescanBatch{
+		addrs: []btcutil.Address{addr},
+		bs:    testBlockStamp(100),
+	}
+	w.checkpointRescan(batch)
+	checkpoints := fetchRescanCheckpoints(t, w)
+	require.Len(t, checkpoints, 1)
+	require.Equal(t, rescanSetHash(batch.addrs, nil), checkpoints[0].SetHash)
+	require.Equal(t, int32(100), checkpoints[0].Start.Height)
+	require.Equal(t, int32(100), checkpoints[0].LastProcessed.Height)
+
+	// Its progress is recorded and reported.
+	w.updateRescanCheckpoint(testBlockStamp(150))
+	checkpoints = fetchRescanCheckpoints(t, w)
+	require.Len(t, checkpoints, 1)
+	require.Equal(t, testBlockStamp(150), checkpoints[0].LastProcessed)
+
+	status, err = w.RescanStatus()
+	require.NoError(t, err)
+	require.True(t, status.Active)
+	require.Equal(t, int32(100), status.StartHeight)
+	require.Equal(t, int32(150), status.CurrentHeight)
+	require.Equal(t, int32(200), status.TargetHeight)
+	require.Equal(t, 0.5, status.Progress)
+	require.True(t, status.ETA > 0)
+
+	// Starting the rescan again, as after a restart, resumes it from its
+	// last processed block.
+	w.rescanCheckpoint = nil
+	batch = &rescanBatch{
+		addrs: []btcutil.Address{addr, addr},
+		bs:    testBlockStamp(100),
+	}
+	w.checkpointRescan(batch)
+	require.Equal(t, testBlockStamp(150), batch.bs)
+	require.Len(t, fetchRescanCheckpoints(t, w), 1)
+
+	status, err = w.RescanStatus()
+	require.NoError(t, err)
+	require.Equal(t, int32(100), status.StartHeight)
+	require.Equal(t, int32(150), status.CurrentHeight)
+	require.Equal(t, time.Duration(0), status.ETA)
+
+	// A rescan for other addresses isn't resumed, but can supersede the
+	// interrupted one.
+	setHash := checkpoints[0].SetHash
+	batch = &rescanBatch{
+		addrs:      []btcutil.Address{addr, otherAddr},
+		bs:         testBlockStamp(120),
+		supersedes: []chainhash.Hash{setHash},
+	}
+	w.checkpointRescan(batch)
+	require.Equal(t, testBlockStamp(120), batch.bs)
+	checkpoints = fetchRescanCheckpoints(t, w)
+	require.Len(t, checkpoints, 1)
+	require.NotEqual(t, setHash, checkpoints[0].SetHash)
+
+	// A rescan with a stop block reports its progress towards it rather
+	// than the best block.
+	stop := testBlockStamp(180)
+	batch = &rescanBatch{
+		addrs:      []btcutil.Address{otherAddr},
+		bs:         testBlockStamp(140),
+		stop:       &stop,
+		supersedes: []chainhash.Hash{checkpoints[0].SetHash},
+	}
+	w.checkpointRescan(batch)
+	w.updateRescanCheckpoint(testBlockStamp(150))
+
+	status, err = w.RescanStatus()
+	require.NoError(t, err)
+	require.True(t, status.Active)
+	require.Equal(t, int32(140), status.StartHeight)
+	require.Equal(t, int32(150), status.CurrentHeight)
+	require.Equal(t, int32(180), status.TargetHeight)
+	require.Equal(t, 0.25, status.Progress)
+
+	// The checkpoint is removed once the rescan finishes.
+	w.finishRescanCheckpoint()
+	require.Empty(t, fetchRescanCheckpoints(t, w))
+
+	status, err = w.RescanStatus()
+	require.NoError(t, err)
+	require.False(t, status.Active)
+}
+
+// TestRescanBlockchain ensures that a range of blocks is rescanned for the
+// transactions of the wallet without dropping its history, reporting its
+// progress, and that invalid ranges and aborted rescans are rejected.
+func TestRescanBlockchain(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxOut(wire.NewTxOut(1e8, pkScript))
+
+	chainClient := &rescanChainClient{
+		relevantTx:     tx,
+		relevantHeight: 110,
+	}
+	w.chainClient = chainClient
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		for height := int32(100); height <= 150; height++ {
+			bs := testBlockStamp(height)
+			if err := w.Manager.SetSyncedTo(ns, &bs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	w.wg.Add(3)
+	go w.rescanBatchHandler()
+	go w.rescanProgressHandler()
+	go w.rescanRPCHandler()
+	defer func() {
+		w.Stop()
+		w.wg.Wait()
+	}()
+
+	// Blocks beyond the synced-to block can't be rescanned.
+	_, _, err = w.RescanBlockchain(120, 105, nil, nil)
+	require.Equal(t, ErrInvalidRescanRange, err)
+	_, _, err = w.RescanBlockchain(105, 151, nil, nil)
+	require.Equal(t, ErrInvalidRescanRange, err)
+
+	progress := make(chan *RescanProgressMsg, 1)
+	startStamp, stopStamp, err := w.RescanBlockchain(105, 120, progress, nil)
+	require.NoError(t, err)
+	require.Equal(t, testBlockStamp(105), *startStamp)
+	require.Equal(t, testBlockStamp(120), *stopStamp)
+
+	select {
+	case msg := <-progress:
+		require.Equal(t, int32(120), msg.Notification.Height)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for rescan progress")
+	}
+
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		txHash := tx.TxHash()
+		details, err := w.TxStore.TxDetails(ns, &txHash)
+		if err != nil {
+			return err
+		}
+		require.NotNil(t, details)
+		require.Equal(t, int32(110), details.Block.Height)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Its checkpoint is removed once the rescan finishes, and the output
+	// found is then watched for spends.
+	require.Eventually(t, func() bool {
+		return len(fetchRescanCheckpoints(t, w)) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	chainClient.mtx.Lock()
+	require.Equal(t, []wire.OutPoint{{Hash: tx.TxHash()}},
+		chainClient.spendWatched)
+	chainClient.mtx.Unlock()
+
+	// The whole history is rescanned by default, and cancelled rescans
+	// are aborted.
+	require.False(t, w.AbortRescan())
+	cancel := make(chan struct{})
+	close(cancel)
+	_, _, err = w.RescanBlockchain(0, -1, nil, cancel)
+	require.Equal(t, ErrRescanAborted, err)
+}
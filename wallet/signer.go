@@ -5,15 +5,395 @@
 package wallet
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/tinhnguyenhn/colxd/btcec"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxd/txscript"
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
 )
 
+// signedMessageMagic is the prefix prepended to messages before they are
+// hashed and signed, so a message signature can never double as a
+// transaction signature.
+const signedMessageMagic = "Bitcoin Signed Message:\n"
+
+// KeyDescriptor identifies the key a Signer must sign with. Signers that do
+// not hold the wallet's private keys use the master key fingerprint and
+// derivation path to locate the key, and the public key to check they found
+// the right one.
+type KeyDescriptor struct {
+	// Address is the wallet address backed by the key.
+	Address btcutil.Address
+
+	// PubKey is the public key of the key.
+	PubKey *btcec.PublicKey
+
+	// Compressed is whether the address commits to the compressed
+	// serialization of the public key.
+	Compressed bool
+
+	// MasterKeyFingerprint is the fingerprint of the master key the key
+	// was derived from, or zero if it is unknown.
+	MasterKeyFingerprint uint32
+
+	// Path is the derivation path of the key from the master key, with
+	// hardened indexes offset by hdkeychain.HardenedKeyStart. It is empty
+	// for imported keys, whose derivation is unknown.
+	Path []uint32
+
+	// managedAddr is the address manager's view of the key, used by the
+	// in-process signer to avoid a second database lookup.
+	managedAddr waddrmgr.ManagedPubKeyAddress
+}
+
+// InputSignRequest describes the signature a Signer must produce for a single
+// transaction input.
+type InputSignRequest struct {
+	// Tx is the transaction being signed.
+	Tx *wire.MsgTx
+
+	// InputIndex is the index of the input being signed.
+	InputIndex int
+
+	// Output is the previous output spent by the input.
+	Output *wire.TxOut
+
+	// SubScript is the script committed to by the signature. For witness
+	// inputs this is the witness program, which the signature hash
+	// algorithm expands to the pay-to-pubkey-hash script.
+	SubScript []byte
+
+	// HashType is the signature hash type to sign with.
+	HashType txscript.SigHashType
+
+	// Witness is whether the input is signed with the segregated witness
+	// signature hash algorithm.
+	Witness bool
+
+	// SigHashes optionally caches the midstate of the witness signature
+	// hash algorithm. It is never sent to remote signers.
+	SigHashes *txscript.TxSigHashes
+}
+
+// Sign returns the signature of the input with the given private key, with the
+// hash type appended.
+func (r *InputSignRequest) Sign(privKey *btcec.PrivateKey) ([]byte, error) {
+	if r.InputIndex < 0 || r.InputIndex >= len(r.Tx.TxIn) {
+		return nil, fmt.Errorf("input index %d out of range",
+			r.InputIndex)
+	}
+
+	if !r.Witness {
+		return txscript.RawTxInSignature(
+			r.Tx, r.InputIndex, r.SubScript, r.HashType, privKey,
+		)
+	}
+
+	sigHashes := r.SigHashes
+	if sigHashes == nil {
+		sigHashes = txscript.NewTxSigHashes(r.Tx)
+	}
+	return txscript.RawTxInWitnessSignature(
+		r.Tx, sigHashes, r.InputIndex, r.Output.Value, r.SubScript,
+		r.HashType, privKey,
+	)
+}
+
+// Signer is implemented by the holders of the wallet's private keys. The
+// wallet calls it for every input and message signature, which lets a
+// watch-only wallet delegate signing to another host.
+type Signer interface {
+	// SignInput returns the signature of the input described by req with
+	// the key described by key, with the hash type appended.
+	SignInput(key *KeyDescriptor, req *InputSignRequest) ([]byte, error)
+
+	// SignMessage returns the compact signature of the message hash
+	// returned by MessageHash with the key described by key.
+	SignMessage(key *KeyDescriptor, message string) ([]byte, error)
+}
+
+// MessageHash returns the hash signed by a message signature.
+func MessageHash(message string) []byte {
+	var buf bytes.Buffer
+	_ = wire.WriteVarString(&buf, 0, signedMessageMagic)
+	_ = wire.WriteVarString(&buf, 0, message)
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+// SignMessageCompact returns the compact signature of the message hash
+// returned by MessageHash with the given private key. It is a helper for Signer
+// implementations.
+func SignMessageCompact(privKey *btcec.PrivateKey, message string,
+	compressed bool) ([]byte, error) {
+
+	return btcec.SignCompact(
+		btcec.S256(), privKey, MessageHash(message), compressed,
+	)
+}
+
+// managerSigner is the in-process Signer, which signs with the private keys
+// stored by the wallet's address manager.
+type managerSigner struct {
+	w *Wallet
+}
+
+// A compile-time assertion to ensure that managerSigner meets the Signer
+// interface.
+var _ Signer = (*managerSigner)(nil)
+
+// privKey returns the private key described by key.
+func (s *managerSigner) privKey(key *KeyDescriptor) (*btcec.PrivateKey, error) {
+	if key.managedAddr != nil {
+		return key.managedAddr.PrivKey()
+	}
+	return s.w.PrivKeyForAddress(key.Address)
+}
+
+// SignInput signs an input with the private key stored by the address manager.
+//
+// This is part of the Signer interface.
+func (s *managerSigner) SignInput(key *KeyDescriptor,
+	req *InputSignRequest) ([]byte, error) {
+
+	privKey, err := s.privKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return req.Sign(privKey)
+}
+
+// SignMessage signs a message with the private key stored by the address
+// manager.
+//
+// This is part of the Signer interface.
+func (s *managerSigner) SignMessage(key *KeyDescriptor,
+	message string) ([]byte, error) {
+
+	privKey, err := s.privKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return SignMessageCompact(privKey, message, key.Compressed)
+}
+
+// SetSigner sets the Signer the wallet calls for input and message signatures.
+// A nil signer restores the in-process signer, which uses the private keys
+// stored by the wallet.
+func (w *Wallet) SetSigner(signer Signer) {
+	w.signerMtx.Lock()
+	w.signer = signer
+	w.signerMtx.Unlock()
+}
+
+// Signer returns the Signer the wallet calls for input and message signatures.
+func (w *Wallet) Signer() Signer {
+	signer, _ := w.currentSigner()
+	return signer
+}
+
+// currentSigner returns the wallet's signer and whether it was set with
+// SetSigner rather than being the in-process signer.
+func (w *Wallet) currentSigner() (Signer, bool) {
+	w.signerMtx.Lock()
+	defer w.signerMtx.Unlock()
+
+	if w.signer != nil {
+		return w.signer, true
+	}
+	return &managerSigner{w: w}, false
+}
+
+// keyDescriptor returns the key descriptor of a wallet public key address.
+func (w *Wallet) keyDescriptor(addrmgrNs walletdb.ReadBucket,
+	addr waddrmgr.ManagedPubKeyAddress) (*KeyDescriptor, error) {
+
+	key := &KeyDescriptor{
+		Address:     addr.Address(),
+		PubKey:      addr.PubKey(),
+		Compressed:  addr.Compressed(),
+		managedAddr: addr,
+	}
+
+	scope, path, ok := addr.DerivationInfo()
+	if !ok {
+		return key, nil
+	}
+	key.MasterKeyFingerprint = path.MasterKeyFingerprint
+	key.Path = []uint32{
+		scope.Purpose + hdkeychain.HardenedKeyStart,
+		scope.Coin + hdkeychain.HardenedKeyStart,
+		path.Account,
+		path.Branch,
+		path.Index,
+	}
+
+	// Keys of accounts derived by the wallet share its own master key,
+	// whose fingerprint isn't recorded with the account.
+	if key.MasterKeyFingerprint == 0 {
+		manager, err := w.Manager.FetchScopedKeyManager(scope)
+		if err != nil {
+			return nil, err
+		}
+		props, err := manager.AccountProperties(
+			addrmgrNs, addr.InternalAccount(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !props.IsImported {
+			key.MasterKeyFingerprint, err =
+				w.Manager.MasterKeyFingerprint(addrmgrNs)
+			if err != nil && !waddrmgr.IsError(err, waddrmgr.ErrNoExist) {
+				return nil, err
+			}
+		}
+	}
+
+	return key, nil
+}
+
+// outputPubKeyAddr returns the wallet public key address an output script
+// pays to. ErrNotMine is returned if the script doesn't pay to a wallet
+// address.
+func (w *Wallet) outputPubKeyAddr(addrmgrNs walletdb.ReadBucket,
+	pkScript []byte) (waddrmgr.ManagedPubKeyAddress, error) {
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		pkScript, w.chainParams,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		managedAddr, err := w.Manager.Address(addrmgrNs, addr)
+		if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pubKeyAddr, ok := managedAddr.(waddrmgr.ManagedPubKeyAddress)
+		if !ok {
+			return nil, fmt.Errorf("address %s is not a public "+
+				"key address", addr)
+		}
+		return pubKeyAddr, nil
+	}
+
+	return nil, ErrNotMine
+}
+
+// signPubKeyInput returns the witness and signature script spending an output
+// paid to a wallet public key address, signed by the given signer. Pay to
+// pubkey hash, pay to witness pubkey hash and nested witness pubkey hash
+// outputs are supported.
+func (w *Wallet) signPubKeyInput(addrmgrNs walletdb.ReadBucket, signer Signer,
+	addr waddrmgr.ManagedPubKeyAddress, tx *wire.MsgTx, inputIndex int,
+	output *wire.TxOut, sigHashes *txscript.TxSigHashes,
+	hashType txscript.SigHashType) (wire.TxWitness, []byte, error) {
+
+	key, err := w.keyDescriptor(addrmgrNs, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := &InputSignRequest{
+		Tx:         tx,
+		InputIndex: inputIndex,
+		Output:     output,
+		SubScript:  output.PkScript,
+		HashType:   hashType,
+		Witness:    true,
+		SigHashes:  sigHashes,
+	}
+
+	var sigScript []byte
+	pubKey := key.PubKey.SerializeCompressed()
+	switch addr.AddrType() {
+	case waddrmgr.PubKeyHash:
+		req.Witness = false
+		sig, err := signer.SignInput(key, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !key.Compressed {
+			pubKey = key.PubKey.SerializeUncompressed()
+		}
+		sigScript, err = txscript.NewScriptBuilder().AddData(sig).
+			AddData(pubKey).Script()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, sigScript, nil
+
+	// Nested witness outputs commit to the p2wkh witness program of the
+	// key, which is pushed by the signature script.
+	case waddrmgr.NestedWitnessPubKey:
+		p2wkhAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+			btcutil.Hash160(pubKey), w.chainParams,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.SubScript, err = txscript.PayToAddrScript(p2wkhAddr)
+		if err != nil {
+			return nil, nil, err
+		}
+		sigScript, err = txscript.NewScriptBuilder().
+			AddData(req.SubScript).Script()
+		if err != nil {
+			return nil, nil, err
+		}
+
+	case waddrmgr.WitnessPubKey:
+
+	default:
+		return nil, nil, fmt.Errorf("address %s is not a p2pkh, p2wkh "+
+			"or np2wkh address", addr.Address())
+	}
+
+	sig, err := signer.SignInput(key, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wire.TxWitness{sig, pubKey}, sigScript, nil
+}
+
+// SignMessage signs a message with the key of a wallet address and returns the
+// compact signature, which commits to the message hash returned by
+// MessageHash.
+func (w *Wallet) SignMessage(addr btcutil.Address, message string) ([]byte,
+	error) {
+
+	var key *KeyDescriptor
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		managedAddr, err := w.Manager.Address(addrmgrNs, addr)
+		if err != nil {
+			return err
+		}
+		pubKeyAddr, ok := managedAddr.(waddrmgr.ManagedPubKeyAddress)
+		if !ok {
+			return errors.New("address does not have an associated " +
+				"private key")
+		}
+		key, err = w.keyDescriptor(addrmgrNs, pubKeyAddr)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Signer().SignMessage(key, message)
+}
+
 // scriptForOutput returns the address, witness program and redeem script for a
 // given UTXO. An error is returned if the UTXO does not belong to our wallet or
 // it is not a managed pubKey address.
@@ -87,11 +467,39 @@ type PrivKeyTweaker func(*btcec.PrivateKey) (*btcec.PrivateKey, error)
 // transaction with the signature as defined within the passed SignDescriptor.
 // This method is capable of generating the proper input script for both
 // regular p2wkh output and p2wkh outputs nested within a regular p2sh output.
+// Unless a tweaker is given, the signature is created by the wallet's Signer.
 func (w *Wallet) ComputeInputScript(tx *wire.MsgTx, output *wire.TxOut,
 	inputIndex int, sigHashes *txscript.TxSigHashes,
 	hashType txscript.SigHashType, tweaker PrivKeyTweaker) (wire.TxWitness,
 	[]byte, error) {
 
+	// Without a tweak, the signature is requested from the wallet's
+	// signer, which may not hold the private key in this process.
+	if tweaker == nil {
+		var (
+			witness   wire.TxWitness
+			sigScript []byte
+		)
+		err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+			addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+			walletAddr, err := w.outputPubKeyAddr(
+				addrmgrNs, output.PkScript,
+			)
+			if err != nil {
+				return err
+			}
+			witness, sigScript, err = w.signPubKeyInput(
+				addrmgrNs, w.Signer(), walletAddr, tx,
+				inputIndex, output, sigHashes, hashType,
+			)
+			return err
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return witness, sigScript, nil
+	}
+
 	walletAddr, witnessProgram, sigScript, err := w.scriptForOutput(output)
 	if err != nil {
 		return nil, nil, err
@@ -102,12 +510,11 @@ func (w *Wallet) ComputeInputScript(tx *wire.MsgTx, output *wire.TxOut,
 		return nil, nil, err
 	}
 
-	// If we need to maybe tweak our private key, do it now.
-	if tweaker != nil {
-		privKey, err = tweaker(privKey)
-		if err != nil {
-			return nil, nil, err
-		}
+	// Tweaked keys can only be produced in-process, since the tweak is
+	// applied to the private key itself.
+	privKey, err = tweaker(privKey)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Generate a valid witness stack for the input.
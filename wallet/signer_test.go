@@ -7,10 +7,14 @@ package wallet
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/btcec"
 	"github.com/tinhnguyenhn/colxd/txscript"
 	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxutil/hdkeychain"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
 )
 
 // TestComputeInputScript checks that the wallet can create the full
@@ -102,3 +106,77 @@ func runTestCase(t *testing.T, w *Wallet, scope waddrmgr.KeyScope,
 		t.Fatalf("error validating tx: %v", err)
 	}
 }
+
+// recordingSigner is a Signer that records the keys it is asked to sign with
+// before passing the requests on to another signer.
+type recordingSigner struct {
+	Signer
+	keys []*KeyDescriptor
+}
+
+func (s *recordingSigner) SignInput(key *KeyDescriptor,
+	req *InputSignRequest) ([]byte, error) {
+
+	s.keys = append(s.keys, key)
+	return s.Signer.SignInput(key, req)
+}
+
+func (s *recordingSigner) SignMessage(key *KeyDescriptor,
+	message string) ([]byte, error) {
+
+	s.keys = append(s.keys, key)
+	return s.Signer.SignMessage(key, message)
+}
+
+// TestSetSigner checks that signatures are requested from the signer set on
+// the wallet, with the key origin a remote signer needs to find the key.
+func TestSetSigner(t *testing.T) {
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	signer := &recordingSigner{Signer: w.Signer()}
+	w.SetSigner(signer)
+	require.Equal(t, signer, w.Signer())
+
+	// Sign an input and a message with the same address.
+	runTestCase(t, w, waddrmgr.KeyScopeBIP0084, 0)
+	addr, err := w.CurrentAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	sig, err := w.SignMessage(addr, "colxwallet")
+	require.NoError(t, err)
+
+	pubKey, _, err := btcec.RecoverCompact(
+		btcec.S256(), sig, MessageHash("colxwallet"),
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t, addr.ScriptAddress(),
+		btcutil.Hash160(pubKey.SerializeCompressed()),
+	)
+
+	var fingerprint uint32
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		fingerprint, err = w.Manager.MasterKeyFingerprint(ns)
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, signer.keys, 2)
+	for _, key := range signer.keys {
+		require.Equal(t, addr, key.Address)
+		require.True(t, key.PubKey.IsEqual(pubKey))
+		require.Equal(t, fingerprint, key.MasterKeyFingerprint)
+		require.Equal(t, []uint32{
+			waddrmgr.KeyScopeBIP0084.Purpose +
+				hdkeychain.HardenedKeyStart,
+			waddrmgr.KeyScopeBIP0084.Coin +
+				hdkeychain.HardenedKeyStart,
+			hdkeychain.HardenedKeyStart, 0, 0,
+		}, key.Path)
+	}
+
+	// Clearing the signer restores the in-process signer.
+	w.SetSigner(nil)
+	require.IsType(t, &managerSigner{}, w.Signer())
+}
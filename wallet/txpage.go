@@ -0,0 +1,570 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"github.com/tinhnguyenhn/colxd/btcjson"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// transactionCursorSize is the size of a serialized transaction cursor: the
+// block height, the index of the transaction within the block, and the
+// transaction hash.
+const transactionCursorSize = 4 + 4 + chainhash.HashSize
+
+// ErrInvalidTransactionCursor describes an error where a transaction cursor
+// could not be decoded.
+var ErrInvalidTransactionCursor = errors.New("invalid transaction cursor")
+
+// ErrTransactionCursorOutOfRange describes an error where a transaction cursor
+// refers to a block outside of the requested block range.
+var ErrTransactionCursorOutOfRange = errors.New("transaction cursor is " +
+	"outside of the block range")
+
+// TransactionCursor identifies the position of a transaction within the
+// wallet's transaction history.  Cursors are returned with each page of
+// transactions and are passed back to continue listing from the transaction
+// following the last one returned.
+//
+// A cursor records the height of the block the transaction is mined in (-1
+// for unmined transactions), its index within the wallet's record of the
+// block, and its hash.  Since new blocks only extend the history, resuming
+// from a cursor is unaffected by transactions confirmed after it was created.
+type TransactionCursor struct {
+	height int32
+	index  uint32
+	hash   chainhash.Hash
+}
+
+// String returns the opaque string encoding of the cursor.
+func (c *TransactionCursor) String() string {
+	var b [transactionCursorSize]byte
+	binary.BigEndian.PutUint32(b[0:4], uint32(c.height))
+	binary.BigEndian.PutUint32(b[4:8], c.index)
+	copy(b[8:], c.hash[:])
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// ParseTransactionCursor decodes a cursor from the string returned by its
+// String method.
+func ParseTransactionCursor(s string) (*TransactionCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != transactionCursorSize {
+		return nil, ErrInvalidTransactionCursor
+	}
+
+	c := &TransactionCursor{
+		height: int32(binary.BigEndian.Uint32(b[0:4])),
+		index:  binary.BigEndian.Uint32(b[4:8]),
+	}
+	if c.height < -1 {
+		return nil, ErrInvalidTransactionCursor
+	}
+	copy(c.hash[:], b[8:])
+	return c, nil
+}
+
+// TransactionFilter describes which transactions are included when listing
+// the wallet's transaction history.  The zero value matches every transaction.
+type TransactionFilter struct {
+	// Account, if set, only matches transactions paying to or spending
+	// from an address of an account with this name.
+	Account string
+
+	// Category, if set, only matches transactions with an output in this
+	// listtransactions category: "send", "receive", "generate" or
+	// "immature".
+	Category string
+
+	// Label, if set, only matches transactions with this transaction label
+	// or paying to an address with this address label.
+	Label string
+
+	// MinAmount and MaxAmount, if non-zero, bound the absolute value of
+	// the net amount the transaction adds to or removes from the wallet.
+	MinAmount btcutil.Amount
+	MaxAmount btcutil.Amount
+}
+
+// categoryNames are the names of the categories a TransactionFilter may
+// select.
+var categoryNames = map[string]struct{}{
+	"send":                  {},
+	CreditReceive.String():  {},
+	CreditGenerate.String(): {},
+	CreditImmature.String(): {},
+}
+
+// Validate returns an error if the filter can never match a transaction.
+func (f *TransactionFilter) Validate() error {
+	if f.Category != "" {
+		if _, ok := categoryNames[f.Category]; !ok {
+			return errors.New("unknown transaction category " +
+				f.Category)
+		}
+	}
+	if f.MinAmount < 0 || f.MaxAmount < 0 {
+		return errors.New("amount bounds must not be negative")
+	}
+	if f.MaxAmount != 0 && f.MaxAmount < f.MinAmount {
+		return errors.New("maximum amount is below the minimum amount")
+	}
+	return nil
+}
+
+// matchTransaction returns whether the transaction matches the filter.
+func (w *Wallet) matchTransaction(dbtx walletdb.ReadTx, f *TransactionFilter,
+	details *wtxmgr.TxDetails, syncHeight int32) bool {
+
+	if f == nil {
+		return true
+	}
+
+	if f.MinAmount != 0 || f.MaxAmount != 0 {
+		var net btcutil.Amount
+		for _, cred := range details.Credits {
+			net += cred.Amount
+		}
+		for _, deb := range details.Debits {
+			net -= deb.Amount
+		}
+		if net < 0 {
+			net = -net
+		}
+		if net < f.MinAmount || (f.MaxAmount != 0 && net > f.MaxAmount) {
+			return false
+		}
+	}
+
+	if f.Category != "" {
+		send, recv := w.transactionCategories(details, syncHeight)
+		if !(f.Category == "send" && send) && f.Category != recv {
+			return false
+		}
+	}
+
+	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+
+	if f.Label != "" && details.Label != f.Label {
+		var found bool
+		for _, output := range details.MsgTx.TxOut {
+			_, addrs, _, _ := txscript.ExtractPkScriptAddrs(
+				output.PkScript, w.chainParams,
+			)
+			if len(addrs) == 1 &&
+				w.Manager.AddrLabel(addrmgrNs, addrs[0]) == f.Label {
+
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.Account != "" {
+		var found bool
+		for _, cred := range details.Credits {
+			pkScript := details.MsgTx.TxOut[cred.Index].PkScript
+			if w.pkScriptAccountName(addrmgrNs, pkScript) == f.Account {
+				found = true
+				break
+			}
+		}
+		for i := 0; !found && i < len(details.Debits); i++ {
+			pkScript := w.debitPkScript(dbtx, details, details.Debits[i])
+			if w.pkScriptAccountName(addrmgrNs, pkScript) == f.Account {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// transactionCategories returns whether the transaction has entries in the
+// "send" category of listtransactions results, and the category of its
+// received outputs, or an empty string if it has none.  The categories follow
+// those reported by listTransactions.
+func (w *Wallet) transactionCategories(details *wtxmgr.TxDetails,
+	syncHeight int32) (bool, string) {
+
+	send := len(details.Debits) != 0
+	var recv string
+	for _, cred := range details.Credits {
+		if cred.Change {
+			continue
+		}
+		send = send || cred.Spent
+		recv = RecvCategory(details, syncHeight, w.chainParams).String()
+	}
+	return send, recv
+}
+
+// pkScriptAccountName returns the name of the account of the address an
+// output script pays to, or an empty string if it is not a wallet address.
+func (w *Wallet) pkScriptAccountName(addrmgrNs walletdb.ReadBucket,
+	pkScript []byte) string {
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil || len(addrs) == 0 {
+		return ""
+	}
+	mgr, account, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+	if err != nil {
+		return ""
+	}
+	name, err := mgr.AccountName(addrmgrNs, account)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// debitPkScript returns the output script of the wallet output spent by a
+// debit, or nil if the previous transaction cannot be found.
+func (w *Wallet) debitPkScript(dbtx walletdb.ReadTx, details *wtxmgr.TxDetails,
+	deb wtxmgr.DebitRecord) []byte {
+
+	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+	prevOP := &details.MsgTx.TxIn[deb.Index].PreviousOutPoint
+	prev, err := w.TxStore.TxDetails(txmgrNs, &prevOP.Hash)
+	if err != nil || prev == nil ||
+		int(prevOP.Index) >= len(prev.MsgTx.TxOut) {
+
+		return nil
+	}
+	return prev.MsgTx.TxOut[prevOP.Index].PkScript
+}
+
+// descendingRange returns whether transactions recorded between the begin and
+// end heights are ranged over from newest to oldest by RangeTransactions.
+func descendingRange(begin, end int32) bool {
+	switch {
+	case begin < 0:
+		return end >= 0
+	case end < 0:
+		return false
+	default:
+		return begin > end
+	}
+}
+
+// heightInRange returns whether transactions recorded at a height are ranged
+// over between the begin and end heights by RangeTransactions.
+func heightInRange(height, begin, end int32) bool {
+	// Unmined transactions are ranged over after every block.
+	key := func(height int32) int64 {
+		if height < 0 {
+			return math.MaxInt32 + 1
+		}
+		return int64(height)
+	}
+	low, high := key(begin), key(end)
+	if low > high {
+		low, high = high, low
+	}
+	return low <= key(height) && key(height) <= high
+}
+
+// rangeTransactionsAfter calls f with each transaction recorded between the
+// begin and end heights, in the block order used by RangeTransactions.  When
+// the range is descending, the transactions of each block are also visited in
+// reverse.  Unmined transactions are visited along with abandoned transactions,
+// in order of their hashes.
+//
+// If after is non-nil, transactions up to and including the one it refers to
+// are skipped.  If that transaction is no longer recorded at the cursor's
+// height, iteration continues from the cursor's position within the block.
+// ErrTransactionCursorOutOfRange is returned if the cursor's height is not
+// between the begin and end heights.
+//
+// The details passed to f are only valid until f returns.  Returning true from
+// f stops the iteration.
+func (w *Wallet) rangeTransactionsAfter(txmgrNs walletdb.ReadBucket,
+	begin, end int32, after *TransactionCursor,
+	f func(*wtxmgr.TxDetails, *TransactionCursor) (bool, error)) error {
+
+	descending := descendingRange(begin, end)
+
+	// Rather than ranging over every block before the cursor, begin at
+	// the cursor's block.
+	if after != nil {
+		if !heightInRange(after.height, begin, end) {
+			return ErrTransactionCursorOutOfRange
+		}
+		switch {
+		case after.height == -1 && !descending:
+			begin, end = -1, -1
+		case after.height >= 0:
+			begin = after.height
+		}
+	}
+
+	rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+		height := details[0].Block.Height
+
+		var first int
+		if after != nil && after.height == height {
+			first = resumePosition(details, after, descending)
+		}
+		after = nil
+
+		n := len(details)
+		for pos := first; pos < n; pos++ {
+			i := pos
+			if descending {
+				i = n - 1 - pos
+			}
+			cursor := &TransactionCursor{
+				height: height,
+				index:  uint32(i),
+				hash:   details[i].Hash,
+			}
+			brk, err := f(&details[i], cursor)
+			if err != nil || brk {
+				return brk, err
+			}
+		}
+		return false, nil
+	}
+
+	return w.TxStore.RangeTransactionsWithAbandoned(
+		txmgrNs, begin, end, rangeFn,
+	)
+}
+
+// resumePosition returns the position, in iteration order, of the first
+// transaction of a block following the transaction a cursor refers to.
+func resumePosition(details []wtxmgr.TxDetails, after *TransactionCursor,
+	descending bool) int {
+
+	n := len(details)
+
+	// Unmined transactions are ordered by hash, so the transactions
+	// preceding the cursor are those with hashes ordered before (or,
+	// when descending, after) the cursor's hash.
+	if after.height == -1 {
+		var skip int
+		for i := range details {
+			cmp := bytes.Compare(details[i].Hash[:], after.hash[:])
+			if (!descending && cmp <= 0) || (descending && cmp >= 0) {
+				skip++
+			}
+		}
+		return skip
+	}
+
+	index := int(after.index)
+	for i := range details {
+		if details[i].Hash == after.hash {
+			index = i
+			break
+		}
+	}
+	if index >= n {
+		index = n - 1
+	}
+	if descending {
+		return n - index
+	}
+	return index + 1
+}
+
+// TransactionPage describes a page of transactions to return from the
+// wallet's transaction history.
+type TransactionPage struct {
+	// After, if non-nil, is the cursor of the last transaction of the
+	// previous page.
+	After *TransactionCursor
+
+	// Limit is the maximum number of transactions to return, or zero for
+	// no limit.
+	Limit int
+
+	// Filter selects the transactions included in the page.
+	Filter TransactionFilter
+}
+
+// GetTransactionsPage returns a page of the transactions between a starting
+// and ending block matching the page's filter, as done by GetTransactions.
+// Transactions are returned in the order described by GetTransactions, or
+// from newest to oldest if the ending block is below the starting block.
+//
+// If the page limit is reached before all matching transactions have been
+// returned, or the cancel channel unblocks, the result's NextCursor is set to
+// the cursor to request the following page with.
+func (w *Wallet) GetTransactionsPage(startBlock, endBlock *BlockIdentifier,
+	page *TransactionPage, cancel <-chan struct{}) (*GetTransactionsResult,
+	error) {
+
+	if err := page.Filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	start, end, err := w.blockIdentifierRange(startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	var res GetTransactionsResult
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		syncBlock := w.Manager.SyncedTo()
+
+		var (
+			n    int
+			last *TransactionCursor
+		)
+		rangeFn := func(details *wtxmgr.TxDetails,
+			cursor *TransactionCursor) (bool, error) {
+
+			select {
+			case <-cancel:
+				res.NextCursor = last
+				return true, nil
+			default:
+			}
+
+			if !w.matchTransaction(dbtx, &page.Filter, details,
+				syncBlock.Height) {
+
+				return false, nil
+			}
+
+			// A further matching transaction means the page is
+			// followed by another one.
+			if page.Limit > 0 && n == page.Limit {
+				res.NextCursor = last
+				return true, nil
+			}
+			n++
+			last = cursor
+
+			// The summary refers to the details, which are reused
+			// by RangeTransactions, so it is made from a copy.
+			detail := *details
+			res.addTransaction(&detail, makeTxSummary(dbtx, w, &detail))
+			return false, nil
+		}
+
+		return w.rangeTransactionsAfter(
+			txmgrNs, start, end, page.After, rangeFn,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// addTransaction adds the summary of a transaction to the result, grouping
+// mined transactions with others of the same block.
+func (r *GetTransactionsResult) addTransaction(details *wtxmgr.TxDetails,
+	summary TransactionSummary) {
+
+	if details.Block.Height == -1 {
+		r.UnminedTransactions = append(r.UnminedTransactions, summary)
+		return
+	}
+
+	n := len(r.MinedTransactions)
+	if n == 0 || r.MinedTransactions[n-1].Height != details.Block.Height {
+		blockHash := details.Block.Hash
+		r.MinedTransactions = append(r.MinedTransactions, Block{
+			Hash:      &blockHash,
+			Height:    details.Block.Height,
+			Timestamp: details.Block.Time.Unix(),
+		})
+		n++
+	}
+	block := &r.MinedTransactions[n-1]
+	block.Transactions = append(block.Transactions, summary)
+}
+
+// ListTransactionsPage returns listtransactions results for a page of the
+// wallet's transactions matching the page's filter, from newest to oldest.
+// If the filter selects a category, only the results of that category are
+// returned.
+//
+// The returned cursor is non-nil if more matching transactions follow the
+// page, and is passed as the After cursor of the next page to continue
+// listing.  Unlike the offsets taken by ListTransactions, cursors keep
+// referring to the same position as new transactions are recorded.
+func (w *Wallet) ListTransactionsPage(page *TransactionPage) (
+	[]btcjson.ListTransactionsResult, *TransactionCursor, error) {
+
+	if err := page.Filter.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	txList := []btcjson.ListTransactionsResult{}
+	var next *TransactionCursor
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+
+		// Get current block.  The block height used for calculating
+		// the number of tx confirmations.
+		syncBlock := w.Manager.SyncedTo()
+
+		var (
+			n    int
+			last *TransactionCursor
+		)
+		rangeFn := func(details *wtxmgr.TxDetails,
+			cursor *TransactionCursor) (bool, error) {
+
+			if !w.matchTransaction(tx, &page.Filter, details,
+				syncBlock.Height) {
+
+				return false, nil
+			}
+
+			if page.Limit > 0 && n == page.Limit {
+				next = last
+				return true, nil
+			}
+			n++
+			last = cursor
+
+			jsonResults := w.listTransactionsWithConflicts(
+				tx, details, syncBlock.Height,
+			)
+			for _, result := range jsonResults {
+				if page.Filter.Category != "" &&
+					result.Category != page.Filter.Category {
+
+					continue
+				}
+				txList = append(txList, result)
+			}
+			return false, nil
+		}
+
+		// Return newer results first by starting at mempool height and
+		// working down to the genesis block.
+		return w.rangeTransactionsAfter(txmgrNs, -1, 0, page.After, rangeFn)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return txList, next, nil
+}
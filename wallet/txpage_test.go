@@ -0,0 +1,256 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
+)
+
+// addTestCredit records a transaction paying value to pkScript, mined at the
+// given height or unmined if height is -1, and returns its hash.
+func addTestCredit(t *testing.T, w *Wallet, pkScript []byte, value int64,
+	height int32, nonce uint32) chainhash.Hash {
+
+	t.Helper()
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: nonce}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(value, pkScript))
+
+	rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+	require.NoError(t, err)
+
+	var block *wtxmgr.BlockMeta
+	if height != -1 {
+		block = &wtxmgr.BlockMeta{
+			Block: wtxmgr.Block{
+				Hash:   chainhash.Hash{byte(height)},
+				Height: height,
+			},
+			Time: time.Unix(int64(height), 0),
+		}
+	}
+
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		if err := w.TxStore.InsertTx(ns, rec, block); err != nil {
+			return err
+		}
+		return w.TxStore.AddCredit(ns, rec, block, 0, false)
+	})
+	require.NoError(t, err)
+
+	return rec.Hash
+}
+
+// TestTransactionCursor ensures transaction cursors survive their string
+// encoding and that malformed cursors are rejected.
+func TestTransactionCursor(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []*TransactionCursor{
+		{height: -1, hash: chainhash.Hash{1}},
+		{height: 123456, index: 7, hash: chainhash.Hash{2}},
+	} {
+		parsed, err := ParseTransactionCursor(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+
+	for _, s := range []string{"", "not a cursor", "AAAA"} {
+		_, err := ParseTransactionCursor(s)
+		require.Equal(t, ErrInvalidTransactionCursor, err)
+	}
+}
+
+// TestListTransactionsPage ensures that paging through the transaction
+// history with cursors visits every matching transaction exactly once, even
+// as new transactions are recorded between pages.
+func TestListTransactionsPage(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	// Record two transactions in each of three blocks, followed by an
+	// unmined one.  The history is listed newest first.
+	var hashes []chainhash.Hash
+	for height := int32(100); height < 103; height++ {
+		for i := uint32(0); i < 2; i++ {
+			nonce := uint32(height)*2 + i
+			hash := addTestCredit(
+				t, w, pkScript, int64(nonce)*1e5, height, nonce,
+			)
+			hashes = append(hashes, hash)
+		}
+	}
+	unmined := addTestCredit(t, w, pkScript, 1e8, -1, 1)
+
+	// listPage returns the txids of a page and the cursor following it.
+	listPage := func(page *TransactionPage) ([]string, *TransactionCursor) {
+		t.Helper()
+
+		results, next, err := w.ListTransactionsPage(page)
+		require.NoError(t, err)
+
+		txids := make([]string, 0, len(results))
+		for _, result := range results {
+			txids = append(txids, result.TxID)
+		}
+		return txids, next
+	}
+
+	// The first page holds the unmined transaction and the last
+	// transaction recorded in the newest block.
+	page := &TransactionPage{Limit: 2}
+	txids, next := listPage(page)
+	require.Equal(t, []string{
+		unmined.String(), hashes[5].String(),
+	}, txids)
+	require.NotNil(t, next)
+
+	// A transaction confirmed in a new block must not shift the following
+	// pages.
+	addTestCredit(t, w, pkScript, 1e8, 103, 2)
+
+	var seen []string
+	for next != nil {
+		page.After = next
+		txids, next = listPage(page)
+		seen = append(seen, txids...)
+	}
+	require.Equal(t, []string{
+		hashes[4].String(), hashes[3].String(), hashes[2].String(),
+		hashes[1].String(), hashes[0].String(),
+	}, seen)
+
+	// Filters are applied before the limit, so a page is filled with
+	// matching transactions only.
+	page = &TransactionPage{
+		Limit: 1,
+		Filter: TransactionFilter{
+			MinAmount: btcutil.Amount(201 * 1e5),
+			MaxAmount: btcutil.Amount(203 * 1e5),
+		},
+	}
+	txids, next = listPage(page)
+	require.Equal(t, []string{hashes[3].String()}, txids)
+	page.After = next
+	txids, next = listPage(page)
+	require.Equal(t, []string{hashes[2].String()}, txids)
+	page.After = next
+	txids, next = listPage(page)
+	require.Equal(t, []string{hashes[1].String()}, txids)
+	require.Nil(t, next)
+
+	// Labelling the address selects every transaction paying to it.
+	require.NoError(t, w.SetAddressLabel(addr, "deposits"))
+	results, _, err := w.ListTransactionsPage(&TransactionPage{
+		Filter: TransactionFilter{Label: "deposits", Category: "send"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, results)
+	results, _, err = w.ListTransactionsPage(&TransactionPage{
+		Filter: TransactionFilter{Label: "deposits", Account: "default"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 8)
+
+	_, _, err = w.ListTransactionsPage(&TransactionPage{
+		Filter: TransactionFilter{Category: "unknown"},
+	})
+	require.Error(t, err)
+}
+
+// TestGetTransactionsPage ensures that GetTransactionsPage pages through a
+// block range in ascending order, grouping transactions by block.
+func TestGetTransactionsPage(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToAddrScript(addr)
+	require.NoError(t, err)
+
+	var hashes []chainhash.Hash
+	for height := int32(100); height < 103; height++ {
+		for i := uint32(0); i < 2; i++ {
+			nonce := uint32(height)*2 + i
+			hash := addTestCredit(t, w, pkScript, 1e6, height, nonce)
+			hashes = append(hashes, hash)
+		}
+	}
+	unmined := addTestCredit(t, w, pkScript, 1e6, -1, 1)
+
+	page := &TransactionPage{Limit: 3}
+	var (
+		mined   []chainhash.Hash
+		heights []int32
+		pending []chainhash.Hash
+	)
+	for {
+		res, err := w.GetTransactionsPage(nil, nil, page, nil)
+		require.NoError(t, err)
+
+		for _, block := range res.MinedTransactions {
+			heights = append(heights, block.Height)
+			for _, tx := range block.Transactions {
+				mined = append(mined, *tx.Hash)
+			}
+		}
+		for _, tx := range res.UnminedTransactions {
+			pending = append(pending, *tx.Hash)
+		}
+
+		if res.NextCursor == nil {
+			break
+		}
+		page.After = res.NextCursor
+	}
+
+	require.Equal(t, hashes, mined)
+	require.Equal(t, []int32{100, 101, 101, 102}, heights)
+	require.Equal(t, []chainhash.Hash{unmined}, pending)
+
+	// Paging through a range ending below the tip stops at its last
+	// block, without unmined transactions.
+	endBlock := NewBlockIdentifierFromHeight(101)
+	page = &TransactionPage{Limit: 3}
+	res, err := w.GetTransactionsPage(nil, endBlock, page, nil)
+	require.NoError(t, err)
+	require.Empty(t, res.UnminedTransactions)
+	require.NotNil(t, res.NextCursor)
+
+	page.After = res.NextCursor
+	res, err = w.GetTransactionsPage(nil, endBlock, page, nil)
+	require.NoError(t, err)
+	require.Len(t, res.MinedTransactions, 1)
+	require.Equal(t, int32(101), res.MinedTransactions[0].Height)
+	require.Empty(t, res.UnminedTransactions)
+	require.Nil(t, res.NextCursor)
+
+	// A cursor of an unmined transaction is outside of the range.
+	page.After = &TransactionCursor{height: -1, hash: unmined}
+	_, err = w.GetTransactionsPage(nil, endBlock, page, nil)
+	require.Equal(t, ErrTransactionCursorOutOfRange, err)
+}
@@ -34,6 +34,58 @@ func (u unstableAPI) TxDetails(txHash *chainhash.Hash) (*wtxmgr.TxDetails, error
 	return details, err
 }
 
+// ConflictedTx calls wtxmgr.Store.ConflictedTx under a single database view
+// transaction.
+func (u unstableAPI) ConflictedTx(txHash *chainhash.Hash) (*wtxmgr.ConflictedTx, error) {
+	var conflict *wtxmgr.ConflictedTx
+	err := walletdb.View(u.w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		conflict, err = u.w.TxStore.ConflictedTx(txmgrNs, txHash)
+		return err
+	})
+	return conflict, err
+}
+
+// ConflictsOf calls wtxmgr.Store.ConflictsOf under a single database view
+// transaction.
+func (u unstableAPI) ConflictsOf(txHash *chainhash.Hash) ([]wtxmgr.ConflictedTx, error) {
+	var conflicts []wtxmgr.ConflictedTx
+	err := walletdb.View(u.w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		conflicts, err = u.w.TxStore.ConflictsOf(txmgrNs, txHash)
+		return err
+	})
+	return conflicts, err
+}
+
+// AbandonedTx calls wtxmgr.Store.AbandonedTx under a single database view
+// transaction.
+func (u unstableAPI) AbandonedTx(txHash *chainhash.Hash) (*wtxmgr.AbandonedTx, error) {
+	var abandoned *wtxmgr.AbandonedTx
+	err := walletdb.View(u.w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		abandoned, err = u.w.TxStore.AbandonedTx(txmgrNs, txHash)
+		return err
+	})
+	return abandoned, err
+}
+
+// RebroadcastState calls wtxmgr.Store.RebroadcastState under a single database
+// view transaction.
+func (u unstableAPI) RebroadcastState(txHash *chainhash.Hash) (*wtxmgr.RebroadcastState, error) {
+	var state *wtxmgr.RebroadcastState
+	err := walletdb.View(u.w.db, func(dbtx walletdb.ReadTx) error {
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		state, err = u.w.TxStore.RebroadcastState(txmgrNs, txHash)
+		return err
+	})
+	return state, err
+}
+
 // RangeTransactions calls wtxmgr.Store.RangeTransactions under a single
 // database view tranasction.
 func (u unstableAPI) RangeTransactions(begin, end int32, f func([]wtxmgr.TxDetails) (bool, error)) error {
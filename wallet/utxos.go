@@ -15,6 +15,7 @@ import (
 	"github.com/tinhnguyenhn/colxutil/psbt"
 	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/walletdb"
+	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
 )
 
 var (
@@ -39,24 +40,37 @@ func (p *OutputSelectionPolicy) meetsRequiredConfs(txHeight, curHeight int32) bo
 // described in the passed policy.
 func (w *Wallet) UnspentOutputs(policy OutputSelectionPolicy) ([]*TransactionOutput, error) {
 	var outputResults []*TransactionOutput
-	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+	err := w.ForEachUnspentOutput(policy, func(output *TransactionOutput) (bool, error) {
+		outputResults = append(outputResults, output)
+		return false, nil
+	})
+	return outputResults, err
+}
+
+// ForEachUnspentOutput calls f with each unspent output from the wallet that
+// matches the rules described in the passed policy, in ascending outpoint
+// order.  Outputs are read from the database as they are iterated, so callers
+// that only need some of them can return true from f to stop early without
+// loading the rest.
+//
+// The function f is called within a database transaction and must not call
+// back into the wallet.
+func (w *Wallet) ForEachUnspentOutput(policy OutputSelectionPolicy,
+	f func(output *TransactionOutput) (bool, error)) error {
+
+	return walletdb.View(w.db, func(tx walletdb.ReadTx) error {
 		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
 
 		syncBlock := w.Manager.SyncedTo()
 
-		// TODO: actually stream outputs from the db instead of fetching
-		// all of them at once.
-		outputs, err := w.TxStore.UnspentOutputs(txmgrNs)
-		if err != nil {
-			return err
-		}
+		return w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(
+			output *wtxmgr.Credit) (bool, error) {
 
-		for _, output := range outputs {
 			// Ignore outputs that haven't reached the required
 			// number of confirmations.
 			if !policy.meetsRequiredConfs(output.Height, syncBlock.Height) {
-				continue
+				return false, nil
 			}
 
 			// Ignore outputs that are not controlled by the account.
@@ -67,14 +81,14 @@ func (w *Wallet) UnspentOutputs(policy OutputSelectionPolicy) ([]*TransactionOut
 				// to without a valid address.  TODO: Fix this
 				// by saving outputs per account, or accounts
 				// per output.
-				continue
+				return false, nil
 			}
 			_, outputAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
 			if err != nil {
-				return err
+				return false, err
 			}
 			if outputAcct != policy.Account {
-				continue
+				return false, nil
 			}
 
 			// Stakebase isn't exposed by wtxmgr so those will be
@@ -94,12 +108,9 @@ func (w *Wallet) UnspentOutputs(policy OutputSelectionPolicy) ([]*TransactionOut
 				ContainingBlock: BlockIdentity(output.Block),
 				ReceiveTime:     output.Received,
 			}
-			outputResults = append(outputResults, result)
-		}
-
-		return nil
+			return f(result)
+		})
 	})
-	return outputResults, err
 }
 
 // FetchInputInfo queries for the wallet's knowledge of the passed outpoint. If
@@ -10,6 +10,8 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"sort"
 	"strings"
 	"sync"
@@ -49,6 +51,11 @@ const (
 	// scanned successively by the recovery manager, in the event that the
 	// wallet is started in recovery mode.
 	recoveryBatchSize = 2000
+
+	// recoveryFetchers is the number of batches of blocks that may be
+	// fetched and filtered concurrently, ahead of the batch being
+	// processed, by the recovery manager.
+	recoveryFetchers = 4
 )
 
 var (
@@ -72,6 +79,20 @@ var (
 	// to true.
 	ErrTxLabelExists = errors.New("transaction already labelled")
 
+	// ErrTxConfirmed is returned when an attempt is made to abandon a
+	// transaction that has already been mined.
+	ErrTxConfirmed = errors.New("cannot abandon confirmed transaction")
+
+	// ErrInvalidRescanRange is returned when the blocks requested to be
+	// rescanned are out of order or beyond the block the wallet is synced
+	// to.
+	ErrInvalidRescanRange = errors.New("start height must not exceed " +
+		"the stop height, which must not exceed the synced-to height")
+
+	// ErrRescanAborted is returned when a rescan is aborted before
+	// reaching its stop block.
+	ErrRescanAborted = errors.New("rescan aborted")
+
 	// Namespace bucket keys.
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 	wtxmgrNamespaceKey   = []byte("wtxmgr")
@@ -96,6 +117,24 @@ type Wallet struct {
 	lockedOutpoints    map[wire.OutPoint]struct{}
 	lockedOutpointsMtx sync.Mutex
 
+	// signer creates input and message signatures. It is nil when the
+	// in-process signer is used.
+	signer    Signer
+	signerMtx sync.Mutex
+
+	// rebroadcastRand randomizes the delays between rebroadcasts of
+	// unmined transactions.  It is only used by the goroutine
+	// rebroadcasting them.
+	rebroadcastRand *rand.Rand
+
+	// pruneConfs is the number of confirmations after which fully spent
+	// transactions are pruned from the transaction history.  Zero disables
+	// pruning.  pruning is set while the history is automatically being
+	// pruned.
+	pruneConfs    uint32
+	pruning       bool
+	pruneConfsMtx sync.Mutex
+
 	recoveryWindow uint32
 
 	// Channels for rescan processing.  Requests are added and merged with
@@ -107,6 +146,20 @@ type Wallet struct {
 	rescanProgress      chan *RescanProgressMsg
 	rescanFinished      chan *RescanFinishedMsg
 
+	// rescanCheckpoint is the checkpoint of the rescan being performed,
+	// or nil if there is none.  rescanStop is its stop block, if any.
+	// rescanResumeHeight and rescanResumeTime are the height and time the
+	// wallet (re)started it at.
+	rescanCheckpoint   *waddrmgr.RescanCheckpoint
+	rescanStop         *waddrmgr.BlockStamp
+	rescanResumeHeight int32
+	rescanResumeTime   time.Time
+
+	// rescanAbort is closed to abort the rescan with a stop block being
+	// performed, or nil if there is none.
+	rescanAbort chan struct{}
+	rescanMtx   sync.Mutex
+
 	// Channel for transaction creation requests.
 	createTxRequests chan createTxRequest
 
@@ -183,6 +236,8 @@ func (w *Wallet) SynchronizeRPC(chainClient chain.Interface) {
 		cc.SetStartTime(w.Manager.Birthday())
 	case *chain.BitcoindClient:
 		cc.SetBirthday(w.Manager.Birthday())
+	case *chain.MultiClient:
+		cc.SetBirthday(w.Manager.Birthday())
 	}
 	w.chainClientLock.Unlock()
 
@@ -190,11 +245,12 @@ func (w *Wallet) SynchronizeRPC(chainClient chain.Interface) {
 	// separately from the wallet (use wallet mutator functions to
 	// make changes from the RPC client) and not have to stop and
 	// restart them each time the client disconnects and reconnets.
-	w.wg.Add(4)
+	w.wg.Add(5)
 	go w.handleChainNotifications()
 	go w.rescanBatchHandler()
 	go w.rescanProgressHandler()
 	go w.rescanRPCHandler()
+	go w.rebroadcastHandler()
 }
 
 // requireChainClient marks that a wallet method can only be completed when the
@@ -508,19 +564,61 @@ func (w *Wallet) syncWithChain(birthdayStamp *waddrmgr.BlockStamp) error {
 	// Finally, we'll trigger a wallet rescan and request notifications for
 	// transactions sending to all wallet addresses and spending all wallet
 	// UTXOs.
+	// If rescans were interrupted before reaching our synced-to block, we
+	// resume them by starting from the earliest block they didn't process
+	// yet, which also replaces their checkpoints.
 	var (
-		addrs   []btcutil.Address
-		unspent []wtxmgr.Credit
+		addrs       []btcutil.Address
+		unspent     []wtxmgr.Credit
+		checkpoints []waddrmgr.RescanCheckpoint
 	)
 	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
 		addrs, unspent, err = w.activeData(dbtx)
+		if err != nil {
+			return err
+		}
+		checkpoints, err = waddrmgr.FetchRescanCheckpoints(
+			dbtx.ReadBucket(waddrmgrNamespaceKey),
+		)
 		return err
 	})
 	if err != nil {
 		return err
 	}
 
-	return w.rescanWithTarget(addrs, unspent, nil)
+	startStamp := w.Manager.SyncedTo()
+	supersedes := make([]chainhash.Hash, 0, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		supersedes = append(supersedes, checkpoint.SetHash)
+		if checkpoint.LastProcessed.Height < startStamp.Height {
+			startStamp = checkpoint.LastProcessed
+		}
+	}
+	if startStamp.Height < w.Manager.SyncedTo().Height {
+		// The block may have been reorganized out since it was
+		// processed, so we start from the block of the main chain at
+		// its height.
+		startHash, err := chainClient.GetBlockHash(
+			int64(startStamp.Height),
+		)
+		if err != nil {
+			return err
+		}
+		startHeader, err := chainClient.GetBlockHeader(startHash)
+		if err != nil {
+			return err
+		}
+		startStamp.Hash = *startHash
+		startStamp.Timestamp = startHeader.Timestamp
+	}
+	if len(checkpoints) > 0 {
+		log.Infof("Resuming %d interrupted %s from block %v "+
+			"(height %d)", len(checkpoints),
+			pickNoun(len(checkpoints), "rescan", "rescans"),
+			startStamp.Hash, startStamp.Height)
+	}
+
+	return w.rescanWithTarget(addrs, unspent, &startStamp, supersedes)
 }
 
 // isDevEnv determines whether the wallet is currently under a local developer
@@ -687,69 +785,62 @@ func (w *Wallet) recovery(chainClient chain.Interface,
 	// NOTE: We purposefully don't update our best height since we assume
 	// that a wallet rescan will be performed from the wallet's tip, which
 	// will be of bestHeight after completing the recovery process.
-	var blocks []*waddrmgr.BlockStamp
+	//
+	// The blocks are fetched in batches of 2000 blocks ahead of their
+	// processing, which is done in order since the addresses found in a
+	// batch extend the horizons of the following ones. Blocks before our
+	// birthday, which is possible if our birthday is after our reorg
+	// safe height, are fetched but not scanned.
 	startHeight := w.Manager.SyncedTo().Height + 1
-	for height := startHeight; height <= bestHeight; height++ {
-		hash, err := chainClient.GetBlockHash(int64(height))
+	fetcher := newRecoveryFetcher(
+		chainClient, startHeight, bestHeight, birthdayBlock.Height,
+	)
+	defer fetcher.stop()
+
+	for {
+		blocks, err := fetcher.next()
 		if err != nil {
 			return err
 		}
-		header, err := chainClient.GetBlockHeader(hash)
-		if err != nil {
-			return err
+		if blocks == nil {
+			return nil
 		}
-		blocks = append(blocks, &waddrmgr.BlockStamp{
-			Hash:      *hash,
-			Height:    height,
-			Timestamp: header.Timestamp,
-		})
 
-		// It's possible for us to run into blocks before our birthday
-		// if our birthday is after our reorg safe height, so we'll make
-		// sure to not add those to the batch.
-		if height >= birthdayBlock.Height {
+		for _, block := range blocks.filterBlocks {
 			recoveryMgr.AddToBlockBatch(
-				hash, height, header.Timestamp,
+				&block.Hash, block.Height, block.Time,
 			)
 		}
 
-		// We'll perform our recovery in batches of 2000 blocks.  It's
-		// possible for us to reach our best height without exceeding
-		// the recovery batch size, so we can proceed to commit our
-		// state to disk.
 		recoveryBatch := recoveryMgr.BlockBatch()
-		if len(recoveryBatch) == recoveryBatchSize || height == bestHeight {
-			err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
-				ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
-				for _, block := range blocks {
-					err := w.Manager.SetSyncedTo(ns, block)
-					if err != nil {
-						return err
-					}
+		err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+			ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+			for _, block := range blocks.stamps {
+				err := w.Manager.SetSyncedTo(ns, block)
+				if err != nil {
+					return err
 				}
-				return w.recoverScopedAddresses(
-					chainClient, tx, ns, recoveryBatch,
-					recoveryMgr.State(), scopedMgrs,
-				)
-			})
-			if err != nil {
-				return err
-			}
-
-			if len(recoveryBatch) > 0 {
-				log.Infof("Recovered addresses from blocks "+
-					"%d-%d", recoveryBatch[0].Height,
-					recoveryBatch[len(recoveryBatch)-1].Height)
 			}
+			return w.recoverScopedAddresses(
+				fetcher, tx, ns, recoveryBatch, blocks,
+				recoveryMgr.State(), scopedMgrs,
+				waddrmgr.DefaultAccountNum,
+			)
+		})
+		if err != nil {
+			return err
+		}
 
-			// Clear the batch of all processed blocks to reuse the
-			// same memory for future batches.
-			blocks = blocks[:0]
-			recoveryMgr.ResetBlockBatch()
+		if len(recoveryBatch) > 0 {
+			log.Infof("Recovered addresses from blocks "+
+				"%d-%d", recoveryBatch[0].Height,
+				recoveryBatch[len(recoveryBatch)-1].Height)
 		}
-	}
 
-	return nil
+		// Clear the batch of all processed blocks to reuse the same
+		// memory for future batches.
+		recoveryMgr.ResetBlockBatch()
+	}
 }
 
 // recoverScopedAddresses scans a range of blocks in attempts to recover any
@@ -764,14 +855,18 @@ func (w *Wallet) recovery(chainClient chain.Interface,
 //  5) Trim the range of blocks up to and including the one reporting the addrs.
 //  6) Repeat from (1) if there are still more blocks in the range.
 //
-// TODO(conner): parallelize/pipeline/cache intermediate network requests
+// The blocks may already have been filtered by the fetcher, in which case the
+// response is used for the first pass if no addresses or outpoints were found
+// since.
 func (w *Wallet) recoverScopedAddresses(
-	chainClient chain.Interface,
+	fetcher *recoveryFetcher,
 	tx walletdb.ReadWriteTx,
 	ns walletdb.ReadWriteBucket,
 	batch []wtxmgr.BlockMeta,
+	prefetched *recoveryBlocks,
 	recoveryState *RecoveryState,
-	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager) error {
+	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager,
+	account uint32) error {
 
 	// If there are no blocks in the batch, we are done.
 	if len(batch) == 0 {
@@ -783,25 +878,46 @@ func (w *Wallet) recoverScopedAddresses(
 expandHorizons:
 	for scope, scopedMgr := range scopedMgrs {
 		scopeState := recoveryState.StateForScope(scope)
-		err := expandScopeHorizons(ns, scopedMgr, scopeState)
+		err := expandScopeHorizons(ns, scopedMgr, scopeState, account)
 		if err != nil {
 			return err
 		}
 	}
 
-	// With the internal and external horizons properly expanded, we now
-	// construct the filter blocks request. The request includes the range
-	// of blocks we intend to scan, in addition to the scope-index -> addr
-	// map for all internal and external branches.
-	filterReq := newFilterBlocksRequest(batch, scopedMgrs, recoveryState)
+	// With the horizons expanded, the blocks fetched from now on are
+	// filtered ahead of time for the addresses and outpoints we know of,
+	// unless they already are.
+	fetcherReq := fetcher.filterRequest()
+	if fetcherReq == nil {
+		fetcherReq = fetcher.setFilterRequest(scopedMgrs, recoveryState)
+	}
 
-	// Initiate the filter blocks request using our chain backend. If an
-	// error occurs, we are unable to proceed with the recovery.
-	filterResp, err := chainClient.FilterBlocks(filterReq)
-	if err != nil {
-		return err
+	// If the blocks were filtered with the same request, the response is
+	// used as is. Otherwise, we construct the filter blocks request. The
+	// request includes the range of blocks we intend to scan, in addition
+	// to the scope-index -> addr map for all internal and external
+	// branches.
+	var filterResp *chain.FilterBlocksResponse
+	if prefetched != nil && prefetched.filterReq == fetcherReq {
+		filterResp = prefetched.filterResp
+	} else {
+		filterReq := newFilterBlocksRequest(
+			batch, scopedMgrs, recoveryState,
+		)
+
+		// Initiate the filter blocks request using our chain backend.
+		// If an error occurs, we are unable to proceed with the
+		// recovery.
+		var err error
+		filterResp, err = fetcher.chainClient.FilterBlocks(filterReq)
+		if err != nil {
+			return err
+		}
 	}
 
+	// The prefetched response only applies to the whole batch.
+	prefetched = nil
+
 	// If the filter response is empty, this signals that the rest of the
 	// batch was completed, and no other addresses were discovered. As a
 	// result, no further modifications to our recovery state are required
@@ -817,12 +933,18 @@ expandHorizons:
 	// Log any non-trivial findings of addresses or outpoints.
 	logFilterBlocksResp(block, filterResp)
 
+	// As the addresses and outpoints to look for are about to change, the
+	// blocks filtered ahead of time must be filtered again.
+	fetcher.resetFilterRequest()
+
 	// Report any external or internal addresses found as a result of the
 	// appropriate branch recovery state. Adding indexes above the
 	// last-found index of either will result in the horizons being expanded
 	// upon the next iteration. Any found addresses are also marked used
 	// using the scoped key manager.
-	err = extendFoundAddresses(ns, filterResp, scopedMgrs, recoveryState)
+	err := extendFoundAddresses(
+		ns, filterResp, scopedMgrs, recoveryState, account,
+	)
 	if err != nil {
 		return err
 	}
@@ -872,7 +994,7 @@ expandHorizons:
 // proper number of valid child keys.
 func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	scopedMgr *waddrmgr.ScopedKeyManager,
-	scopeState *ScopeRecoveryState) error {
+	scopeState *ScopeRecoveryState, account uint32) error {
 
 	// Compute the current external horizon and the number of addresses we
 	// must derive to ensure we maintain a sufficient recovery window for
@@ -880,7 +1002,7 @@ func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	exHorizon, exWindow := scopeState.ExternalBranch.ExtendHorizon()
 	count, childIndex := uint32(0), exHorizon
 	for count < exWindow {
-		keyPath := externalKeyPath(childIndex)
+		keyPath := externalKeyPath(account, childIndex)
 		addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 		switch {
 		case err == hdkeychain.ErrInvalidChild:
@@ -910,7 +1032,7 @@ func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	inHorizon, inWindow := scopeState.InternalBranch.ExtendHorizon()
 	count, childIndex = 0, inHorizon
 	for count < inWindow {
-		keyPath := internalKeyPath(childIndex)
+		keyPath := internalKeyPath(account, childIndex)
 		addr, err := scopedMgr.DeriveFromKeyPath(ns, keyPath)
 		switch {
 		case err == hdkeychain.ErrInvalidChild:
@@ -937,21 +1059,23 @@ func expandScopeHorizons(ns walletdb.ReadWriteBucket,
 	return nil
 }
 
-// externalKeyPath returns the relative external derivation path /0/0/index.
-func externalKeyPath(index uint32) waddrmgr.DerivationPath {
+// externalKeyPath returns the relative external derivation path
+// /account/0/index.
+func externalKeyPath(account, index uint32) waddrmgr.DerivationPath {
 	return waddrmgr.DerivationPath{
-		InternalAccount: waddrmgr.DefaultAccountNum,
-		Account:         waddrmgr.DefaultAccountNum,
+		InternalAccount: account,
+		Account:         account,
 		Branch:          waddrmgr.ExternalBranch,
 		Index:           index,
 	}
 }
 
-// internalKeyPath returns the relative internal derivation path /0/1/index.
-func internalKeyPath(index uint32) waddrmgr.DerivationPath {
+// internalKeyPath returns the relative internal derivation path
+// /account/1/index.
+func internalKeyPath(account, index uint32) waddrmgr.DerivationPath {
 	return waddrmgr.DerivationPath{
-		InternalAccount: waddrmgr.DefaultAccountNum,
-		Account:         waddrmgr.DefaultAccountNum,
+		InternalAccount: account,
+		Account:         account,
 		Branch:          waddrmgr.InternalBranch,
 		Index:           index,
 	}
@@ -999,7 +1123,7 @@ func newFilterBlocksRequest(batch []wtxmgr.BlockMeta,
 func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 	filterResp *chain.FilterBlocksResponse,
 	scopedMgrs map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager,
-	recoveryState *RecoveryState) error {
+	recoveryState *RecoveryState, account uint32) error {
 
 	// Mark all recovered external addresses as used. This will be done only
 	// for scopes that reported a non-zero number of external addresses in
@@ -1026,7 +1150,7 @@ func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 		}
 
 		err := scopedMgr.ExtendExternalAddresses(
-			ns, waddrmgr.DefaultAccountNum, exLastFound,
+			ns, account, exLastFound,
 		)
 		if err != nil {
 			return err
@@ -1068,7 +1192,7 @@ func extendFoundAddresses(ns walletdb.ReadWriteBucket,
 			inLastFound--
 		}
 		err := scopedMgr.ExtendInternalAddresses(
-			ns, waddrmgr.DefaultAccountNum, inLastFound,
+			ns, account, inLastFound,
 		)
 		if err != nil {
 			return err
@@ -1130,6 +1254,7 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB btcutil.Amount
+		tagPolicy   *TagPolicy
 		dryRun      bool
 		resp        chan createTxResponse
 	}
@@ -1162,7 +1287,8 @@ out:
 			}
 			tx, err := w.txToOutputs(
 				txr.outputs, txr.keyScope, txr.account,
-				txr.minconf, txr.feeSatPerKB, txr.dryRun,
+				txr.minconf, txr.feeSatPerKB, txr.tagPolicy,
+				txr.dryRun,
 			)
 			heldUnlock.release()
 			txr.resp <- createTxResponse{tx, err}
@@ -1182,13 +1308,14 @@ out:
 // with inputs regardless of their type (NP2WKH, P2WKH, etc.). Change and an
 // appropriate transaction fee are automatically included, if necessary. All
 // transaction creation through this function is serialized to prevent the
-// creation of many transactions which spend the same outputs.
+// creation of many transactions which spend the same outputs.  If tagPolicy
+// is not nil, only the outputs it allows based on their tags are selected.
 //
 // NOTE: The dryRun argument can be set true to create a tx that doesn't alter
 // the database. A tx created with this set to true SHOULD NOT be broadcasted.
 func (w *Wallet) CreateSimpleTx(keyScope *waddrmgr.KeyScope, account uint32,
 	outputs []*wire.TxOut, minconf int32, satPerKb btcutil.Amount,
-	dryRun bool) (*txauthor.AuthoredTx, error) {
+	tagPolicy *TagPolicy, dryRun bool) (*txauthor.AuthoredTx, error) {
 
 	req := createTxRequest{
 		keyScope:    keyScope,
@@ -1196,6 +1323,7 @@ func (w *Wallet) CreateSimpleTx(keyScope *waddrmgr.KeyScope, account uint32,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		tagPolicy:   tagPolicy,
 		dryRun:      dryRun,
 		resp:        make(chan createTxResponse),
 	}
@@ -1484,12 +1612,8 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 		// the number of tx confirmations.
 		syncBlock := w.Manager.SyncedTo()
 
-		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
-		if err != nil {
-			return err
-		}
-		for i := range unspent {
-			output := &unspent[i]
+		return w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(
+			output *wtxmgr.Credit) (bool, error) {
 
 			var outputAcct uint32
 			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
@@ -1498,7 +1622,7 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 				_, outputAcct, err = w.Manager.AddrAccount(addrmgrNs, addrs[0])
 			}
 			if err != nil || outputAcct != account {
-				continue
+				return false, nil
 			}
 
 			bals.Total += output.Amount
@@ -1508,8 +1632,8 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 			} else if confirmed(confirms, output.Height, syncBlock.Height) {
 				bals.Spendable += output.Amount
 			}
-		}
-		return nil
+			return false, nil
+		})
 	})
 	return bals, err
 }
@@ -1708,6 +1832,64 @@ func (w *Wallet) AddressInfo(a btcutil.Address) (waddrmgr.ManagedAddress, error)
 	return managedAddress, err
 }
 
+// AddressDetails describes a wallet address along with the details of how it
+// is tracked by the wallet.
+type AddressDetails struct {
+	// Address is the managed address as known by the address manager.
+	Address waddrmgr.ManagedAddress
+
+	// KeyScope is the key scope of the manager holding the address.
+	KeyScope waddrmgr.KeyScope
+
+	// AccountName is the name of the account the address belongs to.
+	AccountName string
+
+	// WatchOnly is true if the wallet cannot sign for the address.
+	WatchOnly bool
+
+	// Used is true if the address has been seen in a transaction.
+	Used bool
+
+	// Label is the user-defined label of the address, if any.
+	Label string
+}
+
+// AddressDetails returns the managed address for a wallet address along with
+// its key scope, account, spendability, usage and label.
+func (w *Wallet) AddressDetails(a btcutil.Address) (*AddressDetails, error) {
+	var details AddressDetails
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		manager, account, err := w.Manager.AddrAccount(addrmgrNs, a)
+		if err != nil {
+			return err
+		}
+		ma, err := manager.Address(addrmgrNs, a)
+		if err != nil {
+			return err
+		}
+		acctName, err := manager.AccountName(addrmgrNs, account)
+		if err != nil {
+			return err
+		}
+
+		details.Address = ma
+		details.KeyScope = manager.Scope()
+		details.AccountName = acctName
+		details.WatchOnly = w.Manager.WatchOnly()
+		if pka, ok := ma.(waddrmgr.ManagedPubKeyAddress); ok {
+			details.WatchOnly = pka.WatchOnly()
+		}
+		details.Used = ma.Used(addrmgrNs)
+		details.Label = w.Manager.AddrLabel(addrmgrNs, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
 // AccountNumber returns the account number for an account name under a
 // particular key scope.
 func (w *Wallet) AccountNumber(scope waddrmgr.KeyScope, accountName string) (uint32, error) {
@@ -1972,6 +2154,7 @@ outputs:
 
 		var address string
 		var accountName string
+		var label *string
 		_, addrs, _, _ := txscript.ExtractPkScriptAddrs(output.PkScript, net)
 		if len(addrs) == 1 {
 			addr := addrs[0]
@@ -1983,6 +2166,9 @@ outputs:
 					accountName = ""
 				}
 			}
+			if l := addrMgr.AddrLabel(addrmgrNs, addr); l != "" {
+				label = &l
+			}
 		}
 
 		amountF64 := btcutil.Amount(output.Value).ToBTC()
@@ -1997,6 +2183,7 @@ outputs:
 			//   Amount
 			//   Fee
 			Address:         address,
+			Label:           label,
 			Vout:            uint32(i),
 			Confirmations:   confirmations,
 			Generated:       generated,
@@ -2047,16 +2234,17 @@ func (w *Wallet) ListSinceBlock(start, end, syncHeight int32) ([]btcjson.ListTra
 			for _, detail := range details {
 				detail := detail
 
-				jsonResults := listTransactions(
-					tx, &detail, w.Manager, syncHeight,
-					w.chainParams,
+				jsonResults := w.listTransactionsWithConflicts(
+					tx, &detail, syncHeight,
 				)
 				txList = append(txList, jsonResults...)
 			}
 			return false, nil
 		}
 
-		return w.TxStore.RangeTransactions(txmgrNs, start, end, rangeFn)
+		return w.TxStore.RangeTransactionsWithAbandoned(
+			txmgrNs, start, end, rangeFn,
+		)
 	})
 	return txList, err
 }
@@ -2079,41 +2267,139 @@ func (w *Wallet) ListTransactions(from, count int) ([]btcjson.ListTransactionsRe
 		skipped := 0
 		n := 0
 
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
-			// Iterate over transactions at this height in reverse order.
-			// This does nothing for unmined transactions, which are
-			// unsorted, but it will process mined transactions in the
-			// reverse order they were marked mined.
-			for i := len(details) - 1; i >= 0; i-- {
-				if from > skipped {
-					skipped++
-					continue
-				}
+		rangeFn := func(details *wtxmgr.TxDetails,
+			pruned *wtxmgr.PrunedTx) (bool, error) {
 
-				n++
-				if n > count {
-					return true, nil
-				}
+			if from > skipped {
+				skipped++
+				return false, nil
+			}
 
-				jsonResults := listTransactions(tx, &details[i],
-					w.Manager, syncBlock.Height, w.chainParams)
-				txList = append(txList, jsonResults...)
+			n++
+			if n > count {
+				return true, nil
+			}
 
-				if len(jsonResults) > 0 {
-					n++
+			var jsonResults []btcjson.ListTransactionsResult
+			if pruned != nil {
+				jsonResults = []btcjson.ListTransactionsResult{
+					listPrunedTransaction(pruned, syncBlock.Height),
 				}
+			} else {
+				jsonResults = w.listTransactionsWithConflicts(
+					tx, details, syncBlock.Height,
+				)
+			}
+			txList = append(txList, jsonResults...)
+
+			if len(jsonResults) > 0 {
+				n++
 			}
 
 			return false, nil
 		}
 
-		// Return newer results first by starting at mempool height and working
-		// down to the genesis block.
-		return w.TxStore.RangeTransactions(txmgrNs, -1, 0, rangeFn)
+		return w.rangeHistory(txmgrNs, rangeFn)
 	})
 	return txList, err
 }
 
+// rangeHistory runs the function f on every transaction of the wallet, newer
+// transactions first, starting with unmined transactions.  Mined transactions
+// are passed as details, and transactions pruned from the transaction history
+// as their summary, after the transactions still recorded for the same block.
+// Iteration stops early when f returns true.
+func (w *Wallet) rangeHistory(txmgrNs walletdb.ReadBucket,
+	f func(*wtxmgr.TxDetails, *wtxmgr.PrunedTx) (bool, error)) error {
+
+	// next is the highest height whose pruned transactions are yet to be
+	// passed to f.
+	next, err := w.TxStore.PrunedHeight(txmgrNs)
+	if err != nil {
+		return err
+	}
+
+	var brk bool
+	rangePruned := func(end int32) error {
+		if next < end {
+			return nil
+		}
+		begin := next
+		next = end - 1
+		return w.TxStore.RangePrunedTransactions(txmgrNs, begin, end,
+			func(pruned []wtxmgr.PrunedTx) (bool, error) {
+				for i := range pruned {
+					var err error
+					brk, err = f(nil, &pruned[i])
+					if err != nil || brk {
+						return true, err
+					}
+				}
+				return false, nil
+			})
+	}
+
+	rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+		if height := details[0].Block.Height; height != -1 {
+			if err := rangePruned(height + 1); err != nil || brk {
+				return true, err
+			}
+		}
+
+		// Iterate over transactions at this height in reverse order.
+		// This does nothing for unmined transactions, which are
+		// unsorted, but it will process mined transactions in the
+		// reverse order they were marked mined.
+		for i := len(details) - 1; i >= 0; i-- {
+			var err error
+			brk, err = f(&details[i], nil)
+			if err != nil || brk {
+				return true, err
+			}
+		}
+		return false, nil
+	}
+
+	// Return newer results first by starting at mempool height and working
+	// down to the genesis block.  Abandoned transactions are listed with
+	// the unmined transactions.
+	err = w.TxStore.RangeTransactionsWithAbandoned(txmgrNs, -1, 0, rangeFn)
+	if err != nil || brk {
+		return err
+	}
+	return rangePruned(0)
+}
+
+// listPrunedTransaction creates an object that may be marshalled to a response
+// result for a listtransactions RPC, briefly describing a transaction pruned
+// from the transaction history by its net amount.
+func listPrunedTransaction(pruned *wtxmgr.PrunedTx,
+	syncHeight int32) btcjson.ListTransactionsResult {
+
+	category := "receive"
+	if pruned.NetAmount < 0 {
+		category = "send"
+	}
+	var label *string
+	if pruned.Label != "" {
+		label = &pruned.Label
+	}
+	received := pruned.Received.Unix()
+
+	return btcjson.ListTransactionsResult{
+		Category:        category,
+		Amount:          pruned.NetAmount.ToBTC(),
+		Label:           label,
+		Confirmations:   int64(confirms(pruned.Block.Height, syncHeight)),
+		BlockHash:       pruned.Block.Hash.String(),
+		BlockTime:       pruned.Block.Time.Unix(),
+		TxID:            pruned.Hash.String(),
+		WalletConflicts: []string{},
+		Time:            received,
+		TimeReceived:    received,
+	}
+}
+
 // ListAddressTransactions returns a slice of objects with details about
 // recorded transactions to or from any address belonging to a set.  This is
 // intended to be used for listaddresstransactions RPC replies.
@@ -2125,41 +2411,68 @@ func (w *Wallet) ListAddressTransactions(pkHashes map[string]struct{}) ([]btcjso
 		// Get current block.  The block height used for calculating
 		// the number of tx confirmations.
 		syncBlock := w.Manager.SyncedTo()
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
-		loopDetails:
-			for i := range details {
-				detail := &details[i]
 
-				for _, cred := range detail.Credits {
-					pkScript := detail.MsgTx.TxOut[cred.Index].PkScript
-					_, addrs, _, err := txscript.ExtractPkScriptAddrs(
-						pkScript, w.chainParams)
-					if err != nil || len(addrs) != 1 {
-						continue
-					}
-					apkh, ok := addrs[0].(*btcutil.AddressPubKeyHash)
-					if !ok {
-						continue
-					}
-					_, ok = pkHashes[string(apkh.ScriptAddress())]
-					if !ok {
-						continue
-					}
-
-					jsonResults := listTransactions(tx, detail,
-						w.Manager, syncBlock.Height, w.chainParams)
-					txList = append(txList, jsonResults...)
-					continue loopDetails
-				}
+		// Only pay-to-pubkey-hash outputs are matched, so the
+		// transactions of each address are found through the script
+		// index by the P2PKH script of its hash.
+		var details []wtxmgr.TxDetails
+		for pkHash := range pkHashes {
+			addr, err := btcutil.NewAddressPubKeyHash(
+				[]byte(pkHash), w.chainParams,
+			)
+			if err != nil {
+				return err
 			}
-			return false, nil
+			pkScript, err := txscript.PayToAddrScript(addr)
+			if err != nil {
+				return err
+			}
+			addrDetails, err := w.TxStore.TxDetailsForScript(
+				txmgrNs, pkScript,
+			)
+			if err != nil {
+				return err
+			}
+			details = append(details, addrDetails...)
 		}
 
-		return w.TxStore.RangeTransactions(txmgrNs, 0, -1, rangeFn)
+		// Transactions paying to several of the addresses are only
+		// listed once, in the same block order used for a single
+		// address.
+		sortTxDetailsByHeight(details)
+		seen := make(map[chainhash.Hash]struct{}, len(details))
+		for i := range details {
+			detail := &details[i]
+			if _, ok := seen[detail.Hash]; ok {
+				continue
+			}
+			seen[detail.Hash] = struct{}{}
+
+			jsonResults := w.listTransactionsWithConflicts(
+				tx, detail, syncBlock.Height,
+			)
+			txList = append(txList, jsonResults...)
+		}
+		return nil
 	})
 	return txList, err
 }
 
+// sortTxDetailsByHeight sorts transaction details by increasing block height,
+// with unmined transactions last.
+func sortTxDetailsByHeight(details []wtxmgr.TxDetails) {
+	heightKey := func(height int32) int32 {
+		if height == -1 {
+			return math.MaxInt32
+		}
+		return height
+	}
+	sort.SliceStable(details, func(i, j int) bool {
+		return heightKey(details[i].Block.Height) <
+			heightKey(details[j].Block.Height)
+	})
+}
+
 // ListAllTransactions returns a slice of objects with details about a recorded
 // transaction.  This is intended to be used for listalltransactions RPC
 // replies.
@@ -2172,22 +2485,24 @@ func (w *Wallet) ListAllTransactions() ([]btcjson.ListTransactionsResult, error)
 		// the number of tx confirmations.
 		syncBlock := w.Manager.SyncedTo()
 
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
-			// Iterate over transactions at this height in reverse order.
-			// This does nothing for unmined transactions, which are
-			// unsorted, but it will process mined transactions in the
-			// reverse order they were marked mined.
-			for i := len(details) - 1; i >= 0; i-- {
-				jsonResults := listTransactions(tx, &details[i], w.Manager,
-					syncBlock.Height, w.chainParams)
-				txList = append(txList, jsonResults...)
+		rangeFn := func(details *wtxmgr.TxDetails,
+			pruned *wtxmgr.PrunedTx) (bool, error) {
+
+			if pruned != nil {
+				txList = append(txList, listPrunedTransaction(
+					pruned, syncBlock.Height,
+				))
+				return false, nil
 			}
+
+			jsonResults := w.listTransactionsWithConflicts(
+				tx, details, syncBlock.Height,
+			)
+			txList = append(txList, jsonResults...)
 			return false, nil
 		}
 
-		// Return newer results first by starting at mempool height and
-		// working down to the genesis block.
-		return w.TxStore.RangeTransactions(txmgrNs, -1, 0, rangeFn)
+		return w.rangeHistory(txmgrNs, rangeFn)
 	})
 	return txList, err
 }
@@ -2213,11 +2528,16 @@ func NewBlockIdentifierFromHash(hash *chainhash.Hash) *BlockIdentifier {
 type GetTransactionsResult struct {
 	MinedTransactions   []Block
 	UnminedTransactions []TransactionSummary
+
+	// NextCursor is set when further transactions may be requested by
+	// passing it as the After cursor of a TransactionPage.
+	NextCursor *TransactionCursor
 }
 
 // GetTransactions returns transaction results between a starting and ending
 // block.  Blocks in the block range may be specified by either a height or a
-// hash.
+// hash.  If accountName is set, only transactions paying to or spending from
+// the account are returned.
 //
 // Because this is a possibly lenghtly operation, a cancel channel is provided
 // to cancel the task.  If this channel unblocks, the results created thus far
@@ -2226,9 +2546,23 @@ type GetTransactionsResult struct {
 // Transaction results are organized by blocks in ascending order and unmined
 // transactions in an unspecified order.  Mined transactions are saved in a
 // Block structure which records properties about the block.
+//
+// GetTransactionsPage may be used to return the results in pages.
 func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier,
 	accountName string, cancel <-chan struct{}) (*GetTransactionsResult, error) {
 
+	page := &TransactionPage{
+		Filter: TransactionFilter{Account: accountName},
+	}
+	return w.GetTransactionsPage(startBlock, endBlock, page, cancel)
+}
+
+// blockIdentifierRange returns the heights of the blocks of a block range, as
+// passed to GetTransactions.  A nil starting block is the genesis block, and a
+// nil ending block includes all blocks and unmined transactions.
+func (w *Wallet) blockIdentifierRange(startBlock, endBlock *BlockIdentifier) (
+	int32, int32, error) {
+
 	var start, end int32 = 0, -1
 
 	w.chainClientLock.Lock()
@@ -2243,7 +2577,7 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier,
 			start = startBlock.height
 		} else {
 			if chainClient == nil {
-				return nil, errors.New("no chain server client")
+				return 0, 0, errors.New("no chain server client")
 			}
 			switch client := chainClient.(type) {
 			case *chain.RPCClient:
@@ -2251,20 +2585,38 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier,
 					startBlock.hash,
 				)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
 				}
 				start = startHeader.Height
 			case *chain.BitcoindClient:
 				var err error
 				start, err = client.GetBlockHeight(startBlock.hash)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
 				}
 			case *chain.NeutrinoClient:
 				var err error
 				start, err = client.GetBlockHeight(startBlock.hash)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
+				}
+			case *chain.ElectrumClient:
+				var err error
+				start, err = client.GetBlockHeight(startBlock.hash)
+				if err != nil {
+					return 0, 0, err
+				}
+			case *chain.EsploraClient:
+				var err error
+				start, err = client.GetBlockHeight(startBlock.hash)
+				if err != nil {
+					return 0, 0, err
+				}
+			case *chain.MultiClient:
+				var err error
+				start, err = client.GetBlockHeight(startBlock.hash)
+				if err != nil {
+					return 0, 0, err
 				}
 			}
 		}
@@ -2274,7 +2626,7 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier,
 			end = endBlock.height
 		} else {
 			if chainClient == nil {
-				return nil, errors.New("no chain server client")
+				return 0, 0, errors.New("no chain server client")
 			}
 			switch client := chainClient.(type) {
 			case *chain.RPCClient:
@@ -2282,64 +2634,44 @@ func (w *Wallet) GetTransactions(startBlock, endBlock *BlockIdentifier,
 					endBlock.hash,
 				)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
 				}
 				end = endHeader.Height
 			case *chain.BitcoindClient:
 				var err error
 				start, err = client.GetBlockHeight(endBlock.hash)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
 				}
 			case *chain.NeutrinoClient:
 				var err error
 				end, err = client.GetBlockHeight(endBlock.hash)
 				if err != nil {
-					return nil, err
+					return 0, 0, err
+				}
+			case *chain.ElectrumClient:
+				var err error
+				end, err = client.GetBlockHeight(endBlock.hash)
+				if err != nil {
+					return 0, 0, err
+				}
+			case *chain.EsploraClient:
+				var err error
+				end, err = client.GetBlockHeight(endBlock.hash)
+				if err != nil {
+					return 0, 0, err
+				}
+			case *chain.MultiClient:
+				var err error
+				end, err = client.GetBlockHeight(endBlock.hash)
+				if err != nil {
+					return 0, 0, err
 				}
 			}
 		}
 	}
 
-	var res GetTransactionsResult
-	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
-		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
-
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
-			// TODO: probably should make RangeTransactions not reuse the
-			// details backing array memory.
-			dets := make([]wtxmgr.TxDetails, len(details))
-			copy(dets, details)
-			details = dets
-
-			txs := make([]TransactionSummary, 0, len(details))
-			for i := range details {
-				txs = append(txs, makeTxSummary(dbtx, w, &details[i]))
-			}
-
-			if details[0].Block.Height != -1 {
-				blockHash := details[0].Block.Hash
-				res.MinedTransactions = append(res.MinedTransactions, Block{
-					Hash:         &blockHash,
-					Height:       details[0].Block.Height,
-					Timestamp:    details[0].Block.Time.Unix(),
-					Transactions: txs,
-				})
-			} else {
-				res.UnminedTransactions = txs
-			}
-
-			select {
-			case <-cancel:
-				return true, nil
-			default:
-				return false, nil
-			}
-		}
-
-		return w.TxStore.RangeTransactions(txmgrNs, start, end, rangeFn)
-	})
-	return &res, err
+	return start, end, nil
 }
 
 // AccountResult is a single account result for the AccountsResult type.
@@ -2559,123 +2891,219 @@ func (w *Wallet) ListUnspent(minconf, maxconf int32,
 
 		syncBlock := w.Manager.SyncedTo()
 
-		filter := accountName != ""
-		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		// Only the outputs matching the criteria are kept while
+		// iterating, as they must be sorted before being returned.
+		var unspent []wtxmgr.Credit
+		matches := make(map[wire.OutPoint]*btcjson.ListUnspentResult)
+		err := w.TxStore.ForEachUnspentOutput(txmgrNs, nil, func(
+			output *wtxmgr.Credit) (bool, error) {
+
+			result, err := w.listUnspentResult(
+				addrmgrNs, output, syncBlock.Height, minconf,
+				maxconf, accountName,
+			)
+			if err != nil || result == nil {
+				return false, err
+			}
+			unspent = append(unspent, *output)
+			matches[output.OutPoint] = result
+			return false, nil
+		})
 		if err != nil {
 			return err
 		}
 		sort.Sort(sort.Reverse(creditSlice(unspent)))
 
-		defaultAccountName := "default"
-
 		results = make([]*btcjson.ListUnspentResult, 0, len(unspent))
 		for i := range unspent {
-			output := unspent[i]
+			results = append(results, matches[unspent[i].OutPoint])
+		}
+		return nil
+	})
+	return results, err
+}
 
-			// Outputs with fewer confirmations than the minimum or more
-			// confs than the maximum are excluded.
-			confs := confirms(output.Height, syncBlock.Height)
-			if confs < minconf || confs > maxconf {
-				continue
-			}
+// ListAddressUnspent returns a slice of objects representing the unspent
+// wallet transactions paying to any of the given addresses, with
+// confirmations between minconf and maxconf.  Outputs are looked up through
+// the transaction store's script index, so the cost does not depend on the
+// size of the wallet's UTXO set.
+func (w *Wallet) ListAddressUnspent(minconf, maxconf int32,
+	addrs []btcutil.Address) ([]*btcjson.ListUnspentResult, error) {
 
-			// Only mature coinbase outputs are included.
-			if output.FromCoinBase {
-				target := int32(w.ChainParams().CoinbaseMaturity)
-				if !confirmed(target, output.Height, syncBlock.Height) {
-					continue
-				}
-			}
+	var results []*btcjson.ListUnspentResult
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
 
-			// Exclude locked outputs from the result set.
-			if w.LockedOutpoint(output.OutPoint) {
+		var unspent []wtxmgr.Credit
+		seen := make(map[string]struct{}, len(addrs))
+		for _, addr := range addrs {
+			pkScript, err := txscript.PayToAddrScript(addr)
+			if err != nil {
+				return err
+			}
+			if _, ok := seen[string(pkScript)]; ok {
 				continue
 			}
+			seen[string(pkScript)] = struct{}{}
 
-			// Lookup the associated account for the output.  Use the
-			// default account name in case there is no associated account
-			// for some reason, although this should never happen.
-			//
-			// This will be unnecessary once transactions and outputs are
-			// grouped under the associated account in the db.
-			outputAcctName := defaultAccountName
-			sc, addrs, _, err := txscript.ExtractPkScriptAddrs(
-				output.PkScript, w.chainParams)
+			credits, err := w.TxStore.UnspentOutputsForScript(
+				txmgrNs, pkScript,
+			)
 			if err != nil {
-				continue
+				return err
 			}
-			if len(addrs) > 0 {
-				smgr, acct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
-				if err == nil {
-					s, err := smgr.AccountName(addrmgrNs, acct)
-					if err == nil {
-						outputAcctName = s
-					}
-				}
+			unspent = append(unspent, credits...)
+		}
+		sort.Sort(sort.Reverse(creditSlice(unspent)))
+
+		var err error
+		results, err = w.listUnspentResults(
+			tx, unspent, minconf, maxconf, "",
+		)
+		return err
+	})
+	return results, err
+}
+
+// listUnspentResults returns the listunspent results for the given unspent
+// outputs, excluding those filtered out by listUnspentResult.
+func (w *Wallet) listUnspentResults(tx walletdb.ReadTx, unspent []wtxmgr.Credit,
+	minconf, maxconf int32, accountName string) ([]*btcjson.ListUnspentResult,
+	error) {
+
+	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+
+	syncBlock := w.Manager.SyncedTo()
+
+	results := make([]*btcjson.ListUnspentResult, 0, len(unspent))
+	for i := range unspent {
+		result, err := w.listUnspentResult(
+			addrmgrNs, &unspent[i], syncBlock.Height, minconf,
+			maxconf, accountName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// listUnspentResult returns the listunspent result for an unspent output, or
+// nil if the output is outside the confirmation range, is an immature coinbase
+// output, is locked, or belongs to an account other than accountName if it is
+// set.
+func (w *Wallet) listUnspentResult(addrmgrNs walletdb.ReadBucket,
+	output *wtxmgr.Credit, syncHeight, minconf, maxconf int32,
+	accountName string) (*btcjson.ListUnspentResult, error) {
+
+	filter := accountName != ""
+
+	defaultAccountName := "default"
+
+	// Outputs with fewer confirmations than the minimum or more
+	// confs than the maximum are excluded.
+	confs := confirms(output.Height, syncHeight)
+	if confs < minconf || confs > maxconf {
+		return nil, nil
+	}
+
+	// Only mature coinbase outputs are included.
+	if output.FromCoinBase {
+		target := int32(w.ChainParams().CoinbaseMaturity)
+		if !confirmed(target, output.Height, syncHeight) {
+			return nil, nil
+		}
+	}
+
+	// Exclude locked outputs from the result set.
+	if w.LockedOutpoint(output.OutPoint) {
+		return nil, nil
+	}
+
+	// Lookup the associated account for the output.  Use the
+	// default account name in case there is no associated account
+	// for some reason, although this should never happen.
+	//
+	// This will be unnecessary once transactions and outputs are
+	// grouped under the associated account in the db.
+	outputAcctName := defaultAccountName
+	sc, addrs, _, err := txscript.ExtractPkScriptAddrs(
+		output.PkScript, w.chainParams)
+	if err != nil {
+		return nil, nil
+	}
+	if len(addrs) > 0 {
+		smgr, acct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+		if err == nil {
+			s, err := smgr.AccountName(addrmgrNs, acct)
+			if err == nil {
+				outputAcctName = s
 			}
+		}
+	}
+
+	if filter && outputAcctName != accountName {
+		return nil, nil
+	}
 
-			if filter && outputAcctName != accountName {
+	// At the moment watch-only addresses are not supported, so all
+	// recorded outputs that are not multisig are "spendable".
+	// Multisig outputs are only "spendable" if all keys are
+	// controlled by this wallet.
+	//
+	// TODO: Each case will need updates when watch-only addrs
+	// is added.  For P2PK, P2PKH, and P2SH, the address must be
+	// looked up and not be watching-only.  For multisig, all
+	// pubkeys must belong to the manager with the associated
+	// private key (currently it only checks whether the pubkey
+	// exists, since the private key is required at the moment).
+	var spendable bool
+scSwitch:
+	switch sc {
+	case txscript.PubKeyHashTy:
+		spendable = true
+	case txscript.PubKeyTy:
+		spendable = true
+	case txscript.WitnessV0ScriptHashTy:
+		spendable = true
+	case txscript.WitnessV0PubKeyHashTy:
+		spendable = true
+	case txscript.MultiSigTy:
+		for _, a := range addrs {
+			_, err := w.Manager.Address(addrmgrNs, a)
+			if err == nil {
 				continue
 			}
-
-			// At the moment watch-only addresses are not supported, so all
-			// recorded outputs that are not multisig are "spendable".
-			// Multisig outputs are only "spendable" if all keys are
-			// controlled by this wallet.
-			//
-			// TODO: Each case will need updates when watch-only addrs
-			// is added.  For P2PK, P2PKH, and P2SH, the address must be
-			// looked up and not be watching-only.  For multisig, all
-			// pubkeys must belong to the manager with the associated
-			// private key (currently it only checks whether the pubkey
-			// exists, since the private key is required at the moment).
-			var spendable bool
-		scSwitch:
-			switch sc {
-			case txscript.PubKeyHashTy:
-				spendable = true
-			case txscript.PubKeyTy:
-				spendable = true
-			case txscript.WitnessV0ScriptHashTy:
-				spendable = true
-			case txscript.WitnessV0PubKeyHashTy:
-				spendable = true
-			case txscript.MultiSigTy:
-				for _, a := range addrs {
-					_, err := w.Manager.Address(addrmgrNs, a)
-					if err == nil {
-						continue
-					}
-					if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
-						break scSwitch
-					}
-					return err
-				}
-				spendable = true
+			if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+				break scSwitch
 			}
+			return nil, err
+		}
+		spendable = true
+	}
 
-			result := &btcjson.ListUnspentResult{
-				TxID:          output.OutPoint.Hash.String(),
-				Vout:          output.OutPoint.Index,
-				Account:       outputAcctName,
-				ScriptPubKey:  hex.EncodeToString(output.PkScript),
-				Amount:        output.Amount.ToBTC(),
-				Confirmations: int64(confs),
-				Spendable:     spendable,
-			}
+	result := &btcjson.ListUnspentResult{
+		TxID:          output.OutPoint.Hash.String(),
+		Vout:          output.OutPoint.Index,
+		Account:       outputAcctName,
+		ScriptPubKey:  hex.EncodeToString(output.PkScript),
+		Amount:        output.Amount.ToBTC(),
+		Confirmations: int64(confs),
+		Spendable:     spendable,
+	}
 
-			// BUG: this should be a JSON array so that all
-			// addresses can be included, or removed (and the
-			// caller extracts addresses from the pkScript).
-			if len(addrs) > 0 {
-				result.Address = addrs[0].EncodeAddress()
-			}
+	// BUG: this should be a JSON array so that all
+	// addresses can be included, or removed (and the
+	// caller extracts addresses from the pkScript).
+	if len(addrs) > 0 {
+		result.Address = addrs[0].EncodeAddress()
+	}
 
-			results = append(results, result)
-		}
-		return nil
-	})
-	return results, err
+	return result, nil
 }
 
 // ListLeasedOutputs returns a list of objects representing the currently locked
@@ -3100,63 +3528,131 @@ func (w *Wallet) TotalReceivedForAccounts(scope waddrmgr.KeyScope,
 	return results, err
 }
 
-// TotalReceivedForAddr iterates through a wallet's transaction history,
-// returning the total amount of bitcoins received for a single wallet
-// address.
+// TotalReceivedForAddr returns the total amount of bitcoins received for a
+// single wallet address, found through the transaction store's script index.
+// Outputs paying to the public key of a pay-to-pubkey-hash wallet address are
+// counted along with those paying to the address.  Bare multisig outputs are
+// not indexed by the scripts of their keys, so they are not counted.
 func (w *Wallet) TotalReceivedForAddr(addr btcutil.Address, minConf int32) (btcutil.Amount, error) {
 	var amount btcutil.Amount
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
 
 		syncBlock := w.Manager.SyncedTo()
 
-		var (
-			addrStr    = addr.EncodeAddress()
-			stopHeight int32
-		)
-
-		if minConf > 0 {
-			stopHeight = syncBlock.Height - minConf + 1
-		} else {
-			stopHeight = -1
+		pkScripts, err := w.receivingScripts(addrmgrNs, addr)
+		if err != nil {
+			return err
 		}
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+
+		// A transaction paying to several of the scripts is only
+		// counted once.
+		seen := make(map[chainhash.Hash]struct{})
+		for _, pkScript := range pkScripts {
+			details, err := w.TxStore.TxDetailsForScript(
+				txmgrNs, pkScript,
+			)
+			if err != nil {
+				return err
+			}
 			for i := range details {
 				detail := &details[i]
-				for _, cred := range detail.Credits {
-					pkScript := detail.MsgTx.TxOut[cred.Index].PkScript
-					_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
-						w.chainParams)
-					// An error creating addresses from the output script only
-					// indicates a non-standard script, so ignore this credit.
-					if err != nil {
-						continue
-					}
-					for _, a := range addrs {
-						if addrStr == a.EncodeAddress() {
-							amount += cred.Amount
-							break
-						}
-					}
+				if _, ok := seen[detail.Hash]; ok {
+					continue
 				}
+				seen[detail.Hash] = struct{}{}
+
+				// Only transactions with the required number
+				// of confirmations are included.  Unmined
+				// transactions are only included when no
+				// confirmations are required.
+				if minConf > 0 && !confirmed(minConf,
+					detail.Block.Height, syncBlock.Height) {
+
+					continue
+				}
+
+				amount += creditsForScripts(detail, pkScripts)
 			}
-			return false, nil
 		}
-		return w.TxStore.RangeTransactions(txmgrNs, 0, stopHeight, rangeFn)
+		return nil
 	})
 	return amount, err
 }
 
+// receivingScripts returns the output scripts that receive payments to an
+// address: the script of the address and, for a pay-to-pubkey-hash address of
+// the wallet, the pay-to-pubkey script of its public key.
+func (w *Wallet) receivingScripts(addrmgrNs walletdb.ReadBucket,
+	addr btcutil.Address) ([][]byte, error) {
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, err
+	}
+	pkScripts := [][]byte{pkScript}
+
+	if _, ok := addr.(*btcutil.AddressPubKeyHash); !ok {
+		return pkScripts, nil
+	}
+	ma, err := w.Manager.Address(addrmgrNs, addr)
+	switch {
+	case waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound):
+		return pkScripts, nil
+	case err != nil:
+		return nil, err
+	}
+	mpka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return pkScripts, nil
+	}
+
+	pubKey := mpka.PubKey().SerializeUncompressed()
+	if ma.Compressed() {
+		pubKey = mpka.PubKey().SerializeCompressed()
+	}
+	pkAddr, err := btcutil.NewAddressPubKey(pubKey, w.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err = txscript.PayToAddrScript(pkAddr)
+	if err != nil {
+		return nil, err
+	}
+	return append(pkScripts, pkScript), nil
+}
+
+// creditsForScripts returns the total amount of the credits of a transaction
+// paying to any of the given output scripts.
+func creditsForScripts(detail *wtxmgr.TxDetails,
+	pkScripts [][]byte) btcutil.Amount {
+
+	var amount btcutil.Amount
+	for _, cred := range detail.Credits {
+		txOut := detail.MsgTx.TxOut[cred.Index]
+		for _, pkScript := range pkScripts {
+			if bytes.Equal(txOut.PkScript, pkScript) {
+				amount += cred.Amount
+				break
+			}
+		}
+	}
+	return amount
+}
+
 // SendOutputs creates and sends payment transactions. Coin selection is
 // performed by the wallet, choosing inputs that belong to the given key scope
 // and account, unless a key scope is not specified. In that case, inputs from
 // accounts matching the account number provided across all key scopes may be
 // selected. This is done to handle the default account case, where a user wants
-// to fund a PSBT with inputs regardless of their type (NP2WKH, P2WKH, etc.). It
-// returns the transaction upon success.
+// to fund a PSBT with inputs regardless of their type (NP2WKH, P2WKH, etc.).
+// Inputs are further restricted by their tags when a tag policy is given, which
+// may also request the change output to be tagged like the inputs. It returns
+// the transaction upon success.
 func (w *Wallet) SendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	account uint32, minconf int32, satPerKb btcutil.Amount,
-	label string) (*wire.MsgTx, error) {
+	tagPolicy *TagPolicy, label string) (*wire.MsgTx, error) {
 
 	// Ensure the outputs to be created adhere to the network's consensus
 	// rules.
@@ -3174,7 +3670,7 @@ func (w *Wallet) SendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 	// continue to re-broadcast the transaction upon restarts until it has
 	// been confirmed.
 	createdTx, err := w.CreateSimpleTx(
-		keyScope, account, outputs, minconf, satPerKb, false,
+		keyScope, account, outputs, minconf, satPerKb, tagPolicy, false,
 	)
 	if err != nil {
 		return nil, err
@@ -3190,6 +3686,18 @@ func (w *Wallet) SendOutputs(outputs []*wire.TxOut, keyScope *waddrmgr.KeyScope,
 		return nil, errors.New("tx hash mismatch")
 	}
 
+	// The transaction was already published, so failing to tag its change
+	// output is not fatal.
+	if tagPolicy != nil && tagPolicy.CarryTagsToChange &&
+		createdTx.ChangeIndex >= 0 {
+
+		err := w.carryTagsToChange(createdTx.Tx, createdTx.ChangeIndex)
+		if err != nil {
+			log.Warnf("Unable to tag change output of transaction "+
+				"%v: %v", txHash, err)
+		}
+	}
+
 	return createdTx.Tx, nil
 }
 
@@ -3215,6 +3723,7 @@ func (w *Wallet) SignTransaction(tx *wire.MsgTx, hashType txscript.SigHashType,
 	p2shRedeemScriptsByAddress map[string][]byte) ([]SignatureError, error) {
 
 	var signErrors []SignatureError
+	signer := w.Signer()
 	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
 		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
 		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
@@ -3296,9 +3805,32 @@ func (w *Wallet) SignTransaction(tx *wire.MsgTx, hashType txscript.SigHashType,
 			if (hashType&txscript.SigHashSingle) !=
 				txscript.SigHashSingle || i < len(tx.TxOut) {
 
-				script, err := txscript.SignTxOutput(w.ChainParams(),
-					tx, i, prevOutScript, hashType, getKey,
-					getScript, txIn.SignatureScript)
+				// Pay to pubkey hash inputs of wallet addresses
+				// are signed by the wallet's signer, which may
+				// not hold the private key in this process.
+				var (
+					script []byte
+					err    error
+				)
+				pubKeyAddr, addrErr := w.outputPubKeyAddr(
+					addrmgrNs, prevOutScript,
+				)
+				if len(additionalKeysByAddress) == 0 &&
+					addrErr == nil &&
+					pubKeyAddr.AddrType() == waddrmgr.PubKeyHash {
+
+					_, script, err = w.signPubKeyInput(
+						addrmgrNs, signer, pubKeyAddr,
+						tx, i, wire.NewTxOut(0, prevOutScript),
+						nil, hashType,
+					)
+				} else {
+					script, err = txscript.SignTxOutput(
+						w.ChainParams(), tx, i,
+						prevOutScript, hashType, getKey,
+						getScript, txIn.SignatureScript,
+					)
+				}
 				// Failure to sign isn't an error, it just means that
 				// the tx isn't complete.
 				if err != nil {
@@ -3774,6 +4306,11 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		return nil, err
 	}
 
+	rebroadcastRand, err := newSeededRand()
+	if err != nil {
+		return nil, err
+	}
+
 	log.Infof("Opened wallet") // TODO: log balance? last sync height?
 
 	w := &Wallet{
@@ -3795,6 +4332,7 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 		lockState:           make(chan bool),
 		changePassphrase:    make(chan changePassphraseRequest),
 		changePassphrases:   make(chan changePassphrasesRequest),
+		rebroadcastRand:     rebroadcastRand,
 		chainParams:         params,
 		quit:                make(chan struct{}),
 	}
@@ -3803,6 +4341,9 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 	w.TxStore.NotifyUnspent = func(hash *chainhash.Hash, index uint32) {
 		w.NtfnServer.notifyUnspentOutput(0, hash, index)
 	}
+	w.TxStore.NotifyConflict = func(c *wtxmgr.ConflictedTx) {
+		w.NtfnServer.notifyConflict(c)
+	}
 
 	return w, nil
 }
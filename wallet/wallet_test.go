@@ -5,6 +5,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/tinhnguyenhn/colxd/btcec"
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/txscript"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/chain"
+	"github.com/tinhnguyenhn/colxwallet/waddrmgr"
 	"github.com/tinhnguyenhn/colxwallet/walletdb"
 	"github.com/tinhnguyenhn/colxwallet/wtxmgr"
 
@@ -203,3 +209,299 @@ func TestLabelTransaction(t *testing.T) {
 		})
 	}
 }
+
+// TestAddressLabels tests labelling of wallet addresses, lookups by label and
+// that address labels are kept when the transaction history is dropped.
+func TestAddressLabels(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr1, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	addr2, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	if err := w.SetAddressLabel(addr1, "customer-1"); err != nil {
+		t.Fatalf("unable to label address: %v", err)
+	}
+	if err := w.SetAddressLabel(addr2, "customer-1"); err != nil {
+		t.Fatalf("unable to label address: %v", err)
+	}
+
+	addrs, err := w.AddressesByLabel("customer-1")
+	if err != nil {
+		t.Fatalf("unable to look up addresses: %v", err)
+	}
+	if len(addrs) != 2 {
+		t.Fatalf("expected 2 addresses, got %d", len(addrs))
+	}
+
+	if err := w.SetAddressLabel(addr2, "customer-2"); err != nil {
+		t.Fatalf("unable to relabel address: %v", err)
+	}
+
+	// The labels must survive dropping the transaction history.
+	if err := DropTransactionHistory(w.db, false); err != nil {
+		t.Fatalf("unable to drop history: %v", err)
+	}
+
+	labels, err := w.AddressLabels()
+	if err != nil {
+		t.Fatalf("unable to fetch labels: %v", err)
+	}
+	if labels[addr1.EncodeAddress()] != "customer-1" ||
+		labels[addr2.EncodeAddress()] != "customer-2" {
+
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+
+	label, err := w.AddressLabel(addr2)
+	if err != nil {
+		t.Fatalf("unable to fetch label: %v", err)
+	}
+	if label != "customer-2" {
+		t.Fatalf("expected label customer-2, got %q", label)
+	}
+}
+
+// TestAddressDetails ensures that the derivation, spendability and label
+// details of wallet addresses are reported correctly.
+func TestAddressDetails(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0084)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	if err := w.SetAddressLabel(addr, "deposit"); err != nil {
+		t.Fatalf("unable to label address: %v", err)
+	}
+
+	details, err := w.AddressDetails(addr)
+	if err != nil {
+		t.Fatalf("unable to fetch address details: %v", err)
+	}
+	if details.KeyScope != waddrmgr.KeyScopeBIP0084 {
+		t.Fatalf("expected key scope %v, got %v",
+			waddrmgr.KeyScopeBIP0084, details.KeyScope)
+	}
+	if details.AccountName != "default" {
+		t.Fatalf("expected default account, got %q", details.AccountName)
+	}
+	if details.WatchOnly || details.Used || details.Address.Internal() {
+		t.Fatalf("unexpected address details: %+v", details)
+	}
+	if details.Label != "deposit" {
+		t.Fatalf("expected label deposit, got %q", details.Label)
+	}
+	pka, ok := details.Address.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		t.Fatalf("expected pubkey address, got %T", details.Address)
+	}
+	_, path, ok := pka.DerivationInfo()
+	if !ok || path.Branch != waddrmgr.ExternalBranch || path.Index != 0 {
+		t.Fatalf("unexpected derivation info: %v %+v", ok, path)
+	}
+
+	// An imported public key cannot be spent from, so it must be reported
+	// as watch-only.
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create key: %v", err)
+	}
+	err = w.ImportPublicKey(privKey.PubKey(), waddrmgr.WitnessPubKey)
+	if err != nil {
+		t.Fatalf("unable to import public key: %v", err)
+	}
+	imported, err := btcutil.NewAddressWitnessPubKeyHash(
+		btcutil.Hash160(privKey.PubKey().SerializeCompressed()),
+		w.ChainParams(),
+	)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	details, err = w.AddressDetails(imported)
+	if err != nil {
+		t.Fatalf("unable to fetch address details: %v", err)
+	}
+	if !details.WatchOnly || !details.Address.Imported() {
+		t.Fatalf("expected imported watch-only address: %+v", details)
+	}
+}
+
+// recoveryChainClient is a mock chain client whose blocks pay to the external
+// addresses of the default BIP0084 account at the given child indexes.
+type recoveryChainClient struct {
+	rescanChainClient
+
+	bestHeight   int32
+	foundIndexes map[int32]uint32
+}
+
+func (c *recoveryChainClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	hash := testBlockStamp(c.bestHeight).Hash
+	return &hash, c.bestHeight, nil
+}
+
+func (c *recoveryChainClient) FilterBlocks(req *chain.FilterBlocksRequest) (
+	*chain.FilterBlocksResponse, error) {
+
+	for i, block := range req.Blocks {
+		index, ok := c.foundIndexes[block.Height]
+		if !ok {
+			continue
+		}
+
+		// The address is only found if it is within the horizon.
+		scopedIndex := waddrmgr.ScopedIndex{
+			Scope: waddrmgr.KeyScopeBIP0084,
+			Index: index,
+		}
+		if _, ok := req.ExternalAddrs[scopedIndex]; !ok {
+			continue
+		}
+
+		return &chain.FilterBlocksResponse{
+			BatchIndex: uint32(i),
+			BlockMeta:  block,
+			FoundExternalAddrs: map[waddrmgr.KeyScope]map[uint32]struct{}{
+				scopedIndex.Scope: {scopedIndex.Index: {}},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// TestRecovery ensures that addresses are recovered from blocks fetched and
+// filtered ahead of time, with each address found in a batch extending the
+// horizon of the following ones.
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	// Each address can only be found once the previous one was, as it is
+	// beyond the horizon of the recovery window of 250 addresses until
+	// then. The addresses are found in different batches.
+	chainClient := &recoveryChainClient{
+		bestHeight: 4*recoveryBatchSize + 500,
+		foundIndexes: map[int32]uint32{
+			10:                        200,
+			recoveryBatchSize + 500:   440,
+			2*recoveryBatchSize + 100: 680,
+			4*recoveryBatchSize + 100: 920,
+		},
+	}
+	w.chainClient = chainClient
+
+	birthdayBlock := testBlockStamp(1)
+	if err := w.recovery(chainClient, &birthdayBlock); err != nil {
+		t.Fatalf("unable to recover wallet: %v", err)
+	}
+
+	props, err := w.AccountProperties(
+		waddrmgr.KeyScopeBIP0084, waddrmgr.DefaultAccountNum,
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch account properties: %v", err)
+	}
+	if props.ExternalKeyCount != 921 {
+		t.Fatalf("expected 921 external addresses, got %d",
+			props.ExternalKeyCount)
+	}
+
+	syncedTo := w.Manager.SyncedTo()
+	if syncedTo.Height != chainClient.bestHeight {
+		t.Fatalf("expected wallet synced to height %d, got %d",
+			chainClient.bestHeight, syncedTo.Height)
+	}
+}
+
+// TestTotalReceivedForAddr ensures that the amount received by a
+// pay-to-pubkey-hash address includes outputs paying to its public key, but
+// not bare multisig outputs, which are not indexed by the scripts of their
+// keys.
+func TestTotalReceivedForAddr(t *testing.T) {
+	t.Parallel()
+
+	w, cleanup := testWallet(t)
+	defer cleanup()
+
+	addr, err := w.NewAddress(0, waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to create address: %v", err)
+	}
+	pubKey, err := w.PubKeyForAddress(addr)
+	if err != nil {
+		t.Fatalf("unable to fetch public key: %v", err)
+	}
+	pkAddr, err := btcutil.NewAddressPubKey(
+		pubKey.SerializeCompressed(), w.chainParams,
+	)
+	if err != nil {
+		t.Fatalf("unable to create public key address: %v", err)
+	}
+
+	p2pkh, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	p2pk, err := txscript.PayToAddrScript(pkAddr)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+	multisig, err := txscript.MultiSigScript(
+		[]*btcutil.AddressPubKey{pkAddr}, 1,
+	)
+	if err != nil {
+		t.Fatalf("unable to create script: %v", err)
+	}
+
+	// Record a mined credit paying to each of the scripts, of 1, 2 and 4
+	// BTC respectively.
+	block := &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Height: 100},
+		Time:  time.Unix(100, 0),
+	}
+	for i, pkScript := range [][]byte{p2pkh, p2pk, multisig} {
+		tx := wire.NewMsgTx(2)
+		tx.AddTxIn(wire.NewTxIn(
+			&wire.OutPoint{Index: uint32(i)}, nil, nil,
+		))
+		tx.AddTxOut(wire.NewTxOut(1e8<<uint(i), pkScript))
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+		if err != nil {
+			t.Fatalf("unable to create tx record: %v", err)
+		}
+
+		err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+			ns := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+			if err := w.TxStore.InsertTx(ns, rec, block); err != nil {
+				return err
+			}
+			return w.TxStore.AddCredit(ns, rec, block, 0, false)
+		})
+		if err != nil {
+			t.Fatalf("unable to record credit: %v", err)
+		}
+	}
+
+	total, err := w.TotalReceivedForAddr(addr, 0)
+	if err != nil {
+		t.Fatalf("unable to fetch total received: %v", err)
+	}
+	if total != 3e8 {
+		t.Fatalf("expected %v received, got %v", btcutil.Amount(3e8),
+			total)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// ConflictedTx describes an unmined transaction that was removed from the
+// store because a conflicting transaction was mined.  This happens when a
+// transaction is replaced (RBF) or double spent, and to every unmined
+// transaction spending the outputs of a removed transaction.
+//
+// The credits and debits are those recorded for the transaction at the time it
+// was removed.  None of the credits are spendable.
+type ConflictedTx struct {
+	TxRecord
+	Credits []CreditRecord
+	Debits  []DebitRecord
+
+	// ConflictingTx is the hash of the mined transaction that double
+	// spent this transaction or the unmined transaction it descended
+	// from.
+	ConflictingTx chainhash.Hash
+
+	// Removed is the time the transaction was removed from the store.
+	Removed time.Time
+}
+
+// ConflictedTx returns the conflict record of the transaction with the given
+// hash, or nil if the transaction was never removed because of a conflict.
+func (s *Store) ConflictedTx(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) (*ConflictedTx, error) {
+
+	return fetchConflictedTx(ns, txHash)
+}
+
+// ConflictsOf returns the conflict records of all transactions that were
+// removed from the store because they conflicted with the transaction with the
+// given hash.  Records are ordered by the hash of the removed transaction.
+func (s *Store) ConflictsOf(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) ([]ConflictedTx, error) {
+
+	hashes := fetchConflictIndexHashes(ns, txHash)
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	conflicts := make([]ConflictedTx, 0, len(hashes))
+	for i := range hashes {
+		c, err := fetchConflictedTx(ns, &hashes[i])
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			str := "missing conflicted transaction for index entry"
+			return nil, storeError(ErrData, str, nil)
+		}
+		conflicts = append(conflicts, *c)
+	}
+	return conflicts, nil
+}
+
+// AbandonedTx describes an unmined transaction that was removed from the store
+// because it, or the unmined transaction it descended from, was abandoned.
+//
+// The credits and debits are those recorded for the transaction at the time it
+// was abandoned.  None of the credits are spendable.
+type AbandonedTx struct {
+	TxRecord
+	Credits []CreditRecord
+	Debits  []DebitRecord
+
+	// Root is the hash of the abandoned transaction this transaction was
+	// removed with.  It is the hash of this transaction unless it was
+	// removed as a descendant.
+	Root chainhash.Hash
+
+	// Abandoned is the time the transaction was abandoned.
+	Abandoned time.Time
+}
+
+// AbandonTx removes the unmined transaction with the given hash, and all
+// unmined transactions spending its outputs, from the store.  Previous outputs
+// spent by the removed transactions become spendable again.  Each removed
+// transaction is kept as an abandonment record.  ErrUnminedTxNotFound is
+// returned if the transaction is not an unmined transaction of the store.
+func (s *Store) AbandonTx(ns walletdb.ReadWriteBucket,
+	txHash *chainhash.Hash) error {
+
+	v := existsRawUnmined(ns, txHash[:])
+	if v == nil {
+		return ErrUnminedTxNotFound
+	}
+	var rec TxRecord
+	if err := readRawTxRecord(txHash, v, &rec); err != nil {
+		return err
+	}
+
+	log.Infof("Abandoning unconfirmed transaction %v", txHash)
+
+	cause := &removalCause{hash: *txHash, abandoned: true}
+	return s.removeConflict(ns, &rec, cause)
+}
+
+// AbandonedTx returns the abandonment record of the transaction with the given
+// hash, or nil if the transaction was never abandoned.
+func (s *Store) AbandonedTx(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) (*AbandonedTx, error) {
+
+	return fetchAbandonedTx(ns, txHash)
+}
+
+// RangeTransactionsWithAbandoned runs the function f like RangeTransactions,
+// except that abandoned transactions are passed along with the unmined
+// transactions, as the details recorded for them when they were abandoned.
+// The unmined and abandoned transactions are ordered by hash.
+func (s *Store) RangeTransactionsWithAbandoned(ns walletdb.ReadBucket,
+	begin, end int32, f func([]TxDetails) (bool, error)) error {
+
+	rangeUnmined := func(ns walletdb.ReadBucket,
+		f func([]TxDetails) (bool, error)) (bool, error) {
+
+		var details []TxDetails
+		_, err := s.rangeUnminedTransactions(ns,
+			func(unmined []TxDetails) (bool, error) {
+				details = append(details, unmined...)
+				return false, nil
+			})
+		if err != nil {
+			return false, err
+		}
+
+		abandoned, err := fetchAbandonedTxs(ns)
+		if err != nil {
+			return false, err
+		}
+		for i := range abandoned {
+			details = append(details, TxDetails{
+				TxRecord: abandoned[i].TxRecord,
+				Block:    BlockMeta{Block: Block{Height: -1}},
+				Credits:  abandoned[i].Credits,
+				Debits:   abandoned[i].Debits,
+			})
+		}
+		if len(details) == 0 {
+			return false, nil
+		}
+
+		sort.Slice(details, func(i, j int) bool {
+			return bytes.Compare(details[i].Hash[:],
+				details[j].Hash[:]) < 0
+		})
+		return f(details)
+	}
+
+	return s.rangeTransactions(ns, begin, end, rangeUnmined, f)
+}
+
+// newConflictedTx creates the record for an unmined transaction that is about
+// to be removed because of the conflicting, or abandoned, transaction.  It
+// must be called before any of the transaction's credits, or those of its
+// unmined parents, are removed.
+func (s *Store) newConflictedTx(ns walletdb.ReadBucket, rec *TxRecord,
+	conflicting *chainhash.Hash) (*ConflictedTx, error) {
+
+	c := &ConflictedTx{
+		TxRecord:      *rec,
+		ConflictingTx: *conflicting,
+		Removed:       s.clock.Now(),
+	}
+
+	for i := range rec.MsgTx.TxOut {
+		k := canonicalOutPoint(&rec.Hash, uint32(i))
+		v := existsRawUnminedCredit(ns, k)
+		if v == nil {
+			continue
+		}
+		amount, change, err := fetchRawUnminedCreditAmountChange(v)
+		if err != nil {
+			return nil, err
+		}
+		c.Credits = append(c.Credits, CreditRecord{
+			Amount: amount,
+			Index:  uint32(i),
+			Change: change,
+		})
+	}
+
+	for i, input := range rec.MsgTx.TxIn {
+		amount, ok, err := fetchSpentCreditAmount(
+			ns, &input.PreviousOutPoint,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		c.Debits = append(c.Debits, DebitRecord{
+			Amount: amount,
+			Index:  uint32(i),
+		})
+	}
+
+	return c, nil
+}
+
+// fetchSpentCreditAmount returns the amount of the wallet credit, mined or
+// unmined, at the previous outpoint of an input.  The boolean is false if the
+// outpoint is not a wallet credit.
+func fetchSpentCreditAmount(ns walletdb.ReadBucket,
+	prevOut *wire.OutPoint) (btcutil.Amount, bool, error) {
+
+	k := canonicalOutPoint(&prevOut.Hash, prevOut.Index)
+	if v := existsRawUnminedCredit(ns, k); v != nil {
+		amount, err := fetchRawUnminedCreditAmount(v)
+		return amount, err == nil, err
+	}
+
+	recKey, _ := latestTxRecord(ns, &prevOut.Hash)
+	if recKey == nil {
+		return 0, false, nil
+	}
+	var block Block
+	if err := readRawTxRecordBlock(recKey, &block); err != nil {
+		return 0, false, err
+	}
+	_, v := existsCredit(ns, &prevOut.Hash, prevOut.Index, &block)
+	if v == nil {
+		return 0, false, nil
+	}
+	amount, err := fetchRawCreditAmount(v)
+	return amount, err == nil, err
+}
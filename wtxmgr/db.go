@@ -70,6 +70,13 @@ var (
 	bucketUnminedCredits = []byte("mc")
 	bucketUnminedInputs  = []byte("mi")
 	bucketLockedOutputs  = []byte("lo")
+	bucketScriptIndex    = []byte("si")
+	bucketConflicts      = []byte("cf")
+	bucketConflictIndex  = []byte("ci")
+	bucketAbandoned      = []byte("ab")
+	bucketRebroadcast    = []byte("rb")
+	bucketPrunedTxs      = []byte("pt")
+	bucketOutputLabels   = []byte("ol")
 )
 
 // Root (namespace) bucket keys
@@ -77,6 +84,7 @@ var (
 	rootCreateDate   = []byte("date")
 	rootVersion      = []byte("vers")
 	rootMinedBalance = []byte("bal")
+	rootPrunedHeight = []byte("prun")
 )
 
 // The root bucket's mined balance k/v pair records the total balance for all
@@ -105,6 +113,34 @@ func putMinedBalance(ns walletdb.ReadWriteBucket, amt btcutil.Amount) error {
 	return nil
 }
 
+// The root bucket's pruned height k/v pair records the height up to which
+// transaction history was pruned.  Blocks at or below it can not be rolled
+// back.  The value is the height serialized as a uint32, and the pair does not
+// exist if history was never pruned.
+func fetchPrunedHeight(ns walletdb.ReadBucket) (int32, error) {
+	v := ns.Get(rootPrunedHeight)
+	if v == nil {
+		return -1, nil
+	}
+	if len(v) != 4 {
+		str := fmt.Sprintf("pruned height: short read (expected 4 "+
+			"bytes, read %v)", len(v))
+		return 0, storeError(ErrData, str, nil)
+	}
+	return int32(byteOrder.Uint32(v)), nil
+}
+
+func putPrunedHeight(ns walletdb.ReadWriteBucket, height int32) error {
+	v := make([]byte, 4)
+	byteOrder.PutUint32(v, uint32(height))
+	err := ns.Put(rootPrunedHeight, v)
+	if err != nil {
+		str := "failed to put pruned height"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
 // Several data structures are given canonical serialization formats as either
 // keys or values.  These common formats allow keys and values to be reused
 // across different buckets.
@@ -171,6 +207,29 @@ func appendRawBlockRecord(v []byte, txHash *chainhash.Hash) ([]byte, error) {
 	return newv, nil
 }
 
+// removeRawBlockRecord returns a new block record value with a transaction
+// hash removed and a decremented number of transactions.
+func removeRawBlockRecord(v []byte, txHash *chainhash.Hash) ([]byte, error) {
+	var block blockRecord
+	err := readRawBlockRecord(keyBlockRecord(0), v, &block)
+	if err != nil {
+		return nil, err
+	}
+
+	newv := make([]byte, 44, len(v))
+	copy(newv, v[:40])
+	n := uint32(0)
+	for i := range block.transactions {
+		if block.transactions[i] == *txHash {
+			continue
+		}
+		newv = append(newv, block.transactions[i][:]...)
+		n++
+	}
+	byteOrder.PutUint32(newv[40:44], n)
+	return newv, nil
+}
+
 func putRawBlockRecord(ns walletdb.ReadWriteBucket, k, v []byte) error {
 	err := ns.NestedReadWriteBucket(bucketBlocks).Put(k, v)
 	if err != nil {
@@ -1405,6 +1464,551 @@ func forEachLockedOutput(ns walletdb.ReadBucket,
 	})
 }
 
+// The script index records the outpoint of every credit, mined or unmined, by
+// the hash of the credit's output script.  This allows the credits and
+// transactions of a single script to be found without scanning every record.
+//
+// Keys are serialized as such:
+//
+//   [0:32]  SHA256 hash of the output script (32 bytes)
+//   [32:68] Canonical outpoint of the credit (36 bytes)
+//
+// Values are empty.
+//
+// As entries are keyed by outpoint rather than by credit record, they remain
+// valid while a transaction moves between the mined and unmined buckets.
+// Entries are only removed when the credit is removed in every form, and
+// readers must skip entries of credits that no longer exist.
+
+func scriptIndexHash(pkScript []byte) []byte {
+	return chainhash.HashB(pkScript)
+}
+
+func keyScriptIndex(pkScript []byte, outPoint *wire.OutPoint) []byte {
+	k := make([]byte, 68)
+	copy(k[:32], scriptIndexHash(pkScript))
+	copy(k[32:68], canonicalOutPoint(&outPoint.Hash, outPoint.Index))
+	return k
+}
+
+func putScriptIndex(ns walletdb.ReadWriteBucket, pkScript []byte,
+	outPoint *wire.OutPoint) error {
+
+	k := keyScriptIndex(pkScript, outPoint)
+	err := ns.NestedReadWriteBucket(bucketScriptIndex).Put(k, nil)
+	if err != nil {
+		str := "failed to put script index entry"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func deleteScriptIndex(ns walletdb.ReadWriteBucket, pkScript []byte,
+	outPoint *wire.OutPoint) error {
+
+	k := keyScriptIndex(pkScript, outPoint)
+	err := ns.NestedReadWriteBucket(bucketScriptIndex).Delete(k)
+	if err != nil {
+		str := "failed to delete script index entry"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// fetchScriptIndexOutPoints returns the outpoints recorded in the script index
+// for an output script, ordered by their canonical serialization.
+func fetchScriptIndexOutPoints(ns walletdb.ReadBucket,
+	pkScript []byte) ([]wire.OutPoint, error) {
+
+	prefix := scriptIndexHash(pkScript)
+
+	var outPoints []wire.OutPoint
+	c := ns.NestedReadBucket(bucketScriptIndex).ReadCursor()
+	for k, _ := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		var op wire.OutPoint
+		if err := readCanonicalOutPoint(k[32:], &op); err != nil {
+			return nil, err
+		}
+		outPoints = append(outPoints, op)
+	}
+	return outPoints, nil
+}
+
+// Conflicted transactions are unmined transactions that were removed from the
+// store because a conflicting transaction was mined.  They are kept, keyed by
+// their transaction hash, so the wallet history retains an audit trail of
+// replaced and double spent transactions.
+//
+// The value is serialized as such:
+//
+//   [0:8]   Removal time (8 bytes)
+//   [8:40]  Hash of the conflicting mined transaction (32 bytes)
+//   [40:44] Number of credits (4 bytes)
+//   [44:N]  Credits, each serialized as such:
+//             [0:4]   Output index (4 bytes)
+//             [4:12]  Amount (8 bytes)
+//             [12]    Flags (1 byte)
+//                       0x02: Change
+//   [N:N+4] Number of debits (4 bytes)
+//   [N+4:M] Debits, each serialized as such:
+//             [0:4]   Input index (4 bytes)
+//             [4:12]  Amount (8 bytes)
+//   [M:]    Transaction record value, as in the tx records bucket
+//
+// The conflict index records which transactions were removed because of a
+// conflicting transaction.  Keys are the hash of the conflicting transaction
+// followed by the hash of the removed transaction.  Values are empty.
+
+func keyConflictIndex(conflicting, removed *chainhash.Hash) []byte {
+	k := make([]byte, 64)
+	copy(k[:32], conflicting[:])
+	copy(k[32:], removed[:])
+	return k
+}
+
+func valueConflictedTx(c *ConflictedTx) ([]byte, error) {
+	recVal, err := valueTxRecord(&c.TxRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 48 + 13*len(c.Credits) + 12*len(c.Debits) + len(recVal)
+	v := make([]byte, 44, size)
+	byteOrder.PutUint64(v, uint64(c.Removed.Unix()))
+	copy(v[8:40], c.ConflictingTx[:])
+	byteOrder.PutUint32(v[40:44], uint32(len(c.Credits)))
+	for _, cred := range c.Credits {
+		var b [13]byte
+		byteOrder.PutUint32(b[:4], cred.Index)
+		byteOrder.PutUint64(b[4:12], uint64(cred.Amount))
+		if cred.Change {
+			b[12] = 1 << 1
+		}
+		v = append(v, b[:]...)
+	}
+	var n [4]byte
+	byteOrder.PutUint32(n[:], uint32(len(c.Debits)))
+	v = append(v, n[:]...)
+	for _, deb := range c.Debits {
+		var b [12]byte
+		byteOrder.PutUint32(b[:4], deb.Index)
+		byteOrder.PutUint64(b[4:12], uint64(deb.Amount))
+		v = append(v, b[:]...)
+	}
+	return append(v, recVal...), nil
+}
+
+func readRawConflictedTx(txHash *chainhash.Hash, v []byte, c *ConflictedTx) error {
+	short := func() error {
+		str := fmt.Sprintf("%s: short read for %v", bucketConflicts,
+			txHash)
+		return storeError(ErrData, str, nil)
+	}
+
+	if len(v) < 44 {
+		return short()
+	}
+	c.Removed = time.Unix(int64(byteOrder.Uint64(v)), 0)
+	copy(c.ConflictingTx[:], v[8:40])
+	numCredits := int(byteOrder.Uint32(v[40:44]))
+	v = v[44:]
+	if len(v) < 13*numCredits+4 {
+		return short()
+	}
+	c.Credits = make([]CreditRecord, numCredits)
+	for i := range c.Credits {
+		c.Credits[i] = CreditRecord{
+			Index:  byteOrder.Uint32(v[:4]),
+			Amount: btcutil.Amount(byteOrder.Uint64(v[4:12])),
+			Change: v[12]&(1<<1) != 0,
+		}
+		v = v[13:]
+	}
+	numDebits := int(byteOrder.Uint32(v[:4]))
+	v = v[4:]
+	if len(v) < 12*numDebits {
+		return short()
+	}
+	c.Debits = make([]DebitRecord, numDebits)
+	for i := range c.Debits {
+		c.Debits[i] = DebitRecord{
+			Index:  byteOrder.Uint32(v[:4]),
+			Amount: btcutil.Amount(byteOrder.Uint64(v[4:12])),
+		}
+		v = v[12:]
+	}
+	return readRawTxRecord(txHash, v, &c.TxRecord)
+}
+
+func putConflictedTx(ns walletdb.ReadWriteBucket, c *ConflictedTx) error {
+	v, err := valueConflictedTx(c)
+	if err != nil {
+		return err
+	}
+	err = ns.NestedReadWriteBucket(bucketConflicts).Put(c.Hash[:], v)
+	if err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketConflicts,
+			c.Hash)
+		return storeError(ErrDatabase, str, err)
+	}
+	k := keyConflictIndex(&c.ConflictingTx, &c.Hash)
+	err = ns.NestedReadWriteBucket(bucketConflictIndex).Put(k, nil)
+	if err != nil {
+		str := "failed to put conflict index entry"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func existsRawConflictedTx(ns walletdb.ReadBucket, txHash *chainhash.Hash) []byte {
+	return ns.NestedReadBucket(bucketConflicts).Get(txHash[:])
+}
+
+func fetchConflictedTx(ns walletdb.ReadBucket, txHash *chainhash.Hash) (*ConflictedTx, error) {
+	v := existsRawConflictedTx(ns, txHash)
+	if v == nil {
+		return nil, nil
+	}
+	c := new(ConflictedTx)
+	if err := readRawConflictedTx(txHash, v, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func deleteConflictedTx(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash) error {
+	v := existsRawConflictedTx(ns, txHash)
+	if v == nil {
+		return nil
+	}
+	if len(v) < 40 {
+		str := fmt.Sprintf("%s: short read for %v", bucketConflicts,
+			txHash)
+		return storeError(ErrData, str, nil)
+	}
+	var conflicting chainhash.Hash
+	copy(conflicting[:], v[8:40])
+
+	k := keyConflictIndex(&conflicting, txHash)
+	err := ns.NestedReadWriteBucket(bucketConflictIndex).Delete(k)
+	if err != nil {
+		str := "failed to delete conflict index entry"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.NestedReadWriteBucket(bucketConflicts).Delete(txHash[:])
+	if err != nil {
+		str := "failed to delete conflicted transaction"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// fetchConflictIndexHashes returns the hashes of the transactions removed
+// because they conflicted with the passed transaction.
+func fetchConflictIndexHashes(ns walletdb.ReadBucket,
+	conflicting *chainhash.Hash) []chainhash.Hash {
+
+	prefix := conflicting[:]
+
+	var hashes []chainhash.Hash
+	c := ns.NestedReadBucket(bucketConflictIndex).ReadCursor()
+	for k, _ := c.Seek(prefix); bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		var hash chainhash.Hash
+		copy(hash[:], k[32:])
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// Abandoned transactions are unmined transactions removed from the store at
+// the request of the user, along with their unmined descendants.  They are
+// keyed by their transaction hash and use the serialization of conflicted
+// transactions, with the hash of the abandoned transaction in place of the
+// conflicting transaction.
+
+func putAbandonedTx(ns walletdb.ReadWriteBucket, c *ConflictedTx) error {
+	v, err := valueConflictedTx(c)
+	if err != nil {
+		return err
+	}
+	err = ns.NestedReadWriteBucket(bucketAbandoned).Put(c.Hash[:], v)
+	if err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketAbandoned,
+			c.Hash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchAbandonedTx(ns walletdb.ReadBucket, txHash *chainhash.Hash) (*AbandonedTx, error) {
+	v := ns.NestedReadBucket(bucketAbandoned).Get(txHash[:])
+	if v == nil {
+		return nil, nil
+	}
+	var c ConflictedTx
+	if err := readRawConflictedTx(txHash, v, &c); err != nil {
+		return nil, err
+	}
+	return &AbandonedTx{
+		TxRecord:  c.TxRecord,
+		Credits:   c.Credits,
+		Debits:    c.Debits,
+		Root:      c.ConflictingTx,
+		Abandoned: c.Removed,
+	}, nil
+}
+
+// fetchAbandonedTxs returns every abandoned transaction, ordered by hash.
+func fetchAbandonedTxs(ns walletdb.ReadBucket) ([]AbandonedTx, error) {
+	var abandoned []AbandonedTx
+	b := ns.NestedReadBucket(bucketAbandoned)
+	err := b.ForEach(func(k, _ []byte) error {
+		if len(k) != chainhash.HashSize {
+			str := fmt.Sprintf("%s: short key (expected %d "+
+				"bytes, read %d)", bucketAbandoned,
+				chainhash.HashSize, len(k))
+			return storeError(ErrData, str, nil)
+		}
+		var txHash chainhash.Hash
+		copy(txHash[:], k)
+		a, err := fetchAbandonedTx(ns, &txHash)
+		if err != nil {
+			return err
+		}
+		abandoned = append(abandoned, *a)
+		return nil
+	})
+	return abandoned, err
+}
+
+func deleteAbandonedTx(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash) error {
+	err := ns.NestedReadWriteBucket(bucketAbandoned).Delete(txHash[:])
+	if err != nil {
+		str := "failed to delete abandoned transaction"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// The rebroadcast state of an unmined transaction records how often the wallet
+// has rebroadcast it.  States are keyed by the transaction hash and serialized
+// as such:
+//
+//   [0:4]   Number of rebroadcast attempts (4 bytes)
+//   [4:12]  Time of the last attempt, Unix seconds (8 bytes)
+//   [12:20] Time the next attempt is due, Unix seconds (8 bytes)
+//   [20]    Flags (1 byte)
+//             0x01: Dropped from the mempool of the backend
+//
+// The state of a transaction is removed along with its unmined record.
+
+const rebroadcastStateSize = 21
+
+func valueRebroadcastState(state *RebroadcastState) []byte {
+	v := make([]byte, rebroadcastStateSize)
+	byteOrder.PutUint32(v, state.Attempts)
+	byteOrder.PutUint64(v[4:12], uint64(state.LastAttempt.Unix()))
+	byteOrder.PutUint64(v[12:20], uint64(state.NextAttempt.Unix()))
+	if state.Dropped {
+		v[20] |= 1 << 0
+	}
+	return v
+}
+
+func putRebroadcastState(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	state *RebroadcastState) error {
+
+	v := valueRebroadcastState(state)
+	err := ns.NestedReadWriteBucket(bucketRebroadcast).Put(txHash[:], v)
+	if err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketRebroadcast,
+			txHash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchRebroadcastState(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) (*RebroadcastState, error) {
+
+	v := ns.NestedReadBucket(bucketRebroadcast).Get(txHash[:])
+	if v == nil {
+		return nil, nil
+	}
+	if len(v) < rebroadcastStateSize {
+		str := fmt.Sprintf("%s: short read (expected %d bytes, read %d)",
+			bucketRebroadcast, rebroadcastStateSize, len(v))
+		return nil, storeError(ErrData, str, nil)
+	}
+	return &RebroadcastState{
+		Attempts:    byteOrder.Uint32(v),
+		LastAttempt: time.Unix(int64(byteOrder.Uint64(v[4:12])), 0),
+		NextAttempt: time.Unix(int64(byteOrder.Uint64(v[12:20])), 0),
+		Dropped:     v[20]&(1<<0) != 0,
+	}, nil
+}
+
+func deleteRebroadcastState(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash) error {
+	err := ns.NestedReadWriteBucket(bucketRebroadcast).Delete(txHash[:])
+	if err != nil {
+		str := "failed to delete rebroadcast state"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// Pruned transactions are mined transactions whose records were removed from
+// the store by PruneHistory.  A summary of each is kept, keyed by the height of
+// its block followed by its hash so that summaries iterate in block order:
+//
+//   [0:4]   Block height (4 bytes)
+//   [4:36]  Transaction hash (32 bytes)
+//
+// The value is serialized as such:
+//
+//   [0:32]  Block hash (32 bytes)
+//   [32:40] Block time, Unix seconds (8 bytes)
+//   [40:48] Received time, Unix seconds (8 bytes)
+//   [48:56] Net amount, credits less debits (8 bytes, signed)
+
+const prunedTxSize = 56
+
+func keyPrunedTx(txHash *chainhash.Hash, height int32) []byte {
+	k := make([]byte, 36)
+	byteOrder.PutUint32(k, uint32(height))
+	copy(k[4:36], txHash[:])
+	return k
+}
+
+func valuePrunedTx(p *PrunedTx) []byte {
+	v := make([]byte, prunedTxSize)
+	copy(v, p.Block.Hash[:])
+	byteOrder.PutUint64(v[32:40], uint64(p.Block.Time.Unix()))
+	byteOrder.PutUint64(v[40:48], uint64(p.Received.Unix()))
+	byteOrder.PutUint64(v[48:56], uint64(p.NetAmount))
+	return v
+}
+
+func putPrunedTx(ns walletdb.ReadWriteBucket, p *PrunedTx) error {
+	k := keyPrunedTx(&p.Hash, p.Block.Height)
+	err := ns.NestedReadWriteBucket(bucketPrunedTxs).Put(k, valuePrunedTx(p))
+	if err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketPrunedTxs,
+			p.Hash)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func existsRawPrunedTx(ns walletdb.ReadBucket, k []byte) []byte {
+	return ns.NestedReadBucket(bucketPrunedTxs).Get(k)
+}
+
+func readRawPrunedTx(k, v []byte, p *PrunedTx) error {
+	if len(k) < 36 {
+		str := fmt.Sprintf("%s: short key (expected %d bytes, read %d)",
+			bucketPrunedTxs, 36, len(k))
+		return storeError(ErrData, str, nil)
+	}
+	if len(v) < prunedTxSize {
+		str := fmt.Sprintf("%s: short read (expected %d bytes, read %d)",
+			bucketPrunedTxs, prunedTxSize, len(v))
+		return storeError(ErrData, str, nil)
+	}
+	p.Block.Height = int32(byteOrder.Uint32(k))
+	copy(p.Hash[:], k[4:36])
+	copy(p.Block.Hash[:], v)
+	p.Block.Time = time.Unix(int64(byteOrder.Uint64(v[32:40])), 0)
+	p.Received = time.Unix(int64(byteOrder.Uint64(v[40:48])), 0)
+	p.NetAmount = btcutil.Amount(int64(byteOrder.Uint64(v[48:56])))
+	return nil
+}
+
+// The output labels bucket records the label and tags of an output, keyed by
+// its canonical outpoint.  The value is serialized as such:
+//
+//   [0:2]      Label length (2 bytes)
+//   [2:2+n]    Label
+//   [2+n]      Number of tags (1 byte)
+//
+// followed by each tag, serialized as its length (1 byte) and the tag itself.
+
+func valueOutputLabel(l *OutputLabel) []byte {
+	size := 3 + len(l.Label)
+	for _, tag := range l.Tags {
+		size += 1 + len(tag)
+	}
+	v := make([]byte, size)
+	byteOrder.PutUint16(v, uint16(len(l.Label)))
+	off := 2 + copy(v[2:], l.Label)
+	v[off] = byte(len(l.Tags))
+	off++
+	for _, tag := range l.Tags {
+		v[off] = byte(len(tag))
+		off += 1 + copy(v[off+1:], tag)
+	}
+	return v
+}
+
+func putOutputLabel(ns walletdb.ReadWriteBucket, op *wire.OutPoint,
+	l *OutputLabel) error {
+
+	k := canonicalOutPoint(&op.Hash, op.Index)
+	err := ns.NestedReadWriteBucket(bucketOutputLabels).Put(
+		k, valueOutputLabel(l),
+	)
+	if err != nil {
+		str := fmt.Sprintf("%s: put failed for %v", bucketOutputLabels,
+			op)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func existsRawOutputLabel(ns walletdb.ReadBucket, k []byte) []byte {
+	return ns.NestedReadBucket(bucketOutputLabels).Get(k)
+}
+
+func readRawOutputLabel(v []byte, l *OutputLabel) error {
+	shortRead := func() error {
+		str := fmt.Sprintf("%s: short read (read %d bytes)",
+			bucketOutputLabels, len(v))
+		return storeError(ErrData, str, nil)
+	}
+	if len(v) < 3 {
+		return shortRead()
+	}
+	labelLen := int(byteOrder.Uint16(v))
+	if len(v) < 3+labelLen {
+		return shortRead()
+	}
+	l.Label = string(v[2 : 2+labelLen])
+	off := 2 + labelLen
+	numTags := int(v[off])
+	off++
+	l.Tags = make([]string, 0, numTags)
+	for i := 0; i < numTags; i++ {
+		if len(v) < off+1 || len(v) < off+1+int(v[off]) {
+			return shortRead()
+		}
+		tagLen := int(v[off])
+		l.Tags = append(l.Tags, string(v[off+1:off+1+tagLen]))
+		off += 1 + tagLen
+	}
+	return nil
+}
+
+func deleteOutputLabel(ns walletdb.ReadWriteBucket, op *wire.OutPoint) error {
+	k := canonicalOutPoint(&op.Hash, op.Index)
+	err := ns.NestedReadWriteBucket(bucketOutputLabels).Delete(k)
+	if err != nil {
+		str := fmt.Sprintf("%s: delete failed for %v",
+			bucketOutputLabels, op)
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
 // openStore opens an existing transaction store from the passed namespace.
 func openStore(ns walletdb.ReadBucket) error {
 	version, err := fetchVersion(ns)
@@ -1504,6 +2108,34 @@ func createBuckets(ns walletdb.ReadWriteBucket) error {
 		str := "failed to create locked outputs bucket"
 		return storeError(ErrDatabase, str, err)
 	}
+	if _, err := ns.CreateBucket(bucketScriptIndex); err != nil {
+		str := "failed to create script index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketConflicts); err != nil {
+		str := "failed to create conflicts bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketConflictIndex); err != nil {
+		str := "failed to create conflict index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketAbandoned); err != nil {
+		str := "failed to create abandoned bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketRebroadcast); err != nil {
+		str := "failed to create rebroadcast bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketPrunedTxs); err != nil {
+		str := "failed to create pruned transactions bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if _, err := ns.CreateBucket(bucketOutputLabels); err != nil {
+		str := "failed to create output labels bucket"
+		return storeError(ErrDatabase, str, err)
+	}
 
 	return nil
 }
@@ -1548,6 +2180,41 @@ func deleteBuckets(ns walletdb.ReadWriteBucket) error {
 		str := "failed to delete locked outputs bucket"
 		return storeError(ErrDatabase, str, err)
 	}
+	err = ns.DeleteNestedBucket(bucketScriptIndex)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete script index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketConflicts)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete conflicts bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketConflictIndex)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete conflict index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketAbandoned)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete abandoned bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketRebroadcast)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete rebroadcast bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketPrunedTxs)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete pruned transactions bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	err = ns.DeleteNestedBucket(bucketOutputLabels)
+	if err != nil && err != walletdb.ErrBucketNotFound {
+		str := "failed to delete output labels bucket"
+		return storeError(ErrDatabase, str, err)
+	}
 
 	return nil
 }
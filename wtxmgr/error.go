@@ -49,6 +49,12 @@ const (
 	// but the database version is newer than latest version known to this
 	// software.  This likely indicates an outdated binary.
 	ErrUnknownVersion
+
+	// ErrPruned describes an error where an operation requires transaction
+	// history that was pruned from the store, such as rolling back blocks
+	// at or below the pruned height.  Recovering from an ErrPruned
+	// requires dropping and rescanning all transaction history.
+	ErrPruned
 )
 
 var errStrs = [...]string{
@@ -59,6 +65,7 @@ var errStrs = [...]string{
 	ErrNoExists:       "ErrNoExists",
 	ErrNeedsUpgrade:   "ErrNeedsUpgrade",
 	ErrUnknownVersion: "ErrUnknownVersion",
+	ErrPruned:         "ErrPruned",
 }
 
 // String returns the ErrorCode as a human-readable name.
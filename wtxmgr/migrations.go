@@ -1,6 +1,7 @@
 package wtxmgr
 
 import (
+	"github.com/tinhnguyenhn/colxd/wire"
 	"github.com/tinhnguyenhn/colxwallet/walletdb"
 	"github.com/tinhnguyenhn/colxwallet/walletdb/migration"
 )
@@ -18,6 +19,30 @@ var versions = []migration.Version{
 		Number:    2,
 		Migration: dropTransactionHistory,
 	},
+	{
+		Number:    3,
+		Migration: addScriptIndex,
+	},
+	{
+		Number:    4,
+		Migration: addConflictBuckets,
+	},
+	{
+		Number:    5,
+		Migration: addAbandonedBucket,
+	},
+	{
+		Number:    6,
+		Migration: addRebroadcastBucket,
+	},
+	{
+		Number:    7,
+		Migration: addPrunedTxsBucket,
+	},
+	{
+		Number:    8,
+		Migration: addOutputLabelsBucket,
+	},
 }
 
 // getLatestVersion returns the version number of the latest database version.
@@ -108,3 +133,143 @@ func dropTransactionHistory(ns walletdb.ReadWriteBucket) error {
 	// Finally, we'll insert a 0 value for our mined balance.
 	return putMinedBalance(ns, 0)
 }
+
+// addScriptIndex is a migration that creates the script index and records the
+// credits already in the store within it.
+func addScriptIndex(ns walletdb.ReadWriteBucket) error {
+	log.Info("Indexing wallet credits by output script")
+
+	_, err := ns.CreateBucketIfNotExists(bucketScriptIndex)
+	if err != nil {
+		str := "failed to create script index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+
+	// Mined credits are keyed by their transaction record key followed by
+	// the output index, so the output script is read from the record.
+	type indexEntry struct {
+		pkScript []byte
+		outPoint wire.OutPoint
+	}
+	var entries []indexEntry
+	err = ns.NestedReadBucket(bucketCredits).ForEach(func(k, v []byte) error {
+		if len(k) < 72 {
+			str := "short credit key"
+			return storeError(ErrData, str, nil)
+		}
+		recKey := extractRawCreditTxRecordKey(k)
+		index := extractRawCreditIndex(k)
+		recVal := existsRawTxRecord(ns, recKey)
+		pkScript, err := fetchRawTxRecordPkScript(recKey, recVal, index)
+		if err != nil {
+			return err
+		}
+
+		var op wire.OutPoint
+		copy(op.Hash[:], k[:32])
+		op.Index = index
+		entries = append(entries, indexEntry{pkScript, op})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Unmined credits are keyed by outpoint.
+	err = ns.NestedReadBucket(bucketUnminedCredits).ForEach(func(k, v []byte) error {
+		var op wire.OutPoint
+		if err := readCanonicalOutPoint(k, &op); err != nil {
+			return err
+		}
+
+		var rec TxRecord
+		recVal := existsRawUnmined(ns, op.Hash[:])
+		if err := readRawTxRecord(&op.Hash, recVal, &rec); err != nil {
+			return err
+		}
+		if int(op.Index) >= len(rec.MsgTx.TxOut) {
+			str := "missing transaction output for credit index"
+			return storeError(ErrData, str, nil)
+		}
+		pkScript := rec.MsgTx.TxOut[op.Index].PkScript
+		entries = append(entries, indexEntry{pkScript, op})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The entries are written after iterating, as buckets may not be
+	// modified during a ForEach.
+	for i := range entries {
+		err := putScriptIndex(ns, entries[i].pkScript, &entries[i].outPoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Indexed %d credits by output script", len(entries))
+
+	return nil
+}
+
+// addConflictBuckets is a migration that creates the buckets used to keep
+// unmined transactions removed because of a conflicting transaction.  Stores
+// never recorded conflicts before, so the buckets start out empty.
+func addConflictBuckets(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketConflicts)
+	if err != nil {
+		str := "failed to create conflicts bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	_, err = ns.CreateBucketIfNotExists(bucketConflictIndex)
+	if err != nil {
+		str := "failed to create conflict index bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// addAbandonedBucket is a migration that creates the bucket used to keep
+// abandoned transactions.
+func addAbandonedBucket(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketAbandoned)
+	if err != nil {
+		str := "failed to create abandoned bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// addRebroadcastBucket is a migration that creates the bucket used to track the
+// rebroadcast attempts of unmined transactions.
+func addRebroadcastBucket(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketRebroadcast)
+	if err != nil {
+		str := "failed to create rebroadcast bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// addPrunedTxsBucket is a migration that creates the bucket used to keep the
+// summaries of transactions pruned from the store.
+func addPrunedTxsBucket(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketPrunedTxs)
+	if err != nil {
+		str := "failed to create pruned transactions bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// addOutputLabelsBucket is a migration that creates the bucket used to store the
+// labels and tags of outputs.
+func addOutputLabelsBucket(ns walletdb.ReadWriteBucket) error {
+	_, err := ns.CreateBucketIfNotExists(bucketOutputLabels)
+	if err != nil {
+		str := "failed to create output labels bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
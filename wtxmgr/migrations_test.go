@@ -185,3 +185,190 @@ func TestMigrationDropTransactionHistory(t *testing.T) {
 		false,
 	)
 }
+
+// TestMigrationAddScriptIndex ensures that the script index is populated with
+// the credits that existed in the store before the migration.
+func TestMigrationAddScriptIndex(t *testing.T) {
+	t.Parallel()
+
+	pkScript := []byte{0x51}
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		// We'll add a confirmed credit and an unconfirmed credit that
+		// spends it, both paying to the same script.
+		b := &BlockMeta{Block: Block{Height: 100}}
+		confirmedSpend := newCoinBase(1e8)
+		confirmedSpend.TxOut[0].PkScript = pkScript
+		confirmedSpendRec, err := NewTxRecordFromMsgTx(
+			confirmedSpend, timeNow(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := s.InsertTx(ns, confirmedSpendRec, b); err != nil {
+			return err
+		}
+		err = s.AddCredit(ns, confirmedSpendRec, b, 0, false)
+		if err != nil {
+			return err
+		}
+
+		unconfirmedSpend := spendOutput(&confirmedSpendRec.Hash, 0, 5e7)
+		unconfirmedSpend.TxOut[0].PkScript = pkScript
+		unconfirmedSpendRec, err := NewTxRecordFromMsgTx(
+			unconfirmedSpend, timeNow(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := s.InsertTx(ns, unconfirmedSpendRec, nil); err != nil {
+			return err
+		}
+		err = s.AddCredit(ns, unconfirmedSpendRec, nil, 0, true)
+		if err != nil {
+			return err
+		}
+
+		// To simulate a store created before the index existed, we'll
+		// remove the index bucket entirely.
+		return ns.DeleteNestedBucket(bucketScriptIndex)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		// Both credits should now be found within the index.
+		ops, err := fetchScriptIndexOutPoints(ns, pkScript)
+		if err != nil {
+			return err
+		}
+		if len(ops) != 2 {
+			return fmt.Errorf("expected 2 indexed outputs, found %d",
+				len(ops))
+		}
+
+		details, err := s.TxDetailsForScript(ns, pkScript)
+		if err != nil {
+			return err
+		}
+		if len(details) != 2 {
+			return fmt.Errorf("expected 2 transactions for script, "+
+				"found %d", len(details))
+		}
+
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addScriptIndex, false,
+	)
+}
+
+// TestMigrationAddConflictBuckets ensures that the conflict buckets are created
+// for stores that predate them.
+func TestMigrationAddConflictBuckets(t *testing.T) {
+	t.Parallel()
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if err := ns.DeleteNestedBucket(bucketConflicts); err != nil {
+			return err
+		}
+		return ns.DeleteNestedBucket(bucketConflictIndex)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if ns.NestedReadBucket(bucketConflicts) == nil {
+			return errors.New("missing conflicts bucket")
+		}
+		if ns.NestedReadBucket(bucketConflictIndex) == nil {
+			return errors.New("missing conflict index bucket")
+		}
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addConflictBuckets, false,
+	)
+}
+
+// TestMigrationAddAbandonedBucket ensures that the abandoned bucket is created
+// for stores that predate it.
+func TestMigrationAddAbandonedBucket(t *testing.T) {
+	t.Parallel()
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		return ns.DeleteNestedBucket(bucketAbandoned)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if ns.NestedReadBucket(bucketAbandoned) == nil {
+			return errors.New("missing abandoned bucket")
+		}
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addAbandonedBucket, false,
+	)
+}
+
+// TestMigrationAddRebroadcastBucket ensures that the rebroadcast bucket is
+// created for stores that predate it.
+func TestMigrationAddRebroadcastBucket(t *testing.T) {
+	t.Parallel()
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		return ns.DeleteNestedBucket(bucketRebroadcast)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if ns.NestedReadBucket(bucketRebroadcast) == nil {
+			return errors.New("missing rebroadcast bucket")
+		}
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addRebroadcastBucket, false,
+	)
+}
+
+// TestMigrationAddPrunedTxsBucket ensures that the pruned transactions bucket
+// is created for stores that predate it.
+func TestMigrationAddPrunedTxsBucket(t *testing.T) {
+	t.Parallel()
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		return ns.DeleteNestedBucket(bucketPrunedTxs)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if ns.NestedReadBucket(bucketPrunedTxs) == nil {
+			return errors.New("missing pruned transactions bucket")
+		}
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addPrunedTxsBucket, false,
+	)
+}
+
+// TestMigrationAddOutputLabelsBucket ensures that the output labels bucket is
+// created for stores that predate it.
+func TestMigrationAddOutputLabelsBucket(t *testing.T) {
+	t.Parallel()
+
+	beforeMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		return ns.DeleteNestedBucket(bucketOutputLabels)
+	}
+
+	afterMigration := func(ns walletdb.ReadWriteBucket, s *Store) error {
+		if ns.NestedReadBucket(bucketOutputLabels) == nil {
+			return errors.New("missing output labels bucket")
+		}
+		return nil
+	}
+
+	applyMigration(
+		t, beforeMigration, afterMigration, addOutputLabelsBucket, false,
+	)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+const (
+	// OutputTagLimit is the length limit we impose on output tags.
+	OutputTagLimit = 64
+
+	// MaxOutputTags is the maximum number of tags an output may have.
+	MaxOutputTags = 32
+)
+
+var (
+	// ErrEmptyTag is returned when an attempt to tag an output with an
+	// empty tag is made.
+	ErrEmptyTag = errors.New("empty output tag not allowed")
+
+	// ErrTagTooLong is returned when an attempt to tag an output with a
+	// tag that exceeds OutputTagLimit is made.
+	ErrTagTooLong = errors.New("output tag exceeds limit")
+
+	// ErrTooManyTags is returned when an attempt to tag an output with more
+	// than MaxOutputTags tags is made.
+	ErrTooManyTags = errors.New("too many output tags")
+)
+
+// OutputLabel is the label and tags of an output, used to describe it and to
+// control whether it may be spent by coin selection.
+type OutputLabel struct {
+	Label string
+
+	// Tags is the sorted set of tags of the output.
+	Tags []string
+}
+
+// HasTag returns whether the output is tagged with the given tag.
+func (l *OutputLabel) HasTag(tag string) bool {
+	i := sort.SearchStrings(l.Tags, tag)
+	return i < len(l.Tags) && l.Tags[i] == tag
+}
+
+// PutOutputLabel replaces the label and tags of an unspent output known to the
+// store.  Tags are deduplicated and sorted.  The label and tags of the output
+// are removed if the label is empty and no tags are given, which is also allowed
+// for outputs that were spent since.
+//
+// If the output is not known, ErrUnknownOutput is returned.
+func (s *Store) PutOutputLabel(ns walletdb.ReadWriteBucket, op wire.OutPoint,
+	label string, tags []string) error {
+
+	if label == "" && len(tags) == 0 {
+		return deleteOutputLabel(ns, &op)
+	}
+
+	if len(label) > TxLabelLimit {
+		return ErrLabelTooLong
+	}
+	sorted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		switch {
+		case tag == "":
+			return ErrEmptyTag
+		case len(tag) > OutputTagLimit:
+			return ErrTagTooLong
+		}
+		sorted = append(sorted, tag)
+	}
+	sort.Strings(sorted)
+	unique := sorted[:0]
+	for i, tag := range sorted {
+		if i == 0 || tag != sorted[i-1] {
+			unique = append(unique, tag)
+		}
+	}
+	if len(unique) > MaxOutputTags {
+		return ErrTooManyTags
+	}
+
+	if !isKnownOutput(ns, op) {
+		return ErrUnknownOutput
+	}
+
+	return putOutputLabel(ns, &op, &OutputLabel{Label: label, Tags: unique})
+}
+
+// FetchOutputLabel returns the label and tags of an output, or nil if the
+// output has neither.
+func (s *Store) FetchOutputLabel(ns walletdb.ReadBucket,
+	op wire.OutPoint) (*OutputLabel, error) {
+
+	v := existsRawOutputLabel(ns, canonicalOutPoint(&op.Hash, op.Index))
+	if v == nil {
+		return nil, nil
+	}
+	var l OutputLabel
+	if err := readRawOutputLabel(v, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// ForEachOutputLabel calls f with the label and tags of every labeled or tagged
+// output.  Iteration stops early if f returns an error, which is then returned
+// to the caller.
+func (s *Store) ForEachOutputLabel(ns walletdb.ReadBucket,
+	f func(op wire.OutPoint, l *OutputLabel) error) error {
+
+	return ns.NestedReadBucket(bucketOutputLabels).ForEach(func(k,
+		v []byte) error {
+
+		var op wire.OutPoint
+		if err := readCanonicalOutPoint(k, &op); err != nil {
+			return err
+		}
+		var l OutputLabel
+		if err := readRawOutputLabel(v, &l); err != nil {
+			return err
+		}
+		return f(op, &l)
+	})
+}
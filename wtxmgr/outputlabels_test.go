@@ -0,0 +1,136 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// TestOutputLabels ensures that the labels and tags of outputs are validated,
+// stored and removed as expected.
+func TestOutputLabels(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	cb := newCoinBase(1e8, 2e8)
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, cb, 0, b100)
+	op := wire.OutPoint{Hash: cb.TxHash(), Index: 0}
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		// Outputs not known to the store, including those not credited
+		// to the wallet, can't be labeled.
+		unknown := []wire.OutPoint{
+			{Hash: chainhash.Hash{1}},
+			{Hash: op.Hash, Index: 1},
+		}
+		for _, u := range unknown {
+			err := store.PutOutputLabel(ns, u, "label", nil)
+			if err != ErrUnknownOutput {
+				t.Fatalf("expected ErrUnknownOutput, got %v", err)
+			}
+		}
+
+		invalid := []struct {
+			label string
+			tags  []string
+			err   error
+		}{
+			{strings.Repeat("l", TxLabelLimit+1), nil, ErrLabelTooLong},
+			{"", []string{""}, ErrEmptyTag},
+			{"", []string{strings.Repeat("t", OutputTagLimit+1)},
+				ErrTagTooLong},
+		}
+		for _, test := range invalid {
+			err := store.PutOutputLabel(ns, op, test.label, test.tags)
+			if err != test.err {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		}
+		tooMany := make([]string, MaxOutputTags+1)
+		for i := range tooMany {
+			tooMany[i] = strings.Repeat("t", i+1)
+		}
+		if err := store.PutOutputLabel(ns, op, "", tooMany); err != ErrTooManyTags {
+			t.Fatalf("expected ErrTooManyTags, got %v", err)
+		}
+
+		tags := []string{"kyc-verified", "cold-reserve", "kyc-verified"}
+		if err := store.PutOutputLabel(ns, op, "savings", tags); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		expected := &OutputLabel{
+			Label: "savings",
+			Tags:  []string{"cold-reserve", "kyc-verified"},
+		}
+		l, err := store.FetchOutputLabel(ns, op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(l, expected) {
+			t.Fatalf("got output label %+v, expected %+v", l, expected)
+		}
+		if !l.HasTag("kyc-verified") || l.HasTag("do-not-spend") {
+			t.Fatalf("unexpected tags %v", l.Tags)
+		}
+
+		var n int
+		err = store.ForEachOutputLabel(ns, func(o wire.OutPoint,
+			l *OutputLabel) error {
+
+			n++
+			if o != op || !reflect.DeepEqual(l, expected) {
+				t.Fatalf("unexpected output label %v: %+v", o, l)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Fatalf("got %d output labels, expected 1", n)
+		}
+
+		// Tags can be set without a label.
+		err = store.PutOutputLabel(ns, op, "", []string{"do-not-spend"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err = store.FetchOutputLabel(ns, op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected = &OutputLabel{Tags: []string{"do-not-spend"}}
+		if !reflect.DeepEqual(l, expected) {
+			t.Fatalf("got output label %+v, expected %+v", l, expected)
+		}
+
+		// An empty label without tags removes them.
+		if err := store.PutOutputLabel(ns, op, "", nil); err != nil {
+			t.Fatal(err)
+		}
+		l, err = store.FetchOutputLabel(ns, op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l != nil {
+			t.Fatalf("unexpected output label %+v after removal", l)
+		}
+	})
+}
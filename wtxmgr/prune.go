@@ -0,0 +1,357 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// PrunedTx is the summary kept for a mined transaction whose records were
+// removed from the store by PruneHistory.
+type PrunedTx struct {
+	Hash     chainhash.Hash
+	Block    BlockMeta
+	Received time.Time
+
+	// NetAmount is the total value of the credits of the transaction less
+	// the total value of its debits.
+	NetAmount btcutil.Amount
+
+	// Label is the label of the transaction, which is kept when it is
+	// pruned.
+	Label string
+}
+
+// PrunedHeight returns the height up to which transaction history was pruned,
+// or -1 if history was never pruned.  Blocks at or below this height can not
+// be rolled back.
+func (s *Store) PrunedHeight(ns walletdb.ReadBucket) (int32, error) {
+	return fetchPrunedHeight(ns)
+}
+
+// isPrunedTx returns whether the transaction with the given hash mined at the
+// given height was pruned from the store.
+func isPrunedTx(ns walletdb.ReadBucket, txHash *chainhash.Hash,
+	height int32) (bool, error) {
+
+	prunedHeight, err := fetchPrunedHeight(ns)
+	if err != nil || height > prunedHeight {
+		return false, err
+	}
+	return existsRawPrunedTx(ns, keyPrunedTx(txHash, height)) != nil, nil
+}
+
+// PruneHistory removes the records of every mined transaction at or below the
+// given height whose credits, if any, were all spent by transactions also mined
+// at or below it.  Only a PrunedTx summary is kept for each of them, and the
+// transactions and blocks they belong to are no longer returned by the other
+// methods of the store.  The conflicted transactions removed because of a
+// pruned transaction are removed with it, while transaction labels are kept.
+//
+// The pruned height of the store is raised to the given height, after which
+// rollbacks of blocks at or below it fail with ErrPruned.  Callers should
+// therefore only prune history buried deeper than any expected reorg.  The
+// number of pruned transactions is returned.
+func (s *Store) PruneHistory(ns walletdb.ReadWriteBucket, height int32) (int,
+	error) {
+
+	pruned, _, err := pruneBlocks(ns, height, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if pruned != 0 {
+		log.Infof("Pruned %d transactions mined at or below height %d",
+			pruned, height)
+	}
+
+	return pruned, nil
+}
+
+// PruneHistoryBatch prunes the transaction history like PruneHistory, but only
+// visits the blocks recorded from startHeight, up to maxBlocks of them, so that
+// large histories can be pruned over several database transactions.  The
+// number of pruned transactions is returned, along with the height to resume
+// pruning from, or -1 once every block at or below height was visited.
+func (s *Store) PruneHistoryBatch(ns walletdb.ReadWriteBucket, height,
+	startHeight int32, maxBlocks int) (int, int32, error) {
+
+	return pruneBlocks(ns, height, startHeight, maxBlocks)
+}
+
+// pruneBlocks prunes the transactions of the blocks recorded from startHeight
+// through height, visiting at most maxBlocks of them unless it is zero, and
+// returns the number of pruned transactions and the height of the next block
+// to visit, or -1 if there is none.
+func pruneBlocks(ns walletdb.ReadWriteBucket, height, startHeight int32,
+	maxBlocks int) (int, int32, error) {
+
+	prunedHeight, err := fetchPrunedHeight(ns)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Block records are modified while pruning, so collect the heights to
+	// visit before doing so.
+	var heights []int32
+	next := int32(-1)
+	it := makeReadBlockIterator(ns, startHeight)
+	for it.next() && it.elem.Height <= height {
+		if maxBlocks != 0 && len(heights) == maxBlocks {
+			next = it.elem.Height
+			break
+		}
+		heights = append(heights, it.elem.Height)
+	}
+	if it.err != nil {
+		return 0, 0, it.err
+	}
+
+	var pruned int
+	for _, blockHeight := range heights {
+		k, v := existsBlockRecord(ns, blockHeight)
+		var block blockRecord
+		if err := readRawBlockRecord(k, v, &block); err != nil {
+			return 0, 0, err
+		}
+
+		for i := range block.transactions {
+			txHash := &block.transactions[i]
+			recKey := keyTxRecord(txHash, &block.Block)
+
+			prunable, err := isPrunable(ns, recKey, height)
+			if err != nil {
+				return 0, 0, err
+			}
+			if !prunable {
+				continue
+			}
+
+			meta := BlockMeta{Block: block.Block, Time: block.Time}
+			if err := pruneTx(ns, txHash, &meta); err != nil {
+				return 0, 0, err
+			}
+			pruned++
+		}
+	}
+
+	if height > prunedHeight {
+		if err := putPrunedHeight(ns, height); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return pruned, next, nil
+}
+
+// isPrunable returns whether every credit of the mined transaction with the
+// given record key is spent by a transaction mined at or below height.
+func isPrunable(ns walletdb.ReadBucket, recKey []byte, height int32) (bool,
+	error) {
+
+	it := makeReadCreditIterator(ns, recKey)
+	for it.next() {
+		if !it.elem.Spent || len(it.cv) < 81 {
+			return false, nil
+		}
+		spenderHeight := int32(byteOrder.Uint32(it.cv[41:45]))
+		if spenderHeight > height {
+			return false, nil
+		}
+	}
+	return true, it.err
+}
+
+// pruneTx removes the records of a mined transaction from the store and keeps
+// a summary of it.  The credits of the transaction must all be spent.
+func pruneTx(ns walletdb.ReadWriteBucket, txHash *chainhash.Hash,
+	block *BlockMeta) error {
+
+	recKey := keyTxRecord(txHash, &block.Block)
+	recVal := existsRawTxRecord(ns, recKey)
+	if recVal == nil {
+		str := fmt.Sprintf("missing transaction %v for block %v",
+			txHash, block.Height)
+		return storeError(ErrData, str, nil)
+	}
+	var rec TxRecord
+	if err := readRawTxRecord(txHash, recVal, &rec); err != nil {
+		return err
+	}
+
+	summary := PrunedTx{
+		Hash:     *txHash,
+		Block:    *block,
+		Received: rec.Received,
+	}
+
+	// Remove the credits with their script index entries and output
+	// labels.  Spent credits are not recorded as unspent, so only the
+	// credit records remain.
+	var creditKeys [][]byte
+	credIt := makeReadCreditIterator(ns, recKey)
+	for credIt.next() {
+		summary.NetAmount += credIt.elem.Amount
+		creditKeys = append(creditKeys, copyBytes(credIt.ck))
+	}
+	if credIt.err != nil {
+		return credIt.err
+	}
+	for _, k := range creditKeys {
+		index := extractRawCreditIndex(k)
+		if int(index) >= len(rec.MsgTx.TxOut) {
+			str := fmt.Sprintf("missing output %d of transaction %v",
+				index, txHash)
+			return storeError(ErrData, str, nil)
+		}
+		op := wire.OutPoint{Hash: *txHash, Index: index}
+		pkScript := rec.MsgTx.TxOut[index].PkScript
+		if err := deleteScriptIndex(ns, pkScript, &op); err != nil {
+			return err
+		}
+		if err := deleteRawCredit(ns, k); err != nil {
+			return err
+		}
+		if err := deleteOutputLabel(ns, &op); err != nil {
+			return err
+		}
+	}
+
+	// Remove the debits.  The credits they spend are left marked as spent
+	// by the pruned transaction.
+	var debitKeys [][]byte
+	debIt := makeReadDebitIterator(ns, recKey)
+	for debIt.next() {
+		summary.NetAmount -= debIt.elem.Amount
+		debitKeys = append(debitKeys, copyBytes(debIt.ck))
+	}
+	if debIt.err != nil {
+		return debIt.err
+	}
+	for _, k := range debitKeys {
+		if err := deleteRawDebit(ns, k); err != nil {
+			return err
+		}
+	}
+
+	// The transactions removed because they conflicted with this one are
+	// pruned along with it.
+	for _, removed := range fetchConflictIndexHashes(ns, txHash) {
+		removed := removed
+		if err := deleteConflictedTx(ns, &removed); err != nil {
+			return err
+		}
+	}
+
+	if err := deleteTxRecord(ns, txHash, &block.Block); err != nil {
+		return err
+	}
+
+	// Remove the transaction from its block record, and the block record
+	// itself once it has no transactions left.
+	blockKey, blockVal := existsBlockRecord(ns, block.Height)
+	blockVal, err := removeRawBlockRecord(blockVal, txHash)
+	if err != nil {
+		return err
+	}
+	if byteOrder.Uint32(blockVal[40:44]) == 0 {
+		err = deleteBlockRecord(ns, block.Height)
+	} else {
+		err = putRawBlockRecord(ns, blockKey, blockVal)
+	}
+	if err != nil {
+		return err
+	}
+
+	return putPrunedTx(ns, &summary)
+}
+
+// RangePrunedTransactions runs the function f on the summaries of all pruned
+// transactions mined over the height range [begin,end], grouped by block.  If
+// the end height comes before the begin height, blocks are iterated in reverse
+// order.
+//
+// The function f may return an error which, if non-nil, is propagated to the
+// caller.  Additionally, a boolean return value allows exiting the function
+// early without reading any additional summaries when true.
+//
+// All calls to f are guaranteed to be passed a slice with more than zero
+// elements.  The slice may be reused for multiple blocks, so it is not safe to
+// use it after the loop iteration it was acquired.
+func (s *Store) RangePrunedTransactions(ns walletdb.ReadBucket, begin,
+	end int32, f func([]PrunedTx) (bool, error)) error {
+
+	if begin < 0 || end < 0 {
+		str := "pruned transactions can only be ranged by block height"
+		return storeError(ErrInput, str, nil)
+	}
+
+	labels := ns.NestedReadBucket(bucketTxLabels)
+	c := ns.NestedReadBucket(bucketPrunedTxs).ReadCursor()
+
+	var k, v []byte
+	var advance func() ([]byte, []byte)
+	var inRange func(height int32) bool
+	if begin <= end {
+		k, v = c.Seek(keyBlockRecord(begin))
+		advance = c.Next
+		inRange = func(height int32) bool { return height <= end }
+	} else {
+		// Position the cursor at the last summary at or below the
+		// begin height.
+		if begin == int32(^uint32(0)>>1) {
+			k, v = c.Last()
+		} else if k, v = c.Seek(keyBlockRecord(begin + 1)); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		advance = c.Prev
+		inRange = func(height int32) bool { return height >= end }
+	}
+
+	var summaries []PrunedTx
+	for ; k != nil; k, v = advance() {
+		var p PrunedTx
+		if err := readRawPrunedTx(k, v, &p); err != nil {
+			return err
+		}
+		if !inRange(p.Block.Height) {
+			break
+		}
+
+		if len(summaries) != 0 &&
+			summaries[0].Block.Height != p.Block.Height {
+
+			brk, err := f(summaries)
+			if err != nil || brk {
+				return err
+			}
+			summaries = summaries[:0]
+		}
+
+		if labels != nil {
+			if lv := labels.Get(p.Hash[:]); lv != nil {
+				label, err := DeserializeLabel(lv)
+				if err != nil {
+					return err
+				}
+				p.Label = label
+			}
+		}
+		summaries = append(summaries, p)
+	}
+	if len(summaries) != 0 {
+		_, err := f(summaries)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// TestPruneHistory ensures that fully spent transactions are pruned into
+// summaries, that the store stays consistent, and that rollbacks past the
+// pruned height are refused.
+func TestPruneHistory(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	blockMeta := func(height int32) *BlockMeta {
+		return &BlockMeta{
+			Block: Block{Hash: chainhash.Hash{byte(height)}, Height: height},
+			Time:  time.Unix(int64(height), 0),
+		}
+	}
+	b100, b101, b150 := blockMeta(100), blockMeta(101), blockMeta(150)
+
+	newRec := func(tx *wire.MsgTx) *TxRecord {
+		rec, err := NewTxRecordFromMsgTx(tx, timeNow())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+
+	// A coinbase at height 100 is fully spent at height 101 by a
+	// transaction whose only credit is spent at height 150.  The
+	// transaction at height 150 keeps an unspent credit.
+	cb := newRec(newCoinBase(1e8, 2e8))
+	spend1 := newRec(spendOutputs([]wire.OutPoint{
+		{Hash: cb.Hash, Index: 0}, {Hash: cb.Hash, Index: 1},
+	}, 29e7))
+	spend2 := newRec(spendOutput(&spend1.Hash, 0, 1e8, 18e7))
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		recs := []struct {
+			rec     *TxRecord
+			block   *BlockMeta
+			credits []uint32
+		}{
+			{cb, b100, []uint32{0, 1}},
+			{spend1, b101, []uint32{0}},
+			{spend2, b150, []uint32{0}},
+		}
+		for _, r := range recs {
+			if err := store.InsertTx(ns, r.rec, r.block); err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range r.credits {
+				err := store.AddCredit(ns, r.rec, r.block, i, false)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if err := store.PutTxLabel(ns, spend1.Hash, "label"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	prune := func(height int32, expected int) {
+		t.Helper()
+		commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+			n, err := store.PruneHistory(ns, height)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != expected {
+				t.Fatalf("pruned %d transactions at height %d, "+
+					"expected %d", n, height, expected)
+			}
+			prunedHeight, err := store.PrunedHeight(ns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prunedHeight != height {
+				t.Fatalf("pruned height %d, expected %d",
+					prunedHeight, height)
+			}
+			report, err := Verify(ns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(report.Problems) != 0 {
+				t.Fatalf("unexpected problems after pruning: %v",
+					report.Problems)
+			}
+		})
+	}
+
+	// Only the coinbase is fully spent at or below height 120.
+	prune(120, 1)
+	prune(160, 1)
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		for _, hash := range []*chainhash.Hash{&cb.Hash, &spend1.Hash} {
+			details, err := store.TxDetails(ns, hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details != nil {
+				t.Fatalf("pruned transaction %v still recorded",
+					hash)
+			}
+		}
+		details, err := store.TxDetails(ns, &spend2.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if details == nil || len(details.Debits) != 1 {
+			t.Fatalf("unexpected details of unpruned transaction: %v",
+				details)
+		}
+
+		var summaries []PrunedTx
+		err = store.RangePrunedTransactions(ns, 200, 0, func(
+			p []PrunedTx) (bool, error) {
+
+			summaries = append(summaries, p...)
+			return false, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []PrunedTx{
+			{
+				Hash:      spend1.Hash,
+				Block:     *b101,
+				Received:  spend1.Received,
+				NetAmount: -1e7,
+				Label:     "label",
+			},
+			{
+				Hash:      cb.Hash,
+				Block:     *b100,
+				Received:  cb.Received,
+				NetAmount: 3e8,
+			},
+		}
+		if len(summaries) != len(expected) {
+			t.Fatalf("got %d summaries, expected %d",
+				len(summaries), len(expected))
+		}
+		for i := range expected {
+			got, exp := summaries[i], expected[i]
+			if got.Hash != exp.Hash || got.Block.Block != exp.Block.Block ||
+				!got.Block.Time.Equal(exp.Block.Time) ||
+				!got.Received.Equal(exp.Received) ||
+				got.NetAmount != exp.NetAmount || got.Label != exp.Label {
+
+				t.Fatalf("summary %d: got %+v, expected %+v", i,
+					got, exp)
+			}
+		}
+
+		// Ranging forwards stops at the end height.
+		var n int
+		err = store.RangePrunedTransactions(ns, 0, 100, func(
+			p []PrunedTx) (bool, error) {
+
+			n += len(p)
+			return false, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Fatalf("got %d summaries up to height 100, expected 1", n)
+		}
+
+		// A rescan finding a pruned transaction again doesn't record
+		// it.
+		if err := store.InsertTx(ns, cb, b100); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, cb, b100, 0, false); err != nil {
+			t.Fatal(err)
+		}
+		details, err = store.TxDetails(ns, &cb.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if details != nil {
+			t.Fatal("pruned transaction recorded again")
+		}
+
+		bal, err := store.Balance(ns, 1, 200)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bal != btcutil.Amount(1e8) {
+			t.Fatalf("balance %v, expected %v", bal,
+				btcutil.Amount(1e8))
+		}
+
+		// Blocks above the pruned height can still be rolled back,
+		// but not blocks at or below it.
+		if err := store.Rollback(ns, 161); err != nil {
+			t.Fatal(err)
+		}
+		err = store.Rollback(ns, 160)
+		if serr, ok := err.(Error); !ok || serr.Code != ErrPruned {
+			t.Fatalf("expected ErrPruned rolling back pruned "+
+				"blocks, got %v", err)
+		}
+	})
+}
+
+// TestPruneHistoryBatch ensures that history can be pruned in batches of
+// blocks, resuming from the next block to visit.
+func TestPruneHistoryBatch(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	blockMeta := func(height int32) *BlockMeta {
+		return &BlockMeta{
+			Block: Block{Hash: chainhash.Hash{byte(height)}, Height: height},
+			Time:  time.Unix(int64(height), 0),
+		}
+	}
+	b100, b101 := blockMeta(100), blockMeta(101)
+
+	// A coinbase at height 100 is fully spent at height 101 by a
+	// transaction keeping an unspent credit.
+	cb, err := NewTxRecordFromMsgTx(newCoinBase(1e8), timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend, err := NewTxRecordFromMsgTx(
+		spendOutput(&cb.Hash, 0, 9e7), timeNow(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, cb, b100); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, cb, b100, 0, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.InsertTx(ns, spend, b101); err != nil {
+			t.Fatal(err)
+		}
+		err := store.AddCredit(ns, spend, b101, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	tests := []struct {
+		startHeight int32
+		pruned      int
+		next        int32
+	}{
+		{startHeight: 0, pruned: 1, next: 101},
+		{startHeight: 101, pruned: 0, next: -1},
+	}
+	for _, test := range tests {
+		commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+			pruned, next, err := store.PruneHistoryBatch(
+				ns, 120, test.startHeight, 1,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pruned != test.pruned || next != test.next {
+				t.Fatalf("pruned %d transactions from height "+
+					"%d with next height %d, expected %d "+
+					"and %d", pruned, test.startHeight, next,
+					test.pruned, test.next)
+			}
+			prunedHeight, err := store.PrunedHeight(ns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prunedHeight != 120 {
+				t.Fatalf("pruned height %d, expected 120",
+					prunedHeight)
+			}
+		})
+	}
+}
+
+// TestRemoveRawBlockRecord ensures that transaction hashes are removed from
+// block records.
+func TestRemoveRawBlockRecord(t *testing.T) {
+	t.Parallel()
+
+	block := &BlockMeta{
+		Block: Block{Hash: chainhash.Hash{1}, Height: 1},
+		Time:  time.Unix(1, 0),
+	}
+	hashes := []chainhash.Hash{{1}, {2}, {3}}
+
+	v := valueBlockRecord(block, &hashes[0])
+	for i := 1; i < len(hashes); i++ {
+		var err error
+		v, err = appendRawBlockRecord(v, &hashes[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v, err := removeRawBlockRecord(v, &hashes[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec blockRecord
+	if err := readRawBlockRecord(keyBlockRecord(1), v, &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Hash != block.Hash || !rec.Time.Equal(block.Time) ||
+		len(rec.transactions) != 2 || rec.transactions[0] != hashes[0] ||
+		rec.transactions[1] != hashes[2] {
+
+		t.Fatalf("unexpected block record %+v", rec)
+	}
+}
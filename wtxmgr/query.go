@@ -6,7 +6,10 @@
 package wtxmgr
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
 	"github.com/tinhnguyenhn/colxutil"
@@ -227,6 +230,66 @@ func (s *Store) TxDetails(ns walletdb.ReadBucket, txHash *chainhash.Hash) (*TxDe
 	return s.minedTxDetails(ns, txHash, k, v)
 }
 
+// TxDetailsForScript returns the details of every transaction with a credit
+// paying to an output script, found through the script index.  Mined
+// transactions are returned in increasing block height order, followed by
+// unmined transactions.
+func (s *Store) TxDetailsForScript(ns walletdb.ReadBucket,
+	pkScript []byte) ([]TxDetails, error) {
+
+	outPoints, err := fetchScriptIndexOutPoints(ns, pkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var details []TxDetails
+	for i := range outPoints {
+		// Outpoints are ordered by transaction hash, so all credits of
+		// a transaction are adjacent and it is only looked up once.
+		txHash := &outPoints[i].Hash
+		if i > 0 && outPoints[i-1].Hash == *txHash {
+			continue
+		}
+
+		detail, err := s.TxDetails(ns, txHash)
+		if err != nil {
+			return nil, err
+		}
+
+		// Index entries of credits that were since removed are
+		// skipped.
+		if detail == nil || !hasCreditForScript(detail, pkScript) {
+			continue
+		}
+		details = append(details, *detail)
+	}
+
+	heightKey := func(height int32) int32 {
+		if height == -1 {
+			return math.MaxInt32
+		}
+		return height
+	}
+	sort.SliceStable(details, func(i, j int) bool {
+		return heightKey(details[i].Block.Height) <
+			heightKey(details[j].Block.Height)
+	})
+
+	return details, nil
+}
+
+// hasCreditForScript returns whether a transaction records a credit paying to
+// an output script.
+func hasCreditForScript(detail *TxDetails, pkScript []byte) bool {
+	for _, cred := range detail.Credits {
+		txOut := detail.MsgTx.TxOut[cred.Index]
+		if bytes.Equal(txOut.PkScript, pkScript) {
+			return true
+		}
+	}
+	return false
+}
+
 // UniqueTxDetails looks up all recorded details for a transaction recorded
 // mined in some particular block, or an unmined transaction if block is nil.
 //
@@ -373,9 +436,19 @@ func (s *Store) rangeBlockTransactions(ns walletdb.ReadBucket, begin, end int32,
 func (s *Store) RangeTransactions(ns walletdb.ReadBucket, begin, end int32,
 	f func([]TxDetails) (bool, error)) error {
 
+	return s.rangeTransactions(ns, begin, end, s.rangeUnminedTransactions, f)
+}
+
+// rangeTransactions implements RangeTransactions, with the unmined
+// transactions ranged over by the function rangeUnmined.
+func (s *Store) rangeTransactions(ns walletdb.ReadBucket, begin, end int32,
+	rangeUnmined func(walletdb.ReadBucket,
+		func([]TxDetails) (bool, error)) (bool, error),
+	f func([]TxDetails) (bool, error)) error {
+
 	var addedUnmined bool
 	if begin < 0 {
-		brk, err := s.rangeUnminedTransactions(ns, f)
+		brk, err := rangeUnmined(ns, f)
 		if err != nil || brk {
 			return err
 		}
@@ -384,7 +457,7 @@ func (s *Store) RangeTransactions(ns walletdb.ReadBucket, begin, end int32,
 
 	brk, err := s.rangeBlockTransactions(ns, begin, end, f)
 	if err == nil && !brk && !addedUnmined && end < 0 {
-		_, err = s.rangeUnminedTransactions(ns, f)
+		_, err = rangeUnmined(ns, f)
 	}
 	return err
 }
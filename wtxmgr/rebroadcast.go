@@ -0,0 +1,50 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// RebroadcastState describes the rebroadcast attempts made for an unmined
+// transaction.  The state is removed once the transaction is mined or removed
+// from the store.
+type RebroadcastState struct {
+	// Attempts is the number of times the transaction was rebroadcast.
+	Attempts uint32
+
+	// LastAttempt is the time of the last rebroadcast attempt.
+	LastAttempt time.Time
+
+	// NextAttempt is the time the next rebroadcast attempt is due.
+	NextAttempt time.Time
+
+	// Dropped is set when the transaction was missing from the mempool of
+	// the backend during the last attempt.
+	Dropped bool
+}
+
+// RebroadcastState returns the rebroadcast state of the unmined transaction
+// with the given hash, or nil if it was never rebroadcast.
+func (s *Store) RebroadcastState(ns walletdb.ReadBucket,
+	txHash *chainhash.Hash) (*RebroadcastState, error) {
+
+	return fetchRebroadcastState(ns, txHash)
+}
+
+// PutRebroadcastState records the rebroadcast state of the unmined transaction
+// with the given hash.  ErrUnminedTxNotFound is returned if the transaction is
+// not an unmined transaction of the store.
+func (s *Store) PutRebroadcastState(ns walletdb.ReadWriteBucket,
+	txHash *chainhash.Hash, state *RebroadcastState) error {
+
+	if existsRawUnmined(ns, txHash[:]) == nil {
+		return ErrUnminedTxNotFound
+	}
+	return putRebroadcastState(ns, txHash, state)
+}
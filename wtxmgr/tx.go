@@ -55,6 +55,11 @@ var (
 	// ErrOutputUnlockNotAllowed is an error returned when an output unlock
 	// is attempted with a different ID than the one which locked it.
 	ErrOutputUnlockNotAllowed = errors.New("output unlock not alowed")
+
+	// ErrUnminedTxNotFound is an error returned when an unmined
+	// transaction is abandoned but no unmined transaction with its hash
+	// exists.
+	ErrUnminedTxNotFound = errors.New("unmined transaction not found")
 )
 
 // Block contains the minimum amount of data to uniquely identify any block on
@@ -190,8 +195,10 @@ type Store struct {
 	clock clock.Clock
 
 	// Event callbacks.  These execute in the same goroutine as the wtxmgr
-	// caller.
-	NotifyUnspent func(hash *chainhash.Hash, index uint32)
+	// caller.  NotifyConflict is called when the database transaction
+	// removing the conflicted transaction is committed.
+	NotifyUnspent  func(hash *chainhash.Hash, index uint32)
+	NotifyConflict func(conflict *ConflictedTx)
 }
 
 // Open opens the wallet transaction store from a walletdb namespace.  If the
@@ -204,7 +211,7 @@ func Open(ns walletdb.ReadBucket, chainParams *chaincfg.Params) (*Store, error)
 	if err != nil {
 		return nil, err
 	}
-	s := &Store{chainParams, clock.NewDefaultClock(), nil} // TODO: set callbacks
+	s := &Store{chainParams, clock.NewDefaultClock(), nil, nil} // TODO: set callbacks
 	return s, nil
 }
 
@@ -349,6 +356,10 @@ func (s *Store) deleteUnminedTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 		}
 	}
 
+	if err := deleteRebroadcastState(ns, &rec.Hash); err != nil {
+		return err
+	}
+
 	return deleteRawUnmined(ns, rec.Hash[:])
 }
 
@@ -372,7 +383,7 @@ func (s *Store) RemoveUnminedTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 	// As we already have a tx record, we can directly call the
 	// removeConflict method. This will do the job of recursively removing
 	// this unmined transaction, and any transactions that depend on it.
-	return s.removeConflict(ns, rec)
+	return s.removeConflict(ns, rec, nil)
 }
 
 // insertMinedTx inserts a new transaction record for a mined transaction into
@@ -389,10 +400,26 @@ func (s *Store) insertMinedTx(ns walletdb.ReadWriteBucket, rec *TxRecord,
 		return nil
 	}
 
+	// Pruned transactions, which may be found again by a rescan, are not
+	// recorded again.
+	pruned, err := isPrunedTx(ns, &rec.Hash, block.Height)
+	if err != nil || pruned {
+		return err
+	}
+
+	// A transaction previously removed as a conflict or abandoned is no
+	// longer conflicted or abandoned once mined, which may happen after a
+	// reorg.
+	if err := deleteConflictedTx(ns, &rec.Hash); err != nil {
+		return err
+	}
+	if err := deleteAbandonedTx(ns, &rec.Hash); err != nil {
+		return err
+	}
+
 	// If a block record does not yet exist for any transactions from this
 	// block, insert a block record first. Otherwise, update it by adding
 	// the transaction hash to the set of transactions from this block.
-	var err error
 	blockKey, blockValue := existsBlockRecord(ns, block.Height)
 	if blockValue == nil {
 		err = putBlockRecord(ns, block, &rec.Hash)
@@ -485,13 +512,22 @@ func (s *Store) addCredit(ns walletdb.ReadWriteBucket, rec *TxRecord, block *Blo
 			return false, nil
 		}
 		v := valueUnminedCredit(btcutil.Amount(rec.MsgTx.TxOut[index].Value), change)
-		return true, putRawUnminedCredit(ns, k, v)
+		if err := putRawUnminedCredit(ns, k, v); err != nil {
+			return false, err
+		}
+		op := wire.OutPoint{Hash: rec.Hash, Index: index}
+		pkScript := rec.MsgTx.TxOut[index].PkScript
+		return true, putScriptIndex(ns, pkScript, &op)
 	}
 
 	k, v := existsCredit(ns, &rec.Hash, index, &block.Block)
 	if v != nil {
 		return false, nil
 	}
+	pruned, err := isPrunedTx(ns, &rec.Hash, block.Height)
+	if err != nil || pruned {
+		return false, err
+	}
 
 	txOutAmt := btcutil.Amount(rec.MsgTx.TxOut[index].Value)
 	log.Debugf("Marking transaction %v output %d (%v) spendable",
@@ -508,7 +544,7 @@ func (s *Store) addCredit(ns walletdb.ReadWriteBucket, rec *TxRecord, block *Blo
 		spentBy: indexedIncidence{index: ^uint32(0)},
 	}
 	v = valueUnspentCredit(&cred)
-	err := putRawCredit(ns, k, v)
+	err = putRawCredit(ns, k, v)
 	if err != nil {
 		return false, err
 	}
@@ -522,12 +558,30 @@ func (s *Store) addCredit(ns walletdb.ReadWriteBucket, rec *TxRecord, block *Blo
 		return false, err
 	}
 
+	pkScript := rec.MsgTx.TxOut[index].PkScript
+	if err := putScriptIndex(ns, pkScript, &cred.outPoint); err != nil {
+		return false, err
+	}
+
 	return true, putUnspent(ns, &cred.outPoint, &block.Block)
 }
 
 // Rollback removes all blocks at height onwards, moving any transactions within
-// each block to the unconfirmed pool.
+// each block to the unconfirmed pool.  Blocks at or below the pruned height of
+// the store can not be removed, and ErrPruned is returned if height does not
+// exceed it.
 func (s *Store) Rollback(ns walletdb.ReadWriteBucket, height int32) error {
+	prunedHeight, err := fetchPrunedHeight(ns)
+	if err != nil {
+		return err
+	}
+	if height <= prunedHeight {
+		str := fmt.Sprintf("unable to roll back to height %d: "+
+			"transaction history is pruned up to height %d", height,
+			prunedHeight)
+		return storeError(ErrPruned, str, nil)
+	}
+
 	return s.rollback(ns, height)
 }
 
@@ -603,6 +657,12 @@ func (s *Store) rollback(ns walletdb.ReadWriteBucket, height int32) error {
 					if err != nil {
 						return err
 					}
+					err = deleteScriptIndex(
+						ns, output.PkScript, &op,
+					)
+					if err != nil {
+						return err
+					}
 				}
 
 				continue
@@ -761,7 +821,7 @@ func (s *Store) rollback(ns walletdb.ReadWriteBucket, height int32) error {
 
 			log.Debugf("Transaction %v spends a removed coinbase "+
 				"output -- removing as well", unminedRec.Hash)
-			err = s.removeConflict(ns, &unminedRec)
+			err = s.removeConflict(ns, &unminedRec, nil)
 			if err != nil {
 				return err
 			}
@@ -771,121 +831,266 @@ func (s *Store) rollback(ns walletdb.ReadWriteBucket, height int32) error {
 	return putMinedBalance(ns, minedBalance)
 }
 
-// UnspentOutputs returns all unspent received transaction outputs.
-// The order is undefined.
+// UnspentOutputs returns all unspent received transaction outputs, ordered by
+// outpoint.  Callers that may need to process a large number of outputs should
+// prefer ForEachUnspentOutput, which does not hold every output in memory.
 func (s *Store) UnspentOutputs(ns walletdb.ReadBucket) ([]Credit, error) {
 	var unspent []Credit
+	err := s.ForEachUnspentOutput(ns, nil, func(c *Credit) (bool, error) {
+		unspent = append(unspent, *c)
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return unspent, nil
+}
+
+// ForEachUnspentOutput calls f with each unspent received transaction output,
+// both mined and unmined, in ascending outpoint order.  Outputs that are
+// locked or spent by an unmined transaction are skipped, as done by
+// UnspentOutputs.
+//
+// If after is non-nil, iteration begins with the first output ordered after
+// it, whether or not the output it refers to is still unspent.  This allows
+// callers to resume iteration in a later database transaction by passing the
+// outpoint of the last credit they processed.
+//
+// The function f may return an error which, if non-nil, is propagated to the
+// caller.  Additionally, a boolean return value allows exiting the function
+// early without reading any additional outputs when true.  The function f must
+// not modify the store.
+func (s *Store) ForEachUnspentOutput(ns walletdb.ReadBucket,
+	after *wire.OutPoint, f func(c *Credit) (bool, error)) error {
+
+	// Mined unspent outputs and unmined credits are both keyed by
+	// canonical outpoint, so the two buckets are merged by walking a
+	// cursor over each in lockstep.
+	minedCursor := ns.NestedReadBucket(bucketUnspent).ReadCursor()
+	unminedCursor := ns.NestedReadBucket(bucketUnminedCredits).ReadCursor()
+
+	var minedKey, minedVal, unminedKey []byte
+	if after == nil {
+		minedKey, minedVal = minedCursor.First()
+		unminedKey, _ = unminedCursor.First()
+	} else {
+		seek := canonicalOutPoint(&after.Hash, after.Index)
+		minedKey, minedVal = minedCursor.Seek(seek)
+		if bytes.Equal(minedKey, seek) {
+			minedKey, minedVal = minedCursor.Next()
+		}
+		unminedKey, _ = unminedCursor.Seek(seek)
+		if bytes.Equal(unminedKey, seek) {
+			unminedKey, _ = unminedCursor.Next()
+		}
+	}
 
+	now := s.clock.Now()
 	var op wire.OutPoint
-	var block Block
-	err := ns.NestedReadBucket(bucketUnspent).ForEach(func(k, v []byte) error {
-		err := readCanonicalOutPoint(k, &op)
-		if err != nil {
+	for minedKey != nil || unminedKey != nil {
+		mined := unminedKey == nil ||
+			(minedKey != nil && bytes.Compare(minedKey, unminedKey) <= 0)
+
+		k, v := unminedKey, []byte(nil)
+		if mined {
+			k, v = minedKey, minedVal
+		}
+		if err := readCanonicalOutPoint(k, &op); err != nil {
 			return err
 		}
 
-		// Skip the output if it's locked.
-		_, _, isLocked := isLockedOutput(ns, op, s.clock.Now())
-		if isLocked {
-			return nil
+		// Skip the output if it's locked or spent by an unmined
+		// transaction.
+		var cred *Credit
+		_, _, isLocked := isLockedOutput(ns, op, now)
+		if !isLocked && existsRawUnminedInput(ns, k) == nil {
+			var err error
+			if mined {
+				cred, err = fetchMinedUnspentCredit(ns, &op, v)
+			} else {
+				cred, err = fetchUnminedCredit(ns, &op)
+			}
+			if err != nil {
+				if _, ok := err.(Error); ok {
+					return err
+				}
+				str := "failed iterating unspent outputs"
+				return storeError(ErrDatabase, str, err)
+			}
 		}
 
-		if existsRawUnminedInput(ns, k) != nil {
-			// Output is spent by an unmined transaction.
-			// Skip this k/v pair.
-			return nil
+		if mined {
+			minedKey, minedVal = minedCursor.Next()
+		} else {
+			unminedKey, _ = unminedCursor.Next()
 		}
 
-		err = readUnspentBlock(v, &block)
-		if err != nil {
+		if cred == nil {
+			continue
+		}
+		brk, err := f(cred)
+		if err != nil || brk {
 			return err
 		}
+	}
 
-		blockTime, err := fetchBlockTime(ns, block.Height)
-		if err != nil {
-			return err
+	return nil
+}
+
+// UnspentOutputsForScript returns the unspent received transaction outputs
+// paying to an output script, found through the script index.  Outputs are
+// returned ordered by outpoint, and outputs that are locked or spent by an
+// unmined transaction are excluded, as done by UnspentOutputs.
+func (s *Store) UnspentOutputsForScript(ns walletdb.ReadBucket,
+	pkScript []byte) ([]Credit, error) {
+
+	outPoints, err := fetchScriptIndexOutPoints(ns, pkScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var unspent []Credit
+	for i := range outPoints {
+		op := &outPoints[i]
+
+		_, _, isLocked := isLockedOutput(ns, *op, s.clock.Now())
+		if isLocked {
+			continue
 		}
-		// TODO(jrick): reading the entire transaction should
-		// be avoidable.  Creating the credit only requires the
-		// output amount and pkScript.
-		rec, err := fetchTxRecord(ns, &op.Hash, &block)
+
+		cred, err := fetchUnspentCredit(ns, op)
 		if err != nil {
-			return fmt.Errorf("unable to retrieve transaction %v: "+
-				"%v", op.Hash, err)
+			return nil, err
 		}
-		txOut := rec.MsgTx.TxOut[op.Index]
-		cred := Credit{
-			OutPoint: op,
-			BlockMeta: BlockMeta{
-				Block: block,
-				Time:  blockTime,
-			},
-			Amount:       btcutil.Amount(txOut.Value),
-			PkScript:     txOut.PkScript,
-			Received:     rec.Received,
-			FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+		if cred == nil {
+			continue
 		}
-		unspent = append(unspent, cred)
-		return nil
-	})
+		unspent = append(unspent, *cred)
+	}
+
+	return unspent, nil
+}
+
+// LockedUnspentOutputs returns the unspent received transaction outputs that
+// are currently locked, which UnspentOutputs excludes.  Outputs are returned
+// ordered by outpoint, and outputs spent by an unmined transaction are
+// excluded.
+func (s *Store) LockedUnspentOutputs(ns walletdb.ReadBucket) ([]Credit, error) {
+	now := s.clock.Now()
+
+	var outPoints []wire.OutPoint
+	err := forEachLockedOutput(
+		ns, func(op wire.OutPoint, _ LockID, expiration time.Time) {
+			if now.Before(expiration) {
+				outPoints = append(outPoints, op)
+			}
+		},
+	)
 	if err != nil {
-		if _, ok := err.(Error); ok {
+		return nil, err
+	}
+
+	var locked []Credit
+	for i := range outPoints {
+		cred, err := fetchUnspentCredit(ns, &outPoints[i])
+		if err != nil {
 			return nil, err
 		}
-		str := "failed iterating unspent bucket"
-		return nil, storeError(ErrDatabase, str, err)
+		if cred == nil {
+			continue
+		}
+		locked = append(locked, *cred)
 	}
 
-	err = ns.NestedReadBucket(bucketUnminedCredits).ForEach(func(k, v []byte) error {
-		if err := readCanonicalOutPoint(k, &op); err != nil {
-			return err
-		}
+	return locked, nil
+}
 
-		// Skip the output if it's locked.
-		_, _, isLocked := isLockedOutput(ns, op, s.clock.Now())
-		if isLocked {
-			return nil
-		}
+// fetchUnspentCredit returns the credit of an output if it is unspent, whether
+// it is mined or not, or nil if it is spent or not a credit of the store.
+// Outputs spent by an unmined transaction are considered spent.
+func fetchUnspentCredit(ns walletdb.ReadBucket, op *wire.OutPoint) (*Credit,
+	error) {
 
-		if existsRawUnminedInput(ns, k) != nil {
-			// Output is spent by an unmined transaction.
-			// Skip to next unmined credit.
-			return nil
-		}
+	k := canonicalOutPoint(&op.Hash, op.Index)
+	if existsRawUnminedInput(ns, k) != nil {
+		return nil, nil
+	}
 
-		// TODO(jrick): Reading/parsing the entire transaction record
-		// just for the output amount and script can be avoided.
-		recVal := existsRawUnmined(ns, op.Hash[:])
-		var rec TxRecord
-		err = readRawTxRecord(&op.Hash, recVal, &rec)
-		if err != nil {
-			return fmt.Errorf("unable to retrieve raw transaction "+
-				"%v: %v", op.Hash, err)
-		}
+	unspentVal := ns.NestedReadBucket(bucketUnspent).Get(k)
+	switch {
+	case unspentVal != nil:
+		return fetchMinedUnspentCredit(ns, op, unspentVal)
+	case existsRawUnminedCredit(ns, k) != nil:
+		return fetchUnminedCredit(ns, op)
+	default:
+		// The credit is spent by a mined transaction or no longer
+		// exists.
+		return nil, nil
+	}
+}
 
-		txOut := rec.MsgTx.TxOut[op.Index]
-		cred := Credit{
-			OutPoint: op,
-			BlockMeta: BlockMeta{
-				Block: Block{Height: -1},
-			},
-			Amount:       btcutil.Amount(txOut.Value),
-			PkScript:     txOut.PkScript,
-			Received:     rec.Received,
-			FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
-		}
-		unspent = append(unspent, cred)
-		return nil
-	})
+// fetchMinedUnspentCredit returns the credit of a mined unspent output given
+// its unspent bucket value.
+func fetchMinedUnspentCredit(ns walletdb.ReadBucket, op *wire.OutPoint,
+	unspentVal []byte) (*Credit, error) {
+
+	var block Block
+	err := readUnspentBlock(unspentVal, &block)
 	if err != nil {
-		if _, ok := err.(Error); ok {
-			return nil, err
-		}
-		str := "failed iterating unmined credits bucket"
-		return nil, storeError(ErrDatabase, str, err)
+		return nil, err
 	}
 
-	return unspent, nil
+	blockTime, err := fetchBlockTime(ns, block.Height)
+	if err != nil {
+		return nil, err
+	}
+	// TODO(jrick): reading the entire transaction should
+	// be avoidable.  Creating the credit only requires the
+	// output amount and pkScript.
+	rec, err := fetchTxRecord(ns, &op.Hash, &block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve transaction %v: "+
+			"%v", op.Hash, err)
+	}
+	txOut := rec.MsgTx.TxOut[op.Index]
+	return &Credit{
+		OutPoint: *op,
+		BlockMeta: BlockMeta{
+			Block: block,
+			Time:  blockTime,
+		},
+		Amount:       btcutil.Amount(txOut.Value),
+		PkScript:     txOut.PkScript,
+		Received:     rec.Received,
+		FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+	}, nil
+}
+
+// fetchUnminedCredit returns the credit of an unmined output.
+func fetchUnminedCredit(ns walletdb.ReadBucket, op *wire.OutPoint) (*Credit,
+	error) {
+
+	// TODO(jrick): Reading/parsing the entire transaction record
+	// just for the output amount and script can be avoided.
+	recVal := existsRawUnmined(ns, op.Hash[:])
+	var rec TxRecord
+	err := readRawTxRecord(&op.Hash, recVal, &rec)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve raw transaction "+
+			"%v: %v", op.Hash, err)
+	}
+
+	txOut := rec.MsgTx.TxOut[op.Index]
+	return &Credit{
+		OutPoint: *op,
+		BlockMeta: BlockMeta{
+			Block: Block{Height: -1},
+		},
+		Amount:       btcutil.Amount(txOut.Value),
+		PkScript:     txOut.PkScript,
+		Received:     rec.Received,
+		FromCoinBase: blockchain.IsCoinBaseTx(&rec.MsgTx),
+	}, nil
 }
 
 // Balance returns the spendable wallet balance (total value of all unspent
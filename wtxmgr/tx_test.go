@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -2720,6 +2721,47 @@ func TestOutputLocks(t *testing.T) {
 				})
 			},
 		},
+		{
+			// Asserts that locked outputs are returned by
+			// LockedUnspentOutputs until their locks expire.
+			name: "locked unspent outputs",
+			run: func(t *testing.T, s *Store, ns walletdb.ReadWriteBucket) {
+				assertLockedUtxos := func(exp ...wire.OutPoint) {
+					t.Helper()
+
+					locked, err := s.LockedUnspentOutputs(ns)
+					if err != nil {
+						t.Fatal(err)
+					}
+					ops := make([]wire.OutPoint, 0, len(locked))
+					for _, cred := range locked {
+						ops = append(ops, cred.OutPoint)
+					}
+					if len(ops) != len(exp) {
+						t.Fatalf("expected locked utxos "+
+							"%v, got %v", exp, ops)
+					}
+					for i := range exp {
+						if ops[i] != exp[i] {
+							t.Fatalf("expected locked "+
+								"utxos %v, got %v",
+								exp, ops)
+						}
+					}
+				}
+
+				assertLockedUtxos()
+
+				lockID := LockID{1}
+				expiry := lock(
+					t, s, ns, lockID, confirmedOutPoint, nil,
+				)
+				assertLockedUtxos(confirmedOutPoint)
+
+				s.clock.(*clock.TestClock).SetTime(expiry)
+				assertLockedUtxos()
+			},
+		},
 		{
 			// Asserts that output locks are removed for outputs
 			// which have had a confirmed spend, ensuring the
@@ -2819,3 +2861,762 @@ func TestOutputLocks(t *testing.T) {
 		})
 	}
 }
+
+// TestScriptIndex ensures that the script index tracks the wallet's credits as
+// transactions are added, mined, rolled back and removed from the store.
+func TestScriptIndex(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	scriptA := []byte{0x51}
+	scriptB := []byte{0x52}
+
+	// assertUnspent ensures the outputs paying to the script match the
+	// expected outpoints.
+	assertUnspent := func(ns walletdb.ReadBucket, pkScript []byte,
+		expected ...wire.OutPoint) {
+
+		t.Helper()
+
+		credits, err := store.UnspentOutputsForScript(ns, pkScript)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(credits) != len(expected) {
+			t.Fatalf("expected %d unspent outputs for script %x, "+
+				"got %d", len(expected), pkScript, len(credits))
+		}
+	outer:
+		for _, op := range expected {
+			for _, c := range credits {
+				if c.OutPoint == op {
+					if !bytes.Equal(c.PkScript, pkScript) {
+						t.Fatalf("credit %v has script "+
+							"%x, want %x", op,
+							c.PkScript, pkScript)
+					}
+					continue outer
+				}
+			}
+			t.Fatalf("expected unspent output %v for script %x",
+				op, pkScript)
+		}
+	}
+
+	// assertTxs ensures the transactions paying to the script match the
+	// expected hashes in order.
+	assertTxs := func(ns walletdb.ReadBucket, pkScript []byte,
+		expected ...chainhash.Hash) {
+
+		t.Helper()
+
+		details, err := store.TxDetailsForScript(ns, pkScript)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(details) != len(expected) {
+			t.Fatalf("expected %d transactions for script %x, "+
+				"got %d", len(expected), pkScript, len(details))
+		}
+		for i, hash := range expected {
+			if details[i].Hash != hash {
+				t.Fatalf("expected transaction %d for script "+
+					"%x to be %v, got %v", i, pkScript,
+					hash, details[i].Hash)
+			}
+		}
+	}
+
+	// assertIndexed ensures the number of raw index entries for the script
+	// matches the expected count.
+	assertIndexed := func(ns walletdb.ReadBucket, pkScript []byte,
+		expected int) {
+
+		t.Helper()
+
+		ops, err := fetchScriptIndexOutPoints(ns, pkScript)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ops) != expected {
+			t.Fatalf("expected %d index entries for script %x, "+
+				"got %d", expected, pkScript, len(ops))
+		}
+	}
+
+	// A mined transaction pays twice to script A and once to script B.
+	minedTx := &wire.MsgTx{
+		TxIn: []*wire.TxIn{{
+			PreviousOutPoint: wire.OutPoint{Index: 1},
+		}},
+		TxOut: []*wire.TxOut{
+			{Value: 1e8, PkScript: scriptA},
+			{Value: 2e8, PkScript: scriptB},
+			{Value: 3e8, PkScript: scriptA},
+		},
+	}
+	minedRec, err := NewTxRecordFromMsgTx(minedTx, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, minedRec, b100); err != nil {
+			t.Fatal(err)
+		}
+		for i := uint32(0); i < 3; i++ {
+			err := store.AddCredit(ns, minedRec, b100, i, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	minedOp0 := wire.OutPoint{Hash: minedRec.Hash, Index: 0}
+	minedOp1 := wire.OutPoint{Hash: minedRec.Hash, Index: 1}
+	minedOp2 := wire.OutPoint{Hash: minedRec.Hash, Index: 2}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertUnspent(ns, scriptA, minedOp0, minedOp2)
+		assertUnspent(ns, scriptB, minedOp1)
+		assertTxs(ns, scriptA, minedRec.Hash)
+		assertTxs(ns, []byte{0x53})
+	})
+
+	// An unmined transaction spends the first output and pays back to
+	// script A. The spent output should no longer be reported, while the
+	// new one should, and the unmined transaction should sort last.
+	unminedTx := spendOutput(&minedRec.Hash, 0, 5e7)
+	unminedTx.TxOut[0].PkScript = scriptA
+	unminedRec, err := NewTxRecordFromMsgTx(unminedTx, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, unminedRec, nil); err != nil {
+			t.Fatal(err)
+		}
+		err := store.AddCredit(ns, unminedRec, nil, 0, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	unminedOp := wire.OutPoint{Hash: unminedRec.Hash, Index: 0}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertUnspent(ns, scriptA, minedOp2, unminedOp)
+		assertTxs(ns, scriptA, minedRec.Hash, unminedRec.Hash)
+		assertIndexed(ns, scriptA, 3)
+	})
+
+	// Removing the unmined transaction should drop its index entry and
+	// make the output it spent available again.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.RemoveUnminedTx(ns, unminedRec); err != nil {
+			t.Fatal(err)
+		}
+	})
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertUnspent(ns, scriptA, minedOp0, minedOp2)
+		assertTxs(ns, scriptA, minedRec.Hash)
+		assertIndexed(ns, scriptA, 2)
+	})
+
+	// Rolling back the block moves the credits to the unmined buckets,
+	// where they should still be found through the index.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.Rollback(ns, 100); err != nil {
+			t.Fatal(err)
+		}
+	})
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertUnspent(ns, scriptA, minedOp0, minedOp2)
+		assertUnspent(ns, scriptB, minedOp1)
+		assertTxs(ns, scriptA, minedRec.Hash)
+
+		details, err := store.TxDetailsForScript(ns, scriptB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if details[0].Block.Height != -1 {
+			t.Fatalf("expected rolled back transaction to be "+
+				"unmined, found at height %d",
+				details[0].Block.Height)
+		}
+	})
+
+	// Rolling back a coinbase removes it from the store entirely, along
+	// with its index entries.
+	cb := newCoinBase(1e8)
+	cb.TxOut[0].PkScript = scriptB
+	b200 := &BlockMeta{Block: Block{Height: 200}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, cb, 0, b200)
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertIndexed(ns, scriptB, 2)
+		if err := store.Rollback(ns, 200); err != nil {
+			t.Fatal(err)
+		}
+	})
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		assertIndexed(ns, scriptB, 1)
+		assertUnspent(ns, scriptB, minedOp1)
+		assertTxs(ns, scriptB, minedRec.Hash)
+	})
+}
+
+// TestForEachUnspentOutput ensures that unspent outputs are iterated in
+// outpoint order across mined and unmined credits, and that iteration can be
+// stopped early and resumed from a previously seen output.
+func TestForEachUnspentOutput(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	// Add a mined coinbase with a few outputs and an unmined transaction
+	// spending one of them.
+	cb := newCoinBase(1e8, 2e8, 3e8, 4e8)
+	cbRec, err := NewTxRecordFromMsgTx(cb, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	spend := spendOutput(&cbRec.Hash, 1, 5e7, 6e7)
+	spendRec, err := NewTxRecordFromMsgTx(spend, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, cbRec, b100); err != nil {
+			t.Fatal(err)
+		}
+		for i := range cb.TxOut {
+			err := store.AddCredit(ns, cbRec, b100, uint32(i), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.InsertTx(ns, spendRec, nil); err != nil {
+			t.Fatal(err)
+		}
+		for i := range spend.TxOut {
+			err := store.AddCredit(ns, spendRec, nil, uint32(i), true)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	// collect iterates over the unspent outputs after the given outpoint,
+	// stopping once limit outputs have been seen if it is non-zero.
+	collect := func(ns walletdb.ReadBucket, after *wire.OutPoint,
+		limit int) []wire.OutPoint {
+
+		t.Helper()
+
+		var ops []wire.OutPoint
+		err := store.ForEachUnspentOutput(ns, after, func(c *Credit) (bool, error) {
+			ops = append(ops, c.OutPoint)
+			return limit != 0 && len(ops) == limit, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ops
+	}
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		// The output spent by the unmined transaction should be
+		// excluded, leaving five outputs in outpoint order.
+		all := collect(ns, nil, 0)
+		if len(all) != 5 {
+			t.Fatalf("expected 5 unspent outputs, got %d", len(all))
+		}
+		for i := 1; i < len(all); i++ {
+			prev := canonicalOutPoint(&all[i-1].Hash, all[i-1].Index)
+			cur := canonicalOutPoint(&all[i].Hash, all[i].Index)
+			if bytes.Compare(prev, cur) >= 0 {
+				t.Fatalf("outputs not in outpoint order: %v "+
+					"before %v", all[i-1], all[i])
+			}
+		}
+		for _, op := range all {
+			if op == (wire.OutPoint{Hash: cbRec.Hash, Index: 1}) {
+				t.Fatalf("found output %v spent by unmined "+
+					"transaction", op)
+			}
+		}
+
+		// UnspentOutputs should match the iteration.
+		credits, err := store.UnspentOutputs(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(credits) != len(all) {
+			t.Fatalf("expected %d credits, got %d", len(all),
+				len(credits))
+		}
+		for i := range credits {
+			if credits[i].OutPoint != all[i] {
+				t.Fatalf("expected credit %d to be %v, got %v",
+					i, all[i], credits[i].OutPoint)
+			}
+		}
+
+		// Paging through the outputs two at a time should visit each
+		// exactly once.
+		var (
+			paged []wire.OutPoint
+			after *wire.OutPoint
+		)
+		for {
+			page := collect(ns, after, 2)
+			if len(page) == 0 {
+				break
+			}
+			if len(page) > 2 {
+				t.Fatalf("expected at most 2 outputs, got %d",
+					len(page))
+			}
+			paged = append(paged, page...)
+			after = &page[len(page)-1]
+		}
+		if len(paged) != len(all) {
+			t.Fatalf("expected %d paged outputs, got %d", len(all),
+				len(paged))
+		}
+		for i := range paged {
+			if paged[i] != all[i] {
+				t.Fatalf("expected paged output %d to be %v, "+
+					"got %v", i, all[i], paged[i])
+			}
+		}
+
+		// Resuming after an output that is no longer unspent should
+		// continue with the outputs ordered after it.
+		spent := wire.OutPoint{Hash: cbRec.Hash, Index: 1}
+		rest := collect(ns, &spent, 0)
+		spentKey := canonicalOutPoint(&spent.Hash, spent.Index)
+		var expected int
+		for _, op := range all {
+			k := canonicalOutPoint(&op.Hash, op.Index)
+			if bytes.Compare(k, spentKey) > 0 {
+				expected++
+			}
+		}
+		if len(rest) != expected {
+			t.Fatalf("expected %d outputs after %v, got %d",
+				expected, spent, len(rest))
+		}
+	})
+}
+
+// TestConflictedTxs ensures that unmined transactions removed because of a
+// mined double spend, along with their unmined descendants, are kept as
+// conflict records.
+func TestConflictedTxs(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	var notified []chainhash.Hash
+	store.NotifyConflict = func(c *ConflictedTx) {
+		notified = append(notified, c.Hash)
+	}
+
+	// Add a mined coinbase credit, an unmined transaction spending it and
+	// an unmined transaction spending the latter's change output.
+	cb := newCoinBase(1e8)
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, cb, 0, b100)
+	cbHash := cb.TxHash()
+
+	newRec := func(tx *wire.MsgTx) *TxRecord {
+		t.Helper()
+
+		rec, err := NewTxRecordFromMsgTx(tx, timeNow())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+	replaced := newRec(spendOutput(&cbHash, 0, 9e7))
+	child := newRec(spendOutput(&replaced.Hash, 0, 8e7))
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, replaced, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, replaced, nil, 0, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.InsertTx(ns, child, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, child, nil, 0, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Confirming a replacement of the first spend removes both unmined
+	// transactions from the store.
+	replacement := spendOutput(&cbHash, 0, 5e7)
+	replacementHash := replacement.TxHash()
+	b101 := &BlockMeta{Block: Block{Height: 101}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, replacement, 0, b101)
+
+	if len(notified) != 2 {
+		t.Fatalf("expected 2 conflict notifications, got %d",
+			len(notified))
+	}
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		hashes, err := store.UnminedTxHashes(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hashes) != 0 {
+			t.Fatalf("expected no unmined transactions, got %v",
+				hashes)
+		}
+
+		c, err := store.ConflictedTx(ns, &replaced.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c == nil {
+			t.Fatal("missing conflict record of replaced transaction")
+		}
+		if c.ConflictingTx != replacementHash {
+			t.Fatalf("expected conflicting tx %v, got %v",
+				replacementHash, c.ConflictingTx)
+		}
+		if c.MsgTx.TxHash() != replaced.Hash {
+			t.Fatal("conflict record holds the wrong transaction")
+		}
+		wantCredits := []CreditRecord{{Amount: 9e7, Change: true}}
+		if !reflect.DeepEqual(c.Credits, wantCredits) {
+			t.Fatalf("expected credits %v, got %v", wantCredits,
+				c.Credits)
+		}
+		wantDebits := []DebitRecord{{Amount: 1e8}}
+		if !reflect.DeepEqual(c.Debits, wantDebits) {
+			t.Fatalf("expected debits %v, got %v", wantDebits,
+				c.Debits)
+		}
+
+		// The descendant is recorded as conflicting with the same
+		// mined transaction.
+		c, err = store.ConflictedTx(ns, &child.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c == nil || c.ConflictingTx != replacementHash {
+			t.Fatalf("unexpected conflict record of child: %v", c)
+		}
+		wantDebits = []DebitRecord{{Amount: 9e7}}
+		if !reflect.DeepEqual(c.Debits, wantDebits) {
+			t.Fatalf("expected debits %v, got %v", wantDebits,
+				c.Debits)
+		}
+
+		conflicts, err := store.ConflictsOf(ns, &replacementHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 2 {
+			t.Fatalf("expected 2 conflicts, got %d", len(conflicts))
+		}
+
+		c, err = store.ConflictedTx(ns, &replacementHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != nil {
+			t.Fatal("mined transaction recorded as conflicted")
+		}
+	})
+
+	// Transactions removed explicitly are not conflict records.
+	removed := newRec(spendOutput(&replacementHash, 0, 4e7))
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, removed, nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.RemoveUnminedTx(ns, removed); err != nil {
+			t.Fatal(err)
+		}
+		c, err := store.ConflictedTx(ns, &removed.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != nil {
+			t.Fatal("removed transaction recorded as conflicted")
+		}
+	})
+
+	// A conflicted transaction seen again is no longer conflicted.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, child, nil); err != nil {
+			t.Fatal(err)
+		}
+		c, err := store.ConflictedTx(ns, &child.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c != nil {
+			t.Fatal("reinserted transaction still conflicted")
+		}
+		conflicts, err := store.ConflictsOf(ns, &replacementHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 1 || conflicts[0].Hash != replaced.Hash {
+			t.Fatalf("unexpected conflicts %v", conflicts)
+		}
+	})
+}
+
+// TestAbandonTx ensures that abandoning an unmined transaction removes it and
+// its unmined descendants, restores the credits they spent, and keeps them as
+// abandonment records.
+func TestAbandonTx(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	cb := newCoinBase(1e8)
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, cb, 0, b100)
+	cbHash := cb.TxHash()
+
+	newRec := func(tx *wire.MsgTx) *TxRecord {
+		t.Helper()
+
+		rec, err := NewTxRecordFromMsgTx(tx, timeNow())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+	spend := newRec(spendOutput(&cbHash, 0, 9e7))
+	child := newRec(spendOutput(&spend.Hash, 0, 8e7))
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		for _, rec := range []*TxRecord{spend, child} {
+			if err := store.InsertTx(ns, rec, nil); err != nil {
+				t.Fatal(err)
+			}
+			err := store.AddCredit(ns, rec, nil, 0, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		// Only unmined transactions may be abandoned.
+		err := store.AbandonTx(ns, &cbHash)
+		if err != ErrUnminedTxNotFound {
+			t.Fatalf("expected ErrUnminedTxNotFound, got %v", err)
+		}
+
+		if err := store.AbandonTx(ns, &spend.Hash); err != nil {
+			t.Fatal(err)
+		}
+
+		hashes, err := store.UnminedTxHashes(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hashes) != 0 {
+			t.Fatalf("expected no unmined transactions, got %v",
+				hashes)
+		}
+
+		// The coinbase output is spendable again.
+		outputs, err := store.UnspentOutputs(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outputs) != 1 || outputs[0].Hash != cbHash {
+			t.Fatalf("expected coinbase output to be unspent, got "+
+				"%v", outputs)
+		}
+
+		for _, rec := range []*TxRecord{spend, child} {
+			a, err := store.AbandonedTx(ns, &rec.Hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a == nil {
+				t.Fatalf("missing abandonment record of %v",
+					rec.Hash)
+			}
+			if a.Root != spend.Hash {
+				t.Fatalf("expected root %v, got %v", spend.Hash,
+					a.Root)
+			}
+			if len(a.Credits) != 1 || len(a.Debits) != 1 {
+				t.Fatalf("unexpected credits %v and debits %v",
+					a.Credits, a.Debits)
+			}
+
+			c, err := store.ConflictedTx(ns, &rec.Hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c != nil {
+				t.Fatal("abandoned transaction recorded as " +
+					"conflicted")
+			}
+		}
+
+		// Abandoned transactions are only ranged over along with
+		// the unmined transactions when requested.
+		var ranged, rangedWithAbandoned []chainhash.Hash
+		rangeFn := func(hashes *[]chainhash.Hash) func(
+			[]TxDetails) (bool, error) {
+
+			return func(details []TxDetails) (bool, error) {
+				for i := range details {
+					*hashes = append(*hashes, details[i].Hash)
+				}
+				return false, nil
+			}
+		}
+		err = store.RangeTransactions(ns, -1, 0, rangeFn(&ranged))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = store.RangeTransactionsWithAbandoned(
+			ns, -1, 0, rangeFn(&rangedWithAbandoned),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		abandoned := []chainhash.Hash{spend.Hash, child.Hash}
+		if bytes.Compare(abandoned[0][:], abandoned[1][:]) > 0 {
+			abandoned[0], abandoned[1] = abandoned[1], abandoned[0]
+		}
+		if !reflect.DeepEqual(ranged, []chainhash.Hash{cbHash}) {
+			t.Fatalf("unexpected transactions %v", ranged)
+		}
+		expected := append(abandoned, cbHash)
+		if !reflect.DeepEqual(rangedWithAbandoned, expected) {
+			t.Fatalf("expected transactions %v, got %v", expected,
+				rangedWithAbandoned)
+		}
+
+		// Abandoned transactions can't be abandoned again.
+		err = store.AbandonTx(ns, &spend.Hash)
+		if err != ErrUnminedTxNotFound {
+			t.Fatalf("expected ErrUnminedTxNotFound, got %v", err)
+		}
+	})
+
+	// An abandoned transaction that is mined after all is no longer
+	// abandoned.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		b101 := &BlockMeta{Block: Block{Height: 101}, Time: timeNow()}
+		if err := store.InsertTx(ns, spend, b101); err != nil {
+			t.Fatal(err)
+		}
+		a, err := store.AbandonedTx(ns, &spend.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a != nil {
+			t.Fatal("mined transaction still abandoned")
+		}
+	})
+}
+
+// TestRebroadcastState ensures that the rebroadcast state of an unmined
+// transaction round trips and is removed once the transaction is mined.
+func TestRebroadcastState(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	cb := newCoinBase(1e8)
+	b100 := &BlockMeta{Block: Block{Height: 100}, Time: timeNow()}
+	insertConfirmedCredit(t, store, db, cb, 0, b100)
+	cbHash := cb.TxHash()
+
+	spend, err := NewTxRecordFromMsgTx(spendOutput(&cbHash, 0, 9e7), timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, spend, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	state := &RebroadcastState{
+		Attempts:    3,
+		LastAttempt: time.Unix(1000, 0),
+		NextAttempt: time.Unix(5000, 0),
+		Dropped:     true,
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		// Only unmined transactions have a rebroadcast state.
+		err := store.PutRebroadcastState(ns, &cbHash, state)
+		if err != ErrUnminedTxNotFound {
+			t.Fatalf("expected ErrUnminedTxNotFound, got %v", err)
+		}
+
+		got, err := store.RebroadcastState(ns, &spend.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatalf("unexpected rebroadcast state %v", got)
+		}
+
+		err = store.PutRebroadcastState(ns, &spend.Hash, state)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err = store.RebroadcastState(ns, &spend.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, state) {
+			t.Fatalf("expected rebroadcast state %v, got %v", state,
+				got)
+		}
+	})
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		b101 := &BlockMeta{Block: Block{Height: 101}, Time: timeNow()}
+		if err := store.InsertTx(ns, spend, b101); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.RebroadcastState(ns, &spend.Hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != nil {
+			t.Fatal("mined transaction still has a rebroadcast state")
+		}
+	})
+}
@@ -41,6 +41,17 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 	if err != nil {
 		return err
 	}
+
+	// A transaction previously removed as a conflict or abandoned may be
+	// seen again, for example when it is rebroadcast after a reorg, in
+	// which case it is no longer conflicted or abandoned.
+	if err := deleteConflictedTx(ns, &rec.Hash); err != nil {
+		return err
+	}
+	if err := deleteAbandonedTx(ns, &rec.Hash); err != nil {
+		return err
+	}
+
 	err = putRawUnmined(ns, rec.Hash[:], v)
 	if err != nil {
 		return err
@@ -61,10 +72,21 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 	return nil
 }
 
+// removalCause describes why unmined transactions are removed from the store.
+type removalCause struct {
+	// hash is the hash of the mined transaction conflicting with the
+	// removed transactions, or of the transaction that was abandoned.
+	hash chainhash.Hash
+
+	// abandoned is set when the transactions are removed because they
+	// were abandoned rather than double spent.
+	abandoned bool
+}
+
 // removeDoubleSpends checks for any unmined transactions which would introduce
 // a double spend if tx was added to the store (either as a confirmed or unmined
 // transaction).  Each conflicting transaction and all transactions which spend
-// it are recursively removed.
+// it are recursively removed, and recorded as conflicted by tx.
 func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) error {
 	for _, input := range rec.MsgTx.TxIn {
 		prevOut := &input.PreviousOutPoint
@@ -101,7 +123,9 @@ func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) e
 			log.Debugf("Removing double spending transaction %v",
 				doubleSpend.Hash)
 
-			if err := s.removeConflict(ns, &doubleSpend); err != nil {
+			cause := &removalCause{hash: rec.Hash}
+			err = s.removeConflict(ns, &doubleSpend, cause)
+			if err != nil {
 				return err
 			}
 		}
@@ -114,7 +138,24 @@ func (s *Store) removeDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) e
 // deriving from it from the store.  This is designed to remove transactions
 // that would otherwise result in double spend conflicts if left in the store,
 // and to remove transactions that spend coinbase transactions on reorgs.
-func (s *Store) removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) error {
+//
+// If cause is non-nil, each removed transaction is kept as a conflict or
+// abandonment record.
+func (s *Store) removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord,
+	cause *removalCause) error {
+
+	// The record must be created before any credits are removed so that
+	// the amounts of the transaction's credits and debits can still be
+	// looked up.
+	var conflict *ConflictedTx
+	if cause != nil {
+		var err error
+		conflict, err = s.newConflictedTx(ns, rec, &cause.hash)
+		if err != nil {
+			return err
+		}
+	}
+
 	// For each potential credit for this record, each spender (if any) must
 	// be recursively removed as well.  Once the spenders are removed, the
 	// credit is deleted.
@@ -141,13 +182,22 @@ func (s *Store) removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) error
 
 			log.Debugf("Transaction %v is part of a removed conflict "+
 				"chain -- removing as well", spender.Hash)
-			if err := s.removeConflict(ns, &spender); err != nil {
+			err = s.removeConflict(ns, &spender, cause)
+			if err != nil {
 				return err
 			}
 		}
+		if existsRawUnminedCredit(ns, k) == nil {
+			continue
+		}
 		if err := deleteRawUnminedCredit(ns, k); err != nil {
 			return err
 		}
+		op := wire.OutPoint{Hash: rec.Hash, Index: uint32(i)}
+		pkScript := rec.MsgTx.TxOut[i].PkScript
+		if err := deleteScriptIndex(ns, pkScript, &op); err != nil {
+			return err
+		}
 	}
 
 	// If this tx spends any previous credits (either mined or unmined), set
@@ -162,7 +212,32 @@ func (s *Store) removeConflict(ns walletdb.ReadWriteBucket, rec *TxRecord) error
 		}
 	}
 
-	return deleteRawUnmined(ns, rec.Hash[:])
+	if err := deleteRebroadcastState(ns, &rec.Hash); err != nil {
+		return err
+	}
+	if err := deleteRawUnmined(ns, rec.Hash[:]); err != nil {
+		return err
+	}
+
+	switch {
+	case cause == nil:
+		return nil
+
+	case cause.abandoned:
+		return putAbandonedTx(ns, conflict)
+	}
+
+	if err := putConflictedTx(ns, conflict); err != nil {
+		return err
+	}
+	// The conflict is only notified once its removal is committed, so that
+	// notifications are never sent for removals that are rolled back.
+	if notify := s.NotifyConflict; notify != nil {
+		ns.Tx().OnCommit(func() {
+			notify(conflict)
+		})
+	}
+	return nil
 }
 
 // UnminedTxs returns the underlying transactions for all unmined transactions
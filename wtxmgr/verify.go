@@ -0,0 +1,432 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxd/wire"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// ProblemKind identifies a kind of inconsistency between the buckets of the
+// store.
+type ProblemKind uint8
+
+// These constants identify the inconsistencies reported by Verify.
+const (
+	// ProblemOrphanCredit describes a mined credit without the record of
+	// the transaction it belongs to.  It is repaired by deleting the
+	// credit.
+	ProblemOrphanCredit ProblemKind = iota
+
+	// ProblemMissingUnspent describes an unspent mined credit missing from
+	// the unspent index.  It is repaired by indexing the credit.
+	ProblemMissingUnspent
+
+	// ProblemStaleUnspent describes an unspent index entry for a credit
+	// that does not exist or is spent.  It is repaired by deleting the
+	// entry.
+	ProblemStaleUnspent
+
+	// ProblemSpentCreditMissingDebit describes a mined credit marked spent
+	// by a debit that does not exist, and whose spending transaction was
+	// not pruned.  It is repaired by marking the credit unspent.
+	ProblemSpentCreditMissingDebit
+
+	// ProblemOrphanDebit describes a debit without the record of the
+	// transaction it belongs to.  It is repaired by deleting the debit.
+	ProblemOrphanDebit
+
+	// ProblemDebitMissingCredit describes a debit of a credit that does not
+	// exist, and whose transaction was not pruned.  It is repaired by
+	// deleting the debit.
+	ProblemDebitMissingCredit
+
+	// ProblemDebitCreditMismatch describes a debit of a credit that is not
+	// marked spent by it.  It is repaired by marking the credit spent by
+	// the debit.
+	ProblemDebitCreditMismatch
+
+	// ProblemOrphanUnminedCredit describes an unmined credit without the
+	// record of the unmined transaction it belongs to.  It is repaired by
+	// deleting the credit.
+	ProblemOrphanUnminedCredit
+
+	// ProblemStaleUnminedInput describes an output recorded as spent by an
+	// unmined transaction that does not exist.  It is repaired by removing
+	// the transaction from the spenders of the output.
+	ProblemStaleUnminedInput
+
+	// ProblemBalanceDrift describes a mined balance that differs from the
+	// total value of the unspent mined credits.  It is repaired by
+	// recording the total value as the mined balance.
+	ProblemBalanceDrift
+)
+
+var problemStrs = [...]string{
+	ProblemOrphanCredit:            "ProblemOrphanCredit",
+	ProblemMissingUnspent:          "ProblemMissingUnspent",
+	ProblemStaleUnspent:            "ProblemStaleUnspent",
+	ProblemSpentCreditMissingDebit: "ProblemSpentCreditMissingDebit",
+	ProblemOrphanDebit:             "ProblemOrphanDebit",
+	ProblemDebitMissingCredit:      "ProblemDebitMissingCredit",
+	ProblemDebitCreditMismatch:     "ProblemDebitCreditMismatch",
+	ProblemOrphanUnminedCredit:     "ProblemOrphanUnminedCredit",
+	ProblemStaleUnminedInput:       "ProblemStaleUnminedInput",
+	ProblemBalanceDrift:            "ProblemBalanceDrift",
+}
+
+// String returns the ProblemKind as a human-readable name.
+func (k ProblemKind) String() string {
+	if k < ProblemKind(len(problemStrs)) {
+		return problemStrs[k]
+	}
+	return fmt.Sprintf("ProblemKind(%d)", k)
+}
+
+// Problem describes an inconsistency found in the store.
+type Problem struct {
+	Kind        ProblemKind
+	Description string
+
+	// fix repairs the inconsistency.  It is nil for problems that are
+	// repaired separately, such as balance drift.
+	fix func(ns walletdb.ReadWriteBucket) error
+}
+
+// String returns the kind and description of the problem.
+func (p *Problem) String() string {
+	return fmt.Sprintf("%v: %s", p.Kind, p.Description)
+}
+
+// VerifyReport is the result of verifying, and possibly repairing, the store.
+type VerifyReport struct {
+	// Problems lists every inconsistency found, in the order the buckets
+	// were checked.
+	Problems []Problem
+
+	// MinedBalance is the mined balance recorded by the store when it was
+	// verified, and ExpectedMinedBalance the total value of its unspent
+	// mined credits.
+	MinedBalance         btcutil.Amount
+	ExpectedMinedBalance btcutil.Amount
+
+	// Repaired is set when the problems were repaired.
+	Repaired bool
+}
+
+// maxRepairPasses bounds the number of times Repair verifies the store again
+// after repairing it.  Repairing a problem may reveal another, such as the
+// debits of a deleted orphan credit.
+const maxRepairPasses = 4
+
+// Verify checks the invariants between the credits, unspent index, debits,
+// unmined credits and unmined inputs of the store, and that the recorded mined
+// balance matches the unspent mined credits.  The store is not modified.
+func Verify(ns walletdb.ReadBucket) (*VerifyReport, error) {
+	if err := openStore(ns); err != nil {
+		return nil, err
+	}
+	return verify(ns)
+}
+
+// Repair verifies the store like Verify and repairs every problem found.  The
+// store is verified again after each repair until no problems remain, and the
+// report lists the problems of every pass.  Repairs never touch transaction
+// records, so the history of the wallet is kept.
+func Repair(ns walletdb.ReadWriteBucket) (*VerifyReport, error) {
+	if err := openStore(ns); err != nil {
+		return nil, err
+	}
+
+	report, err := verify(ns)
+	if err != nil {
+		return nil, err
+	}
+	problems := report.Problems
+	for pass := 0; len(problems) != 0; pass++ {
+		if pass == maxRepairPasses {
+			str := "store still inconsistent after repair"
+			return nil, storeError(ErrData, str, nil)
+		}
+
+		for i := range problems {
+			if problems[i].fix == nil {
+				continue
+			}
+			if err := problems[i].fix(ns); err != nil {
+				return nil, err
+			}
+		}
+		balance, err := expectedMinedBalance(ns)
+		if err != nil {
+			return nil, err
+		}
+		if err := putMinedBalance(ns, balance); err != nil {
+			return nil, err
+		}
+
+		again, err := verify(ns)
+		if err != nil {
+			return nil, err
+		}
+		problems = again.Problems
+		report.Problems = append(report.Problems, problems...)
+	}
+	report.Repaired = true
+
+	return report, nil
+}
+
+// copyBytes returns a copy of a bucket key or value, which is only valid for
+// the life of the database transaction.
+func copyBytes(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
+
+// describeKey returns a description of a credit or debit key.
+func describeKey(k []byte) string {
+	var txHash chainhash.Hash
+	copy(txHash[:], k[:32])
+	return fmt.Sprintf("%v:%d at height %d", txHash,
+		byteOrder.Uint32(k[68:72]), int32(byteOrder.Uint32(k[32:36])))
+}
+
+// describeOutPoint returns a description of a canonical outpoint key.
+func describeOutPoint(k []byte) string {
+	var op wire.OutPoint
+	_ = readCanonicalOutPoint(k, &op)
+	return op.String()
+}
+
+// prunedTxKey returns the pruned transaction key for the transaction of a
+// credit or debit key.  Credits spent by pruned transactions have no debit, and
+// debits of credits of pruned transactions have no credit.
+func prunedTxKey(k []byte) []byte {
+	var txHash chainhash.Hash
+	copy(txHash[:], k[:32])
+	return keyPrunedTx(&txHash, int32(byteOrder.Uint32(k[32:36])))
+}
+
+// verify returns the problems found in the store.
+func verify(ns walletdb.ReadBucket) (*VerifyReport, error) {
+	var problems []Problem
+	report := func(kind ProblemKind, fix func(walletdb.ReadWriteBucket) error,
+		format string, args ...interface{}) {
+
+		problems = append(problems, Problem{
+			Kind:        kind,
+			Description: fmt.Sprintf(format, args...),
+			fix:         fix,
+		})
+	}
+
+	credits := ns.NestedReadBucket(bucketCredits)
+	debits := ns.NestedReadBucket(bucketDebits)
+
+	err := credits.ForEach(func(k, v []byte) error {
+		if len(k) < 72 || len(v) < 9 {
+			str := fmt.Sprintf("%s: malformed credit", bucketCredits)
+			return storeError(ErrData, str, nil)
+		}
+		k = copyBytes(k)
+		opKey := canonicalOutPoint(new(chainhash.Hash), 0)
+		copy(opKey[:32], k[:32])
+		copy(opKey[32:36], k[68:72])
+
+		if existsRawTxRecord(ns, k[:68]) == nil {
+			report(ProblemOrphanCredit, func(ns walletdb.ReadWriteBucket) error {
+				if bytes.Equal(existsRawUnspent(ns, opKey), k) {
+					err := deleteRawUnspent(ns, opKey)
+					if err != nil {
+						return err
+					}
+				}
+				return deleteRawCredit(ns, k)
+			}, "credit %s has no transaction record", describeKey(k))
+			return nil
+		}
+
+		spent := v[8]&(1<<0) != 0
+		switch {
+		case !spent && !bytes.Equal(existsRawUnspent(ns, opKey), k):
+			report(ProblemMissingUnspent, func(ns walletdb.ReadWriteBucket) error {
+				return putRawUnspent(ns, opKey, k[32:68])
+			}, "unspent credit %s is not indexed as unspent",
+				describeKey(k))
+
+		case spent && (len(v) < 81 || debits.Get(v[9:81]) == nil &&
+			existsRawPrunedTx(ns, prunedTxKey(v[9:81])) == nil):
+			report(ProblemSpentCreditMissingDebit, func(ns walletdb.ReadWriteBucket) error {
+				if _, err := unspendRawCredit(ns, k); err != nil {
+					return err
+				}
+				return putRawUnspent(ns, opKey, k[32:68])
+			}, "credit %s is spent by a missing debit",
+				describeKey(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var expectedBalance btcutil.Amount
+	err = ns.NestedReadBucket(bucketUnspent).ForEach(func(k, v []byte) error {
+		if len(k) < 36 || len(v) < 36 {
+			str := fmt.Sprintf("%s: malformed unspent index entry",
+				bucketUnspent)
+			return storeError(ErrData, str, nil)
+		}
+		k = copyBytes(k)
+
+		credKey := existsRawUnspent(ns, k)
+		cv := credits.Get(credKey)
+		if len(cv) < 9 || cv[8]&(1<<0) != 0 {
+			report(ProblemStaleUnspent, func(ns walletdb.ReadWriteBucket) error {
+				return deleteRawUnspent(ns, k)
+			}, "unspent index entry for %s refers to a missing or "+
+				"spent credit", describeOutPoint(k))
+			return nil
+		}
+		expectedBalance += btcutil.Amount(byteOrder.Uint64(cv))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = debits.ForEach(func(k, v []byte) error {
+		if len(k) < 72 || len(v) < 80 {
+			str := fmt.Sprintf("%s: malformed debit", bucketDebits)
+			return storeError(ErrData, str, nil)
+		}
+		k = copyBytes(k)
+		credKey := copyBytes(extractRawDebitCreditKey(v))
+
+		if existsRawTxRecord(ns, k[:68]) == nil {
+			report(ProblemOrphanDebit, func(ns walletdb.ReadWriteBucket) error {
+				return deleteRawDebit(ns, k)
+			}, "debit %s has no transaction record", describeKey(k))
+			return nil
+		}
+
+		cv := credits.Get(credKey)
+		switch {
+		case len(cv) < 9 && existsRawPrunedTx(ns, prunedTxKey(credKey)) == nil:
+			report(ProblemDebitMissingCredit, func(ns walletdb.ReadWriteBucket) error {
+				return deleteRawDebit(ns, k)
+			}, "debit %s spends missing credit %s", describeKey(k),
+				describeKey(credKey))
+
+		case len(cv) < 9:
+			// The credit was pruned along with its transaction.
+
+		case cv[8]&(1<<0) == 0 || len(cv) < 81 || !bytes.Equal(cv[9:81], k):
+			report(ProblemDebitCreditMismatch, func(ns walletdb.ReadWriteBucket) error {
+				spender := &indexedIncidence{
+					incidence: incidence{
+						block: Block{
+							Height: int32(byteOrder.Uint32(k[32:36])),
+						},
+					},
+					index: byteOrder.Uint32(k[68:72]),
+				}
+				copy(spender.txHash[:], k[:32])
+				copy(spender.block.Hash[:], k[36:68])
+				if _, err := spendCredit(ns, credKey, spender); err != nil {
+					return err
+				}
+
+				opKey := canonicalOutPoint(new(chainhash.Hash), 0)
+				copy(opKey[:32], credKey[:32])
+				copy(opKey[32:36], credKey[68:72])
+				return deleteRawUnspent(ns, opKey)
+			}, "debit %s spends credit %s not marked spent by it",
+				describeKey(k), describeKey(credKey))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketUnminedCredits).ForEach(func(k, v []byte) error {
+		if len(k) < 36 {
+			str := "short unmined credit key"
+			return storeError(ErrData, str, nil)
+		}
+		k = copyBytes(k)
+
+		if existsRawUnmined(ns, k[:32]) == nil {
+			report(ProblemOrphanUnminedCredit, func(ns walletdb.ReadWriteBucket) error {
+				return deleteRawUnminedCredit(ns, k)
+			}, "unmined credit %s has no unmined transaction record",
+				describeOutPoint(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = ns.NestedReadBucket(bucketUnminedInputs).ForEach(func(k, v []byte) error {
+		k = copyBytes(k)
+		for len(v) >= 32 {
+			var spender chainhash.Hash
+			copy(spender[:], v[:32])
+			v = v[32:]
+
+			if existsRawUnmined(ns, spender[:]) != nil {
+				continue
+			}
+			report(ProblemStaleUnminedInput, func(ns walletdb.ReadWriteBucket) error {
+				return deleteRawUnminedInput(ns, k, spender)
+			}, "output %s is spent by missing unmined transaction %v",
+				describeOutPoint(k), spender)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	minedBalance, err := fetchMinedBalance(ns)
+	if err != nil {
+		return nil, err
+	}
+	if minedBalance != expectedBalance {
+		report(ProblemBalanceDrift, nil, "mined balance %v differs from "+
+			"unspent mined credits totaling %v", minedBalance,
+			expectedBalance)
+	}
+
+	return &VerifyReport{
+		Problems:             problems,
+		MinedBalance:         minedBalance,
+		ExpectedMinedBalance: expectedBalance,
+	}, nil
+}
+
+// expectedMinedBalance returns the total value of the unspent mined credits
+// recorded by the unspent index.
+func expectedMinedBalance(ns walletdb.ReadBucket) (btcutil.Amount, error) {
+	credits := ns.NestedReadBucket(bucketCredits)
+
+	var balance btcutil.Amount
+	err := ns.NestedReadBucket(bucketUnspent).ForEach(func(k, v []byte) error {
+		cv := credits.Get(existsRawUnspent(ns, k))
+		if len(cv) < 9 || cv[8]&(1<<0) != 0 {
+			return nil
+		}
+		balance += btcutil.Amount(byteOrder.Uint64(cv))
+		return nil
+	})
+	return balance, err
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2021 The btcsuite developers
+// Use of this source code is governed by an ISC
+// license that can be found in the LICENSE file.
+
+package wtxmgr
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tinhnguyenhn/colxd/chaincfg/chainhash"
+	"github.com/tinhnguyenhn/colxutil"
+	"github.com/tinhnguyenhn/colxwallet/walletdb"
+)
+
+// problemKinds returns the kinds of the problems of a report.
+func problemKinds(report *VerifyReport) []ProblemKind {
+	var kinds []ProblemKind
+	for _, p := range report.Problems {
+		kinds = append(kinds, p.Kind)
+	}
+	return kinds
+}
+
+// TestVerifyRepair ensures that inconsistencies between the buckets of the
+// store are reported by Verify and repaired by Repair.
+func TestVerifyRepair(t *testing.T) {
+	t.Parallel()
+
+	store, db, teardown, err := testStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardown()
+
+	b100 := BlockMeta{
+		Block: Block{Hash: chainhash.Hash{100}, Height: 100},
+		Time:  time.Unix(100, 0),
+	}
+	b101 := BlockMeta{
+		Block: Block{Hash: chainhash.Hash{101}, Height: 101},
+		Time:  time.Unix(101, 0),
+	}
+
+	// Record a coinbase with two outputs, the first of which is spent by a
+	// mined transaction paying back to the wallet.
+	cb := newCoinBase(1e8, 2e8)
+	cbRec, err := NewTxRecordFromMsgTx(cb, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spend := spendOutput(&cbRec.Hash, 0, 5e7)
+	spendRec, err := NewTxRecordFromMsgTx(spend, timeNow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		if err := store.InsertTx(ns, cbRec, &b100); err != nil {
+			t.Fatal(err)
+		}
+		for i := uint32(0); i < 2; i++ {
+			err := store.AddCredit(ns, cbRec, &b100, i, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.InsertTx(ns, spendRec, &b101); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.AddCredit(ns, spendRec, &b101, 0, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	verifyStore := func() *VerifyReport {
+		t.Helper()
+		var report *VerifyReport
+		err := walletdb.View(db, func(tx walletdb.ReadTx) error {
+			var err error
+			report, err = Verify(tx.ReadBucket(namespaceKey))
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+
+	report := verifyStore()
+	if len(report.Problems) != 0 {
+		t.Fatalf("unexpected problems in consistent store: %v",
+			report.Problems)
+	}
+	if report.MinedBalance != 25e7 || report.ExpectedMinedBalance != 25e7 {
+		t.Fatalf("unexpected balances %v and %v, expected %v",
+			report.MinedBalance, report.ExpectedMinedBalance,
+			btcutil.Amount(25e7))
+	}
+
+	// Corrupt the store: drop the debit of the spent output and the
+	// unspent index entry of the other coinbase output, and record unmined
+	// credits and inputs of a transaction that doesn't exist.
+	missingHash := chainhash.Hash{0xff}
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		err := deleteRawDebit(ns, keyDebit(&spendRec.Hash, 0, &b101.Block))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = deleteRawUnspent(ns, canonicalOutPoint(&cbRec.Hash, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = putRawUnminedCredit(
+			ns, canonicalOutPoint(&missingHash, 0),
+			valueUnminedCredit(1e6, false),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = putRawUnminedInput(
+			ns, canonicalOutPoint(&cbRec.Hash, 1), missingHash[:],
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	report = verifyStore()
+	expected := []ProblemKind{
+		ProblemSpentCreditMissingDebit,
+		ProblemMissingUnspent,
+		ProblemOrphanUnminedCredit,
+		ProblemStaleUnminedInput,
+		ProblemBalanceDrift,
+	}
+	if kinds := problemKinds(report); !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("unexpected problems %v, expected %v", kinds, expected)
+	}
+	if report.MinedBalance != 25e7 || report.ExpectedMinedBalance != 5e7 {
+		t.Fatalf("unexpected balances %v and %v", report.MinedBalance,
+			report.ExpectedMinedBalance)
+	}
+
+	// Verifying must not modify the store.
+	if kinds := problemKinds(verifyStore()); !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("unexpected problems %v after verify, expected %v",
+			kinds, expected)
+	}
+
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		report, err := Repair(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.Repaired {
+			t.Fatal("store not reported as repaired")
+		}
+		kinds := problemKinds(report)
+		if !reflect.DeepEqual(kinds, expected) {
+			t.Fatalf("unexpected repaired problems %v, expected %v",
+				kinds, expected)
+		}
+	})
+
+	// With the debit gone, both coinbase outputs are unspent again.
+	report = verifyStore()
+	if len(report.Problems) != 0 {
+		t.Fatalf("unexpected problems after repair: %v", report.Problems)
+	}
+	if report.MinedBalance != 35e7 {
+		t.Fatalf("unexpected balance %v after repair, expected %v",
+			report.MinedBalance, btcutil.Amount(35e7))
+	}
+}